@@ -0,0 +1,189 @@
+// Package codegen builds Go source files from declarations, so generators add fields and types as values
+// rather than splicing them into format strings
+//
+// It covers the code whose shape depends on the fields of a model: the model struct and the DTO fields. The
+// repository files stay text/templates under internal/templates, where projects can override them
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// Tag is one key of a struct field tag, e.g. json:"email"
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// Field is a struct field; a field without a name embeds its type
+type Field struct {
+	Name string
+	Type string // Go type expression, e.g. *string, []int64 or datatypes.JSON
+	Tags []Tag
+}
+
+// Decl is a top-level declaration of a file
+type Decl interface {
+	source() (string, error)
+}
+
+// Struct declares a struct type
+type Struct struct {
+	Doc    string // comment above the declaration, without the leading //
+	Name   string
+	Fields []Field
+}
+
+// Source is a declaration given as Go source, for code no builder covers yet
+type Source string
+
+// File is a Go source file: its package clause, imports and declarations
+type File struct {
+	Package string
+	Module  string   // module path of the generated app, whose packages are imported with the third-party ones
+	Imports []string // import paths; standard library paths get their own group, first
+	Decls   []Decl
+}
+
+func (s Source) source() (string, error) {
+	return string(s), nil
+}
+
+func (s Struct) source() (string, error) {
+	list, err := fieldList(s.Fields)
+	if err != nil {
+		return "", fmt.Errorf("struct %s: %w", s.Name, err)
+	}
+	decl := &ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{
+		&ast.TypeSpec{Name: ast.NewIdent(s.Name), Type: &ast.StructType{Fields: list}},
+	}}
+	var b strings.Builder
+	for _, line := range strings.Split(s.Doc, "\n") {
+		if line != "" {
+			fmt.Fprintf(&b, "// %s\n", line)
+		}
+	}
+	var node bytes.Buffer
+	if err := format.Node(&node, token.NewFileSet(), decl); err != nil {
+		return "", err
+	}
+	b.Write(node.Bytes())
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+// Source renders the file, formatted as gofmt would
+func (f File) Source() (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n", f.Package)
+	b.WriteString(imports(f.Imports, f.Module))
+	for _, decl := range f.Decls {
+		source, err := decl.source()
+		if err != nil {
+			return "", err
+		}
+		if source != "" {
+			b.WriteString("\n" + source)
+		}
+	}
+	formatted, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return "", fmt.Errorf("package %s: %w", f.Package, err)
+	}
+	return string(formatted), nil
+}
+
+// Fields renders struct fields one per line, each indented by a tab and aligned as gofmt would,
+// for splicing into the body of a struct in a template
+func Fields(fields []Field) (string, error) {
+	source, err := Struct{Name: "T", Fields: fields}.source()
+	if err != nil {
+		return "", err
+	}
+	formatted, err := format.Source([]byte("package p\n\n" + source))
+	if err != nil {
+		return "", err
+	}
+	body := string(formatted)
+	body = body[strings.Index(body, "{")+1:]
+	body = strings.TrimPrefix(body[:strings.LastIndex(body, "}")], "\n")
+	return body, nil
+}
+
+// fieldList builds the AST of struct fields, parsing each type so an invalid one fails here rather than in the output
+func fieldList(fields []Field) (*ast.FieldList, error) {
+	list := &ast.FieldList{}
+	for _, f := range fields {
+		typ, err := parser.ParseExpr(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: invalid type %q", f.Name, f.Type)
+		}
+		field := &ast.Field{Type: typ}
+		if f.Name != "" {
+			if !token.IsIdentifier(f.Name) {
+				return nil, fmt.Errorf("field %q: not a Go identifier", f.Name)
+			}
+			field.Names = []*ast.Ident{ast.NewIdent(f.Name)}
+		}
+		if len(f.Tags) > 0 {
+			tag, err := structTag(f.Tags)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			field.Tag = &ast.BasicLit{Kind: token.STRING, Value: tag}
+		}
+		list.List = append(list.List, field)
+	}
+	return list, nil
+}
+
+// structTag renders tags as a raw string literal, quoting each value, e.g. `json:"email" validate:"required"`
+func structTag(tags []Tag) (string, error) {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	parts := make([]string, len(tags))
+	for i, t := range tags {
+		if strings.Contains(t.Value, "`") {
+			return "", fmt.Errorf("tag %s: backticks cannot appear in a struct tag", t.Key)
+		}
+		parts[i] = fmt.Sprintf(`%s:"%s"`, t.Key, quote.Replace(t.Value))
+	}
+	return "`" + strings.Join(parts, " ") + "`", nil
+}
+
+// imports renders the import declaration, surrounded by blank lines, with the standard library group first
+// Standard library paths have no dot in their first element, unlike those of other modules
+func imports(paths []string, module string) string {
+	var std, other []string
+	for _, path := range paths {
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") || module != "" && strings.HasPrefix(path, module+"/") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	switch all := append(append([]string{}, std...), other...); len(all) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("\nimport %q\n", all[0])
+	}
+	var b strings.Builder
+	b.WriteString("\nimport (\n")
+	for _, path := range std {
+		fmt.Fprintf(&b, "\t%q\n", path)
+	}
+	if len(std) > 0 && len(other) > 0 {
+		b.WriteString("\n")
+	}
+	for _, path := range other {
+		fmt.Fprintf(&b, "\t%q\n", path)
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package codegen
+
+import "testing"
+
+func TestFileSource(t *testing.T) {
+	file := File{
+		Package: "models",
+		Imports: []string{"gorm.io/gorm", "time"},
+		Decls: []Decl{
+			Struct{Doc: "Product is sold in the shop", Name: "Product", Fields: []Field{
+				{Type: "gorm.Model"},
+				{Name: "Name", Type: "string", Tags: []Tag{{Key: "json", Value: "name"}}},
+				{Name: "PublishedAt", Type: "*time.Time", Tags: []Tag{{Key: "json", Value: "published_at"}, {Key: "gorm", Value: `check:chk_title,title <> ""`}}},
+			}},
+			Source("func (Product) TableName() string { return \"products\" }\n"),
+		},
+	}
+	want := "package models\n\nimport (\n\t\"time\"\n\n\t\"gorm.io/gorm\"\n)\n\n" +
+		"// Product is sold in the shop\ntype Product struct {\n\tgorm.Model\n" +
+		"\tName        string     `json:\"name\"`\n" +
+		"\tPublishedAt *time.Time `json:\"published_at\" gorm:\"check:chk_title,title <> \\\"\\\"\"`\n}\n\n" +
+		"func (Product) TableName() string { return \"products\" }\n"
+	got, err := file.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("Source() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFileModuleImports(t *testing.T) {
+	file := File{Package: "seo", Module: "shop", Imports: []string{"context", "gorm.io/gorm", "shop/internal/models"}}
+	want := "package seo\n\nimport (\n\t\"context\"\n\n\t\"gorm.io/gorm\"\n\t\"shop/internal/models\"\n)\n"
+	if got, err := file.Source(); err != nil || got != want {
+		t.Errorf("Source() = %q, %v, want %q", got, err, want)
+	}
+}
+
+func TestFields(t *testing.T) {
+	got, err := Fields([]Field{
+		{Name: "ID", Type: "uint", Tags: []Tag{{Key: "json", Value: "id"}}},
+		{Name: "Tags", Type: "[]string"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "\tID   uint `json:\"id\"`\n\tTags []string\n"; got != want {
+		t.Errorf("Fields() = %q, want %q", got, want)
+	}
+	if got, _ := Fields(nil); got != "" {
+		t.Errorf("Fields(nil) = %q, want empty", got)
+	}
+}
+
+func TestInvalidFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		field Field
+	}{
+		{"type", Field{Name: "Price", Type: "decimal("}},
+		{"name", Field{Name: "first name", Type: "string"}},
+		{"tag", Field{Name: "Code", Type: "string", Tags: []Tag{{Key: "validate", Value: "regexp=`x`"}}}},
+	}
+	for _, tt := range tests {
+		if _, err := Fields([]Field{tt.field}); err == nil {
+			t.Errorf("%s: expected an error for %+v", tt.name, tt.field)
+		}
+	}
+}
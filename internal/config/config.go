@@ -0,0 +1,161 @@
+// Package config holds the server settings read at startup from mcpgo.yaml or mcpgo.json, and the flags overriding them
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"mcpgo/internal/templates"
+)
+
+// Config is the server behavior a team shares in its config file
+type Config struct {
+	ModulePrefix    string   `json:"module_prefix" yaml:"module_prefix"`       // prepended to app names for the module path of new apps, e.g. github.com/acme
+	Database        string   `json:"database" yaml:"database"`                 // default dialect of new apps: sqlite or postgres
+	Tools           []string `json:"tools" yaml:"tools"`                       // names of the tools to expose; empty exposes every tool
+	Templates       string   `json:"templates" yaml:"templates"`               // directory of .tmpl files shadowing the embedded templates
+	OutputFormat    string   `json:"output_format" yaml:"output_format"`       // default output_format of the produce_* tools: markdown or json
+	TemplateVersion string   `json:"template_version" yaml:"template_version"` // template generation of apps that have not pinned one, e.g. v2
+}
+
+// Files are the config files looked up in the working directory, in order, when no --config flag is given
+var Files = []string{"mcpgo.yaml", "mcpgo.yml", "mcpgo.json"}
+
+// Load reads a config file, as JSON when its name ends in .json and as YAML otherwise
+// Unknown keys are errors, so a misspelled setting does not go unnoticed
+func Load(path string) (Config, error) {
+	var c Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&c)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err = dec.Decode(&c); errors.Is(err, io.EOF) {
+			err = nil // an empty file keeps the defaults
+		}
+	}
+	if err != nil {
+		return c, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if err := c.Validate(); err != nil {
+		return c, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Validate checks the settings that have a fixed set of values
+func (c Config) Validate() error {
+	if c.ModulePrefix != "" && (strings.ContainsAny(c.ModulePrefix, " \t\"`") || strings.HasSuffix(c.ModulePrefix, "/")) {
+		return fmt.Errorf("module_prefix %q is not a module path prefix such as github.com/acme", c.ModulePrefix)
+	}
+	switch c.Database {
+	case "", "sqlite", "postgres":
+	default:
+		return fmt.Errorf("database %q: expected sqlite or postgres", c.Database)
+	}
+	switch c.OutputFormat {
+	case "", "markdown", "json":
+	default:
+		return fmt.Errorf("output_format %q: expected markdown or json", c.OutputFormat)
+	}
+	if c.TemplateVersion != "" && !slices.Contains(templates.Versions, c.TemplateVersion) {
+		return fmt.Errorf("template_version %q: expected one of %s", c.TemplateVersion, strings.Join(templates.Versions, ", "))
+	}
+	return nil
+}
+
+// ModulePath returns the module path of a new app, the app name under ModulePrefix
+func (c Config) ModulePath(appName string) string {
+	if c.ModulePrefix == "" {
+		return appName
+	}
+	return c.ModulePrefix + "/" + appName
+}
+
+// Flags are the command-line flags selecting the config file and overriding its settings
+type Flags struct {
+	set          *flag.FlagSet
+	path         *string
+	modulePrefix *string
+	database     *string
+	tools        *string
+	templates    *string
+	outputFormat *string
+	version      *string
+}
+
+// RegisterFlags defines the config flags on set
+func RegisterFlags(set *flag.FlagSet) *Flags {
+	return &Flags{
+		set:          set,
+		path:         set.String("config", "", "Config file (YAML, or JSON when it ends in .json); defaults to mcpgo.yaml, mcpgo.yml or mcpgo.json in the working directory"),
+		modulePrefix: set.String("module-prefix", "", "Prefix of the module path of new apps, e.g. github.com/acme"),
+		database:     set.String("database", "", "Default database of new apps: sqlite or postgres"),
+		tools:        set.String("tools", "", "Comma-separated names of the tools to expose; empty exposes every tool"),
+		templates:    set.String("templates", "", "Directory of .tmpl files shadowing the embedded templates"),
+		outputFormat: set.String("output-format", "", "Default output_format of the produce_* tools: markdown or json"),
+		version:      set.String("template-version", "", "Template generation of apps that have not pinned one: "+strings.Join(templates.Versions, " or ")),
+	}
+}
+
+// Load reads the config file, then applies the flags given on the command line over it
+func (f *Flags) Load() (Config, error) {
+	var c Config
+	path := *f.path
+	if path == "" {
+		for _, name := range Files {
+			if _, err := os.Stat(name); err == nil {
+				path = name
+				break
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return c, err
+			}
+		}
+	}
+	if path != "" {
+		var err error
+		if c, err = Load(path); err != nil {
+			return c, err
+		}
+	}
+
+	f.set.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "module-prefix":
+			c.ModulePrefix = *f.modulePrefix
+		case "database":
+			c.Database = *f.database
+		case "tools":
+			c.Tools = nil
+			for _, name := range strings.Split(*f.tools, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					c.Tools = append(c.Tools, name)
+				}
+			}
+		case "templates":
+			c.Templates = *f.templates
+		case "output-format":
+			c.OutputFormat = *f.outputFormat
+		case "template-version":
+			c.TemplateVersion = *f.version
+		}
+	})
+	return c, c.Validate()
+}
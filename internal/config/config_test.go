@@ -0,0 +1,73 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "mcpgo.yaml")
+	if err := os.WriteFile(yamlPath, []byte("module_prefix: github.com/acme\ndatabase: postgres\ntools:\n  - start_here_produce_app_boilerplate\n  - produce_model_boilerplate\noutput_format: json\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Load(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ModulePrefix != "github.com/acme" || c.Database != "postgres" || len(c.Tools) != 2 || c.OutputFormat != "json" {
+		t.Errorf("Load(yaml) = %+v", c)
+	}
+	if got := c.ModulePath("shop"); got != "github.com/acme/shop" {
+		t.Errorf("ModulePath(shop) = %q", got)
+	}
+
+	jsonPath := filepath.Join(dir, "mcpgo.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"templates": "./my-templates"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if c, err := Load(jsonPath); err != nil || c.Templates != "./my-templates" {
+		t.Errorf("Load(json) = %+v, %v", c, err)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"unknown.yaml":  "modul_prefix: github.com/acme\n",
+		"database.yaml": "database: oracle\n",
+		"format.json":   `{"output_format": "html"}`,
+		"prefix.yaml":   "module_prefix: github.com/acme/\n",
+		"version.yaml":  "template_version: v0\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("Load(%s) succeeded, want an error", name)
+		}
+	}
+}
+
+func TestFlagsOverrideFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "team.yaml")
+	if err := os.WriteFile(path, []byte("database: postgres\noutput_format: json\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	set := flag.NewFlagSet("mcpgo", flag.ContinueOnError)
+	flags := RegisterFlags(set)
+	if err := set.Parse([]string{"-config", path, "-output-format", "markdown", "-tools", "a, b"}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := flags.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Database != "postgres" || c.OutputFormat != "markdown" || !slices.Equal(c.Tools, []string{"a", "b"}) {
+		t.Errorf("Load() = %+v", c)
+	}
+}
@@ -0,0 +1,227 @@
+// Package diff renders line-based unified diffs, as printed by diff -u
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context is the number of unchanged lines shown around each change
+const context = 3
+
+// op is one line of an edit script: ' ' kept, '-' removed from old, '+' added from new
+type op struct {
+	kind byte
+	line string
+}
+
+// Unified returns the unified diff turning old into new, or "" when they are equal
+// oldName and newName label the --- and +++ headers, e.g. a/main.go and b/main.go
+func Unified(oldName, newName, old, new string) string {
+	if old == new {
+		return ""
+	}
+	ops := edits(splitLines(old), splitLines(new))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for start := 0; start < len(ops); {
+		// Find the next change and the end of its hunk, merging changes closer than twice the context
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		last := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				last = i
+			} else if i-last > 2*context {
+				break
+			}
+		}
+		from, to := max(first-context, start), min(last+context+1, len(ops))
+		writeHunk(&b, ops, from, to)
+		start = to
+	}
+	return b.String()
+}
+
+// writeHunk writes the @@ header and the lines of ops[from:to]
+func writeHunk(b *strings.Builder, ops []op, from, to int) {
+	oldStart, newStart := 1, 1
+	for _, o := range ops[:from] {
+		if o.kind != '+' {
+			oldStart++
+		}
+		if o.kind != '-' {
+			newStart++
+		}
+	}
+	oldCount, newCount := 0, 0
+	for _, o := range ops[from:to] {
+		if o.kind != '+' {
+			oldCount++
+		}
+		if o.kind != '-' {
+			newCount++
+		}
+	}
+	// An empty side starts at the line before the hunk, as diff -u prints it
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+	for _, o := range ops[from:to] {
+		b.WriteByte(o.kind)
+		b.WriteString(o.line)
+		if !strings.HasSuffix(o.line, "\n") {
+			b.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats a start,count pair, omitting a count of one
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// splitLines splits text after each newline, keeping the newlines
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// edits returns the shortest edit script turning a into b, from a longest common subsequence
+// The quadratic table is fine for generated source files, which are at most a few thousand lines
+func edits(a, b []string) []op {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
+
+// Merge combines the changes ours and theirs each made to base, line by line as diff3 -m does
+// Where both changed the same lines differently, the merge holds both versions between conflict markers
+// labelled oursName and theirsName, and conflicts counts those places
+func Merge(oursName, theirsName, base, ours, theirs string) (merged string, conflicts int) {
+	baseLines, oursLines, theirsLines := splitLines(base), splitLines(ours), splitLines(theirs)
+	inOurs, inTheirs := matches(baseLines, oursLines), matches(baseLines, theirsLines)
+
+	var b strings.Builder
+	i, j, k := 0, 0, 0
+	for {
+		// The next base line both sides kept ends the unstable chunk before it
+		n := i
+		for n < len(baseLines) && (inOurs[n] < 0 || inTheirs[n] < 0) {
+			n++
+		}
+		oursEnd, theirsEnd := len(oursLines), len(theirsLines)
+		if n < len(baseLines) {
+			oursEnd, theirsEnd = inOurs[n], inTheirs[n]
+		}
+		baseChunk, oursChunk, theirsChunk := baseLines[i:n], oursLines[j:oursEnd], theirsLines[k:theirsEnd]
+		switch {
+		case equalLines(oursChunk, baseChunk):
+			writeLines(&b, theirsChunk)
+		case equalLines(theirsChunk, baseChunk), equalLines(oursChunk, theirsChunk):
+			writeLines(&b, oursChunk)
+		default:
+			conflicts++
+			fmt.Fprintf(&b, "<<<<<<< %s\n", oursName)
+			writeConflictSide(&b, oursChunk)
+			b.WriteString("=======\n")
+			writeConflictSide(&b, theirsChunk)
+			fmt.Fprintf(&b, ">>>>>>> %s\n", theirsName)
+		}
+		if n == len(baseLines) {
+			return b.String(), conflicts
+		}
+		b.WriteString(baseLines[n])
+		i, j, k = n+1, oursEnd+1, theirsEnd+1
+	}
+}
+
+// matches returns, for each line of a, the index of the line of b it is kept as, or -1 when it was removed
+func matches(a, b []string) []int {
+	kept := make([]int, len(a))
+	i, j := 0, 0
+	for _, o := range edits(a, b) {
+		switch o.kind {
+		case ' ':
+			kept[i] = j
+			i++
+			j++
+		case '-':
+			kept[i] = -1
+			i++
+		default:
+			j++
+		}
+	}
+	return kept
+}
+
+// equalLines reports whether a and b hold the same lines
+func equalLines(a, b []string) bool {
+	return strings.Join(a, "") == strings.Join(b, "")
+}
+
+// writeLines writes lines as they are
+func writeLines(b *strings.Builder, lines []string) {
+	for _, line := range lines {
+		b.WriteString(line)
+	}
+}
+
+// writeConflictSide writes one side of a conflict, ending its last line so the next marker starts a line
+func writeConflictSide(b *strings.Builder, lines []string) {
+	writeLines(b, lines)
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		b.WriteString("\n")
+	}
+}
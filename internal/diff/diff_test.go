@@ -0,0 +1,47 @@
+package diff
+
+import "testing"
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name, old, new, want string
+	}{
+		{"equal", "a\nb\n", "a\nb\n", ""},
+		{"new file", "", "a\nb\n", "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"change", "a\nb\nc\n", "a\nx\nc\n", "--- a\n+++ b\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n"},
+		{"no final newline", "a\n", "a", "--- a\n+++ b\n@@ -1 +1 @@\n-a\n+a\n\\ No newline at end of file\n"},
+		{
+			"separate hunks",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			"x\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ny\n",
+			"--- a\n+++ b\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+y\n",
+		},
+	}
+	for _, tt := range tests {
+		if got := Unified("a", "b", tt.old, tt.new); got != tt.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name, base, ours, theirs, want string
+		conflicts                      int
+	}{
+		{"unchanged", "a\nb\n", "a\nb\n", "a\nb\n", "a\nb\n", 0},
+		{"ours only", "a\nb\nc\n", "a\nx\nc\n", "a\nb\nc\n", "a\nx\nc\n", 0},
+		{"theirs only", "a\nb\nc\n", "a\nb\nc\n", "a\nb\ny\n", "a\nb\ny\n", 0},
+		{"both apart", "a\nb\nc\nd\n", "x\nb\nc\nd\n", "a\nb\nc\ny\n", "x\nb\nc\ny\n", 0},
+		{"same change", "a\nb\nc\n", "a\nx\nc\n", "a\nx\nc\n", "a\nx\nc\n", 0},
+		{"ours added", "a\nc\n", "a\nkeep\nc\n", "a\nc\nd\n", "a\nkeep\nc\nd\n", 0},
+		{"conflict", "a\nb\nc\n", "a\nx\nc\n", "a\ny\nc\n", "a\n<<<<<<< ours\nx\n=======\ny\n>>>>>>> theirs\nc\n", 1},
+		{"conflict at end", "a\nb", "a\nx", "a\ny", "a\n<<<<<<< ours\nx\n=======\ny\n>>>>>>> theirs\n", 1},
+	}
+	for _, tt := range tests {
+		got, conflicts := Merge("ours", "theirs", tt.base, tt.ours, tt.theirs)
+		if got != tt.want || conflicts != tt.conflicts {
+			t.Errorf("%s: got %d conflicts\n%s\nwant %d\n%s", tt.name, conflicts, got, tt.conflicts, tt.want)
+		}
+	}
+}
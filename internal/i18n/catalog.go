@@ -0,0 +1,948 @@
+package i18n
+
+// entry is an English sentence and its translations; {0} marks text carried over unchanged (names, paths)
+type entry struct {
+	source       string
+	translations map[string]string
+}
+
+// catalog lists translatable sentences, longest first where one source is a prefix of another
+var catalog = []entry{
+	// Titles
+	{"# Echo Web Application Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar la aplicación web Echo",
+		"pt": "# Instruções para gerar a aplicação web Echo",
+		"ja": "# Echo Web アプリケーションのスキャフォールド手順",
+	}},
+	{"# Model and Repository Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el modelo y el repositorio",
+		"pt": "# Instruções para gerar o modelo e o repositório",
+		"ja": "# モデルとリポジトリのスキャフォールド手順",
+	}},
+	{"# Service Layer and DTOs Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar la capa de servicio y los DTOs",
+		"pt": "# Instruções para gerar a camada de serviço e os DTOs",
+		"ja": "# サービス層と DTO のスキャフォールド手順",
+	}},
+	{"# API Controller Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el controlador API",
+		"pt": "# Instruções para gerar o controlador de API",
+		"ja": "# API コントローラーのスキャフォールド手順",
+	}},
+	{"# HTML Controller Scaffold Instructions using templUI", map[string]string{
+		"es": "# Instrucciones para generar el controlador HTML con templUI",
+		"pt": "# Instruções para gerar o controlador HTML com templUI",
+		"ja": "# templUI を使った HTML コントローラーのスキャフォールド手順",
+	}},
+	{"# Query Scopes Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar los scopes de consulta",
+		"pt": "# Instruções para gerar os escopos de consulta",
+		"ja": "# クエリスコープのスキャフォールド手順",
+	}},
+	{"# Resilience Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar las utilidades de resiliencia",
+		"pt": "# Instruções para gerar os utilitários de resiliência",
+		"ja": "# レジリエンス用ユーティリティのスキャフォールド手順",
+	}},
+	{"# HTTP Client Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el cliente HTTP",
+		"pt": "# Instruções para gerar o cliente HTTP",
+		"ja": "# HTTP クライアントのスキャフォールド手順",
+	}},
+	{"# Idempotency Key Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el manejo de claves de idempotencia",
+		"pt": "# Instruções para gerar o tratamento de chaves de idempotência",
+		"ja": "# 冪等性キー処理のスキャフォールド手順",
+	}},
+	{"# Event Log Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el registro de eventos",
+		"pt": "# Instruções para gerar o registro de eventos",
+		"ja": "# イベントログのスキャフォールド手順",
+	}},
+	{"# Authorization Policy Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar la política de autorización",
+		"pt": "# Instruções para gerar a política de autorização",
+		"ja": "# 認可ポリシーのスキャフォールド手順",
+	}},
+	{"# Activity Feed Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el feed de actividad",
+		"pt": "# Instruções para gerar o feed de atividades",
+		"ja": "# アクティビティフィードのスキャフォールド手順",
+	}},
+	{"# Status Page Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar la página de estado",
+		"pt": "# Instruções para gerar a página de status",
+		"ja": "# ステータスページのスキャフォールド手順",
+	}},
+	{"# Usage Quota Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar la medición de uso y las cuotas",
+		"pt": "# Instruções para gerar a medição de uso e as cotas",
+		"ja": "# 使用量計測とクォータのスキャフォールド手順",
+	}},
+	{"# Archival Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el archivado de registros",
+		"pt": "# Instruções para gerar o arquivamento de registros",
+		"ja": "# アーカイブジョブのスキャフォールド手順",
+	}},
+	{"# Backfill Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el relleno de datos existentes",
+		"pt": "# Instruções para gerar o preenchimento de dados existentes",
+		"ja": "# バックフィルのスキャフォールド手順",
+	}},
+	{"# Backup Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar las copias de seguridad",
+		"pt": "# Instruções para gerar os backups",
+		"ja": "# バックアップのスキャフォールド手順",
+	}},
+	{"# In-Memory Cache Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar la caché en memoria",
+		"pt": "# Instruções para gerar o cache em memória",
+		"ja": "# インメモリキャッシュのスキャフォールド手順",
+	}},
+	{"# Deployment Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el despliegue",
+		"pt": "# Instruções para gerar o deploy",
+		"ja": "# デプロイのスキャフォールド手順",
+	}},
+	{"# Request Logging Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el registro de solicitudes",
+		"pt": "# Instruções para gerar o registro de requisições",
+		"ja": "# リクエストログのスキャフォールド手順",
+	}},
+	{"# Maintenance Mode Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el modo de mantenimiento",
+		"pt": "# Instruções para gerar o modo de manutenção",
+		"ja": "# メンテナンスモードのスキャフォールド手順",
+	}},
+	{"# Mapper Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el mapper",
+		"pt": "# Instruções para gerar o mapper",
+		"ja": "# マッパーのスキャフォールド手順",
+	}},
+	{"# Response Cache Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar la caché de respuestas",
+		"pt": "# Instruções para gerar o cache de respostas",
+		"ja": "# レスポンスキャッシュのスキャフォールド手順",
+	}},
+	{"# Wiring Checks Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar las comprobaciones de conexión",
+		"pt": "# Instruções para gerar as verificações de ligação",
+		"ja": "# 配線チェックのスキャフォールド手順",
+	}},
+	{"# Database Query Metrics Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar las métricas de consultas a la base de datos",
+		"pt": "# Instruções para gerar as métricas de consultas ao banco de dados",
+		"ja": "# データベースクエリメトリクスのスキャフォールド手順",
+	}},
+	{"# Startup Checks Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar las comprobaciones de arranque",
+		"pt": "# Instruções para gerar as verificações de inicialização",
+		"ja": "# 起動時チェックのスキャフォールド手順",
+	}},
+	{"# Configuration Profiles Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar los perfiles de configuración",
+		"pt": "# Instruções para gerar os perfis de configuração",
+		"ja": "# 設定プロファイルのスキャフォールド手順",
+	}},
+	{"# Error Pages Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar las páginas de error",
+		"pt": "# Instruções para gerar as páginas de erro",
+		"ja": "# エラーページのスキャフォールド手順",
+	}},
+	{"# SEO Metadata Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar los metadatos SEO",
+		"pt": "# Instruções para gerar os metadados de SEO",
+		"ja": "# SEO メタデータのスキャフォールド手順",
+	}},
+	{"# Wizard Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el asistente de varios pasos",
+		"pt": "# Instruções para gerar o assistente de várias etapas",
+		"ja": "# ウィザードのスキャフォールド手順",
+	}},
+	{"# Live Search Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar la búsqueda en vivo",
+		"pt": "# Instruções para gerar a busca em tempo real",
+		"ja": "# ライブ検索のスキャフォールド手順",
+	}},
+	{"# UI Library Adoption Instructions", map[string]string{
+		"es": "# Instrucciones para adoptar la biblioteca de UI",
+		"pt": "# Instruções para adotar a biblioteca de UI",
+		"ja": "# UI ライブラリの導入手順",
+	}},
+	{"# UI Library Export Instructions", map[string]string{
+		"es": "# Instrucciones para exportar la biblioteca de UI",
+		"pt": "# Instruções para exportar a biblioteca de UI",
+		"ja": "# UI ライブラリのエクスポート手順",
+	}},
+	{"# User Settings Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar las preferencias de usuario",
+		"pt": "# Instruções para gerar as preferências do usuário",
+		"ja": "# ユーザー設定のスキャフォールド手順",
+	}},
+	{"# Organizations Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar las organizaciones",
+		"pt": "# Instruções para gerar as organizações",
+		"ja": "# 組織のスキャフォールド手順",
+	}},
+	{"# Auth Throttle Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar la limitación de intentos de inicio de sesión",
+		"pt": "# Instruções para gerar a limitação de tentativas de login",
+		"ja": "# 認証試行制限のスキャフォールド手順",
+	}},
+	{"# CAPTCHA Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el CAPTCHA",
+		"pt": "# Instruções para gerar o CAPTCHA",
+		"ja": "# CAPTCHA のスキャフォールド手順",
+	}},
+	{"# Public Pages Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar las páginas públicas",
+		"pt": "# Instruções para gerar as páginas públicas",
+		"ja": "# 公開ページのスキャフォールド手順",
+	}},
+	{"# Consent Tracking Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el registro de consentimientos",
+		"pt": "# Instruções para gerar o registro de consentimentos",
+		"ja": "# 同意記録のスキャフォールド手順",
+	}},
+	{"# Invite-Only Scaffold Instructions", map[string]string{
+		"es": "# Instrucciones para generar el registro solo por invitación",
+		"pt": "# Instruções para gerar o cadastro somente por convite",
+		"ja": "# 招待制登録のスキャフォールド手順",
+	}},
+	{"# Full CRUD Scaffold Plan", map[string]string{
+		"es": "# Plan para generar el CRUD completo",
+		"pt": "# Plano para gerar o CRUD completo",
+		"ja": "# フル CRUD のスキャフォールド計画",
+	}},
+	{"# Scaffold Plan", map[string]string{
+		"es": "# Plan de la estructura generada",
+		"pt": "# Plano da estrutura gerada",
+		"ja": "# スキャフォールドの計画",
+	}},
+	{"# Verification", map[string]string{
+		"es": "# Verificación",
+		"pt": "# Verificação",
+		"ja": "# 検証",
+	}},
+	{"# Scaffold Summary", map[string]string{
+		"es": "# Resumen de la estructura generada",
+		"pt": "# Resumo da estrutura gerada",
+		"ja": "# スキャフォールドの概要",
+	}},
+
+	// Introductions
+	{"To scaffold the Echo web application '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar la aplicación web Echo '{0}', sigue estos pasos:",
+		"pt": "Para gerar a aplicação web Echo '{0}', siga estes passos:",
+		"ja": "Echo Web アプリケーション '{0}' を作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the model '{0}' and its repository, please perform the following steps:", map[string]string{
+		"es": "Para generar el modelo '{0}' y su repositorio, sigue estos pasos:",
+		"pt": "Para gerar o modelo '{0}' e seu repositório, siga estes passos:",
+		"ja": "モデル '{0}' とそのリポジトリを作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the service layer with DTOs for model '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar la capa de servicio con DTOs del modelo '{0}', sigue estos pasos:",
+		"pt": "Para gerar a camada de serviço com DTOs do modelo '{0}', siga estes passos:",
+		"ja": "モデル '{0}' のサービス層と DTO を作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the query scopes for model '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar los scopes de consulta del modelo '{0}', sigue estos pasos:",
+		"pt": "Para gerar os escopos de consulta do modelo '{0}', siga estes passos:",
+		"ja": "モデル '{0}' のクエリスコープを作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the resilience package and a service calling '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar el paquete de resiliencia y un servicio que llama a '{0}', sigue estos pasos:",
+		"pt": "Para gerar o pacote de resiliência e um serviço que chama '{0}', siga estes passos:",
+		"ja": "レジリエンスパッケージと '{0}' を呼び出すサービスを作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the typed client for the '{0}' API, please perform the following steps:", map[string]string{
+		"es": "Para generar el cliente tipado de la API '{0}', sigue estos pasos:",
+		"pt": "Para gerar o cliente tipado da API '{0}', siga estes passos:",
+		"ja": "'{0}' API の型付きクライアントを作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold Idempotency-Key handling for the application '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar el manejo de Idempotency-Key de la aplicación '{0}', sigue estos pasos:",
+		"pt": "Para gerar o tratamento de Idempotency-Key da aplicação '{0}', siga estes passos:",
+		"ja": "アプリケーション '{0}' に Idempotency-Key の処理を追加するには、次の手順を実行してください:",
+	}},
+	{"To scaffold a database-backed event log for the application '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar un registro de eventos respaldado por la base de datos para la aplicación '{0}', sigue estos pasos:",
+		"pt": "Para gerar um registro de eventos apoiado no banco de dados para a aplicação '{0}', siga estes passos:",
+		"ja": "アプリケーション '{0}' にデータベースを使ったイベントログを追加するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the ownership policy for model '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar la política de propiedad del modelo '{0}', sigue estos pasos:",
+		"pt": "Para gerar a política de propriedade do modelo '{0}', siga estes passos:",
+		"ja": "モデル '{0}' の所有者ポリシーを生成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the live admin activity feed for the application '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar el feed de actividad en vivo del panel de administración de la aplicación '{0}', sigue estos pasos:",
+		"pt": "Para gerar o feed de atividades ao vivo do painel de administração da aplicação '{0}', siga estes passos:",
+		"ja": "アプリケーション '{0}' に管理画面のライブアクティビティフィードを追加するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the public status page for the application '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar la página de estado pública de la aplicación '{0}', sigue estos pasos:",
+		"pt": "Para gerar a página de status pública da aplicação '{0}', siga estes passos:",
+		"ja": "アプリケーション '{0}' に公開ステータスページを追加するには、次の手順を実行してください:",
+	}},
+	{"To scaffold usage metering and quotas for the application '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar la medición de uso y las cuotas de la aplicación '{0}', sigue estos pasos:",
+		"pt": "Para gerar a medição de uso e as cotas da aplicação '{0}', siga estes passos:",
+		"ja": "アプリケーション '{0}' に使用量計測とクォータを追加するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the archival job for model '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar el trabajo de archivado del modelo '{0}', sigue estos pasos:",
+		"pt": "Para gerar o job de arquivamento do modelo '{0}', siga estes passos:",
+		"ja": "モデル '{0}' のアーカイブジョブを作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the backfill job '{0}' for model '{1}', please perform the following steps:", map[string]string{
+		"es": "Para generar el trabajo de relleno '{0}' del modelo '{1}', sigue estos pasos:",
+		"pt": "Para gerar o job de preenchimento '{0}' do modelo '{1}', siga estes passos:",
+		"ja": "モデル '{1}' のバックフィルジョブ '{0}' を作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold database backups for the application '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar las copias de seguridad de la base de datos de la aplicación '{0}', sigue estos pasos:",
+		"pt": "Para gerar os backups do banco de dados da aplicação '{0}', siga estes passos:",
+		"ja": "アプリケーション '{0}' のデータベースバックアップを作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold an in-memory cache of the model '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar una caché en memoria del modelo '{0}', sigue estos pasos:",
+		"pt": "Para gerar um cache em memória do modelo '{0}', siga estes passos:",
+		"ja": "モデル '{0}' のインメモリキャッシュを作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold zero-downtime Kubernetes deployments for the application '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar despliegues de Kubernetes sin tiempo de inactividad para la aplicación '{0}', sigue estos pasos:",
+		"pt": "Para gerar deploys do Kubernetes sem tempo de inatividade para a aplicação '{0}', siga estes passos:",
+		"ja": "アプリケーション '{0}' のゼロダウンタイムな Kubernetes デプロイを作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold structured request logging for the application '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar el registro estructurado de solicitudes de la aplicación '{0}', sigue estos pasos:",
+		"pt": "Para gerar o registro estruturado de requisições da aplicação '{0}', siga estes passos:",
+		"ja": "アプリケーション '{0}' に構造化リクエストログを追加するには、次の手順を実行してください:",
+	}},
+	{"To scaffold maintenance mode for the application '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar el modo de mantenimiento de la aplicación '{0}', sigue estos pasos:",
+		"pt": "Para gerar o modo de manutenção da aplicação '{0}', siga estes passos:",
+		"ja": "アプリケーション '{0}' にメンテナンスモードを追加するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the mapper between model '{0}' and its DTOs, please perform the following steps:", map[string]string{
+		"es": "Para generar el mapper entre el modelo '{0}' y sus DTOs, sigue estos pasos:",
+		"pt": "Para gerar o mapper entre o modelo '{0}' e seus DTOs, siga estes passos:",
+		"ja": "モデル '{0}' とその DTO の間のマッパーを作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold HTTP response caching for the routes of model '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar la caché de respuestas HTTP de las rutas del modelo '{0}', sigue estos pasos:",
+		"pt": "Para gerar o cache de respostas HTTP das rotas do modelo '{0}', siga estes passos:",
+		"ja": "モデル '{0}' のルートの HTTP レスポンスキャッシュを作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold compile-time wiring checks for the application '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar las comprobaciones de conexión en tiempo de compilación de la aplicación '{0}', sigue estos pasos:",
+		"pt": "Para gerar as verificações de ligação em tempo de compilação da aplicação '{0}', siga estes passos:",
+		"ja": "アプリケーション '{0}' にコンパイル時の配線チェックを追加するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the API controller for model '{0}', please perform the following steps:", map[string]string{
+		"es": "Para generar el controlador API del modelo '{0}', sigue estos pasos:",
+		"pt": "Para gerar o controlador de API do modelo '{0}', siga estes passos:",
+		"ja": "モデル '{0}' の API コントローラーを作成するには、次の手順を実行してください:",
+	}},
+	{"To scaffold the HTML controller for model '{0}' using templUI, please perform the following steps:", map[string]string{
+		"es": "Para generar el controlador HTML del modelo '{0}' con templUI, sigue estos pasos:",
+		"pt": "Para gerar o controlador HTML do modelo '{0}' com templUI, siga estes passos:",
+		"ja": "templUI を使ってモデル '{0}' の HTML コントローラーを作成するには、次の手順を実行してください:",
+	}},
+
+	// Section headings
+	{"## Prerequisites", map[string]string{
+		"es": "## Requisitos previos",
+		"pt": "## Pré-requisitos",
+		"ja": "## 前提条件",
+	}},
+	{"## Base Configuration", map[string]string{
+		"es": "## Configuración base",
+		"pt": "## Configuração base",
+		"ja": "## 基本設定",
+	}},
+	{"## Create HTML Controller Structure", map[string]string{
+		"es": "## Crear la estructura del controlador HTML",
+		"pt": "## Criar a estrutura do controlador HTML",
+		"ja": "## HTML コントローラーの構成を作成する",
+	}},
+	{"## Next Steps: Building Your Application Components", map[string]string{
+		"es": "## Próximos pasos: construir los componentes de tu aplicación",
+		"pt": "## Próximos passos: construir os componentes da sua aplicação",
+		"ja": "## 次のステップ: アプリケーションのコンポーネントを作成する",
+	}},
+	{"## Form Validation", map[string]string{
+		"es": "## Validación de formularios",
+		"pt": "## Validação de formulários",
+		"ja": "## フォームのバリデーション",
+	}},
+	{"## Understanding DTOs (Data Transfer Objects)", map[string]string{
+		"es": "## Qué son los DTOs (objetos de transferencia de datos)",
+		"pt": "## Entendendo os DTOs (objetos de transferência de dados)",
+		"ja": "## DTO（データ転送オブジェクト）について",
+	}},
+	{"### Model and repository", map[string]string{
+		"es": "### Modelo y repositorio",
+		"pt": "### Modelo e repositório",
+		"ja": "### モデルとリポジトリ",
+	}},
+	{"### Service and DTOs", map[string]string{
+		"es": "### Servicio y DTOs",
+		"pt": "### Serviço e DTOs",
+		"ja": "### サービスと DTO",
+	}},
+	{"### API controller", map[string]string{
+		"es": "### Controlador API",
+		"pt": "### Controlador de API",
+		"ja": "### API コントローラー",
+	}},
+	{"#### For API Controllers:", map[string]string{
+		"es": "#### Para controladores API:",
+		"pt": "#### Para controladores de API:",
+		"ja": "#### API コントローラーの場合:",
+	}},
+	{"#### For HTML Controllers:", map[string]string{
+		"es": "#### Para controladores HTML:",
+		"pt": "#### Para controladores HTML:",
+		"ja": "#### HTML コントローラーの場合:",
+	}},
+
+	// Steps
+	{"Create or update the file at {0} with the following content:", map[string]string{
+		"es": "Crea o actualiza el archivo {0} con el siguiente contenido:",
+		"pt": "Crie ou atualize o arquivo {0} com o seguinte conteúdo:",
+		"ja": "{0} を次の内容で作成または更新してください:",
+	}},
+	{"Create {0} in your project root with the following content:", map[string]string{
+		"es": "Crea {0} en la raíz de tu proyecto con el siguiente contenido:",
+		"pt": "Crie {0} na raiz do seu projeto com o seguinte conteúdo:",
+		"ja": "プロジェクトのルートに {0} を次の内容で作成してください:",
+	}},
+	{"Create {0} with the following content:", map[string]string{
+		"es": "Crea {0} con el siguiente contenido:",
+		"pt": "Crie {0} com o seguinte conteúdo:",
+		"ja": "{0} を次の内容で作成してください:",
+	}},
+	{"Replace {0} with the following content:", map[string]string{
+		"es": "Reemplaza {0} con el siguiente contenido:",
+		"pt": "Substitua {0} pelo seguinte conteúdo:",
+		"ja": "{0} を次の内容に置き換えてください:",
+	}},
+	{"Add the new methods to the interfaces:", map[string]string{
+		"es": "Añade los nuevos métodos a las interfaces:",
+		"pt": "Adicione os novos métodos às interfaces:",
+		"ja": "インターフェースに新しいメソッドを追加してください:",
+	}},
+	{"Add the following to your main.go file:", map[string]string{
+		"es": "Añade lo siguiente a tu archivo main.go:",
+		"pt": "Adicione o seguinte ao seu arquivo main.go:",
+		"ja": "main.go に次の内容を追加してください:",
+	}},
+	{"Every request gets a context deadline (default {0}, overridable with the {1} environment variable). Slow routes get their own budget through {2}, keyed by route path.", map[string]string{
+		"es": "Cada petición recibe un plazo en su contexto (por defecto {0}, configurable con la variable de entorno {1}). Las rutas lentas tienen su propio plazo mediante {2}, indexado por la ruta.",
+		"pt": "Cada requisição recebe um prazo no contexto (padrão {0}, configurável pela variável de ambiente {1}). Rotas lentas têm seu próprio prazo via {2}, indexado pelo caminho da rota.",
+		"ja": "すべてのリクエストのコンテキストに期限が設定されます（既定値は {0}、環境変数 {1} で変更可能）。遅いルートには、ルートのパスをキーとする {2} で個別の期限を設定できます。",
+	}},
+	{"All settings are read from {0} environment variables by {1}; the defaults suit a local SQLite file.", map[string]string{
+		"es": "Todos los ajustes se leen de las variables de entorno {0} mediante {1}; los valores por defecto sirven para un archivo SQLite local.",
+		"pt": "Todas as configurações são lidas das variáveis de ambiente {0} por {1}; os padrões servem para um arquivo SQLite local.",
+		"ja": "すべての設定は {1} が環境変数 {0} から読み込みます。既定値はローカルの SQLite ファイル向けです。",
+	}},
+	{"This typically involves:", map[string]string{
+		"es": "Normalmente esto implica:",
+		"pt": "Normalmente isso envolve:",
+		"ja": "通常は次の作業を行います:",
+	}},
+	{"For each of the following, create or update the file in {0} as needed:", map[string]string{
+		"es": "Para cada uno de los siguientes, crea o actualiza el archivo en {0} según sea necesario:",
+		"pt": "Para cada um dos itens a seguir, crie ou atualize o arquivo em {0} conforme necessário:",
+		"ja": "以下のそれぞれについて、必要に応じて {0} 内のファイルを作成または更新してください:",
+	}},
+	{"Create the directory structure (or ensure it exists):", map[string]string{
+		"es": "Crea la estructura de directorios (o asegúrate de que exista):",
+		"pt": "Crie a estrutura de diretórios (ou verifique se ela existe):",
+		"ja": "ディレクトリ構成を作成してください（既に存在する場合はそのままで構いません）:",
+	}},
+	{"Create the directory structure:", map[string]string{
+		"es": "Crea la estructura de directorios:",
+		"pt": "Crie a estrutura de diretórios:",
+		"ja": "ディレクトリ構成を作成してください:",
+	}},
+	{"Create the repository directory (or ensure it exists):", map[string]string{
+		"es": "Crea el directorio del repositorio (o asegúrate de que exista):",
+		"pt": "Crie o diretório do repositório (ou verifique se ele existe):",
+		"ja": "リポジトリ用のディレクトリを作成してください（既に存在する場合はそのままで構いません）:",
+	}},
+	{"Create the DTOs directory (or ensure it exists):", map[string]string{
+		"es": "Crea el directorio de los DTOs (o asegúrate de que exista):",
+		"pt": "Crie o diretório dos DTOs (ou verifique se ele existe):",
+		"ja": "DTO 用のディレクトリを作成してください（既に存在する場合はそのままで構いません）:",
+	}},
+	{"Create the service directory (or ensure it exists):", map[string]string{
+		"es": "Crea el directorio del servicio (o asegúrate de que exista):",
+		"pt": "Crie o diretório do serviço (ou verifique se ele existe):",
+		"ja": "サービス用のディレクトリを作成してください（既に存在する場合はそのままで構いません）:",
+	}},
+	{"Create the controller directory (or ensure it exists):", map[string]string{
+		"es": "Crea el directorio del controlador (o asegúrate de que exista):",
+		"pt": "Crie o diretório do controlador (ou verifique se ele existe):",
+		"ja": "コントローラー用のディレクトリを作成してください（既に存在する場合はそのままで構いません）:",
+	}},
+	{"Create the service files:", map[string]string{
+		"es": "Crea los archivos del servicio:",
+		"pt": "Crie os arquivos do serviço:",
+		"ja": "サービスのファイルを作成してください:",
+	}},
+	{"Initialize the Go module and fetch dependencies:", map[string]string{
+		"es": "Inicializa el módulo de Go y descarga las dependencias:",
+		"pt": "Inicialize o módulo Go e baixe as dependências:",
+		"ja": "Go モジュールを初期化し、依存関係を取得してください:",
+	}},
+	{"To run the server, navigate to the application directory and execute:", map[string]string{
+		"es": "Para ejecutar el servidor, entra en el directorio de la aplicación y ejecuta:",
+		"pt": "Para executar o servidor, entre no diretório da aplicação e execute:",
+		"ja": "サーバーを起動するには、アプリケーションのディレクトリに移動して次を実行してください:",
+	}},
+	{"Bootstrap dependencies in {0}:", map[string]string{
+		"es": "Inicializa las dependencias en {0}:",
+		"pt": "Inicialize as dependências em {0}:",
+		"ja": "{0} で依存関係を初期化してください:",
+	}},
+	{"Update your controller to use the service layer instead of repository directly.", map[string]string{
+		"es": "Actualiza tu controlador para que use la capa de servicio en lugar del repositorio directamente.",
+		"pt": "Atualize seu controlador para usar a camada de serviço em vez do repositório diretamente.",
+		"ja": "コントローラーがリポジトリを直接使わず、サービス層を使うように更新してください。",
+	}},
+	{"Install the templUI CLI and templ:", map[string]string{
+		"es": "Instala la CLI de templUI y templ:",
+		"pt": "Instale a CLI do templUI e o templ:",
+		"ja": "templUI CLI と templ をインストールしてください:",
+	}},
+	{"Install Tailwind CSS (on Mac):", map[string]string{
+		"es": "Instala Tailwind CSS (en Mac):",
+		"pt": "Instale o Tailwind CSS (no Mac):",
+		"ja": "Tailwind CSS をインストールしてください（Mac の場合）:",
+	}},
+	{"Create the CSS configuration file and base styles:", map[string]string{
+		"es": "Crea el archivo de configuración CSS y los estilos base:",
+		"pt": "Crie o arquivo de configuração CSS e os estilos base:",
+		"ja": "CSS の設定ファイルと基本スタイルを作成してください:",
+	}},
+	{"Create a Makefile for development tools:", map[string]string{
+		"es": "Crea un Makefile para las herramientas de desarrollo:",
+		"pt": "Crie um Makefile para as ferramentas de desenvolvimento:",
+		"ja": "開発ツール用の Makefile を作成してください:",
+	}},
+	{"Initialize templUI in your project:", map[string]string{
+		"es": "Inicializa templUI en tu proyecto:",
+		"pt": "Inicialize o templUI no seu projeto:",
+		"ja": "プロジェクトで templUI を初期化してください:",
+	}},
+	{"Add required components:", map[string]string{
+		"es": "Añade los componentes necesarios:",
+		"pt": "Adicione os componentes necessários:",
+		"ja": "必要なコンポーネントを追加してください:",
+	}},
+	{"Create the base layout:", map[string]string{
+		"es": "Crea el layout base:",
+		"pt": "Crie o layout base:",
+		"ja": "ベースレイアウトを作成してください:",
+	}},
+	{"Create the navbar module:", map[string]string{
+		"es": "Crea el módulo de la barra de navegación:",
+		"pt": "Crie o módulo da barra de navegação:",
+		"ja": "ナビゲーションバーのモジュールを作成してください:",
+	}},
+	{"Create the theme switcher module:", map[string]string{
+		"es": "Crea el módulo para cambiar de tema:",
+		"pt": "Crie o módulo de troca de tema:",
+		"ja": "テーマ切り替えのモジュールを作成してください:",
+	}},
+	{"Create the {0} pages:", map[string]string{
+		"es": "Crea las páginas de {0}:",
+		"pt": "Crie as páginas de {0}:",
+		"ja": "{0} のページを作成してください:",
+	}},
+	{"Create the HTML controller:", map[string]string{
+		"es": "Crea el controlador HTML:",
+		"pt": "Crie o controlador HTML:",
+		"ja": "HTML コントローラーを作成してください:",
+	}},
+	{"Update your main.go to register the HTML routes:", map[string]string{
+		"es": "Actualiza tu main.go para registrar las rutas HTML:",
+		"pt": "Atualize seu main.go para registrar as rotas HTML:",
+		"ja": "main.go を更新して HTML のルートを登録してください:",
+	}},
+	{"Start the development server:", map[string]string{
+		"es": "Inicia el servidor de desarrollo:",
+		"pt": "Inicie o servidor de desenvolvimento:",
+		"ja": "開発サーバーを起動してください:",
+	}},
+	{"This will:", map[string]string{
+		"es": "Esto hará lo siguiente:",
+		"pt": "Isso irá:",
+		"ja": "これにより次のことが行われます:",
+	}},
+	{"Create {0} (List page):", map[string]string{
+		"es": "Crea {0} (página de listado):",
+		"pt": "Crie {0} (página de listagem):",
+		"ja": "{0} を作成してください（一覧ページ）:",
+	}},
+	{"Create {0} (Detail page):", map[string]string{
+		"es": "Crea {0} (página de detalle):",
+		"pt": "Crie {0} (página de detalhes):",
+		"ja": "{0} を作成してください（詳細ページ）:",
+	}},
+	{"Create {0} (Create/Edit form):", map[string]string{
+		"es": "Crea {0} (formulario de creación/edición):",
+		"pt": "Crie {0} (formulário de criação/edição):",
+		"ja": "{0} を作成してください（作成・編集フォーム）:",
+	}},
+
+	{"### 1. Create Models", map[string]string{
+		"es": "### 1. Crear los modelos",
+		"pt": "### 1. Criar os modelos",
+		"ja": "### 1. モデルを作成する",
+	}},
+	{"### 2. Create Services", map[string]string{
+		"es": "### 2. Crear los servicios",
+		"pt": "### 2. Criar os serviços",
+		"ja": "### 2. サービスを作成する",
+	}},
+	{"### 3. Create Controllers", map[string]string{
+		"es": "### 3. Crear los controladores",
+		"pt": "### 3. Criar os controladores",
+		"ja": "### 3. コントローラーを作成する",
+	}},
+	{"### 4. Integrate Components", map[string]string{
+		"es": "### 4. Integrar los componentes",
+		"pt": "### 4. Integrar os componentes",
+		"ja": "### 4. コンポーネントを統合する",
+	}},
+	{"### 5. Add Dependencies", map[string]string{
+		"es": "### 5. Añadir las dependencias",
+		"pt": "### 5. Adicionar as dependências",
+		"ja": "### 5. 依存関係を追加する",
+	}},
+	{"### 6. Run and Test", map[string]string{
+		"es": "### 6. Ejecutar y probar",
+		"pt": "### 6. Executar e testar",
+		"ja": "### 6. 実行してテストする",
+	}},
+	{"The model declares check constraints. GORM adds them when {0} creates the table; for a table that already exists, apply these migrations:", map[string]string{
+		"es": "El modelo declara restricciones check. GORM las añade cuando {0} crea la tabla; para una tabla que ya existe, aplica estas migraciones:",
+		"pt": "O modelo declara restrições check. O GORM as adiciona quando {0} cria a tabela; para uma tabela que já existe, aplique estas migrações:",
+		"ja": "このモデルは CHECK 制約を宣言しています。GORM は {0} がテーブルを作成するときに制約を追加します。既存のテーブルには次のマイグレーションを適用してください:",
+	}},
+	{"JSON fields are stored in JSON columns (JSONB on Postgres). Run {0} for the column types and queries.", map[string]string{
+		"es": "Los campos JSON se guardan en columnas JSON (JSONB en Postgres). Ejecuta {0} para obtener los tipos de columna y las consultas.",
+		"pt": "Os campos JSON são armazenados em colunas JSON (JSONB no Postgres). Execute {0} para obter os tipos de coluna e as consultas.",
+		"ja": "JSON フィールドは JSON 列 (Postgres では JSONB) に保存されます。列型とクエリのために {0} を実行してください。",
+	}},
+	{"In {0}, accept the documents in the request DTOs and copy them in the service mapping helpers:", map[string]string{
+		"es": "En {0}, acepta los documentos en los DTO de solicitud y cópialos en las funciones de mapeo del servicio:",
+		"pt": "Em {0}, aceite os documentos nos DTOs de requisição e copie-os nas funções de mapeamento do serviço:",
+		"ja": "{0} のリクエスト DTO でドキュメントを受け取り、サービスの変換ヘルパーでコピーしてください:",
+	}},
+	{"Create the file at {0} to query inside the documents; the methods are declared in {1} below:", map[string]string{
+		"es": "Crea el archivo {0} para consultar dentro de los documentos; los métodos se declaran en {1} más abajo:",
+		"pt": "Crie o arquivo {0} para consultar dentro dos documentos; os métodos são declarados em {1} abaixo:",
+		"ja": "ドキュメント内を検索するために {0} を作成してください。メソッドは下の {1} で宣言されています:",
+	}},
+	{"Array fields use {0} array types, which map to Postgres array columns. Run {1}.", map[string]string{
+		"es": "Los campos de tipo array usan los tipos de array de {0}, que se corresponden con columnas array de Postgres. Ejecuta {1}.",
+		"pt": "Os campos de array usam os tipos de array de {0}, que correspondem a colunas array do Postgres. Execute {1}.",
+		"ja": "配列フィールドは {0} の配列型を使い、Postgres の配列列に対応します。{1} を実行してください。",
+	}},
+	{"In {0}, expose them as plain slices, and convert in the service mapping helpers:", map[string]string{
+		"es": "En {0}, exponlos como slices normales y conviértelos en las funciones de mapeo del servicio:",
+		"pt": "Em {0}, exponha-os como slices simples e converta-os nas funções de mapeamento do serviço:",
+		"ja": "{0} では通常のスライスとして公開し、サービスの変換ヘルパーで変換してください:",
+	}},
+	{"Create the file at {0} to filter on the arrays; the methods are declared in {1} below:", map[string]string{
+		"es": "Crea el archivo {0} para filtrar por los arrays; los métodos se declaran en {1} más abajo:",
+		"pt": "Crie o arquivo {0} para filtrar pelos arrays; os métodos são declarados em {1} abaixo:",
+		"ja": "配列で絞り込むために {0} を作成してください。メソッドは下の {1} で宣言されています:",
+	}},
+	{"Geometry fields are stored in PostGIS columns with SRID 4326 (longitude/latitude). Enable the extension once per database before migrating:", map[string]string{
+		"es": "Los campos geométricos se guardan en columnas PostGIS con SRID 4326 (longitud/latitud). Habilita la extensión una vez por base de datos antes de migrar:",
+		"pt": "Os campos geométricos são armazenados em colunas PostGIS com SRID 4326 (longitude/latitude). Habilite a extensão uma vez por banco de dados antes de migrar:",
+		"ja": "ジオメトリフィールドは SRID 4326（経度/緯度）の PostGIS 列に保存されます。マイグレーションの前に、データベースごとに一度拡張機能を有効にしてください:",
+	}},
+	{"Create the file at {0} with the value types, which read and write PostGIS columns:", map[string]string{
+		"es": "Crea el archivo {0} con los tipos de valor, que leen y escriben columnas PostGIS:",
+		"pt": "Crie o arquivo {0} com os tipos de valor, que leem e gravam colunas PostGIS:",
+		"ja": "PostGIS 列を読み書きする値型を含む {0} を作成してください:",
+	}},
+	{"Create the file at {0} with the nearest-neighbour queries; the methods are declared in {1} below:", map[string]string{
+		"es": "Crea el archivo {0} con las consultas de vecino más cercano; los métodos se declaran en {1} más abajo:",
+		"pt": "Crie o arquivo {0} com as consultas de vizinho mais próximo; os métodos são declarados em {1} abaixo:",
+		"ja": "最近傍検索を含む {0} を作成してください。メソッドは下の {1} で宣言されています:",
+	}},
+	{"Create the file at {0}, and add {1} to {2}; the PATCH route is part of step 3:", map[string]string{
+		"es": "Crea el archivo {0} y añade {1} a {2}; la ruta PATCH forma parte del paso 3:",
+		"pt": "Crie o arquivo {0} e adicione {1} a {2}; a rota PATCH faz parte do passo 3:",
+		"ja": "{0} を作成し、{2} に {1} を追加してください。PATCH ルートは手順 3 に含まれています:",
+	}},
+	{"Register the routes in {0}:", map[string]string{
+		"es": "Registra las rutas en {0}:",
+		"pt": "Registre as rotas em {0}:",
+		"ja": "{0} でルートを登録してください:",
+	}},
+	{"Create the file at {0}, add {1} to {2}, and register the route before {3}:", map[string]string{
+		"es": "Crea el archivo {0}, añade {1} a {2} y registra la ruta antes de {3}:",
+		"pt": "Crie o arquivo {0}, adicione {1} a {2} e registre a rota antes de {3}:",
+		"ja": "{0} を作成し、{2} に {1} を追加して、{3} より前にルートを登録してください:",
+	}},
+	{"Create the file at {0}, and add {1} to {2}:", map[string]string{
+		"es": "Crea el archivo {0} y añade {1} a {2}:",
+		"pt": "Crie o arquivo {0} e adicione {1} a {2}:",
+		"ja": "{0} を作成し、{2} に {1} を追加してください:",
+	}},
+	{"The DTOs carry the value types as they are:", map[string]string{
+		"es": "Los DTO usan los tipos de valor tal cual:",
+		"pt": "Os DTOs usam os tipos de valor como estão:",
+		"ja": "DTO では値型をそのまま使います:",
+	}},
+	{"The fields declare validation rules. Run {0}, and create the file at {1}:", map[string]string{
+		"es": "Los campos declaran reglas de validación. Ejecuta {0} y crea el archivo {1}:",
+		"pt": "Os campos declaram regras de validação. Execute {0} e crie o arquivo {1}:",
+		"ja": "フィールドにバリデーションルールが宣言されています。{0} を実行し、{1} を作成してください:",
+	}},
+	{"Register it in {0} with {1}, and call {2} right after {3} in the create and update handlers.", map[string]string{
+		"es": "Regístralo en {0} con {1} y llama a {2} justo después de {3} en los handlers de creación y actualización.",
+		"pt": "Registre-o em {0} com {1} e chame {2} logo após {3} nos handlers de criação e atualização.",
+		"ja": "{0} で {1} として登録し、作成と更新のハンドラーで {3} の直後に {2} を呼び出してください。",
+	}},
+	{"The API handlers return the error as is (400 with a message per field); the HTML handlers re-render the form with {0}.", map[string]string{
+		"es": "Los handlers de la API devuelven el error tal cual (400 con un mensaje por campo); los handlers HTML vuelven a mostrar el formulario con {0}.",
+		"pt": "Os handlers da API retornam o erro como está (400 com uma mensagem por campo); os handlers HTML renderizam o formulário novamente com {0}.",
+		"ja": "API ハンドラーはエラーをそのまま返し（フィールドごとのメッセージ付きの 400）、HTML ハンドラーは {0} を使ってフォームを再表示します。",
+	}},
+	{"The service tool generates these request fields in {0}:", map[string]string{
+		"es": "La herramienta de servicios genera estos campos de solicitud en {0}:",
+		"pt": "A ferramenta de serviço gera estes campos de requisição em {0}:",
+		"ja": "サービスツールは {0} に次のリクエストフィールドを生成します:",
+	}},
+	{"The {0} fields declare validation rules. Use these inputs in {1} in place of the example fields; their attributes mirror the validate tags of the request DTOs, so the browser rejects invalid input before it is submitted:", map[string]string{
+		"es": "Los campos de {0} declaran reglas de validación. Usa estos inputs en {1} en lugar de los campos de ejemplo; sus atributos reflejan las etiquetas validate de los DTO de solicitud, así que el navegador rechaza la entrada no válida antes de enviarla:",
+		"pt": "Os campos de {0} declaram regras de validação. Use estes inputs em {1} no lugar dos campos de exemplo; os atributos refletem as tags validate dos DTOs de requisição, então o navegador rejeita entradas inválidas antes do envio:",
+		"ja": "{0} のフィールドにはバリデーションルールが宣言されています。{1} では例のフィールドの代わりにこれらの入力を使ってください。属性はリクエスト DTO の validate タグと対応しているため、ブラウザーが送信前に不正な入力を拒否します:",
+	}},
+	{"Numeric inputs format their values with {0}, so import {1} in the page, and add the fields to {2} if it does not carry them yet.", map[string]string{
+		"es": "Los inputs numéricos formatean sus valores con {0}, así que importa {1} en la página y añade los campos a {2} si aún no los tiene.",
+		"pt": "Os inputs numéricos formatam os valores com {0}, então importe {1} na página e adicione os campos a {2} se ainda não os tiver.",
+		"ja": "数値の入力は {0} で値を整形するため、ページで {1} をインポートし、{2} にまだフィールドがなければ追加してください。",
+	}},
+	{"In the Create and Update handlers, call {0} after {1}, and re-render the form with {2} as the errors map when it fails.", map[string]string{
+		"es": "En los handlers Create y Update, llama a {0} después de {1} y, si falla, vuelve a mostrar el formulario con {2} como mapa de errores.",
+		"pt": "Nos handlers Create e Update, chame {0} após {1} e, se falhar, renderize o formulário novamente com {2} como mapa de erros.",
+		"ja": "Create と Update のハンドラーでは {1} の後に {0} を呼び出し、失敗した場合は {2} をエラーマップとしてフォームを再表示してください。",
+	}},
+	{"Make sure {0} declares the fields the mapper reads and writes:", map[string]string{
+		"es": "Asegúrate de que {0} declare los campos que el mapper lee y escribe:",
+		"pt": "Garanta que {0} declare os campos que o mapper lê e escreve:",
+		"ja": "{0} にマッパーが読み書きするフィールドが宣言されていることを確認してください:",
+	}},
+	{"Use the mapper instead of the inline helpers, so the service and both controllers convert the same way:", map[string]string{
+		"es": "Usa el mapper en lugar de las funciones auxiliares en línea, para que el servicio y ambos controladores conviertan de la misma forma:",
+		"pt": "Use o mapper no lugar das funções auxiliares inline, para que o serviço e os dois controladores convertam da mesma forma:",
+		"ja": "インラインのヘルパーの代わりにマッパーを使い、サービスと両方のコントローラーが同じ方法で変換するようにしてください:",
+	}},
+	{"API controllers keep calling the service, which now returns mapper output.", map[string]string{
+		"es": "Los controladores de la API siguen llamando al servicio, que ahora devuelve la salida del mapper.",
+		"pt": "Os controladores da API continuam chamando o serviço, que agora retorna a saída do mapper.",
+		"ja": "API コントローラーは引き続きサービスを呼び出し、サービスはマッパーの出力を返します。",
+	}},
+	{"The enum fields get named string types. Create the file at {0}:", map[string]string{
+		"es": "Los campos enum reciben tipos string con nombre. Crea el archivo {0}:",
+		"pt": "Os campos enum recebem tipos string nomeados. Crie o arquivo {0}:",
+		"ja": "enum フィールドには名前付きの文字列型が割り当てられます。{0} を作成してください:",
+	}},
+	{"and change the fields of {0} to these types; GORM stores them as plain strings:", map[string]string{
+		"es": "y cambia los campos de {0} a estos tipos; GORM los guarda como strings normales:",
+		"pt": "e altere os campos de {0} para estes tipos; o GORM os armazena como strings simples:",
+		"ja": "{0} のフィールドをこれらの型に変更してください。GORM は通常の文字列として保存します:",
+	}},
+	{"Create the file at {0} so every error is rendered as {1}:", map[string]string{
+		"es": "Crea el archivo {0} para que todos los errores se devuelvan como {1}:",
+		"pt": "Crie o arquivo {0} para que todos os erros sejam retornados como {1}:",
+		"ja": "{0} を作成し、すべてのエラーが {1} として返されるようにしてください:",
+	}},
+	{"Install the handler in {0}, right after {1}:", map[string]string{
+		"es": "Instala el manejador en {0}, justo después de {1}:",
+		"pt": "Instale o handler em {0}, logo após {1}:",
+		"ja": "{0} の {1} の直後でハンドラーを設定してください:",
+	}},
+	{"Handlers return {0}; errors from Echo itself (unknown routes, bind failures) and from {1} are converted too, with invalid fields listed under {2}. Details of 5xx errors are logged and never sent to the client.", map[string]string{
+		"es": "Los handlers devuelven {0}; los errores del propio Echo (rutas desconocidas, fallos de bind) y de {1} también se convierten, con los campos no válidos listados en {2}. Los detalles de los errores 5xx se registran y nunca se envían al cliente.",
+		"pt": "Os handlers retornam {0}; os erros do próprio Echo (rotas desconhecidas, falhas de bind) e de {1} também são convertidos, com os campos inválidos listados em {2}. Os detalhes dos erros 5xx são registrados e nunca enviados ao cliente.",
+		"ja": "ハンドラーは {0} を返します。Echo 自体のエラー (未知のルート、bind の失敗) や {1} のエラーも変換され、不正なフィールドは {2} に列挙されます。5xx エラーの詳細はログに記録され、クライアントには送信されません。",
+	}},
+	{"Add a PATCH endpoint applying JSON merge patches (RFC 7386). Unlike the PUT handler, whose pointer DTO cannot tell a missing field from one to clear, members set to {0} are cleared and absent members keep their stored values.", map[string]string{
+		"es": "Añade un endpoint PATCH que aplique JSON merge patches (RFC 7386). A diferencia del handler PUT, cuyo DTO de punteros no distingue un campo ausente de uno que se debe vaciar, los miembros con {0} se vacían y los ausentes conservan su valor guardado.",
+		"pt": "Adicione um endpoint PATCH que aplique JSON merge patches (RFC 7386). Ao contrário do handler PUT, cujo DTO de ponteiros não distingue um campo ausente de um que deve ser limpo, os membros com {0} são limpos e os ausentes mantêm o valor armazenado.",
+		"ja": "JSON Merge Patch (RFC 7386) を適用する PATCH エンドポイントを追加してください。ポインター DTO では欠けているフィールドとクリアしたいフィールドを区別できない PUT ハンドラーと異なり、{0} を指定したメンバーはクリアされ、指定のないメンバーは保存済みの値を保ちます。",
+	}},
+	{"Create the file at {0} with the merge function:", map[string]string{
+		"es": "Crea el archivo {0} con la función de merge:",
+		"pt": "Crie o arquivo {0} com a função de merge:",
+		"ja": "merge 関数を含む {0} を作成してください:",
+	}},
+	{"The patch is applied to the stored record as JSON, so model fields hidden with {0} are not part of the document: copy them from the existing record before saving.", map[string]string{
+		"es": "El patch se aplica al registro guardado como JSON, así que los campos del modelo ocultos con {0} no forman parte del documento: cópialos del registro existente antes de guardar.",
+		"pt": "O patch é aplicado ao registro armazenado como JSON, então os campos do modelo ocultos com {0} não fazem parte do documento: copie-os do registro existente antes de salvar.",
+		"ja": "patch は保存済みレコードの JSON に適用されるため、{0} で隠したモデルのフィールドはドキュメントに含まれません。保存前に既存レコードからコピーしてください。",
+	}},
+	{"The list and get handlers answer in the format named by the {0} header: JSON (the default), CSV or XML, and 406 when none of them is acceptable. CSV lists one row per record, with the JSON field names as the header.", map[string]string{
+		"es": "Los handlers de listado y consulta responden en el formato indicado por la cabecera {0}: JSON (por defecto), CSV o XML, y 406 cuando ninguno es aceptable. El CSV tiene una fila por registro, con los nombres de campo JSON como cabecera.",
+		"pt": "Os handlers de listagem e consulta respondem no formato indicado pelo cabeçalho {0}: JSON (o padrão), CSV ou XML, e 406 quando nenhum é aceitável. O CSV tem uma linha por registro, com os nomes de campo JSON como cabeçalho.",
+		"ja": "一覧と取得のハンドラーは {0} ヘッダーで指定された形式 (既定は JSON、ほかに CSV と XML) で応答し、どれも受け付けられない場合は 406 を返します。CSV はレコードごとに 1 行で、JSON のフィールド名がヘッダーになります。",
+	}},
+	{"Create the file at {0} with the serializers, which export endpoints can reuse:", map[string]string{
+		"es": "Crea el archivo {0} con los serializadores, que los endpoints de exportación pueden reutilizar:",
+		"pt": "Crie o arquivo {0} com os serializadores, que os endpoints de exportação podem reutilizar:",
+		"ja": "エクスポート用エンドポイントでも再利用できるシリアライザーを含む {0} を作成してください:",
+	}},
+	{"Create the file at {0} with the negotiation:", map[string]string{
+		"es": "Crea el archivo {0} con la negociación:",
+		"pt": "Crie o arquivo {0} com a negociação:",
+		"ja": "ネゴシエーションを行う {0} を作成してください:",
+	}},
+	{"Try it with {0}. XML cannot encode map fields, so give JSON document fields a struct type before offering XML.", map[string]string{
+		"es": "Pruébalo con {0}. XML no puede codificar campos de tipo map, así que da un tipo struct a los campos de documentos JSON antes de ofrecer XML.",
+		"pt": "Teste com {0}. XML não consegue codificar campos do tipo map, então dê um tipo struct aos campos de documentos JSON antes de oferecer XML.",
+		"ja": "{0} で試してください。XML は map 型のフィールドをエンコードできないため、XML を提供する前に JSON ドキュメントのフィールドを struct 型にしてください。",
+	}},
+	{"Mirror the constraints in {0} so invalid input is rejected with 400 before it reaches the database:", map[string]string{
+		"es": "Replica las restricciones en {0} para que la entrada no válida se rechace con 400 antes de llegar a la base de datos:",
+		"pt": "Replique as restrições em {0} para que entradas inválidas sejam rejeitadas com 400 antes de chegar ao banco de dados:",
+		"ja": "{0} にも同じ制約を記述し、不正な入力がデータベースに届く前に 400 で拒否されるようにしてください:",
+	}},
+
+	// File descriptions
+	{"(constructor and interface for dependency injection):", map[string]string{
+		"es": "(constructor e interfaz para la inyección de dependencias):",
+		"pt": "(construtor e interface para injeção de dependências):",
+		"ja": "（依存性注入のためのコンストラクターとインターフェース）:",
+	}},
+	{"(interface and constructor):", map[string]string{
+		"es": "(interfaz y constructor):",
+		"pt": "(interface e construtor):",
+		"ja": "（インターフェースとコンストラクター）:",
+	}},
+	{"(Get method - many-to-many with filtering):", map[string]string{
+		"es": "(método Get - muchos a muchos con filtrado):",
+		"pt": "(método Get - muitos para muitos com filtragem):",
+		"ja": "（Get メソッド - フィルター付きの多対多）:",
+	}},
+	{"({0} method - JSON request & response):", map[string]string{
+		"es": "(método {0} - petición y respuesta JSON):",
+		"pt": "(método {0} - requisição e resposta JSON):",
+		"ja": "（{0} メソッド - JSON リクエストとレスポンス）:",
+	}},
+	{"({0} method):", map[string]string{
+		"es": "(método {0}):",
+		"pt": "(método {0}):",
+		"ja": "（{0} メソッド）:",
+	}},
+
+	// Embedded file summaries
+	{"Run the following commands:", map[string]string{
+		"es": "Ejecuta los siguientes comandos:",
+		"pt": "Execute os seguintes comandos:",
+		"ja": "次のコマンドを実行してください:",
+	}},
+	{"Create the following files (attached as embedded resources):", map[string]string{
+		"es": "Crea los siguientes archivos (adjuntos como recursos embebidos):",
+		"pt": "Crie os seguintes arquivos (anexados como recursos incorporados):",
+		"ja": "次のファイルを作成してください（埋め込みリソースとして添付されています）:",
+	}},
+	{"Wrote the following files under {0}:", map[string]string{
+		"es": "Se escribieron los siguientes archivos en {0}:",
+		"pt": "Os seguintes arquivos foram gravados em {0}:",
+		"ja": "次のファイルを {0} に書き込みました:",
+	}},
+	{"Regenerated the following files, keeping their protected regions:", map[string]string{
+		"es": "Se regeneraron los siguientes archivos, conservando sus regiones protegidas:",
+		"pt": "Os seguintes arquivos foram regenerados, mantendo suas regiões protegidas:",
+		"ja": "次のファイルを保護された領域を残したまま再生成しました:",
+	}},
+	{"Overwrote the following files with the generated content:", map[string]string{
+		"es": "Se sobrescribieron los siguientes archivos con el contenido generado:",
+		"pt": "Os seguintes arquivos foram sobrescritos com o conteúdo gerado:",
+		"ja": "次のファイルを生成された内容で上書きしました:",
+	}},
+	{"The following files already have the generated content:", map[string]string{
+		"es": "Los siguientes archivos ya tienen el contenido generado:",
+		"pt": "Os seguintes arquivos já têm o conteúdo gerado:",
+		"ja": "次のファイルは既に生成された内容になっています:",
+	}},
+	{"go.mod already requires every module the generated code imports.", map[string]string{
+		"es": "go.mod ya requiere todos los módulos que importa el código generado.",
+		"pt": "O go.mod já requer todos os módulos que o código gerado importa.",
+		"ja": "go.mod は生成されたコードがインポートするすべてのモジュールを既に require しています。",
+	}},
+	{"The following files already exist and were left unchanged. Merge the generated content into them:", map[string]string{
+		"es": "Los siguientes archivos ya existen y no se modificaron. Incorpora en ellos el contenido generado:",
+		"pt": "Os seguintes arquivos já existem e não foram alterados. Incorpore neles o conteúdo gerado:",
+		"ja": "次のファイルは既に存在するため変更していません。生成された内容を手作業でマージしてください:",
+	}},
+	{"# Scaffold Conflicts", map[string]string{
+		"es": "# Conflictos de la estructura generada",
+		"pt": "# Conflitos da estrutura gerada",
+		"ja": "# スキャフォールドの競合",
+	}},
+	{"Nothing was written: the following files under {0} changed since they were generated. Merge the generated content into them by hand, or pass force=true to overwrite them:", map[string]string{
+		"es": "No se escribió nada: los siguientes archivos en {0} cambiaron desde que se generaron. Incorpora en ellos el contenido generado a mano, o pasa force=true para sobrescribirlos:",
+		"pt": "Nada foi gravado: os seguintes arquivos em {0} mudaram desde que foram gerados. Incorpore neles o conteúdo gerado manualmente, ou passe force=true para sobrescrevê-los:",
+		"ja": "何も書き込んでいません: {0} の次のファイルは生成後に変更されています。生成された内容を手作業でマージするか、force=true を指定して上書きしてください:",
+	}},
+	{"# Scaffold Dry Run", map[string]string{
+		"es": "# Simulación de la estructura generada",
+		"pt": "# Simulação da estrutura gerada",
+		"ja": "# スキャフォールドのドライラン",
+	}},
+	{"Nothing was written. Compared with the files under {0}:", map[string]string{
+		"es": "No se escribió nada. Comparado con los archivos en {0}:",
+		"pt": "Nada foi gravado. Comparado com os arquivos em {0}:",
+		"ja": "何も書き込んでいません。{0} 以下のファイルとの比較:",
+	}},
+	{"Existing files that differ (write_files keeps them; merge by hand):", map[string]string{
+		"es": "Archivos existentes que difieren (write_files los conserva; combínalos a mano):",
+		"pt": "Arquivos existentes que diferem (write_files os mantém; mescle manualmente):",
+		"ja": "内容が異なる既存のファイル（write_files は変更しないため、手作業でマージしてください）:",
+	}},
+	{"Existing files with protected regions (write_files regenerates them, keeping the regions):", map[string]string{
+		"es": "Archivos existentes con regiones protegidas (write_files los regenera, conservando las regiones):",
+		"pt": "Arquivos existentes com regiões protegidas (write_files os regenera, mantendo as regiões):",
+		"ja": "保護された領域を持つ既存のファイル（write_files が領域を残したまま再生成します）:",
+	}},
+	{"Existing files that differ (write_files overwrites them):", map[string]string{
+		"es": "Archivos existentes que difieren (write_files los sobrescribe):",
+		"pt": "Arquivos existentes que diferem (write_files os sobrescreve):",
+		"ja": "内容が異なる既存のファイル（write_files が上書きします）:",
+	}},
+	{"Files changed since they were written (write_files refuses to overwrite them without force):", map[string]string{
+		"es": "Archivos modificados desde que se escribieron (write_files no los sobrescribe sin force):",
+		"pt": "Arquivos alterados desde que foram gravados (write_files não os sobrescreve sem force):",
+		"ja": "書き込み後に変更されたファイル（force なしでは write_files は上書きしません）:",
+	}},
+	{"Existing files already up to date:", map[string]string{
+		"es": "Archivos existentes ya actualizados:",
+		"pt": "Arquivos existentes já atualizados:",
+		"ja": "既に最新の既存ファイル:",
+	}},
+	{"New files:", map[string]string{
+		"es": "Archivos nuevos:",
+		"pt": "Arquivos novos:",
+		"ja": "新しいファイル:",
+	}},
+	{"Notes:", map[string]string{
+		"es": "Notas:",
+		"pt": "Observações:",
+		"ja": "注意事項:",
+	}},
+}
@@ -0,0 +1,91 @@
+package i18n
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultLanguage is the language the instructions are written in
+const DefaultLanguage = "en"
+
+// message is a compiled catalog entry matching an English sentence with optional {0} placeholders
+type message struct {
+	pattern      *regexp.Regexp
+	translations map[string]string
+}
+
+var messages = compile(catalog)
+
+// Supported returns the languages instructions can be produced in
+func Supported() []string {
+	return []string{"en", "es", "pt", "ja"}
+}
+
+// IsSupported reports whether lang has a message catalog
+func IsSupported(lang string) bool {
+	for _, l := range Supported() {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// Translate rewrites every catalogued sentence in the prose of markdown into lang
+// Fenced code blocks are left untouched, and sentences missing from the catalog stay in English
+func Translate(markdown, lang string) string {
+	if lang == DefaultLanguage || !IsSupported(lang) {
+		return markdown
+	}
+
+	lines := strings.Split(markdown, "\n")
+	inCode := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+		lines[i] = translateLine(line, lang)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// translateLine applies every catalog entry to a single line of prose
+func translateLine(line, lang string) string {
+	for _, m := range messages {
+		translation, ok := m.translations[lang]
+		if !ok {
+			continue
+		}
+		line = m.pattern.ReplaceAllString(line, translation)
+	}
+	return line
+}
+
+// compile turns catalog entries into regular expressions, mapping {n} placeholders to capture groups
+func compile(entries []entry) []message {
+	placeholder := regexp.MustCompile(`\{(\d)\}`)
+	compiled := make([]message, 0, len(entries))
+	for _, e := range entries {
+		parts := placeholder.Split(e.source, -1)
+		quoted := make([]string, len(parts))
+		for i, part := range parts {
+			quoted[i] = regexp.QuoteMeta(part)
+		}
+		translations := make(map[string]string, len(e.translations))
+		for lang, t := range e.translations {
+			t = strings.ReplaceAll(t, "$", "$$")
+			translations[lang] = placeholder.ReplaceAllStringFunc(t, func(p string) string {
+				return "${" + string(rune(p[1]+1)) + "}"
+			})
+		}
+		compiled = append(compiled, message{
+			pattern:      regexp.MustCompile(strings.Join(quoted, "(.+?)")),
+			translations: translations,
+		})
+	}
+	return compiled
+}
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// durationBuckets are the upper bounds (in seconds) of the tool latency histogram
+var durationBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30}
+
+// toolStats holds the counters collected for a single tool
+type toolStats struct {
+	calls        uint64
+	errors       uint64
+	outputBytes  uint64
+	durationSum  float64
+	bucketCounts []uint64
+}
+
+// Registry collects operational metrics about tool invocations
+type Registry struct {
+	mu    sync.Mutex
+	tools map[string]*toolStats
+}
+
+// NewRegistry creates an empty metrics registry
+func NewRegistry() *Registry {
+	return &Registry{tools: make(map[string]*toolStats)}
+}
+
+// Observe records a single tool invocation
+func (r *Registry) Observe(tool string, duration time.Duration, outputBytes int, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats, ok := r.tools[tool]
+	if !ok {
+		stats = &toolStats{bucketCounts: make([]uint64, len(durationBuckets))}
+		r.tools[tool] = stats
+	}
+
+	stats.calls++
+	if failed {
+		stats.errors++
+	}
+	stats.outputBytes += uint64(outputBytes)
+	seconds := duration.Seconds()
+	stats.durationSum += seconds
+	for i, bound := range durationBuckets {
+		if seconds <= bound {
+			stats.bucketCounts[i]++
+		}
+	}
+}
+
+// Middleware records the latency, outcome and output size of every tool call
+func (r *Registry) Middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, request)
+			r.Observe(request.Params.Name, time.Since(start), outputSize(result), err != nil || (result != nil && result.IsError))
+			return result, err
+		}
+	}
+}
+
+// Handler serves the collected metrics in the Prometheus text exposition format
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		fmt.Fprint(w, r.render())
+	})
+}
+
+// render formats every metric family, with tools in sorted order for stable output
+func (r *Registry) render() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.tools))
+	for name := range r.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("# HELP mcpgo_tool_calls_total Total number of tool calls.\n")
+	b.WriteString("# TYPE mcpgo_tool_calls_total counter\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "mcpgo_tool_calls_total{tool=%q} %d\n", name, r.tools[name].calls)
+	}
+
+	b.WriteString("# HELP mcpgo_tool_errors_total Total number of tool calls that returned an error.\n")
+	b.WriteString("# TYPE mcpgo_tool_errors_total counter\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "mcpgo_tool_errors_total{tool=%q} %d\n", name, r.tools[name].errors)
+	}
+
+	b.WriteString("# HELP mcpgo_tool_output_bytes_total Total size of text returned by tool calls.\n")
+	b.WriteString("# TYPE mcpgo_tool_output_bytes_total counter\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "mcpgo_tool_output_bytes_total{tool=%q} %d\n", name, r.tools[name].outputBytes)
+	}
+
+	b.WriteString("# HELP mcpgo_tool_duration_seconds Latency of tool calls.\n")
+	b.WriteString("# TYPE mcpgo_tool_duration_seconds histogram\n")
+	for _, name := range names {
+		stats := r.tools[name]
+		for i, bound := range durationBuckets {
+			fmt.Fprintf(&b, "mcpgo_tool_duration_seconds_bucket{tool=%q,le=\"%g\"} %d\n", name, bound, stats.bucketCounts[i])
+		}
+		fmt.Fprintf(&b, "mcpgo_tool_duration_seconds_bucket{tool=%q,le=\"+Inf\"} %d\n", name, stats.calls)
+		fmt.Fprintf(&b, "mcpgo_tool_duration_seconds_sum{tool=%q} %g\n", name, stats.durationSum)
+		fmt.Fprintf(&b, "mcpgo_tool_duration_seconds_count{tool=%q} %d\n", name, stats.calls)
+	}
+
+	return b.String()
+}
+
+// outputSize returns the number of bytes a tool result carries: its text, the text and base64 blobs of its
+// embedded resources, and its structured content as JSON
+func outputSize(result *mcp.CallToolResult) int {
+	if result == nil {
+		return 0
+	}
+	size := 0
+	for _, content := range result.Content {
+		switch content := content.(type) {
+		case mcp.TextContent:
+			size += len(content.Text)
+		case mcp.EmbeddedResource:
+			switch resource := content.Resource.(type) {
+			case mcp.TextResourceContents:
+				size += len(resource.Text)
+			case mcp.BlobResourceContents:
+				size += len(resource.Blob)
+			}
+		}
+	}
+	if result.StructuredContent != nil {
+		if structured, err := json.Marshal(result.StructuredContent); err == nil {
+			size += len(structured)
+		}
+	}
+	return size
+}
@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestOutputSize(t *testing.T) {
+	result := &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent("summary"),
+			mcp.NewEmbeddedResource(mcp.TextResourceContents{URI: "file:///a.go", Text: "package a\n"}),
+			mcp.NewEmbeddedResource(mcp.BlobResourceContents{URI: "file:///a.png", Blob: "aGVsbG8="}),
+		},
+		StructuredContent: map[string]any{"files": []string{"a.go"}},
+	}
+	if got, want := outputSize(result), len("summary")+len("package a\n")+len("aGVsbG8=")+len(`{"files":["a.go"]}`); got != want {
+		t.Errorf("outputSize = %d, want %d", got, want)
+	}
+	if got := outputSize(nil); got != 0 {
+		t.Errorf("outputSize(nil) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,120 @@
+package naming
+
+import (
+	"strings"
+	"unicode"
+)
+
+// initialisms are the words Go spells in one case, e.g. UserID rather than UserId, as listed by golint
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true, "EOF": true, "GUID": true,
+	"HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true, "QPS": true, "RAM": true,
+	"RPC": true, "SLA": true, "SMTP": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true, "TTL": true,
+	"UDP": true, "UI": true, "UID": true, "UUID": true, "URI": true, "URL": true, "UTF8": true, "VM": true,
+	"XML": true, "XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// reserved are the Go keywords and predeclared identifiers; generated code names variables after models, so they cannot be used
+var reserved = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true, "defer": true,
+	"else": true, "fallthrough": true, "for": true, "func": true, "go": true, "goto": true, "if": true,
+	"import": true, "interface": true, "map": true, "package": true, "range": true, "return": true,
+	"select": true, "struct": true, "switch": true, "type": true, "var": true,
+	"any": true, "bool": true, "byte": true, "comparable": true, "complex64": true, "complex128": true,
+	"error": true, "float32": true, "float64": true, "int": true, "int8": true, "int16": true, "int32": true,
+	"int64": true, "rune": true, "string": true, "uint": true, "uint8": true, "uint16": true, "uint32": true,
+	"uint64": true, "uintptr": true, "true": true, "false": true, "iota": true, "nil": true,
+	"append": true, "cap": true, "clear": true, "close": true, "complex": true, "copy": true, "delete": true,
+	"imag": true, "len": true, "make": true, "max": true, "min": true, "new": true, "panic": true,
+	"print": true, "println": true, "real": true, "recover": true,
+}
+
+// Reserved reports whether an identifier is a Go keyword or predeclared identifier, e.g. type or string
+func Reserved(identifier string) bool {
+	return reserved[identifier]
+}
+
+// Words splits a name in any case into its words: on separators, where a lowercase letter or digit meets an
+// uppercase one, and before the last letter of an acronym, e.g. order_item, orderItem and HTTPClient
+func Words(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+			}
+			start = -1
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && next {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// Pascal returns the exported Go identifier of a name, e.g. OrderItem for order_item and UserID for user_id
+func Pascal(name string) string {
+	var b strings.Builder
+	for _, w := range Words(name) {
+		b.WriteString(title(w))
+	}
+	return b.String()
+}
+
+// Camel returns the unexported Go identifier of a name, e.g. orderItem for OrderItem and userID for UserID
+func Camel(name string) string {
+	words := Words(name)
+	if len(words) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(lower(words[0]))
+	for _, w := range words[1:] {
+		b.WriteString(title(w))
+	}
+	return b.String()
+}
+
+// Snake returns a name in lowercase words joined by underscores, as GORM names columns, e.g. order_item for OrderItem
+func Snake(name string) string {
+	return strings.ToLower(strings.Join(Words(name), "_"))
+}
+
+// Kebab returns a name in lowercase words joined by hyphens, as used in URLs, e.g. order-item for OrderItem
+func Kebab(name string) string {
+	return strings.ToLower(strings.Join(Words(name), "-"))
+}
+
+// title uppercases an initialism and the first letter of any other word
+func title(word string) string {
+	if upper := strings.ToUpper(word); initialisms[upper] {
+		return upper
+	}
+	runes := []rune(word)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// lower lowercases an initialism or an all-uppercase word, and the first letter of any other word
+func lower(word string) string {
+	if upper := strings.ToUpper(word); initialisms[upper] || upper == word {
+		return strings.ToLower(word)
+	}
+	runes := []rune(word)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,171 @@
+// Package naming derives the identifiers, table names and routes of generated code from model names, converting case and inflecting plurals
+package naming
+
+import (
+	"strings"
+	"unicode"
+)
+
+// inflection is a singular and plural pair replaced as the suffix of a word
+type inflection struct {
+	singular, plural string
+	whole            bool // only as a whole word, e.g. man but not human
+}
+
+// irregulars are checked before the rules, in order; uncountable words have the same singular and plural
+var irregulars = []inflection{
+	{"person", "people", false},
+	{"child", "children", false},
+	{"woman", "women", false},
+	{"man", "men", true},
+	{"ox", "oxen", true},
+	{"foot", "feet", false},
+	{"tooth", "teeth", false},
+	{"goose", "geese", false},
+	{"mouse", "mice", false},
+	{"criterion", "criteria", false},
+	{"phenomenon", "phenomena", false},
+	{"index", "indices", false},
+	{"matrix", "matrices", false},
+	{"vertex", "vertices", false},
+	{"quiz", "quizzes", false},
+	{"alias", "aliases", false},
+	{"cache", "caches", false},
+	{"niche", "niches", false},
+	{"movie", "movies", false},
+	{"cookie", "cookies", false},
+	{"zombie", "zombies", false},
+	{"hero", "heroes", false},
+	{"potato", "potatoes", false},
+	{"tomato", "tomatoes", false},
+	{"echo", "echoes", false},
+	{"knife", "knives", false},
+	{"wife", "wives", false},
+	{"life", "lives", true},
+	{"leaf", "leaves", false},
+	{"half", "halves", false},
+	{"shelf", "shelves", false},
+	{"wolf", "wolves", false},
+	{"thief", "thieves", false},
+	{"calf", "calves", false},
+	{"loaf", "loaves", false},
+	{"analysis", "analyses", false},
+	{"crisis", "crises", false},
+	{"thesis", "theses", false},
+	{"diagnosis", "diagnoses", false},
+	{"use", "uses", true},
+	{"excuse", "excuses", false},
+	{"abuse", "abuses", false},
+	{"data", "data", false},
+	{"media", "media", false},
+	{"metadata", "metadata", false},
+	{"equipment", "equipment", false},
+	{"information", "information", false},
+	{"feedback", "feedback", false},
+	{"software", "software", false},
+	{"hardware", "hardware", false},
+	{"money", "money", false},
+	{"news", "news", false},
+	{"series", "series", false},
+	{"species", "species", false},
+	{"sheep", "sheep", false},
+	{"fish", "fish", false},
+	{"deer", "deer", false},
+	{"staff", "staff", false},
+}
+
+// Plural returns the plural of a singular noun, inflecting the last word of compound names, e.g. Category becomes Categories, order_item order_items and SalesPerson SalesPeople
+func Plural(word string) string {
+	if word == "" {
+		return word
+	}
+	for _, in := range irregulars {
+		if i, ok := suffix(word, in.singular, in.whole); ok {
+			return word[:i] + matchCase(word[i:], in.plural)
+		}
+		if _, ok := suffix(word, in.plural, in.whole); ok {
+			return word
+		}
+	}
+
+	lower := strings.ToLower(word)
+	switch {
+	case strings.HasSuffix(lower, "sis"):
+		return replace(word, 2, "es")
+	case endsWith(lower, "s", "x", "z", "ch", "sh"):
+		return word + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !isVowel(lower[len(lower)-2]):
+		return replace(word, 1, "ies")
+	}
+	return word + "s"
+}
+
+// Singular returns the singular of a plural noun, the inverse of Plural; words that are not plural are returned unchanged
+func Singular(word string) string {
+	if word == "" {
+		return word
+	}
+	for _, in := range irregulars {
+		if i, ok := suffix(word, in.plural, in.whole); ok {
+			return word[:i] + matchCase(word[i:], in.singular)
+		}
+	}
+
+	lower := strings.ToLower(word)
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(lower) > 4:
+		return replace(word, 3, "y")
+	case endsWith(lower, "sses", "shes", "ches", "xes", "zzes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(lower, "uses") && len(lower) > 4 && !isVowel(lower[len(lower)-5]):
+		return word[:len(word)-2] // statuses, but houses and causes keep their e
+	case endsWith(lower, "ss", "us", "is"):
+		return word
+	case strings.HasSuffix(lower, "s") && len(lower) > 1:
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// suffix reports where the ending of word matches form case-insensitively; whole forms must start a word
+func suffix(word, form string, whole bool) (int, bool) {
+	i := len(word) - len(form)
+	if i < 0 || !strings.EqualFold(word[i:], form) {
+		return 0, false
+	}
+	if whole && i > 0 && !strings.ContainsRune("_- ", rune(word[i-1])) && !(unicode.IsUpper(rune(word[i])) && !unicode.IsUpper(rune(word[i-1]))) {
+		return 0, false
+	}
+	return i, true
+}
+
+// replace swaps the last n bytes of word for a lowercase ending, uppercased when the replaced ending was
+func replace(word string, n int, ending string) string {
+	return word[:len(word)-n] + matchCase(word[len(word)-n:], ending)
+}
+
+// matchCase cases form like original: upper, title or lower
+func matchCase(original, form string) string {
+	switch {
+	case strings.ToUpper(original) == original && strings.ToLower(original) != original:
+		return strings.ToUpper(form)
+	case unicode.IsUpper(rune(original[0])):
+		return strings.ToUpper(form[:1]) + form[1:]
+	}
+	return form
+}
+
+// endsWith reports whether s ends with any of the suffixes
+func endsWith(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVowel reports whether c is a lowercase vowel
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
@@ -0,0 +1,107 @@
+package naming
+
+import "testing"
+
+func TestPlural(t *testing.T) {
+	for singular, plural := range map[string]string{
+		"product":      "products",
+		"category":     "categories",
+		"day":          "days",
+		"status":       "statuses",
+		"address":      "addresses",
+		"box":          "boxes",
+		"batch":        "batches",
+		"analysis":     "analyses",
+		"person":       "people",
+		"Person":       "People",
+		"SalesPerson":  "SalesPeople",
+		"sales_person": "sales_people",
+		"order_item":   "order_items",
+		"OrderItem":    "OrderItems",
+		"human":        "humans",
+		"ChairMan":     "ChairMen",
+		"CATEGORY":     "CATEGORIES",
+		"URL":          "URLs",
+		"equipment":    "equipment",
+		"people":       "people",
+		"knife":        "knives",
+		"house":        "houses",
+	} {
+		if got := Plural(singular); got != plural {
+			t.Errorf("Plural(%q) = %q, want %q", singular, got, plural)
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	for plural, singular := range map[string]string{
+		"products":    "product",
+		"categories":  "category",
+		"days":        "day",
+		"statuses":    "status",
+		"status":      "status",
+		"addresses":   "address",
+		"boxes":       "box",
+		"batches":     "batch",
+		"analyses":    "analysis",
+		"people":      "person",
+		"SalesPeople": "SalesPerson",
+		"order_items": "order_item",
+		"houses":      "house",
+		"responses":   "response",
+		"movies":      "movie",
+		"knives":      "knife",
+		"series":      "series",
+		"URLs":        "URL",
+		"product":     "product",
+	} {
+		if got := Singular(plural); got != singular {
+			t.Errorf("Singular(%q) = %q, want %q", plural, got, singular)
+		}
+	}
+}
+
+func TestCase(t *testing.T) {
+	tests := []struct {
+		name, pascal, camel, snake, kebab string
+	}{
+		{"product", "Product", "product", "product", "product"},
+		{"order_item", "OrderItem", "orderItem", "order_item", "order-item"},
+		{"orderItem", "OrderItem", "orderItem", "order_item", "order-item"},
+		{"OrderItem", "OrderItem", "orderItem", "order_item", "order-item"},
+		{"order-item", "OrderItem", "orderItem", "order_item", "order-item"},
+		{"user_id", "UserID", "userID", "user_id", "user-id"},
+		{"TenantID", "TenantID", "tenantID", "tenant_id", "tenant-id"},
+		{"HTTPClient", "HTTPClient", "httpClient", "http_client", "http-client"},
+		{"ID", "ID", "id", "id", "id"},
+		{"address2", "Address2", "address2", "address2", "address2"},
+		{"", "", "", "", ""},
+	}
+	for _, tt := range tests {
+		if got := Pascal(tt.name); got != tt.pascal {
+			t.Errorf("Pascal(%q) = %q, want %q", tt.name, got, tt.pascal)
+		}
+		if got := Camel(tt.name); got != tt.camel {
+			t.Errorf("Camel(%q) = %q, want %q", tt.name, got, tt.camel)
+		}
+		if got := Snake(tt.name); got != tt.snake {
+			t.Errorf("Snake(%q) = %q, want %q", tt.name, got, tt.snake)
+		}
+		if got := Kebab(tt.name); got != tt.kebab {
+			t.Errorf("Kebab(%q) = %q, want %q", tt.name, got, tt.kebab)
+		}
+	}
+}
+
+func TestReserved(t *testing.T) {
+	for _, identifier := range []string{"type", "func", "range", "string", "error", "nil", "len"} {
+		if !Reserved(identifier) {
+			t.Errorf("Reserved(%q) = false, want true", identifier)
+		}
+	}
+	for _, identifier := range []string{"product", "Type", "types", "orderItem", ""} {
+		if Reserved(identifier) {
+			t.Errorf("Reserved(%q) = true, want false", identifier)
+		}
+	}
+}
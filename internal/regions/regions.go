@@ -0,0 +1,163 @@
+// Package regions carries the protected regions of a file, marked by mcpgo:keep-begin and mcpgo:keep-end comments, over to its regenerated content
+package regions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The markers open and close a protected region in a comment of any language, e.g. // mcpgo:keep-begin fields or -- mcpgo:keep-end
+const (
+	Begin = "mcpgo:keep-begin"
+	End   = "mcpgo:keep-end"
+)
+
+// region is a protected block of lines
+type region struct {
+	name       string   // text after the begin marker, e.g. fields; empty for an unnamed region
+	begin, end int      // indices of the marker lines
+	anchor     string   // the last non-blank line before the region, trimmed, to place it when the new content lacks it
+	occurrence int      // how many lines up to the anchor read the same, e.g. 3 for the third closing brace
+	lead       []string // the blank lines between the anchor and the region
+	lines      []string // the region, markers included
+}
+
+// Has reports whether content has a protected region
+func Has(content string) bool {
+	return strings.Contains(content, Begin)
+}
+
+// Merge returns generated with the protected regions of existing carried over
+// A named region replaces the body of the region with the same name in generated; other regions are inserted
+// after the same occurrence of the line that preceded them in existing, or appended when generated has no such line
+func Merge(existing, generated string) (string, error) {
+	kept, err := parse(lines(existing))
+	if err != nil {
+		return "", err
+	}
+	out := lines(generated)
+	targets, err := parse(out)
+	if err != nil {
+		return "", fmt.Errorf("generated content: %w", err)
+	}
+
+	// Replace named regions back to front, so the indices of the earlier ones stay valid
+	placed := make([]bool, len(kept))
+	for t := len(targets) - 1; t >= 0; t-- {
+		target := targets[t]
+		for k, r := range kept {
+			if !placed[k] && r.name != "" && r.name == target.name {
+				out = splice(out, target.begin+1, target.end, r.lines[1:len(r.lines)-1])
+				placed[k] = true
+				break
+			}
+		}
+	}
+
+	for k, r := range kept {
+		if placed[k] {
+			continue
+		}
+		block := append(append([]string{}, r.lead...), r.lines...)
+		if last := len(block) - 1; !strings.HasSuffix(block[last], "\n") {
+			block[last] += "\n"
+		}
+		at := find(out, r.anchor, r.occurrence)
+		switch {
+		case r.anchor == "":
+			at = 0
+		case at < 0:
+			if n := len(out); n > 0 && !strings.HasSuffix(out[n-1], "\n") {
+				out[n-1] += "\n"
+			}
+			at = len(out)
+		default:
+			at++
+		}
+		out = splice(out, at, at, block)
+	}
+	return strings.Join(out, ""), nil
+}
+
+// Strip returns content without its protected regions and the blank lines before them, so adding or editing
+// regions can be told from edits to the generated lines; content with unbalanced markers is returned as is
+func Strip(content string) string {
+	all := lines(content)
+	kept, err := parse(all)
+	if err != nil {
+		return content
+	}
+	for i := len(kept) - 1; i >= 0; i-- {
+		all = splice(all, kept[i].begin-len(kept[i].lead), kept[i].end+1, nil)
+	}
+	return strings.Join(all, "")
+}
+
+// parse finds the protected regions of a file's lines, in order
+func parse(content []string) ([]region, error) {
+	var regions []region
+	open := -1
+	anchor, at := "", -1
+	seen := map[string]int{}
+	for i, line := range content {
+		switch {
+		case strings.Contains(line, Begin):
+			if open >= 0 {
+				return nil, fmt.Errorf("line %d: %s inside the region opened on line %d", i+1, Begin, open+1)
+			}
+			open = i
+			regions = append(regions, region{name: markerName(line), begin: i, anchor: anchor, occurrence: seen[anchor], lead: content[at+1 : i]})
+		case strings.Contains(line, End):
+			if open < 0 {
+				return nil, fmt.Errorf("line %d: %s without %s", i+1, End, Begin)
+			}
+			r := &regions[len(regions)-1]
+			r.end, r.lines = i, content[open:i+1]
+			open = -1
+		}
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			anchor, at = trimmed, i
+			seen[trimmed]++
+		}
+	}
+	if open >= 0 {
+		return nil, fmt.Errorf("line %d: %s without %s", open+1, Begin, End)
+	}
+	return regions, nil
+}
+
+// markerName returns the name following the begin marker, without the closing of a block comment
+func markerName(line string) string {
+	name := line[strings.Index(line, Begin)+len(Begin):]
+	name = strings.TrimSpace(name)
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "-->"), "*/")
+	return strings.TrimSpace(name)
+}
+
+// lines splits content after each newline, keeping them
+func lines(content string) []string {
+	split := strings.SplitAfter(content, "\n")
+	if split[len(split)-1] == "" {
+		split = split[:len(split)-1]
+	}
+	return split
+}
+
+// find returns the index of the nth line that reads anchor once trimmed, the last one when there are fewer, or -1
+func find(content []string, anchor string, n int) int {
+	found := -1
+	for i, line := range content {
+		if strings.TrimSpace(line) == anchor {
+			found, n = i, n-1
+			if n == 0 {
+				break
+			}
+		}
+	}
+	return found
+}
+
+// splice returns content with the lines from i to j replaced by with
+func splice(content []string, i, j int, with []string) []string {
+	return append(append(append([]string{}, content[:i]...), with...), content[j:]...)
+}
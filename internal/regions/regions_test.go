@@ -0,0 +1,79 @@
+package regions
+
+import "testing"
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name, existing, generated, want string
+	}{
+		{
+			"named region",
+			"type Product struct {\n\tName string\n\t// mcpgo:keep-begin fields\n\tSKU string\n\t// mcpgo:keep-end\n}\n",
+			"type Product struct {\n\tName  string\n\tPrice float64\n\t// mcpgo:keep-begin fields\n\t// mcpgo:keep-end\n}\n",
+			"type Product struct {\n\tName  string\n\tPrice float64\n\t// mcpgo:keep-begin fields\n\tSKU string\n\t// mcpgo:keep-end\n}\n",
+		},
+		{
+			"anchored region",
+			"func A() {}\n\n// mcpgo:keep-begin\nfunc Custom() {}\n// mcpgo:keep-end\n\nfunc B() {}\n",
+			"func A() {}\n\nfunc B() {}\n\nfunc C() {}\n",
+			"func A() {}\n\n// mcpgo:keep-begin\nfunc Custom() {}\n// mcpgo:keep-end\n\nfunc B() {}\n\nfunc C() {}\n",
+		},
+		{
+			"appended region",
+			"func Old() {}\n// mcpgo:keep-begin\nfunc Custom() {}\n// mcpgo:keep-end",
+			"func New() {}",
+			"func New() {}\n// mcpgo:keep-begin\nfunc Custom() {}\n// mcpgo:keep-end\n",
+		},
+		{
+			"consecutive regions",
+			"package a\n// mcpgo:keep-begin\nvar x = 1\n// mcpgo:keep-end\n// mcpgo:keep-begin\nvar y = 2\n// mcpgo:keep-end\n",
+			"package a\n\nvar z = 3\n",
+			"package a\n// mcpgo:keep-begin\nvar x = 1\n// mcpgo:keep-end\n// mcpgo:keep-begin\nvar y = 2\n// mcpgo:keep-end\n\nvar z = 3\n",
+		},
+		{
+			"repeated anchor",
+			"type A struct {\n}\n\ntype B struct {\n}\n\n// mcpgo:keep-begin\nfunc (B) Custom() {}\n// mcpgo:keep-end\n",
+			"type A struct {\n}\n\ntype B struct {\n\tName string\n}\n",
+			"type A struct {\n}\n\ntype B struct {\n\tName string\n}\n\n// mcpgo:keep-begin\nfunc (B) Custom() {}\n// mcpgo:keep-end\n",
+		},
+		{
+			"html comment",
+			"<div>\n<!-- mcpgo:keep-begin banner -->\n<p>Sale</p>\n<!-- mcpgo:keep-end -->\n</div>\n",
+			"<main>\n<!-- mcpgo:keep-begin banner -->\n<!-- mcpgo:keep-end -->\n</main>\n",
+			"<main>\n<!-- mcpgo:keep-begin banner -->\n<p>Sale</p>\n<!-- mcpgo:keep-end -->\n</main>\n",
+		},
+	}
+	for _, tt := range tests {
+		got, err := Merge(tt.existing, tt.generated)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: Merge() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMergeUnbalanced(t *testing.T) {
+	for _, existing := range []string{
+		"// mcpgo:keep-begin\nvar x = 1\n",
+		"var x = 1\n// mcpgo:keep-end\n",
+		"// mcpgo:keep-begin\n// mcpgo:keep-begin\n// mcpgo:keep-end\n",
+	} {
+		if _, err := Merge(existing, "package a\n"); err == nil {
+			t.Errorf("Merge(%q) succeeded, want an error", existing)
+		}
+	}
+}
+
+func TestStrip(t *testing.T) {
+	content := "type Product struct {\n\t// mcpgo:keep-begin fields\n\tSKU string\n\t// mcpgo:keep-end\n}\n\n// mcpgo:keep-begin\nfunc Custom() {}\n// mcpgo:keep-end\n"
+	want := "type Product struct {\n}\n"
+	if got := Strip(content); got != want {
+		t.Errorf("Strip() = %q, want %q", got, want)
+	}
+	if unbalanced := "// mcpgo:keep-begin\nvar x = 1\n"; Strip(unbalanced) != unbalanced {
+		t.Errorf("Strip(%q) changed unbalanced content", unbalanced)
+	}
+}
@@ -0,0 +1,423 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Field describes a single model field as requested by the client
+type Field struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Check    string `json:"check,omitempty"`
+	Validate string `json:"validate,omitempty"` // validator rules, e.g. required,email,max=100
+	Pattern  string `json:"pattern,omitempty"`  // regular expression the value must match
+	Struct   string `json:"struct,omitempty"`   // document type of a json field
+	Enum     string `json:"enum,omitempty"`     // comma-separated values allowed in the field
+	Gorm     string `json:"gorm,omitempty"`     // extra GORM tag settings, e.g. uniqueIndex;size:255
+}
+
+// Model tracks a scaffolded model and the components generated for it
+type Model struct {
+	Name       string            `json:"name"`
+	Fields     []Field           `json:"fields,omitempty"`
+	Components []string          `json:"components,omitempty"`
+	Routes     []string          `json:"routes,omitempty"` // registered routes, e.g. GET /products/:id
+	Options    map[string]string `json:"options,omitempty"`
+}
+
+// Project is the scaffold inventory for a single application
+type Project struct {
+	AppName string            `json:"app_name"`
+	Models  []Model           `json:"models"`
+	Options map[string]string `json:"options,omitempty"`
+	Files   map[string]string `json:"files,omitempty"`  // SHA-256 checksums of the files written, keyed by absolute path
+	Writes  []Write           `json:"writes,omitempty"` // the last write_files operations, oldest first
+}
+
+// Write is a write_files operation, kept so it can be undone
+type Write struct {
+	ID       string            `json:"id"`                 // when it ran, e.g. 20261016-142301.123
+	Dir      string            `json:"dir"`                // absolute target_dir
+	Created  []string          `json:"created,omitempty"`  // paths of the files created, relative to Dir
+	Replaced []string          `json:"replaced,omitempty"` // paths of the files replaced, whose previous content is in Backup
+	Backup   string            `json:"backup,omitempty"`   // directory holding the previous content of the replaced files
+	Written  map[string]string `json:"written,omitempty"`  // checksums of the files created or replaced, keyed by absolute path
+	Previous map[string]string `json:"previous,omitempty"` // checksums recorded before, keyed by absolute path; empty for untracked files
+}
+
+// MaxWrites is the number of write_files operations kept per application
+const MaxWrites = 10
+
+// Store keeps track of every project scaffolded during the server's lifetime
+type Store struct {
+	mu       sync.RWMutex
+	projects map[string]*Project
+	current  string // application changed last, used when a tool call omits app_name
+	path     string // manifest file every change is saved to, empty to keep state in memory
+}
+
+// manifest is the on-disk form of a Store
+type manifest struct {
+	CurrentApp string    `json:"current_app,omitempty"`
+	Projects   []Project `json:"projects"`
+}
+
+// NewStore creates an empty project store
+func NewStore() *Store {
+	return &Store{projects: make(map[string]*Project)}
+}
+
+// Default is the store shared by all tools of the running server
+var Default = NewStore()
+
+// project returns the project for appName, creating it if needed. Callers must hold the write lock.
+func (s *Store) project(appName string) *Project {
+	p, ok := s.projects[appName]
+	if !ok {
+		p = &Project{AppName: appName, Models: []Model{}, Options: map[string]string{}}
+		s.projects[appName] = p
+	}
+	return p
+}
+
+// model returns the model named name in p, creating it if needed. Callers must hold the write lock.
+func (p *Project) model(name string) *Model {
+	for i := range p.Models {
+		if p.Models[i].Name == name {
+			return &p.Models[i]
+		}
+	}
+	p.Models = append(p.Models, Model{Name: name})
+	return &p.Models[len(p.Models)-1]
+}
+
+// RecordApp registers an application, leaving existing state untouched
+func (s *Store) RecordApp(appName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.project(appName)
+	s.changed(appName)
+}
+
+// RecordModel registers a model and replaces its field list
+func (s *Store) RecordModel(appName, modelName string, fields []Field) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := s.project(appName).model(modelName)
+	m.Fields = append([]Field(nil), fields...)
+	addComponent(m, "model")
+	addComponent(m, "repository")
+	s.changed(appName)
+}
+
+// RecordComponent marks a component (service, api_controller, ...) as generated for a model
+func (s *Store) RecordComponent(appName, modelName, component string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	addComponent(s.project(appName).model(modelName), component)
+	s.changed(appName)
+}
+
+// RecordRoutes adds the routes registered for a model by a controller
+func (s *Store) RecordRoutes(appName, modelName string, routes []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := s.project(appName).model(modelName)
+	for _, route := range routes {
+		if !slices.Contains(m.Routes, route) {
+			m.Routes = append(m.Routes, route)
+		}
+	}
+	s.changed(appName)
+}
+
+// SetOption records a scaffold option chosen for the application
+func (s *Store) SetOption(appName, key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.project(appName).Options[key] = value
+	s.changed(appName)
+}
+
+// SetModelOption records a scaffold option chosen for a single model
+func (s *Store) SetModelOption(appName, modelName, key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := s.project(appName).model(modelName)
+	if m.Options == nil {
+		m.Options = map[string]string{}
+	}
+	m.Options[key] = value
+	s.changed(appName)
+}
+
+// RecordFiles records the checksums of files written for the application, keyed by absolute path
+func (s *Store) RecordFiles(appName string, checksums map[string]string) {
+	if len(checksums) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.project(appName)
+	if p.Files == nil {
+		p.Files = map[string]string{}
+	}
+	for path, sum := range checksums {
+		p.Files[path] = sum
+	}
+	s.changed(appName)
+}
+
+// RecordWrite adds a write_files operation to the application's history, returning the operations dropped
+// from it to stay within MaxWrites, whose backups are no longer needed
+func (s *Store) RecordWrite(appName string, w Write) []Write {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.project(appName)
+	p.Writes = append(p.Writes, w)
+	var dropped []Write
+	if n := len(p.Writes) - MaxWrites; n > 0 {
+		dropped = append(dropped, p.Writes[:n]...)
+		p.Writes = append([]Write(nil), p.Writes[n:]...)
+	}
+	s.changed(appName)
+	return dropped
+}
+
+// LastWrite returns the most recent write_files operation of the application
+func (s *Store) LastWrite(appName string) (Write, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.projects[appName]
+	if !ok || len(p.Writes) == 0 {
+		return Write{}, false
+	}
+	return p.Writes[len(p.Writes)-1].clone(), true
+}
+
+// PopWrite removes the most recent write_files operation once it has been undone, and restores the checksums
+// recorded before it
+func (s *Store) PopWrite(appName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.project(appName)
+	if len(p.Writes) == 0 {
+		return
+	}
+	w := p.Writes[len(p.Writes)-1]
+	p.Writes = p.Writes[:len(p.Writes)-1]
+	for path, sum := range w.Previous {
+		if sum == "" {
+			delete(p.Files, path)
+		} else if p.Files != nil {
+			p.Files[path] = sum
+		}
+	}
+	s.changed(appName)
+}
+
+// FileChecksum returns the checksum recorded when a file of the application was last written
+func (s *Store) FileChecksum(appName, path string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.projects[appName]
+	if !ok {
+		return "", false
+	}
+	sum, ok := p.Files[path]
+	return sum, ok
+}
+
+// Project returns a copy of the project state for appName
+func (s *Store) Project(appName string) (Project, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.projects[appName]
+	if !ok {
+		return Project{}, false
+	}
+	return p.clone(), true
+}
+
+// CurrentApp returns the application changed last, or an empty string before any tool call
+func (s *Store) CurrentApp() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// Apps returns the names of all known applications in sorted order
+func (s *Store) Apps() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.projects))
+	for name := range s.projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Fork returns an in-memory copy of the store, for tool calls that render a scaffold without recording it
+// Changes to the copy are never saved and never reach s; they are dropped with it
+func (s *Store) Fork() *Store {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fork := &Store{projects: make(map[string]*Project, len(s.projects)), current: s.current}
+	for name, p := range s.projects {
+		c := p.clone()
+		fork.projects[name] = &c
+	}
+	return fork
+}
+
+// storeKey is the context key of the store a tool call uses
+type storeKey struct{}
+
+// WithStore returns a context whose tool calls read and record their project state in s
+func WithStore(ctx context.Context, s *Store) context.Context {
+	return context.WithValue(ctx, storeKey{}, s)
+}
+
+// From returns the store of a tool call: the one set by WithStore, or Default
+func From(ctx context.Context) *Store {
+	if s, ok := ctx.Value(storeKey{}).(*Store); ok {
+		return s
+	}
+	return Default
+}
+
+func (p *Project) clone() Project {
+	c := Project{AppName: p.AppName, Models: make([]Model, len(p.Models)), Options: make(map[string]string, len(p.Options))}
+	for i, m := range p.Models {
+		c.Models[i] = Model{
+			Name:       m.Name,
+			Fields:     append([]Field(nil), m.Fields...),
+			Components: append([]string(nil), m.Components...),
+			Routes:     append([]string(nil), m.Routes...),
+		}
+		if m.Options != nil {
+			c.Models[i].Options = make(map[string]string, len(m.Options))
+			for k, v := range m.Options {
+				c.Models[i].Options[k] = v
+			}
+		}
+	}
+	for k, v := range p.Options {
+		c.Options[k] = v
+	}
+	if p.Files != nil {
+		c.Files = make(map[string]string, len(p.Files))
+		for k, v := range p.Files {
+			c.Files[k] = v
+		}
+	}
+	for _, w := range p.Writes {
+		c.Writes = append(c.Writes, w.clone())
+	}
+	return c
+}
+
+func (w Write) clone() Write {
+	c := w
+	c.Created = append([]string(nil), w.Created...)
+	c.Replaced = append([]string(nil), w.Replaced...)
+	c.Written = make(map[string]string, len(w.Written))
+	for k, v := range w.Written {
+		c.Written[k] = v
+	}
+	c.Previous = make(map[string]string, len(w.Previous))
+	for k, v := range w.Previous {
+		c.Previous[k] = v
+	}
+	return c
+}
+
+func addComponent(m *Model, component string) {
+	for _, c := range m.Components {
+		if c == component {
+			return
+		}
+	}
+	m.Components = append(m.Components, component)
+}
+
+// Persist loads the manifest at path, if it exists, and saves every later change to it
+func (s *Store) Persist(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		var m manifest
+		if err := json.Unmarshal(data, &m); err != nil {
+			return fmt.Errorf("invalid project manifest %s: %w", path, err)
+		}
+		for _, p := range m.Projects {
+			if p.Models == nil {
+				p.Models = []Model{}
+			}
+			if p.Options == nil {
+				p.Options = map[string]string{}
+			}
+			s.projects[p.AppName] = &p
+		}
+		s.current = m.CurrentApp
+	}
+	s.path = path
+	return nil
+}
+
+// changed makes appName the current application and saves the manifest. Callers must hold the write lock.
+func (s *Store) changed(appName string) {
+	s.current = appName
+	if s.path == "" {
+		return
+	}
+	if err := s.save(); err != nil {
+		// Scaffolding still works from memory; the manifest catches up on the next successful save
+		fmt.Fprintf(os.Stderr, "Project manifest error: %v\n", err)
+	}
+}
+
+// save writes the manifest atomically, so a crash never leaves a truncated file. Callers must hold the write lock.
+func (s *Store) save() error {
+	m := manifest{CurrentApp: s.current, Projects: make([]Project, 0, len(s.projects))}
+	for _, p := range s.projects {
+		m.Projects = append(m.Projects, p.clone())
+	}
+	sort.Slice(m.Projects, func(i, j int) bool { return m.Projects[i].AppName < m.Projects[j].AppName })
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(append(data, '\n'))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,132 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".mcpgo", "project.json")
+
+	s := NewStore()
+	if err := s.Persist(path); err != nil {
+		t.Fatal(err)
+	}
+	s.RecordModel("shop", "Product", []Field{{Name: "Name", Type: "string"}})
+	s.SetOption("shop", "dialect", "postgres")
+	s.RecordFiles("shop", map[string]string{"/src/shop/cmd/web/main.go": "5e2b"})
+	s.RecordApp("blog")
+
+	restored := NewStore()
+	if err := restored.Persist(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := restored.CurrentApp(); got != "blog" {
+		t.Errorf("CurrentApp() = %q, want blog", got)
+	}
+	project, ok := restored.Project("shop")
+	if !ok {
+		t.Fatal("shop was not restored")
+	}
+	if project.Options["dialect"] != "postgres" || len(project.Models) != 1 || project.Models[0].Fields[0].Name != "Name" {
+		t.Errorf("restored project = %+v", project)
+	}
+	if sum, ok := restored.FileChecksum("shop", "/src/shop/cmd/web/main.go"); sum != "5e2b" || !ok {
+		t.Errorf("FileChecksum() = %q, %v, want 5e2b, true", sum, ok)
+	}
+	if _, ok := restored.Project("blog"); !ok {
+		t.Error("blog was not restored")
+	}
+
+	// A restored project keeps recording changes
+	restored.SetOption("blog", "dialect", "sqlite")
+	if blog, _ := restored.Project("blog"); blog.Options["dialect"] != "sqlite" {
+		t.Errorf("blog options = %v", blog.Options)
+	}
+}
+
+func TestPersistInvalidManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.json")
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewStore().Persist(path); err == nil {
+		t.Error("expected an error for an invalid manifest")
+	}
+}
+
+func TestWrites(t *testing.T) {
+	s := NewStore()
+	s.RecordFiles("shop", map[string]string{"/src/shop/main.go": "aa"})
+	for i := 0; i < MaxWrites+2; i++ {
+		w := Write{ID: fmt.Sprint(i), Previous: map[string]string{"/src/shop/main.go": "aa", "/src/shop/new.go": ""}}
+		dropped := s.RecordWrite("shop", w)
+		if want := min(1, max(0, i+1-MaxWrites)); len(dropped) != want {
+			t.Fatalf("write %d dropped %d writes, want %d", i, len(dropped), want)
+		}
+	}
+	s.RecordFiles("shop", map[string]string{"/src/shop/main.go": "bb", "/src/shop/new.go": "cc"})
+
+	last, ok := s.LastWrite("shop")
+	if !ok || last.ID != fmt.Sprint(MaxWrites+1) {
+		t.Fatalf("LastWrite() = %+v, %v", last, ok)
+	}
+	s.PopWrite("shop")
+	if sum, _ := s.FileChecksum("shop", "/src/shop/main.go"); sum != "aa" {
+		t.Errorf("main.go checksum after PopWrite = %q, want aa", sum)
+	}
+	if _, ok := s.FileChecksum("shop", "/src/shop/new.go"); ok {
+		t.Error("new.go is still tracked after PopWrite")
+	}
+	if project, _ := s.Project("shop"); len(project.Writes) != MaxWrites-1 {
+		t.Errorf("%d writes left, want %d", len(project.Writes), MaxWrites-1)
+	}
+}
+
+func TestFork(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.json")
+	s := NewStore()
+	if err := s.Persist(path); err != nil {
+		t.Fatal(err)
+	}
+	s.SetOption("shop", "template_version", "v1")
+	fork := s.Fork()
+
+	fork.SetOption("shop", "template_version", "v2")
+	fork.RecordModel("shop", "Product", nil)
+	fork.RecordApp("blog")
+	if project, _ := fork.Project("shop"); project.Options["template_version"] != "v2" || len(project.Models) != 1 {
+		t.Errorf("fork project = %+v", project)
+	}
+
+	if got := s.CurrentApp(); got != "shop" {
+		t.Errorf("CurrentApp() = %q, want shop", got)
+	}
+	project, _ := s.Project("shop")
+	if project.Options["template_version"] != "v1" || len(project.Models) != 0 {
+		t.Errorf("project changed through the fork: %+v", project)
+	}
+	if _, ok := s.Project("blog"); ok {
+		t.Error("blog reached the store through the fork")
+	}
+	restored := NewStore()
+	if err := restored.Persist(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := restored.Project("blog"); ok {
+		t.Error("the fork saved its changes to the manifest")
+	}
+}
+
+func TestFrom(t *testing.T) {
+	if From(context.Background()) != Default {
+		t.Error("From() without a store is not Default")
+	}
+	s := NewStore()
+	if From(WithStore(context.Background(), s)) != s {
+		t.Error("From() does not return the store set by WithStore")
+	}
+}
@@ -0,0 +1,191 @@
+// Package templates holds the code the produce_* tools generate, as text/template files embedded in the binary
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed */*.tmpl v2/*/*.tmpl
+var files embed.FS
+
+// Versions are the generations of the templates, oldest first; v1 is the layout every project started with
+// A later generation holds only the templates it changes, under its own directory, e.g. v2/service/get_by_id.go
+var Versions = []string{"v1", "v2"}
+
+// set holds every embedded template, named by its path without the .tmpl suffix, e.g. service/create.go
+var set = parse()
+
+// overrides maps the names of the templates replaced by Override to the files replacing them
+var overrides = map[string]string{}
+
+// parse reads the embedded templates; a template that does not parse is a bug, so it panics at startup
+func parse() *template.Template {
+	root := template.New("").Option("missingkey=error")
+	err := fs.WalkDir(files, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := files.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		_, err = root.New(strings.TrimSuffix(path, ".tmpl")).Parse(string(content))
+		return err
+	})
+	if err != nil {
+		panic(fmt.Sprintf("templates: %v", err))
+	}
+	return root
+}
+
+// Override parses every .tmpl file under dir in place of the embedded template at the same path, e.g. dir/service/create.go.tmpl
+// It is meant to run at startup; a file that shadows no embedded template or does not parse is an error
+func Override(dir string) error {
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("templates: %w", err)
+	}
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".tmpl") {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(filepath.ToSlash(rel), ".tmpl")
+		if set.Lookup(name) == nil {
+			return fmt.Errorf("templates: %s overrides no template; run the server with -export-templates to get the embedded ones", path)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if _, err := set.New(name).Parse(string(content)); err != nil {
+			return fmt.Errorf("templates: %s: %w", path, err)
+		}
+		overrides[name] = path
+		return nil
+	})
+}
+
+// Export writes the embedded templates of the given directories, e.g. model and service, under dir as a starting point for overrides
+// With no directories it writes every template; existing files are left alone, and the paths of the written files are returned
+func Export(dir string, dirs ...string) ([]string, error) {
+	for _, d := range dirs {
+		if entries, err := fs.ReadDir(files, d); err != nil || len(entries) == 0 {
+			return nil, fmt.Errorf("templates: no embedded directory %q", d)
+		}
+	}
+	var written []string
+	err := fs.WalkDir(files, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if len(dirs) > 0 && !slices.Contains(dirs, path.Dir(name)) {
+			return nil
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if _, err := os.Stat(target); err == nil {
+			return nil
+		}
+		content, err := files.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, content, 0o644); err != nil {
+			return err
+		}
+		written = append(written, target)
+		return nil
+	})
+	return written, err
+}
+
+// Names lists the embedded templates in lexical order
+func Names() []string {
+	var names []string
+	for _, t := range set.Templates() {
+		if t.Name() != "" {
+			names = append(names, t.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Source returns the text of the named template before execution: the file overriding it, if any, or the embedded one
+func Source(name string) (string, error) {
+	if path, ok := overrides[name]; ok {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("templates: %w", err)
+		}
+		return string(content), nil
+	}
+	content, err := files.ReadFile(name + ".tmpl")
+	if err != nil {
+		return "", fmt.Errorf("templates: no template %q", name)
+	}
+	return string(content), nil
+}
+
+// Render executes the named template with data; fields missing from a data map are errors
+func Render(name string, data any) (string, error) {
+	t := set.Lookup(name)
+	if t == nil {
+		return "", fmt.Errorf("templates: no template %q", name)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		if path, ok := overrides[name]; ok {
+			return "", fmt.Errorf("%w (the template is overridden by %s)", err, path)
+		}
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Expand executes an inline template such as a file path, e.g. internal/service/{{.Lower}}/create.go
+func Expand(text string, data any) (string, error) {
+	t, err := template.New("inline").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Versioned returns the name of the template rendered for name in a generation: its own variant when it has one,
+// or the variant of the closest earlier generation, e.g. v2/service/get_by_id.go for service/get_by_id.go in v2
+func Versioned(version, name string) string {
+	for i := slices.Index(Versions, version); i > 0; i-- {
+		if candidate := Versions[i] + "/" + name; set.Lookup(candidate) != nil {
+			return candidate
+		}
+	}
+	return name
+}
+
+// MustRender is like Render but panics on error, for templates whose data is fixed by the calling tool
+func MustRender(name string, data any) string {
+	content, err := Render(name, data)
+	if err != nil {
+		panic(err)
+	}
+	return content
+}
@@ -0,0 +1,163 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// sample holds a value for every field the templates use
+var sample = map[string]any{
+	"APIKey": true, "APIPrefix": "/api", "AccessImports": "", "AllowOrigins": `"https://demo.example.com"`, "ActiveScope": "", "App": "demo", "BaseFuncs": "",
+	"BaseURL": "https://api.example.com", "BatchSize": "1000",
+	"Block": "\te.GET(\"/products\", productController.ListProduct)\n", "Cache": true, "CacheTTL": "15 * time.Second",
+	"Calls": "", "Capacity": "1000", "Captcha": true, "Cells": "", "Columns": `"name"`, "ContactTo": "contact@example.com", "Client": "Billing", "Column": "updated_at", "Controller": "productController",
+	"ControllerFilters": "", "Controllers": "", "CreateFields": "", "DB": "r.db", "Debounce": "300", "AccountAttempts": "5", "AccountField": "email", "BaseDelay": "1 * time.Second", "Daily": false, "Database": true, "Default": "member", "DefaultEnv": "development",
+	"Dependency": "Payments", "DrainSeconds": "5", "Documents": []map[string]string{{"Slug": "terms", "Title": "Terms of Service"}}, "Driver": "sqlite", "Exempt": `"/legal"`, "ErrorImports": "", "ExportImports": "",
+	"Field": "Location", "Fields": "", "Funcs": "", "GetReturn": "c.JSON(http.StatusOK, result)", "GracePeriod": "30",
+	"Handlers": "\t\tproductController.CreateProduct,\n", "Idle": "green", "Image": "registry.example.com/demo:latest",
+	"IPAttempts": "30", "Imports": "", "InvitationTTL": "168 * time.Hour", "Interface": "", "Interfaces": "", "Job": "populate_products_slug", "Kind": "API", "Library": "htmx", "Like": "LIKE", "Links": "", "Locales": `"en", "fr"`,
+	"ListReturn": "c.JSON(http.StatusOK, result)", "Lockout": "15 * time.Minute", "Lower": "product", "LowerPlural": "products", "Manager": "admin", "MaxUses": "1", "MaxAgeDays": "90",
+	"Methods": "", "Model": "Product", "Models": "", "Module": "demo", "NPlusOne": true, "Name": "demo-blue",
+	"Notifications": []map[string]string{{"Field": "NotifyComments", "JSON": "notify_comments", "Label": "Comments", "Default": "true"}},
+	"NewError":      "echo.NewHTTPError", "Owner": "OwnerID", "OwnerColumn": "owner_id", "Package": "productcontrollers", "Path": "/products",
+	"Plural": "Products", "Provider": "Cloudflare Turnstile", "Postgres": false, "ProviderImports": "", "Providers": "", "QueryFields": "", "Queue": true, "Rate": "5", "Read": "r.db",
+	"ReadReplicas": true, "Redis": false, "RegistrationAttempts": "10", "Recent": "50", "ResponseField": "cf-turnstile-response", "ScriptURL": "https://challenges.cloudflare.com/turnstile/v0/api.js", "RecordQuota": "1000", "Register": "registerProductRoutes", "Replicas": "3", "Roles": `"owner", "admin", "member"`,
+	"Repositories": "", "RequestQuota": "10000", "RequestTimeout": "30 * time.Second", "ResponseFields": "",
+	"ResponseMapping": "", "RetentionDays": "14", "Routes": "", "SensitiveFields": `"password"`, "ServiceScopes": "",
+	"Services": "", "ShutdownSeconds": "20", "Steps": "\t{Title: \"Details\", Fields: []productpages.WizardField{{Name: \"Name\", Label: \"Name\", Input: \"text\"}}},\n", "SlowThreshold": "200 * time.Millisecond", "Span": "3", "TTL": "5 * time.Minute", "Tagline": "Demo helps you get things done.", "Timezone": "UTC", "Table": "products", "TestSecretKey": "1x0000000000000000000000000000000AA", "TestSiteKey": "1x00000000000000000000AA", "TenantScope": "",
+	"Time": true, "Title": "Demo", "TimeImport": "", "Touch": true, "Track": "blue", "Transactions": true, "Type": "ProductController",
+	"Types": "", "UpdateFields": "", "Validation": true, "Version": "1", "VerifyURL": "https://challenges.cloudflare.com/turnstile/v0/siteverify", "WidgetClass": "cf-turnstile", "Write": "r.db",
+}
+
+func TestRenderAll(t *testing.T) {
+	names := Names()
+	if len(names) == 0 {
+		t.Fatal("no embedded templates")
+	}
+	for _, name := range names {
+		content, err := Render(name, sample)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if strings.Contains(content, "{{.") || strings.Contains(content, "<no value>") {
+			t.Errorf("%s: unexpanded field in output", name)
+		}
+	}
+}
+
+func TestRenderMissingField(t *testing.T) {
+	if _, err := Render("service/create.go", map[string]any{"Model": "Product"}); err == nil {
+		t.Error("expected an error for a missing field")
+	}
+}
+
+func TestSource(t *testing.T) {
+	for _, name := range Names() {
+		if _, err := Source(name); err != nil {
+			t.Errorf("Source(%q): %v", name, err)
+		}
+	}
+	if _, err := Source("model/nothing.go"); err == nil {
+		t.Error("Source of an unknown template succeeded")
+	}
+}
+
+func TestRenderUnknown(t *testing.T) {
+	if _, err := Render("service/missing.go", sample); err == nil {
+		t.Error("expected an error for an unknown template")
+	}
+}
+
+func TestEscapedBraces(t *testing.T) {
+	content := MustRender("api_controller/controller.go", sample)
+	if !strings.Contains(content, "&ProductControllerImpl{productService: productService}") {
+		t.Errorf("literal brace before a field was not kept:\n%s", content)
+	}
+	content = MustRender("html_controller/base.templ", sample)
+	if !strings.Contains(content, "{{ handle := templ.NewOnceHandle() }}") {
+		t.Errorf("templ block was not kept:\n%s", content)
+	}
+}
+
+func TestVersioned(t *testing.T) {
+	for _, tt := range []struct{ version, name, want string }{
+		{"v1", "service/get_by_id.go", "service/get_by_id.go"},
+		{"v2", "service/get_by_id.go", "v2/service/get_by_id.go"},
+		{"v2", "service/list.go", "service/list.go"},
+		{"v9", "service/get_by_id.go", "service/get_by_id.go"},
+	} {
+		if got := Versioned(tt.version, tt.name); got != tt.want {
+			t.Errorf("Versioned(%q, %q) = %q, want %q", tt.version, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOverride(t *testing.T) {
+	t.Cleanup(func() { set, overrides = parse(), map[string]string{} })
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "app"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "app", "timeout.go.tmpl"), []byte("package {{.App}}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Override(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := MustRender("app/timeout.go", sample); got != "package demo\n" {
+		t.Errorf("overridden app/timeout.go = %q", got)
+	}
+	if got, err := Source("app/timeout.go"); err != nil || got != "package {{.App}}\n" {
+		t.Errorf("Source of the overridden app/timeout.go = %q, %v", got, err)
+	}
+	if _, err := Render("app/timeout.go", map[string]any{}); err == nil || !strings.Contains(err.Error(), "timeout.go.tmpl") {
+		t.Errorf("Render with a missing field = %v, want an error naming the override", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "app", "nothing.go.tmpl"), []byte("package x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Override(dir); err == nil {
+		t.Error("Override succeeded with a file shadowing no template")
+	}
+}
+
+func TestExport(t *testing.T) {
+	dir := t.TempDir()
+	custom := filepath.Join(dir, "model", "create.go.tmpl")
+	if err := os.MkdirAll(filepath.Dir(custom), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(custom, []byte("custom"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := Export(dir, "model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) == 0 {
+		t.Fatal("Export wrote nothing")
+	}
+	for _, path := range written {
+		if filepath.Base(filepath.Dir(path)) != "model" || path == custom {
+			t.Errorf("Export wrote %s", path)
+		}
+	}
+	if content, _ := os.ReadFile(custom); string(content) != "custom" {
+		t.Errorf("Export overwrote %s", custom)
+	}
+	if _, err := Export(dir, "nothing"); err == nil {
+		t.Error("Export succeeded with an unknown directory")
+	}
+}
+
+func TestExpand(t *testing.T) {
+	path, err := Expand("internal/service/{{.Lower}}/create.go", sample)
+	if err != nil || path != "internal/service/product/create.go" {
+		t.Errorf("Expand = %q, %v", path, err)
+	}
+}
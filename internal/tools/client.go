@@ -0,0 +1,17 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// clientCapabilities returns what the client of a tool call declared it supports when it initialized
+// Outside a session, or for a transport not recording them, it declares nothing, so the tools fall back to plain results
+func clientCapabilities(ctx context.Context) mcp.ClientCapabilities {
+	if session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo); ok {
+		return session.GetClientCapabilities()
+	}
+	return mcp.ClientCapabilities{}
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"fmt"
+
+	"mcpgo/internal/templates"
+)
+
+// negotiation holds the code fragments letting the list and get handlers answer in the format the client accepts
+type negotiation struct {
+	Imports    string // extra import lines, including indentation and newlines
+	ListReturn string // expression answering the list request
+	GetReturn  string // expression answering the get request
+	Files      []scaffoldFile
+}
+
+// newNegotiation returns the fragments of plain JSON handlers, or of handlers negotiating JSON, CSV and XML when enabled
+func newNegotiation(enabled bool, appName string) negotiation {
+	if !enabled {
+		return negotiation{ListReturn: "c.JSON(http.StatusOK, result)", GetReturn: "c.JSON(http.StatusOK, result)"}
+	}
+	return negotiation{
+		Imports:    fmt.Sprintf("\t\"%s/internal/export\"\n", appName),
+		ListReturn: "export.Respond(c, http.StatusOK, result, result.Data)",
+		GetReturn:  "export.Respond(c, http.StatusOK, result, result)",
+		Files: []scaffoldFile{
+			{Path: "internal/export/export.go", Language: "go", Content: templates.MustRender("api_controller/export.go", nil)},
+			{Path: "internal/export/negotiate.go", Language: "go", Content: templates.MustRender("api_controller/negotiate.go", nil)},
+		},
+	}
+}
+
+// step explains the export package used by the list and get handlers; it is empty without content negotiation
+func (n negotiation) step(step int, path string) string {
+	if len(n.Files) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`
+%[1]d. The list and get handlers answer in the format named by the `+"`Accept`"+` header: JSON (the default), CSV or XML, and 406 when none of them is acceptable. CSV lists one row per record, with the JSON field names as the header.
+
+   Create the file at `+"`%[3]s`"+` with the serializers, which export endpoints can reuse:
+`+"```go"+`
+%[4]s`+"```"+`
+
+   Create the file at `+"`%[5]s`"+` with the negotiation:
+`+"```go"+`
+%[6]s`+"```"+`
+
+   Try it with `+"`curl -H 'Accept: text/csv' http://localhost:8080%[2]s`"+`. XML cannot encode map fields, so give JSON document fields a struct type before offering XML.
+`, step, path,
+		n.Files[0].Path, n.Files[0].Content,
+		n.Files[1].Path, n.Files[1].Content)
+}
@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewNegotiation(t *testing.T) {
+	plain := newNegotiation(false, "shop")
+	if plain.Imports != "" || len(plain.Files) != 0 || plain.ListReturn != "c.JSON(http.StatusOK, result)" || plain.GetReturn != "c.JSON(http.StatusOK, result)" {
+		t.Errorf("the plain handlers are not JSON only: %+v", plain)
+	}
+	if step := plain.step(4, "internal/controllers/product/controller.go"); step != "" {
+		t.Errorf("the plain handlers need no step, got:\n%s", step)
+	}
+
+	negotiated := newNegotiation(true, "shop")
+	if negotiated.Imports != "\t\"shop/internal/export\"\n" {
+		t.Errorf("Imports = %q", negotiated.Imports)
+	}
+	if negotiated.ListReturn != "export.Respond(c, http.StatusOK, result, result.Data)" || negotiated.GetReturn != "export.Respond(c, http.StatusOK, result, result)" {
+		t.Errorf("the handlers do not negotiate: %+v", negotiated)
+	}
+	if len(negotiated.Files) != 2 || negotiated.Files[0].Path != "internal/export/export.go" || negotiated.Files[1].Path != "internal/export/negotiate.go" {
+		t.Fatalf("unexpected files %+v", negotiated.Files)
+	}
+	step := negotiated.step(4, "internal/controllers/product/controller.go")
+	for _, want := range []string{"\n4. The list and get handlers answer in the format named by the `Accept` header", "`internal/export/export.go`", "`internal/export/negotiate.go`"} {
+		if !strings.Contains(step, want) {
+			t.Errorf("the negotiation step lacks %q:\n%s", want, step)
+		}
+	}
+}
+
+// negotiationTest checks the generated negotiation and serializers
+const negotiationTest = `package export
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   Format
+		ok     bool
+	}{
+		{"", JSON, true},
+		{"application/json", JSON, true},
+		{"text/csv", CSV, true},
+		{"text/xml", XML, true},
+		{"text/html, application/xml;q=0.9, */*;q=0.8", XML, true},
+		{"application/json;q=0.5, text/csv", CSV, true},
+		{"text/csv;q=abc, application/json;q=0.1", JSON, true},
+		{"text/*", CSV, true},
+		{"*/*", JSON, true},
+		{"text/html", "", false},
+		{"image/png, text/csv;q=0", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Negotiate(tt.accept)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Negotiate(%q) = %q, %v, want %q, %v", tt.accept, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+type Audit struct {
+	CreatedAt time.Time ` + "`" + `json:"created_at"` + "`" + `
+}
+
+type product struct {
+	ID     uint     ` + "`" + `json:"id"` + "`" + `
+	Audit
+	Name   string   ` + "`" + `json:"name,omitempty"` + "`" + `
+	Secret string   ` + "`" + `json:"-"` + "`" + `
+	Note   *string
+	Tags   []string ` + "`" + `json:"tags"` + "`" + `
+	hidden int
+}
+
+type page struct {
+	Data []product ` + "`" + `json:"data"` + "`" + `
+}
+
+func TestRespond(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	rows := []product{
+		{ID: 1, Audit: Audit{CreatedAt: created}, Name: "Lamp, brass", Secret: "x", Tags: []string{"home"}},
+		{ID: 2, Audit: Audit{CreatedAt: created}, Name: "Desk"},
+	}
+	tests := []struct {
+		accept, contentType, body string
+		status                    int
+	}{
+		{"text/csv", "text/csv; charset=utf-8",
+			"id,created_at,name,Note,tags\n1,2024-05-01T12:00:00Z,\"Lamp, brass\",,\"[\"\"home\"\"]\"\n2,2024-05-01T12:00:00Z,Desk,,\n", http.StatusOK},
+		{"application/json", "application/json; charset=utf-8", ` + "`" + `"id":1` + "`" + `, http.StatusOK},
+		{"application/xml", "application/xml; charset=utf-8", "<?xml", http.StatusOK},
+		{"text/html", "", "", http.StatusNotAcceptable},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		request := httptest.NewRequest(http.MethodGet, "/products", nil)
+		request.Header.Set(echo.HeaderAccept, tt.accept)
+		rec := httptest.NewRecorder()
+		err := Respond(e.NewContext(request, rec), http.StatusOK, page{Data: rows}, rows)
+
+		if tt.status == http.StatusNotAcceptable {
+			if he, ok := err.(*echo.HTTPError); !ok || he.Code != http.StatusNotAcceptable {
+				t.Errorf("%s: got %v, want a 406 error", tt.accept, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: %v", tt.accept, err)
+		}
+		if got := rec.Header().Get(echo.HeaderVary); got != echo.HeaderAccept {
+			t.Errorf("%s: Vary %q, want Accept", tt.accept, got)
+		}
+		if tt.contentType == "" {
+			continue
+		}
+		if got := rec.Header().Get(echo.HeaderContentType); got != tt.contentType {
+			t.Errorf("%s: Content-Type %q, want %q", tt.accept, got, tt.contentType)
+		}
+		if body := rec.Body.String(); !strings.Contains(body, tt.body) || (tt.accept == "text/csv" && body != tt.body) {
+			t.Errorf("%s: body\n%s\nwant %q", tt.accept, body, tt.body)
+		}
+	}
+}
+`
+
+func TestNegotiationFormats(t *testing.T) {
+	goTest(t, append(newNegotiation(true, "shop").Files,
+		scaffoldFile{Path: "internal/export/negotiate_test.go", Language: "go", Content: negotiationTest}))
+}
@@ -0,0 +1,323 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"mcpgo/internal/state"
+)
+
+// packageRole is a package every scaffold places under a fixed directory
+type packageRole struct {
+	Name    string   // suffix of the layout_ option, e.g. controllers
+	Dir     string   // directory the scaffolds use by default
+	Aliases []string // directory names existing projects use for the same role
+}
+
+// packageRoles lists the relocatable packages in the order they are reported
+var packageRoles = []packageRole{
+	{Name: "controllers", Dir: "internal/controllers", Aliases: []string{"controllers", "controller", "handlers", "handler", "api", "http", "transport"}},
+	{Name: "service", Dir: "internal/service", Aliases: []string{"service", "services", "usecase", "usecases"}},
+	{Name: "repository", Dir: "internal/repository", Aliases: []string{"repository", "repositories", "repo", "store", "storage", "persistence"}},
+	{Name: "models", Dir: "internal/models", Aliases: []string{"models", "model", "domain", "entity", "entities"}},
+	{Name: "dto", Dir: "internal/dto", Aliases: []string{"dto", "dtos", "payload", "payloads"}},
+}
+
+// conventionFile is a representative file of an existing project
+type conventionFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// conventions are the choices of an existing project that scaffolds should follow
+type conventions struct {
+	Module   string
+	Go       string
+	Router   string
+	ORM      string
+	Layouts  map[string]string // role name to directory, only for roles found in the files
+	Errors   string            // echo or problem, empty when undecided
+	Wrapping string            // how errors are wrapped, e.g. fmt.Errorf with %w
+	Logger   string
+}
+
+var (
+	goModModule  = regexp.MustCompile(`(?m)^module\s+"?([^"\s]+)"?`)
+	goModVersion = regexp.MustCompile(`(?m)^go\s+(\S+)`)
+)
+
+// libraryChoices maps module path prefixes to the library names reported for a concern, in order of precedence
+var libraryChoices = map[string][][2]string{
+	"router": {{"github.com/labstack/echo", "echo"}, {"github.com/gin-gonic/gin", "gin"}, {"github.com/go-chi/chi", "chi"}, {"github.com/gofiber/fiber", "fiber"}, {"github.com/gorilla/mux", "gorilla/mux"}},
+	"orm":    {{"gorm.io/gorm", "gorm"}, {"entgo.io/ent", "ent"}, {"github.com/jmoiron/sqlx", "sqlx"}, {"github.com/jackc/pgx", "pgx"}, {"github.com/uptrace/bun", "bun"}},
+	"logger": {{"go.uber.org/zap", "zap"}, {"github.com/rs/zerolog", "zerolog"}, {"github.com/sirupsen/logrus", "logrus"}},
+}
+
+// detectConventions reads the module path and dependencies from go.mod, and the layout and styles from the files
+func detectConventions(goMod string, files []conventionFile) (conventions, error) {
+	match := goModModule.FindStringSubmatch(goMod)
+	if match == nil {
+		return conventions{}, fmt.Errorf("Invalid 'go_mod': no module directive found.")
+	}
+	c := conventions{Module: match[1], Layouts: map[string]string{}}
+	if version := goModVersion.FindStringSubmatch(goMod); version != nil {
+		c.Go = version[1]
+	}
+	c.Router = library(goMod, "router")
+	c.ORM = library(goMod, "orm")
+	c.Logger = library(goMod, "logger")
+
+	var sources strings.Builder
+	for _, f := range files {
+		sources.WriteString(f.Content)
+		sources.WriteString("\n")
+	}
+	code := sources.String()
+	if c.Logger == "" {
+		switch {
+		case strings.Contains(code, `"log/slog"`):
+			c.Logger = "slog"
+		case strings.Contains(code, `"log"`):
+			c.Logger = "log"
+		}
+	}
+
+	switch {
+	case strings.Contains(code, "application/problem+json"):
+		c.Errors = "problem"
+	case strings.Contains(code, "echo.NewHTTPError"):
+		c.Errors = "echo"
+	}
+	switch {
+	case strings.Contains(code, `"github.com/pkg/errors"`):
+		c.Wrapping = "github.com/pkg/errors"
+	case strings.Contains(code, "%w"):
+		c.Wrapping = "fmt.Errorf with %w"
+	}
+
+	for _, role := range packageRoles {
+		if dir := roleDir(files, role); dir != "" {
+			c.Layouts[role.Name] = dir
+		}
+	}
+	return c, nil
+}
+
+// library returns the first library of a concern required by go.mod
+func library(goMod, concern string) string {
+	for _, choice := range libraryChoices[concern] {
+		if strings.Contains(goMod, choice[0]) {
+			return choice[1]
+		}
+	}
+	return ""
+}
+
+// roleDir returns the directory most of the files place a package role in, e.g. internal/handlers
+func roleDir(files []conventionFile, role packageRole) string {
+	counts := map[string]int{}
+	for _, f := range files {
+		dirs := strings.Split(path.Dir(path.Clean(strings.TrimPrefix(f.Path, "/"))), "/")
+		if dirs[0] == "cmd" {
+			continue // main packages, e.g. cmd/api
+		}
+		for i, dir := range dirs {
+			if slices.Contains(role.Aliases, dir) {
+				counts[strings.Join(dirs[:i+1], "/")]++
+				break
+			}
+		}
+	}
+	best := ""
+	for dir, count := range counts {
+		if best == "" || count > counts[best] || (count == counts[best] && dir < best) {
+			best = dir
+		}
+	}
+	return best
+}
+
+// record stores the conventions as options of appName, so later scaffolds follow them
+func (c conventions) record(ctx context.Context, appName string) {
+	if c.Module != appName {
+		state.From(ctx).SetOption(appName, "module", c.Module)
+	}
+	for _, role := range packageRoles {
+		if dir, ok := c.Layouts[role.Name]; ok && dir != role.Dir {
+			state.From(ctx).SetOption(appName, "layout_"+role.Name, dir)
+		}
+	}
+	if c.Errors != "" {
+		state.From(ctx).SetOption(appName, "error_format", c.Errors)
+	}
+	if c.Logger != "" {
+		state.From(ctx).SetOption(appName, "logger", c.Logger)
+	}
+}
+
+// slogBridges tells how to send slog records to the loggers existing projects use
+var slogBridges = map[string]string{
+	"zap":     "build the logger with slog.New(zapslog.NewHandler(zapLogger.Core())) from go.uber.org/zap/exp/zapslog",
+	"zerolog": "build the logger with slog.New(slogzerolog.Option{Logger: &zerologLogger}.NewZerologHandler()) from github.com/samber/slog-zerolog/v2",
+	"logrus":  "build the logger with slog.New(sloglogrus.Option{Logger: logrusLogger}.NewLogrusHandler()) from github.com/samber/slog-logrus/v2",
+}
+
+// loggerNote explains how to keep a single log pipeline when the application already logs through another library
+func loggerNote(ctx context.Context, appName string) string {
+	project, ok := state.From(ctx).Project(appName)
+	if !ok {
+		return ""
+	}
+	logger := project.Options["logger"]
+	bridge, ok := slogBridges[logger]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("The application logs with %s: instead of logging.New, %s so both end up in the same output.", logger, bridge)
+}
+
+// projectRewrites returns the rewrites of generated code to the module path and package layout recorded for appName
+// It is empty when the scaffold defaults apply; module is false for scaffolds that already use the module path
+func projectRewrites(ctx context.Context, appName string, module bool) []func(string) string {
+	project, ok := state.From(ctx).Project(appName)
+	if !ok || appName == "" {
+		return nil
+	}
+
+	var rewrites []func(string) string
+	if modulePath := project.Options["module"]; module && modulePath != "" && modulePath != appName {
+		importPath := regexp.MustCompile(`(^|[^\w./-])` + regexp.QuoteMeta(appName) + `/`)
+		rewrites = append(rewrites, func(s string) string { return importPath.ReplaceAllString(s, "${1}"+modulePath+"/") })
+	}
+	for _, role := range packageRoles {
+		dir := project.Options["layout_"+role.Name]
+		if dir == "" || dir == role.Dir {
+			continue
+		}
+		dirPattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(role.Dir) + `\b`)
+		rewrites = append(rewrites, func(s string) string { return dirPattern.ReplaceAllLiteralString(s, dir) })
+
+		oldName, newName := path.Base(role.Dir), path.Base(dir)
+		if newName == oldName || !token.IsIdentifier(newName) || token.IsKeyword(newName) {
+			continue
+		}
+		clause := regexp.MustCompile(`\bpackage ` + oldName + `\b`)
+		qualifier := regexp.MustCompile(`\b` + oldName + `\.([A-Z])`)
+		rewrites = append(rewrites, func(s string) string {
+			s = clause.ReplaceAllLiteralString(s, "package "+newName)
+			return qualifier.ReplaceAllString(s, newName+".$1")
+		})
+	}
+	return rewrites
+}
+
+// applyConventions rewrites the instructions and files of a scaffold for the conventions recorded for appName
+func applyConventions(ctx context.Context, appName, markdown string, s scaffold) (string, scaffold) {
+	rewrites := projectRewrites(ctx, appName, !s.appDir)
+	if len(rewrites) == 0 || s.rewritten {
+		return markdown, s
+	}
+	apply := func(text string) string {
+		for _, rewrite := range rewrites {
+			text = rewrite(text)
+		}
+		return text
+	}
+
+	files := make([]scaffoldFile, len(s.Files))
+	for i, f := range s.Files {
+		files[i] = scaffoldFile{Path: apply(f.Path), Language: f.Language, Content: apply(f.Content)}
+	}
+	commands := make([]string, len(s.Commands))
+	for i, command := range s.Commands {
+		commands[i] = apply(command)
+	}
+	notes := make([]string, len(s.Notes))
+	for i, note := range s.Notes {
+		notes[i] = apply(note)
+	}
+	return apply(markdown), scaffold{Files: files, Commands: commands, Notes: notes, routes: s.routes, appDir: s.appDir, progress: s.progress}
+}
+
+// report renders the detected conventions and what later scaffolds will do with them
+func (c conventions) report(appName string) string {
+	var b strings.Builder
+	b.WriteString("# Detected Conventions\n\n")
+	fmt.Fprintf(&b, "- Module path: `%s`\n", c.Module)
+	if c.Go != "" {
+		fmt.Fprintf(&b, "- Go version: %s\n", c.Go)
+	}
+	fmt.Fprintf(&b, "- Router: %s\n", valueOr(c.Router, "not found in go.mod"))
+	fmt.Fprintf(&b, "- ORM: %s\n", valueOr(c.ORM, "not found in go.mod"))
+	fmt.Fprintf(&b, "- Logger: %s\n", valueOr(c.Logger, "not found"))
+	fmt.Fprintf(&b, "- Error responses: %s\n", valueOr(map[string]string{"echo": "echo.NewHTTPError", "problem": "application/problem+json"}[c.Errors], "not found"))
+	fmt.Fprintf(&b, "- Error wrapping: %s\n", valueOr(c.Wrapping, "not found"))
+
+	b.WriteString("\n## Package Layout\n\n| Package | Scaffold default | This project |\n|---|---|---|\n")
+	roles := make([]string, 0, len(packageRoles))
+	for _, role := range packageRoles {
+		dir, ok := c.Layouts[role.Name]
+		if !ok {
+			dir = "not found, keeping the default"
+		}
+		roles = append(roles, fmt.Sprintf("| %s | `%s` | %s |", role.Name, role.Dir, codeOr(dir, ok)))
+	}
+	b.WriteString(strings.Join(roles, "\n"))
+	b.WriteString("\n\n## Applied to Later Scaffolds\n\n")
+
+	fmt.Fprintf(&b, "Pass `app_name: %q` to the produce_* tools. Their output for this application now:\n", appName)
+	if c.Module != appName {
+		fmt.Fprintf(&b, "- imports internal packages from `%s/...`\n", c.Module)
+	}
+	var moved []string
+	for _, role := range packageRoles {
+		if dir, ok := c.Layouts[role.Name]; ok && dir != role.Dir {
+			moved = append(moved, fmt.Sprintf("`%s` to `%s`", role.Dir, dir))
+		}
+	}
+	sort.Strings(moved)
+	if len(moved) > 0 {
+		fmt.Fprintf(&b, "- moves %s, renaming the packages after their directories\n", strings.Join(moved, ", "))
+	}
+	if c.Errors != "" {
+		fmt.Fprintf(&b, "- uses the `%s` error format in API controllers unless `error_format` is passed\n", c.Errors)
+	}
+	if c.Logger != "" && c.Logger != "slog" {
+		fmt.Fprintf(&b, "- notes how to route the generated slog logging through %s\n", c.Logger)
+	}
+
+	var warnings []string
+	if c.Router != "" && c.Router != "echo" {
+		warnings = append(warnings, fmt.Sprintf("The project uses %s, but the controllers and middleware are generated for Echo; port the handlers or mount Echo under a sub-route.", c.Router))
+	}
+	if c.ORM != "" && c.ORM != "gorm" {
+		warnings = append(warnings, fmt.Sprintf("The project uses %s, but the repositories are generated for GORM; keep the interfaces and rewrite the implementations.", c.ORM))
+	}
+	if len(warnings) > 0 {
+		b.WriteString("\n## Warnings\n\n")
+		for _, warning := range warnings {
+			fmt.Fprintf(&b, "- %s\n", warning)
+		}
+	}
+	return b.String()
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func codeOr(value string, code bool) string {
+	if code {
+		return "`" + value + "`"
+	}
+	return value
+}
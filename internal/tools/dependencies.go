@@ -0,0 +1,217 @@
+package tools
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// dependencyVersions pins the modules the generated code imports to the releases the templates are written against
+// Modules missing here are fetched at their latest release
+var dependencyVersions = map[string]string{
+	"github.com/a-h/templ":                            "v0.3.906",
+	"github.com/aws/aws-sdk-go-v2":                    "v1.36.5",
+	"github.com/aws/aws-sdk-go-v2/config":             "v1.29.17",
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager": "v1.17.80",
+	"github.com/aws/aws-sdk-go-v2/service/s3":         "v1.81.0",
+	"github.com/go-playground/validator/v10":          "v10.26.0",
+	"github.com/google/wire":                          "v0.6.0",
+	"github.com/gorilla/sessions":                     "v1.4.0",
+	"github.com/labstack/echo-contrib":                "v0.17.4",
+	"github.com/labstack/echo/v4":                     "v4.13.4",
+	"github.com/lib/pq":                               "v1.10.9",
+	"github.com/prometheus/client_golang":             "v1.22.0",
+	"github.com/redis/go-redis/v9":                    "v9.10.0",
+	"go.uber.org/fx":                                  "v1.24.0",
+	"gorm.io/datatypes":                               "v1.2.5",
+	"gorm.io/driver/postgres":                         "v1.6.0",
+	"gorm.io/driver/sqlite":                           "v1.6.0",
+	"gorm.io/gorm":                                    "v1.30.0",
+	"gorm.io/plugin/dbresolver":                       "v1.6.0",
+}
+
+// goModFile is the part of a go.mod file deciding which imports it already provides
+type goModFile struct {
+	Module   string
+	Requires []string // module paths of the require directives
+}
+
+// parseGoMod reads the module path and the required modules of a go.mod file
+func parseGoMod(content string) goModFile {
+	var mod goModFile
+	if match := goModModule.FindStringSubmatch(content); match != nil {
+		mod.Module = match[1]
+	}
+	inBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "//")
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case inBlock && fields[0] == ")":
+			inBlock = false
+		case inBlock && len(fields) >= 2:
+			mod.Requires = append(mod.Requires, strings.Trim(fields[0], `"`))
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inBlock = true
+		case fields[0] == "require" && len(fields) >= 3:
+			mod.Requires = append(mod.Requires, strings.Trim(fields[1], `"`))
+		}
+	}
+	return mod
+}
+
+// provides reports whether an import path belongs to the module itself or to one it requires
+func (m goModFile) provides(importPath string) bool {
+	for _, module := range append([]string{m.Module}, m.Requires...) {
+		if module != "" && withinModule(importPath, module) {
+			return true
+		}
+	}
+	return false
+}
+
+// withinModule reports whether an import path is a package of a module
+func withinModule(importPath, module string) bool {
+	return importPath == module || strings.HasPrefix(importPath, module+"/")
+}
+
+// dependencyModule returns the module go get fetches for an import path, or "" for the standard library
+func dependencyModule(importPath string) string {
+	first, _, _ := strings.Cut(importPath, "/")
+	if !strings.Contains(first, ".") {
+		return ""
+	}
+	module := importPath
+	longest := 0
+	for known := range dependencyVersions {
+		if withinModule(importPath, known) && len(known) > longest {
+			module, longest = known, len(known)
+		}
+	}
+	return module
+}
+
+// fileImports returns the import paths of a Go or templ file; templ files also need the templ runtime
+// A templ file starts with a package clause and Go imports, so parsing stops before its components
+func fileImports(f scaffoldFile) []string {
+	var imports []string
+	if f.Language == "templ" {
+		imports = append(imports, "github.com/a-h/templ")
+	}
+	parsed, err := parser.ParseFile(token.NewFileSet(), f.Path, f.Content, parser.ImportsOnly)
+	if err != nil {
+		return imports
+	}
+	for _, spec := range parsed.Imports {
+		if importPath, err := strconv.Unquote(spec.Path.Value); err == nil {
+			imports = append(imports, importPath)
+		}
+	}
+	return imports
+}
+
+// findGoMod returns the directory of the go.mod file closest above a file written under dir, relative to dir
+func findGoMod(dir, file string) (string, bool) {
+	for rel := path.Dir(file); ; rel = path.Dir(rel) {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(rel), "go.mod")); err == nil {
+			return rel, true
+		}
+		if rel == "." || rel == "/" {
+			return "", false
+		}
+	}
+}
+
+// dependencyCommands returns the go get commands fetching the modules the generated code imports and the
+// go.mod files under dir do not require yet, one command per go.mod, pinned to dependencyVersions
+// ok is false when the project has no go.mod yet, or the scaffold creates one, so the generic commands still apply
+func dependencyCommands(dir string, files []scaffoldFile) (commands []string, ok bool) {
+	mods := map[string]goModFile{}
+	missing := map[string][]string{}
+	var modDirs []string
+	for _, f := range files {
+		if path.Base(f.Path) == "go.mod" {
+			return nil, false
+		}
+		if f.Language != "go" && f.Language != "templ" {
+			continue
+		}
+		modDir, found := findGoMod(dir, f.Path)
+		if !found {
+			return nil, false
+		}
+		mod, seen := mods[modDir]
+		if !seen {
+			content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(modDir), "go.mod"))
+			if err != nil {
+				return nil, false
+			}
+			mod = parseGoMod(string(content))
+			mods[modDir] = mod
+			modDirs = append(modDirs, modDir)
+		}
+		for _, importPath := range fileImports(f) {
+			module := dependencyModule(importPath)
+			if module == "" || mod.provides(importPath) || slices.Contains(missing[modDir], module) {
+				continue
+			}
+			missing[modDir] = append(missing[modDir], module)
+		}
+	}
+
+	for _, modDir := range modDirs {
+		if len(missing[modDir]) == 0 {
+			continue
+		}
+		slices.Sort(missing[modDir])
+		args := make([]string, len(missing[modDir]))
+		for i, module := range missing[modDir] {
+			args[i] = module + "@" + valueOr(dependencyVersions[module], "latest")
+		}
+		command := "go get " + strings.Join(args, " ")
+		if modDir != "." {
+			command = "cd " + modDir + " && " + command
+		}
+		commands = append(commands, command)
+	}
+	return commands, true
+}
+
+// writtenCommands returns the commands left to run once a scaffold is written under dir
+// With a go.mod under dir, the dependency commands only fetch the modules it is missing, at pinned versions;
+// satisfied reports that it already requires every one of them
+func writtenCommands(dir string, s scaffold) (commands []string, satisfied bool) {
+	commands = remainingCommands(s.Commands)
+	dependencies, ok := dependencyCommands(dir, s.Files)
+	if !ok {
+		return commands, false
+	}
+	return append(dependencies, withoutDependencyCommands(commands)...), len(dependencies) == 0
+}
+
+// withoutDependencyCommands drops the go mod init, go get and go mod tidy steps of the commands, which
+// dependencyCommands replaces; a command left with only a cd is dropped too
+func withoutDependencyCommands(commands []string) []string {
+	var kept []string
+	for _, command := range commands {
+		var steps []string
+		substantive := false
+		for _, step := range strings.Split(command, " && ") {
+			if strings.HasPrefix(step, "go get ") || strings.HasPrefix(step, "go mod init ") || step == "go mod tidy" {
+				continue
+			}
+			steps = append(steps, step)
+			substantive = substantive || !strings.HasPrefix(step, "cd ")
+		}
+		if substantive {
+			kept = append(kept, strings.Join(steps, " && "))
+		}
+	}
+	return kept
+}
@@ -0,0 +1,184 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+	"mcpgo/internal/templates"
+)
+
+// diOption selects how the app wires its constructors; the choice is remembered per app
+var diOption = mcp.WithString("di",
+	mcp.Description("How cmd/web/main.go wires repositories, services and controllers: none (manual constructor calls), fx (uber/fx provider sets in internal/app, updated as models are added) or wire (a google/wire provider set in internal/app, from which the wire command generates the constructor calls). Defaults to none."),
+	mcp.Enum("none", "fx", "wire"),
+)
+
+// usesFx reports whether the app was scaffolded with uber/fx dependency injection
+func usesFx(ctx context.Context, appName string) bool {
+	project, ok := state.From(ctx).Project(appName)
+	return ok && project.Options["di"] == "fx"
+}
+
+// usesWire reports whether the app was scaffolded with google/wire dependency injection
+func usesWire(ctx context.Context, appName string) bool {
+	project, ok := state.From(ctx).Project(appName)
+	return ok && project.Options["di"] == "wire"
+}
+
+// modelPackage is the name the layer package of a model is imported under next to those of other models, e.g.
+// reviewcontrollers for internal/controllers/review: the repositories, services and controllers of each model are
+// packages of their own, all named after their layer
+func modelPackage(model, layer string) string {
+	return strings.ToLower(model) + layer
+}
+
+// importSet collects the packages of the app a generated file imports, keyed by path, with the name each is
+// imported under, empty for its own
+type importSet map[string]string
+
+// model adds the layer package of a model and returns the name it is imported under
+func (s importSet) model(module, model, layer string) string {
+	name := modelPackage(model, layer)
+	s[module+"/internal/"+layer+"/"+strings.ToLower(model)] = name
+	return name
+}
+
+// lines renders the imports in path order, one indented line each
+func (s importSet) lines() string {
+	var b strings.Builder
+	for _, path := range slices.Sorted(maps.Keys(s)) {
+		if s[path] != "" {
+			fmt.Fprintf(&b, "\t%s %q\n", s[path], path)
+		} else {
+			fmt.Fprintf(&b, "\t%q\n", path)
+		}
+	}
+	return b.String()
+}
+
+// fxProviderData lists the constructors of every model in the manifest, for the provider sets in providers.go
+func fxProviderData(ctx context.Context, appName, module string) map[string]any {
+	imports := importSet{}
+	var modelList, repositories, services, controllers, routes strings.Builder
+
+	project, _ := state.From(ctx).Project(appName)
+	for _, m := range project.Models {
+		has := func(component string) bool { return slices.Contains(m.Components, component) }
+		if has("model") {
+			imports[module+"/internal/models"] = ""
+			fmt.Fprintf(&modelList, "\t&models.%s{},\n", m.Name)
+		}
+		if has("repository") {
+			fmt.Fprintf(&repositories, "\t%s.New%sRepository,\n", imports.model(module, m.Name, "repository"), m.Name)
+		}
+		if has("service") {
+			fmt.Fprintf(&services, "\t%s.New%sService,\n", imports.model(module, m.Name, "service"), m.Name)
+		}
+		if has("api_controller") {
+			fmt.Fprintf(&controllers, "\t%s.New%sController,\n", imports.model(module, m.Name, "controllers"), m.Name)
+			fmt.Fprintf(&routes, "\t%s,\n", fxRegister(m.Name, false))
+		}
+		if has("html_controller") {
+			fmt.Fprintf(&controllers, "\t%s.New%sHtmlController,\n", imports.model(module, m.Name, "controllers"), m.Name)
+			fmt.Fprintf(&routes, "\t%s,\n", fxRegister(m.Name, true))
+		}
+	}
+
+	importBlock := ""
+	if len(imports) > 0 {
+		importBlock = "\n" + imports.lines()
+	}
+	return map[string]any{
+		"ProviderImports": importBlock,
+		"Models":          modelList.String(),
+		"Repositories":    repositories.String(),
+		"Services":        services.String(),
+		"Controllers":     controllers.String(),
+		"Routes":          routes.String(),
+	}
+}
+
+// fxRegister names the function registering the API or HTML routes of a model
+func fxRegister(model string, html bool) string {
+	if html {
+		return "register" + model + "Pages"
+	}
+	return "register" + model + "Routes"
+}
+
+// fxRoutesFile renders the function registering a controller's routes, invoked by fx with the controller it needs
+func fxRoutesFile(appName, model, lower string, mount routes, list []route, html bool) scaffoldFile {
+	controller, typ, kind, path := lower+"Controller", model+"Controller", "API", fmt.Sprintf("internal/app/routes_%s.go", lower)
+	if html {
+		controller, typ, kind, path = lower+"HtmlController", model+"HtmlController", "HTML", fmt.Sprintf("internal/app/pages_%s.go", lower)
+	}
+
+	return scaffoldFile{
+		Path:     path,
+		Language: "go",
+		Content: templates.MustRender("app/fx_routes.go", map[string]any{
+			"App":        appName,
+			"Model":      model,
+			"Package":    modelPackage(model, "controllers"),
+			"Lower":      lower,
+			"Register":   fxRegister(model, html),
+			"Kind":       kind,
+			"Controller": controller,
+			"Type":       typ,
+			"Block":      routeBody(mount, list),
+		}),
+	}
+}
+
+// fxProviderNote tells where to add a new constructor, or how to regenerate providers.go from the manifest
+func fxProviderNote(entries ...string) string {
+	return fmt.Sprintf("Add %s in internal/app/providers.go, or run start_here_produce_app_boilerplate with di=fx again to regenerate it from the project manifest.", strings.Join(entries, " and "))
+}
+
+// wireProviderData lists the constructors of every model in the manifest, for the provider set in providers.go
+// Unlike fx, every controller the router mounts is listed, since wire fills router.Deps from the set
+func wireProviderData(ctx context.Context, appName, module string) map[string]any {
+	imports := importSet{}
+	var modelList, providers strings.Builder
+
+	project, _ := state.From(ctx).Project(appName)
+	for _, m := range project.Models {
+		has := func(component string) bool { return slices.Contains(m.Components, component) }
+		if has("model") {
+			imports[module+"/internal/models"] = ""
+			fmt.Fprintf(&modelList, "\t&models.%s{},\n", m.Name)
+		}
+		if has("repository") {
+			fmt.Fprintf(&providers, "\t%s.New%sRepository,\n", imports.model(module, m.Name, "repository"), m.Name)
+		}
+		if has("service") {
+			fmt.Fprintf(&providers, "\t%s.New%sService,\n", imports.model(module, m.Name, "service"), m.Name)
+		}
+		for _, c := range routerControllers {
+			if has(c.Component) {
+				fmt.Fprintf(&providers, "\t%s.New%s%s,\n", imports.model(module, m.Name, "controllers"), m.Name, c.Suffix)
+			}
+		}
+	}
+
+	importBlock := ""
+	if len(imports) > 0 {
+		importBlock = "\n" + imports.lines()
+	}
+	return map[string]any{
+		"ProviderImports": importBlock,
+		"Models":          modelList.String(),
+		"Providers":       providers.String(),
+	}
+}
+
+// wireProviderNote tells where to add a new constructor, or how to regenerate providers.go from the manifest
+func wireProviderNote(entries ...string) string {
+	return fmt.Sprintf("Add %s in internal/app/providers.go and run wire ./internal/app, or run start_here_produce_app_boilerplate with di=wire again to regenerate it from the project manifest.", strings.Join(entries, " and "))
+}
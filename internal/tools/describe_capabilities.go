@@ -0,0 +1,253 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetDescribeCapabilitiesTool returns the tool definition for describe_capabilities
+func GetDescribeCapabilitiesTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("describe_capabilities",
+		mcp.WithDescription("Returns a JSON catalog of the tools of this server: the recommended order to scaffold an application in, every tool with its parameters, allowed values and the hints of whether it writes files, the parameters shared by every tool generating files, and the choices offered, such as the database dialects and dependency injection frameworks. Call it first to plan a multi-step scaffold."),
+		mcp.WithString("tools",
+			mcp.Description("Comma-separated names of the tools to describe (e.g., produce_model_boilerplate,scaffold_full_crud). Defaults to every tool."),
+		),
+		readOnlyAnnotations,
+		mcp.WithOutputSchema[capabilityCatalog](),
+	)
+
+	return tool, DescribeCapabilitiesHandler
+}
+
+// capabilityCatalog is the JSON document describe_capabilities returns
+type capabilityCatalog struct {
+	Workflow         []workflowStep        `json:"workflow"`
+	CommonParameters []capabilityParameter `json:"common_parameters"` // taken by every tool generating files, left out of their parameters
+	Choices          []capabilityChoice    `json:"choices"`
+	Tools            []capabilityTool      `json:"tools"`
+}
+
+// workflowStep is a step of the recommended order to scaffold an application in
+type workflowStep struct {
+	Step    int      `json:"step"`
+	Tools   []string `json:"tools,omitempty"`
+	Purpose string   `json:"purpose"`
+}
+
+// capabilityTool describes one tool
+type capabilityTool struct {
+	Name             string                `json:"name"`
+	Category         string                `json:"category"` // workflow, utility or project
+	Description      string                `json:"description"`
+	Next             string                `json:"next,omitempty"` // the next recommended step after it
+	ReadOnly         bool                  `json:"read_only"`
+	Destructive      bool                  `json:"destructive"`
+	Idempotent       bool                  `json:"idempotent"`
+	CommonParameters bool                  `json:"common_parameters"` // it also takes the common parameters
+	Parameters       []capabilityParameter `json:"parameters"`
+}
+
+// capabilityParameter describes one parameter of a tool
+type capabilityParameter struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Required    bool     `json:"required,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+}
+
+// capabilityChoice is a parameter with a fixed set of values, and the tools taking it with those values
+type capabilityChoice struct {
+	Parameter string   `json:"parameter"`
+	Values    []string `json:"values"`
+	Tools     []string `json:"tools"`
+}
+
+// workflow is the recommended order of the tools scaffolding an application, as registered
+var workflow = []workflowStep{
+	{1, []string{"start_here_produce_app_boilerplate"}, "Create the Echo application: main.go, the database connection and the router."},
+	{2, []string{"produce_model_boilerplate", "produce_scopes_boilerplate"}, "Create each model and its repository, then, if needed, reusable query scopes for it."},
+	{3, []string{"produce_service_boilerplate", "produce_authorization_boilerplate"}, "Create the service of each model, then, if records belong to users, the ownership policies it checks."},
+	{4, []string{"produce_api_controller_boilerplate", "produce_html_controller_boilerplate"}, "Create a JSON API controller, HTML pages, or both, for each model."},
+	{5, []string{"scaffold_full_crud"}, "Instead of steps 2 to 4, create a model's repository, service, DTOs and API controller in one call."},
+	{6, nil, "Add the utilities the application needs, in any order: the produce_* tools of the utility category."},
+	{7, []string{"list_scaffolded_components", "fix_app"}, "Check what is still missing, and get pointers when the application fails to build or run."},
+}
+
+// DescribeCapabilitiesHandler handles requests to describe the tools of the server
+// The catalog is read from the schemas of the enabled tools, so it always matches what the server accepts
+func DescribeCapabilitiesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	enabled := Enabled()
+	tools := enabled
+	if names := splitArguments(request.GetString("tools", "")); len(names) > 0 {
+		for _, name := range names {
+			if !slices.ContainsFunc(enabled, func(t server.ServerTool) bool { return t.Tool.Name == name }) {
+				return mcp.NewToolResultError(fmt.Sprintf("Unknown tool '%s' in 'tools'. Available tools: %s.", name, strings.Join(toolNames(enabled), ", "))), nil
+			}
+		}
+		tools = slices.DeleteFunc(slices.Clone(enabled), func(t server.ServerTool) bool { return !slices.Contains(names, t.Tool.Name) })
+	}
+
+	common := commonParameters(enabled)
+	catalog := capabilityCatalog{Workflow: enabledWorkflow(enabled, common), CommonParameters: common, Choices: []capabilityChoice{}}
+
+	for _, t := range enabled {
+		withCommon := takesCommonParameters(t.Tool, common)
+		for _, p := range toolParameters(t.Tool) {
+			if len(p.Enum) == 0 || withCommon && isCommon(common, p.Name) {
+				continue
+			}
+			i := slices.IndexFunc(catalog.Choices, func(c capabilityChoice) bool { return c.Parameter == p.Name && slices.Equal(c.Values, p.Enum) })
+			if i < 0 {
+				catalog.Choices = append(catalog.Choices, capabilityChoice{Parameter: p.Name, Values: p.Enum})
+				i = len(catalog.Choices) - 1
+			}
+			catalog.Choices[i].Tools = append(catalog.Choices[i].Tools, t.Tool.Name)
+		}
+	}
+	slices.SortStableFunc(catalog.Choices, func(a, b capabilityChoice) int { return strings.Compare(a.Parameter, b.Parameter) })
+
+	for _, t := range tools {
+		withCommon := takesCommonParameters(t.Tool, common)
+		description, next, _ := strings.Cut(t.Tool.Description, "\n\nNext recommended step: ")
+		c := capabilityTool{
+			Name:             t.Tool.Name,
+			Category:         toolCategory(t.Tool, withCommon),
+			Description:      description,
+			Next:             next,
+			ReadOnly:         hint(t.Tool.Annotations.ReadOnlyHint),
+			Destructive:      hint(t.Tool.Annotations.DestructiveHint),
+			Idempotent:       hint(t.Tool.Annotations.IdempotentHint),
+			CommonParameters: withCommon,
+			Parameters:       []capabilityParameter{},
+		}
+		for _, p := range toolParameters(t.Tool) {
+			if !withCommon || !isCommon(common, p.Name) {
+				c.Parameters = append(c.Parameters, p)
+			}
+		}
+		catalog.Tools = append(catalog.Tools, c)
+	}
+
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Could not encode the capability catalog: %v.", err)), nil
+	}
+	return mcp.NewToolResultStructured(catalog, string(data)), nil
+}
+
+// enabledWorkflow returns the workflow steps with only the enabled tools, dropping the steps left with none
+// Steps keep their numbers, which the purposes of later steps refer to
+func enabledWorkflow(enabled []server.ServerTool, common []capabilityParameter) []workflowStep {
+	names := toolNames(enabled)
+	utilities := slices.ContainsFunc(enabled, func(t server.ServerTool) bool {
+		return toolCategory(t.Tool, takesCommonParameters(t.Tool, common)) == "utility"
+	})
+	steps := []workflowStep{}
+	for _, step := range workflow {
+		if step.Tools == nil {
+			// The step about the utilities names none of them
+			if utilities {
+				steps = append(steps, step)
+			}
+			continue
+		}
+		tools := slices.DeleteFunc(slices.Clone(step.Tools), func(name string) bool { return !slices.Contains(names, name) })
+		if len(tools) > 0 {
+			steps = append(steps, workflowStep{Step: step.Step, Tools: tools, Purpose: step.Purpose})
+		}
+	}
+	return steps
+}
+
+// commonParameters returns the parameters every tool taking write_files declares alike, in alphabetical order
+func commonParameters(tools []server.ServerTool) []capabilityParameter {
+	var common []capabilityParameter
+	first := true
+	for _, t := range tools {
+		if _, ok := t.Tool.InputSchema.Properties["write_files"]; !ok {
+			continue
+		}
+		if first {
+			common, first = toolParameters(t.Tool), false
+			continue
+		}
+		common = slices.DeleteFunc(common, func(p capabilityParameter) bool { return !declares(t.Tool, p) })
+	}
+	slices.SortFunc(common, func(a, b capabilityParameter) int { return strings.Compare(a.Name, b.Name) })
+	return common
+}
+
+// takesCommonParameters reports whether tool declares every common parameter as the others do
+func takesCommonParameters(tool mcp.Tool, common []capabilityParameter) bool {
+	return len(common) > 0 && !slices.ContainsFunc(common, func(p capabilityParameter) bool { return !declares(tool, p) })
+}
+
+// declares reports whether tool has the parameter p, with the same type, description and values
+func declares(tool mcp.Tool, p capabilityParameter) bool {
+	own, ok := toolParameter(tool, p.Name)
+	return ok && own.Type == p.Type && own.Required == p.Required && own.Description == p.Description && slices.Equal(own.Enum, p.Enum)
+}
+
+// isCommon reports whether a parameter named name is among the common ones
+func isCommon(common []capabilityParameter, name string) bool {
+	return slices.ContainsFunc(common, func(p capabilityParameter) bool { return p.Name == name })
+}
+
+// toolCategory places a tool in the workflow, among the utilities generating files, or among the tools about the project
+func toolCategory(tool mcp.Tool, generates bool) string {
+	if !generates {
+		return "project"
+	}
+	if slices.ContainsFunc(workflow, func(step workflowStep) bool { return slices.Contains(step.Tools, tool.Name) }) {
+		return "workflow"
+	}
+	return "utility"
+}
+
+// toolParameters returns the parameters of a tool, the required ones first, then in alphabetical order
+func toolParameters(tool mcp.Tool) []capabilityParameter {
+	var parameters []capabilityParameter
+	for name := range tool.InputSchema.Properties {
+		p, _ := toolParameter(tool, name)
+		parameters = append(parameters, p)
+	}
+	slices.SortFunc(parameters, func(a, b capabilityParameter) int {
+		if a.Required != b.Required {
+			if a.Required {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return parameters
+}
+
+// toolParameter returns the parameter name of a tool as its input schema declares it
+func toolParameter(tool mcp.Tool, name string) (capabilityParameter, bool) {
+	property, ok := tool.InputSchema.Properties[name]
+	if !ok {
+		return capabilityParameter{}, false
+	}
+	p := capabilityParameter{Name: name, Required: slices.Contains(tool.InputSchema.Required, name)}
+	// The schema is built by mcp.With* options as loosely typed maps; decoding it again reads what each declared
+	data, _ := json.Marshal(property)
+	_ = json.Unmarshal(data, &p)
+	return p, true
+}
+
+// hint reads an annotation hint, false when the tool leaves it unset
+func hint(value *bool) bool {
+	return value != nil && *value
+}
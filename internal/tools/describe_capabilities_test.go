@@ -0,0 +1,30 @@
+package tools
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/config"
+)
+
+func TestWorkflowOfEnabledTools(t *testing.T) {
+	previous := settings
+	settings = config.Config{Tools: []string{"start_here_produce_app_boilerplate", "produce_model_boilerplate", "scaffold_full_crud", "fix_app"}}
+	t.Cleanup(func() { settings = previous })
+
+	result, err := DescribeCapabilitiesHandler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var steps [][]string
+	for _, step := range result.StructuredContent.(capabilityCatalog).Workflow {
+		steps = append(steps, step.Tools)
+	}
+	want := [][]string{{"start_here_produce_app_boilerplate"}, {"produce_model_boilerplate"}, {"scaffold_full_crud"}, {"fix_app"}}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("workflow tools %v, want %v", steps, want)
+	}
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetDetectConventionsTool returns the tool definition for detect_conventions
+func GetDetectConventionsTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("detect_conventions",
+		mcp.WithDescription("Reads go.mod and a few representative files of an existing project, and configures the scaffolds of the application (module path, package layout, error style, logger) to match it."),
+		mcp.WithString("go_mod",
+			mcp.Required(),
+			mcp.Description("The content of the project's go.mod file."),
+		),
+		mcp.WithString("files",
+			mcp.Description(`A JSON array of representative files with 'path' and 'content' keys, e.g. a handler, a service, a repository and main.go (e.g., [{"path":"internal/handlers/user.go","content":"package handlers..."}]).`),
+		),
+		mcp.WithString("app_name",
+			mcp.Description("The name the produce_* tools are called with for this project. Defaults to the module path from go.mod."),
+		),
+		// Records the conventions in the project manifest, for later scaffolds, but writes nothing in the project
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			ReadOnlyHint:    mcp.ToBoolPtr(false),
+			DestructiveHint: mcp.ToBoolPtr(false),
+			IdempotentHint:  mcp.ToBoolPtr(true),
+			OpenWorldHint:   mcp.ToBoolPtr(false),
+		}),
+	)
+
+	return tool, DetectConventionsHandler
+}
+
+// DetectConventionsHandler records the conventions of an existing project for later scaffolds
+// It reports what was detected and how the produce_* tools will adapt their output
+func DetectConventionsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	goMod := request.GetString("go_mod", "")
+	if goMod == "" {
+		return missingParameterResult(ctx, "go_mod", "the content of the project's go.mod file.", nil), nil
+	}
+	var files []conventionFile
+	if filesJSON := request.GetString("files", ""); filesJSON != "" {
+		if err := json.Unmarshal([]byte(filesJSON), &files); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'files' JSON: %v. Expected an array of objects with 'path' and 'content' keys.", err)), nil
+		}
+	}
+
+	detected, err := detectConventions(goMod, files)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	appName := request.GetString("app_name", detected.Module)
+
+	state.From(ctx).RecordApp(appName)
+	detected.record(ctx, appName)
+
+	return mcp.NewToolResultText(detected.report(appName)), nil
+}
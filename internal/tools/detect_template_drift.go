@@ -0,0 +1,240 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"mcpgo/internal/diff"
+	"mcpgo/internal/state"
+)
+
+// GetDetectTemplateDriftTool returns the tool definition for detect_template_drift
+func GetDetectTemplateDriftTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("detect_template_drift",
+		mcp.WithDescription("Compares a generated file with its entry in .scaffold-manifest.json: reports whether it was changed by hand since it was generated, shows what differs from the file the current templates render with the same arguments, and suggests a three-way merge of the hand edits with the file generated again, optionally with new arguments. Writes nothing."),
+		mcp.WithString("path",
+			mcp.Required(),
+			mcp.Description("The path of the file, as it is keyed in the files of .scaffold-manifest.json (e.g., internal/models/product.go)."),
+		),
+		mcp.WithString("content",
+			mcp.Required(),
+			mcp.Description("The current content of the file."),
+		),
+		mcp.WithString("manifest_entry",
+			mcp.Required(),
+			mcp.Description(`The entry of the file in the files of .scaffold-manifest.json, as JSON (e.g., {"tool":"produce_model_boilerplate","templates":"v1","params":"54dc753ad2899288","checksum":"9f86d0..."}).`),
+		),
+		mcp.WithString("arguments",
+			mcp.Description(`The arguments recorded under the entry's params in the calls of .scaffold-manifest.json, as a JSON object (e.g., {"app_name":"shop","model_name":"Product"}). Without them, only the checksum is compared.`),
+		),
+		mcp.WithString("options",
+			mcp.Description(`Arguments to change when generating the file again, as a JSON object merged over the recorded ones (e.g., {"soft_delete":false} or {"template_version":"v2"}). Defaults to none, generating it again with the current templates.`),
+		),
+		readOnlyAnnotations,
+	)
+
+	return tool, DetectTemplateDriftHandler
+}
+
+// DetectTemplateDriftHandler handles requests to compare a generated file with what its templates render now
+// It renders the file again with the recorded arguments, and with the new options, without recording either call
+func DetectTemplateDriftHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	path := request.GetString("path", "")
+	if path == "" {
+		return missingParameterResult(ctx, "path", "the path of the file, as it is keyed in .scaffold-manifest.json.", nil), nil
+	}
+	content := request.GetString("content", "")
+	var entry generatedFile
+	if err := json.Unmarshal([]byte(request.GetString("manifest_entry", "")), &entry); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'manifest_entry' JSON: %v. Expected the entry of the file in .scaffold-manifest.json, with 'tool', 'templates', 'params' and 'checksum' keys.", err)), nil
+	}
+	if entry.Tool == "" || entry.Templates == "" || entry.Checksum == "" {
+		return mcp.NewToolResultError("Invalid 'manifest_entry': expected 'tool', 'templates', 'params' and 'checksum' keys, as in the files of .scaffold-manifest.json."), nil
+	}
+	var arguments, options map[string]any
+	for _, object := range []struct {
+		name  string
+		value *map[string]any
+	}{{"arguments", &arguments}, {"options", &options}} {
+		if text := request.GetString(object.name, ""); text != "" {
+			if err := json.Unmarshal([]byte(text), object.value); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid '%s' JSON: %v. Expected an object of tool arguments.", object.name, err)), nil
+			}
+		}
+	}
+
+	d := drift{Path: path, Content: content, Entry: entry, Edited: checksum(content) != entry.Checksum}
+	if arguments == nil {
+		return mcp.NewToolResultText(d.report()), nil
+	}
+	d.Compared = true
+	d.ParamsMatch = paramsHash(entry.Tool, entry.Templates, arguments) == entry.Params
+
+	base, found, err := renderGeneratedFile(ctx, entry.Tool, entry.Templates, arguments, path)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Could not render '%s' with the recorded arguments: %v", path, err)), nil
+	}
+	if !found {
+		d.Gone = true
+		return mcp.NewToolResultText(d.report()), nil
+	}
+	d.Base, d.Language = base.Content, base.Language
+	d.TemplatesChanged = checksum(base.Content) != entry.Checksum
+
+	version := entry.Templates
+	if v, ok := options["template_version"].(string); ok && v != "" {
+		version = v
+	}
+	d.Options = options
+	d.Arguments = maps.Clone(arguments)
+	maps.Copy(d.Arguments, options)
+	regenerated, found, err := renderGeneratedFile(ctx, entry.Tool, version, d.Arguments, path)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Could not render '%s' with the new options: %v", path, err)), nil
+	}
+	if !found {
+		return mcp.NewToolResultError(fmt.Sprintf("'%s' does not generate '%s' with the new options.", entry.Tool, path)), nil
+	}
+	d.Regenerated = regenerated.Content
+	d.Version = version
+	d.Merged, d.Conflicts = diff.Merge("current", "regenerated", d.Base, content, d.Regenerated)
+
+	return mcp.NewToolResultText(d.report()), nil
+}
+
+// renderGeneratedFile runs a tool with arguments and the templates of version, and returns the file it generates at path
+// Only the enabled tools generating files are run, without the arguments that would write them, on a fork of the project
+// state that is dropped once it returns, so the call neither touches the disk nor is recorded
+func renderGeneratedFile(ctx context.Context, tool, version string, arguments map[string]any, path string) (scaffoldFile, bool, error) {
+	var handler server.ToolHandlerFunc
+	for _, t := range Enabled() {
+		if _, generates := t.Tool.InputSchema.Properties["write_files"]; generates && t.Tool.Name == tool {
+			handler = t.Handler
+		}
+	}
+	if handler == nil {
+		return scaffoldFile{}, false, fmt.Errorf("no enabled tool generating files is named '%s'.", tool)
+	}
+
+	ctx = state.WithStore(ctx, state.From(ctx).Fork())
+	// Pinning the version also renders the tools without a template_version argument with it
+	appName, _ := arguments["app_name"].(string)
+	if appName != "" {
+		state.From(ctx).SetOption(appName, "template_version", version)
+	}
+	// The markdown result is followed by the scaffold as JSON, whatever output_format the server defaults to
+	callArguments := maps.Clone(arguments)
+	for _, key := range presentationArguments {
+		delete(callArguments, key)
+	}
+	callArguments["output_format"] = "markdown"
+	request := mcp.CallToolRequest{}
+	request.Params.Name = tool
+	request.Params.Arguments = callArguments
+	result, err := handler(ctx, request)
+	if err != nil {
+		return scaffoldFile{}, false, err
+	}
+	if result.IsError || len(result.Content) < 2 {
+		return scaffoldFile{}, false, fmt.Errorf("%s failed: %s", tool, resultText(result))
+	}
+	var s scaffold
+	if err := json.Unmarshal([]byte(resultText(&mcp.CallToolResult{Content: result.Content[1:]})), &s); err != nil {
+		return scaffoldFile{}, false, fmt.Errorf("%s returned no scaffold: %v.", tool, err)
+	}
+	for _, f := range s.Files {
+		// The files of an app directory are keyed in its manifest without the directory
+		if f.Path == path || f.Path == appName+"/"+path {
+			return f, true, nil
+		}
+	}
+	return scaffoldFile{}, false, nil
+}
+
+// drift is what detect_template_drift found out about a generated file
+type drift struct {
+	Path, Content    string
+	Entry            generatedFile
+	Edited           bool // the content no longer has the checksum it was generated with
+	Compared         bool // the file was rendered again with the recorded arguments
+	ParamsMatch      bool // the recorded arguments hash to the params of the entry
+	Gone             bool // the tool no longer generates the file with the recorded arguments
+	Base, Language   string
+	TemplatesChanged bool // the templates render the file differently than when it was generated
+	Options          map[string]any
+	Arguments        map[string]any // the recorded arguments with the options over them
+	Version          string         // the templates the file was generated again with
+	Regenerated      string
+	Merged           string
+	Conflicts        int
+}
+
+// report renders the drift of the file as markdown
+func (d drift) report() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Template Drift of `%s`\n\nGenerated by `%s` with the %s templates (params `%s`).\n\n", d.Path, d.Entry.Tool, d.Entry.Templates, d.Entry.Params)
+	if d.Edited {
+		b.WriteString("- Changed by hand: yes. The checksum of the file no longer matches the one recorded when it was generated.\n")
+	} else {
+		b.WriteString("- Changed by hand: no. The file has the checksum recorded when it was generated, outside its protected regions.\n")
+	}
+	if !d.Compared {
+		b.WriteString("\nPass the `arguments` of its call from the calls of `.scaffold-manifest.json` to compare the file with its templates.\n")
+		return b.String()
+	}
+	if !d.ParamsMatch {
+		fmt.Fprintf(&b, "- Arguments: they do not hash to the params `%s` of the entry, so they may not be the ones the file was generated with.\n", d.Entry.Params)
+	}
+	if d.Gone {
+		fmt.Fprintf(&b, "- Templates: `%s` no longer generates this file with these arguments, so it cannot be compared or generated again.\n", d.Entry.Tool)
+		return b.String()
+	}
+	if d.TemplatesChanged {
+		b.WriteString("- Templates: changed. The current templates render the file differently than when it was generated, so the changes below also include theirs.\n")
+	} else {
+		b.WriteString("- Templates: unchanged. The current templates render the file as it was generated.\n")
+	}
+
+	b.WriteString("\n## Changes Versus the Template\n\n")
+	if changes := diff.Unified("template/"+d.Path, "current/"+d.Path, d.Base, d.Content); changes != "" {
+		fmt.Fprintf(&b, "From the file the templates render with the recorded arguments to the current file:\n```diff\n%s```\n", changes)
+	} else {
+		b.WriteString("None: the file is what the templates render with the recorded arguments.\n")
+	}
+
+	if len(d.Options) > 0 {
+		options, _ := json.Marshal(d.Options)
+		fmt.Fprintf(&b, "\n## Regenerating With %s\n\n", options)
+	} else {
+		b.WriteString("\n## Regenerating With the Current Templates\n\n")
+	}
+	switch {
+	case d.Regenerated == d.Content:
+		b.WriteString("Generating the file again gives it as it is; there is nothing to merge.\n")
+		return b.String()
+	case !d.Edited:
+		fmt.Fprintf(&b, "The file was not changed by hand, so the file generated again can replace it:\n```%s\n%s```\n", d.Language, d.Regenerated)
+	case d.Conflicts > 0:
+		fmt.Fprintf(&b, "Three-way merge suggestion of the hand edits (current) with the file generated again (regenerated), from the file the templates render with the recorded arguments. %d change(s) conflict and are left between markers; resolve them before writing the file:\n```%s\n%s```\n", d.Conflicts, d.Language, d.Merged)
+	default:
+		fmt.Fprintf(&b, "Three-way merge suggestion of the hand edits with the file generated again, from the file the templates render with the recorded arguments; no change conflicts:\n```%s\n%s```\n", d.Language, d.Merged)
+	}
+	if d.TemplatesChanged && d.Edited {
+		b.WriteString("\nThe templates changed since the file was generated, so the merge keeps the lines of the earlier templates wherever the file generated again did not change them; review those too.\n")
+	}
+
+	params := paramsHash(d.Entry.Tool, d.Version, d.Arguments)
+	call, _ := json.MarshalIndent(generationCall{Tool: d.Entry.Tool, Templates: d.Version, Arguments: d.Arguments}, "", "  ")
+	fmt.Fprintf(&b, "\nOnce the file is written, record it in `.scaffold-manifest.json`: set its entry to params `%s`, templates `%s` and the checksum of the written file, and add the call under `%s`:\n```json\n%s\n```\n", params, d.Version, params, call)
+	return b.String()
+}
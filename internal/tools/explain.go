@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/i18n"
+)
+
+// explainResult lists the files, routes and commands of a scaffold without the file contents
+func explainResult(s scaffold, lang string) *mcp.CallToolResult {
+	var b strings.Builder
+	b.WriteString("# Scaffold Plan\n\n")
+	fmt.Fprintf(&b, "Files (%d):\n```\n", len(s.Files))
+	paths := make([]string, len(s.Files))
+	for i, f := range s.Files {
+		paths[i] = f.Path
+	}
+	b.WriteString(fileTree(paths))
+	b.WriteString("```\n")
+	if len(s.routes) > 0 {
+		b.WriteString("\nRoutes:\n\n| Method | Path |\n|---|---|\n")
+		for _, r := range s.routes {
+			method, path, _ := strings.Cut(r, " ")
+			fmt.Fprintf(&b, "| %s | `%s` |\n", method, path)
+		}
+	}
+	if len(s.Commands) > 0 {
+		b.WriteString("\nCommands:\n")
+		for _, command := range s.Commands {
+			fmt.Fprintf(&b, "- `%s`\n", command)
+		}
+	}
+	b.WriteString("\nCall the tool again without explain to get the code.\n")
+
+	// The structured plan leaves the contents out as well, so explain saves the context of clients reading it instead
+	plan := explainedScaffold{Files: make([]explainedFile, 0, len(s.Files)), Commands: append([]string{}, s.Commands...), Notes: []string{}, Routes: s.routes}
+	for _, f := range s.Files {
+		plan.Files = append(plan.Files, explainedFile{Path: f.Path, Language: f.Language})
+	}
+	return mcp.NewToolResultStructured(plan, i18n.Translate(b.String(), lang))
+}
+
+// explainedFile is a file of an explained scaffold, without its content
+type explainedFile struct {
+	Path     string `json:"path"`
+	Language string `json:"language"`
+}
+
+// explainedScaffold is the structuredContent of explain: the scaffold without file contents, and its routes
+type explainedScaffold struct {
+	Files    []explainedFile `json:"files"`
+	Commands []string        `json:"commands"`
+	Notes    []string        `json:"notes"`
+	Routes   []string        `json:"routes,omitempty"`
+}
+
+// fileTree renders paths as an indented tree, each directory once before its entries, e.g.
+//
+//	internal/
+//	  models/
+//	    product.go
+func fileTree(paths []string) string {
+	var b strings.Builder
+	var previous []string
+	for _, path := range slices.Sorted(slices.Values(paths)) {
+		parts := strings.Split(path, "/")
+		dirs := parts[:len(parts)-1]
+		shared := 0
+		for shared < len(dirs) && shared < len(previous) && dirs[shared] == previous[shared] {
+			shared++
+		}
+		for i := shared; i < len(dirs); i++ {
+			fmt.Fprintf(&b, "%s%s/\n", strings.Repeat("  ", i), dirs[i])
+		}
+		fmt.Fprintf(&b, "%s%s\n", strings.Repeat("  ", len(dirs)), parts[len(parts)-1])
+		previous = dirs
+	}
+	return b.String()
+}
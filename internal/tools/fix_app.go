@@ -2,22 +2,33 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"mcpgo/internal/state"
 )
 
 // GetFixAppTool returns the tool definition for fix_app
 func GetFixAppTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
 	tool := mcp.NewTool("fix_app",
-		mcp.WithDescription("Provides pointers on common issues and how to address them in an Echo web application."),
+		mcp.WithDescription("Provides pointers on common issues and how to address them in an Echo web application. Given an error_message, it locates the failing file and layer, shows the template that file is generated from, and, when the client supports sampling, asks the client's model for a patch grounded in that template."),
 		mcp.WithString("app_name",
-			mcp.Description("The name of the application to fix."),
+			mcp.Description("The name of the application to fix. Defaults to the application used last."),
 		),
 		mcp.WithString("error_message",
 			mcp.Description("The specific error message encountered."),
 		),
+		mcp.WithString("model_name",
+			mcp.Description("The model the error relates to, if it cannot be inferred from the file path in the error message."),
+		),
+		readOnlyAnnotations,
 	)
 
 	return tool, FixAppHandler
@@ -26,7 +37,11 @@ func GetFixAppTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRe
 // FixAppHandler provides guidance on common issues in Echo web applications
 // It returns detailed instructions for addressing specific errors or general best practices
 func FixAppHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	appName := request.GetString("app_name", "")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
 	errorMessage := request.GetString("error_message", "")
 
 	var responseBuilder strings.Builder
@@ -49,9 +64,9 @@ func FixAppHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallT
 
 	responseBuilder.WriteString("3.  **Database Initialization**: Ensure your `main.go` (in `cmd/web/`) correctly initializes the GORM database connection and auto-migrates all your models. For example:\n")
 	responseBuilder.WriteString("    ```go\n")
-	responseBuilder.WriteString(fmt.Sprintf("    import (\n        \"gorm.io/driver/sqlite\"\n        \"gorm.io/gorm\"\n        \"%s/internal/models\"\n    )\n\n", appName))
+	responseBuilder.WriteString(fmt.Sprintf("    import (\n        \"%s/internal/database\"\n        \"%s/internal/models\"\n    )\n\n", appName, appName))
 	responseBuilder.WriteString(`    func main() {
-        db, err := gorm.Open(sqlite.Open("gorm.db"), &gorm.Config{})
+        db, err := database.Open(database.ConfigFromEnv()) // pool tuning, startup ping with retry, slow-query logging
         if err != nil {
             // handle error
         }
@@ -72,11 +87,224 @@ func FixAppHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallT
 
 	if errorMessage != "" {
 		responseBuilder.WriteString(fmt.Sprintf("\n\nRegarding your specific error: \"%s\"\n", errorMessage))
-		if strings.Contains(errorMessage, "is not in std") {
-			responseBuilder.WriteString("This error typically means Go cannot find your internal packages. Double-check your import paths to ensure they use your module name (e.g., `[appname]/internal/models`) and run `go mod tidy`.\n")
+		diagnosis := parseBuildError(errorMessage)
+		if diagnosis.File != "" {
+			responseBuilder.WriteString(fmt.Sprintf("The error was reported in `%s` (line %s), which belongs to the %s layer.\n", diagnosis.File, diagnosis.Line, diagnosis.Layer))
+		}
+		for _, hint := range diagnosis.Hints {
+			responseBuilder.WriteString(hint + "\n")
+		}
+
+		modelName := request.GetString("model_name", diagnosis.Model)
+		grounding := fixGrounding(ctx, appName, modelName, diagnosis.Layer)
+		if grounding != "" {
+			responseBuilder.WriteString("\n## Reference Template\n\n")
+			responseBuilder.WriteString(fmt.Sprintf("Compare the failing file against the %s template this server generates for '%s' and produce a minimal patch that brings it back in line:\n", diagnosis.Layer, modelName))
+			responseBuilder.WriteString(grounding)
+		}
+
+		if patch, model := suggestFix(ctx, errorMessage, diagnosis, grounding); patch != "" {
+			responseBuilder.WriteString("\n## Suggested Patch\n\n")
+			responseBuilder.WriteString(fmt.Sprintf("Synthesized by your client's model (%s) from the error and the reference template. Review it before applying it:\n\n%s\n", model, patch))
 		}
-		// Add more specific error handling logic here if needed
 	}
 
 	return mcp.NewToolResultText(responseBuilder.String()), nil
 }
+
+// buildDiagnosis is the information fix_app extracts from a Go build error
+type buildDiagnosis struct {
+	File  string
+	Line  string
+	Layer string
+	Model string
+	Hints []string
+}
+
+var buildErrorLocation = regexp.MustCompile(`([\w./-]+\.(?:go|templ)):(\d+)(?::\d+)?:`)
+
+// parseBuildError extracts the failing file, the scaffold layer and model it belongs to, and hints for known error patterns
+func parseBuildError(errorMessage string) buildDiagnosis {
+	var diagnosis buildDiagnosis
+	if match := buildErrorLocation.FindStringSubmatch(errorMessage); match != nil {
+		diagnosis.File = match[1]
+		diagnosis.Line = match[2]
+	}
+
+	segments := strings.Split(strings.TrimPrefix(diagnosis.File, "./"), "/")
+	for i, segment := range segments {
+		layer := ""
+		switch segment {
+		case "models":
+			layer = "model"
+			if i+1 < len(segments) {
+				diagnosis.Model = strings.TrimSuffix(segments[i+1], ".go")
+			}
+		case "repository":
+			layer = "repository"
+		case "service", "dto":
+			layer = "service"
+		case "controllers":
+			layer = "api_controller"
+			if i+2 < len(segments) && segments[i+2] == "html_controller.go" {
+				layer = "html_controller"
+			}
+		case "ui", "pages":
+			layer = "html_controller"
+		}
+		if layer == "" {
+			continue
+		}
+		diagnosis.Layer = layer
+		if diagnosis.Model == "" && i+1 < len(segments)-1 {
+			diagnosis.Model = segments[i+1]
+		}
+		break
+	}
+	if diagnosis.Layer == "" {
+		diagnosis.Layer = "application"
+	}
+
+	switch {
+	case strings.Contains(errorMessage, "is not in std"):
+		diagnosis.Hints = append(diagnosis.Hints, "This error typically means Go cannot find your internal packages. Double-check your import paths to ensure they use your module name (e.g., `[appname]/internal/models`) and run `go mod tidy`.")
+	case strings.Contains(errorMessage, "no required module provides package"):
+		diagnosis.Hints = append(diagnosis.Hints, "A third-party dependency is missing from go.mod. Run `go get` for the package named in the error, then `go mod tidy`.")
+	case strings.Contains(errorMessage, "declared and not used"), strings.Contains(errorMessage, "imported and not used"):
+		diagnosis.Hints = append(diagnosis.Hints, "Go rejects unused variables and imports. Remove the unused identifier or import, or use it (e.g., an import of `dto` in `controller.go` is only needed once a method references it).")
+	case strings.Contains(errorMessage, "missing method"), strings.Contains(errorMessage, "does not implement"):
+		diagnosis.Hints = append(diagnosis.Hints, "An implementation no longer satisfies its interface. Make sure every method declared in the interface (repository, service or controller) exists on the `...Impl` struct with exactly the same signature.")
+	case strings.Contains(errorMessage, "undefined:"):
+		diagnosis.Hints = append(diagnosis.Hints, "A referenced identifier does not exist. Check that the file defining it has been created, that it lives in the expected package, and that the name matches the template (e.g., `NewUserService`, `dto.CreateUserRequest`).")
+	case strings.Contains(errorMessage, "cannot use"):
+		diagnosis.Hints = append(diagnosis.Hints, "A value has the wrong type. Compare the DTO, model and interface signatures involved; IDs are `uint` throughout the generated code.")
+	}
+
+	return diagnosis
+}
+
+// suggestFix asks the client's own model, through MCP sampling, for a patch fixing the error, grounded in the template
+// It returns nothing when the client does not support sampling, declines the request or answers with no text, so the
+// pointers above remain the answer
+func suggestFix(ctx context.Context, errorMessage string, diagnosis buildDiagnosis, grounding string) (patch, model string) {
+	srv := server.ServerFromContext(ctx)
+	if srv == nil || clientCapabilities(ctx).Sampling == nil {
+		return "", ""
+	}
+
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "This Go build error comes from an Echo web application using GORM:\n\n```\n%s\n```\n", errorMessage)
+	if diagnosis.File != "" {
+		fmt.Fprintf(&prompt, "\nIt was reported in `%s` (line %s), which belongs to the %s layer.\n", diagnosis.File, diagnosis.Line, diagnosis.Layer)
+	}
+	for _, hint := range diagnosis.Hints {
+		prompt.WriteString("\n" + hint + "\n")
+	}
+	if grounding != "" {
+		prompt.WriteString("\nThe failing file was generated from the template below; the fix should bring it back in line with it:\n\n" + grounding)
+	}
+	prompt.WriteString("\nReply with a minimal unified diff fixing the error, then one sentence explaining the fix.")
+
+	result, err := srv.RequestSampling(ctx, mcp.CreateMessageRequest{CreateMessageParams: mcp.CreateMessageParams{
+		Messages:     []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent(prompt.String())}},
+		SystemPrompt: "You fix build errors in Go web applications generated from templates. Change only what the error requires.",
+		MaxTokens:    2048,
+	}})
+	if err != nil {
+		return "", ""
+	}
+	// In-process clients return the content as is, transports decode it from JSON as a map
+	content := result.Content
+	if m, ok := content.(map[string]any); ok {
+		content, _ = mcp.ParseContent(m)
+	}
+	text, ok := content.(mcp.TextContent)
+	if !ok {
+		return "", ""
+	}
+	return strings.TrimSpace(text.Text), result.Model
+}
+
+// fixGrounding renders the template the failing file was generated from, so the fix can be derived from the expected code
+// Only components already tracked for the model are rendered, with the options recorded when they were generated, on a
+// fork of the project state dropped once rendered, so grounding never alters the project state
+func fixGrounding(ctx context.Context, appName, modelName, layer string) string {
+	project, ok := state.From(ctx).Project(appName)
+	if !ok || modelName == "" {
+		return ""
+	}
+	var model *state.Model
+	for i := range project.Models {
+		if strings.EqualFold(project.Models[i].Name, modelName) {
+			model = &project.Models[i]
+		}
+	}
+	if model == nil || !slices.Contains(model.Components, layer) {
+		return ""
+	}
+
+	var get func() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error))
+	switch layer {
+	case "model", "repository":
+		get = GetProduceModelBoilerplateTool
+	case "service":
+		get = GetProduceServiceBoilerplateTool
+	case "api_controller":
+		get = GetProduceApiControllerBoilerplateTool
+	case "html_controller":
+		get = GetProduceHtmlControllerBoilerplateTool
+	default:
+		return ""
+	}
+	tool, handler := get()
+	arguments := recordedArguments(tool, project.Options, model.Options)
+	arguments["app_name"] = appName
+	arguments["model_name"] = model.Name
+	if _, ok := tool.InputSchema.Properties["fields"]; ok {
+		fields, err := json.Marshal(model.Fields)
+		if err != nil {
+			return ""
+		}
+		arguments["fields"] = string(fields)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = arguments
+	result, err := handler(state.WithStore(ctx, state.From(ctx).Fork()), request)
+	if err != nil || result == nil || result.IsError {
+		return ""
+	}
+
+	// The first content block holds the markdown instructions, later blocks are machine-readable duplicates
+	if len(result.Content) == 0 {
+		return ""
+	}
+	if text, ok := result.Content[0].(mcp.TextContent); ok {
+		return text.Text
+	}
+	return ""
+}
+
+// recordedArguments returns the recorded options that tool takes as arguments, typed as its input schema declares them
+// Later option maps take precedence, so the options of a model override those of its app
+func recordedArguments(tool mcp.Tool, options ...map[string]string) map[string]any {
+	arguments := map[string]any{}
+	for _, o := range options {
+		for key, value := range o {
+			property, _ := tool.InputSchema.Properties[key].(map[string]any)
+			switch property["type"] {
+			case "string":
+				arguments[key] = value
+			case "boolean":
+				if b, err := strconv.ParseBool(value); err == nil {
+					arguments[key] = b
+				}
+			case "number":
+				if n, err := strconv.ParseFloat(value, 64); err == nil {
+					arguments[key] = n
+				}
+			}
+		}
+	}
+	return arguments
+}
@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+func TestFixAppGroundingRecordsNothing(t *testing.T) {
+	ctx, path := persistedProject(t)
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"app_name": "shop", "model_name": "Coupon", "soft_delete": false, "base_model": "AuditedModel", "fields": `[{"name":"Code","type":"string"}]`}
+	if result, err := ProduceModelBoilerplateHandler(ctx, request); err != nil || result.IsError {
+		t.Fatalf("%v %s", err, resultText(result))
+	}
+	// Another app scaffolded since, so fix_app must not make shop the current app again
+	state.From(ctx).RecordModel("outlet", "Review", []state.Field{{Name: "Title", Type: "string"}})
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request.Params.Arguments = map[string]any{"app_name": "shop", "error_message": "internal/models/coupon.go:12:2: undefined: AuditedModel"}
+	result, err := FixAppHandler(ctx, request)
+	if err != nil || result.IsError {
+		t.Fatalf("%v %s", err, resultText(result))
+	}
+	assertManifestUnchanged(t, path, before)
+
+	// The reference template is the model as it was generated, with its own base struct rather than gorm.Model
+	text := resultText(result)
+	_, grounding, found := strings.Cut(text, "## Reference Template")
+	if !found || !strings.Contains(grounding, "type AuditedModel struct") || strings.Contains(grounding, "\tgorm.Model\n") {
+		t.Errorf("the reference template does not use the recorded options:\n%s", grounding)
+	}
+}
+
+func TestRecordedArguments(t *testing.T) {
+	tool, _ := GetProduceModelBoilerplateTool()
+	got := recordedArguments(tool,
+		map[string]string{"dialect": "postgres", "module": "example.com/shop", "soft_delete": "true"},
+		map[string]string{"soft_delete": "false", "timestamps": "maybe", "base_model": "gorm.Model"},
+	)
+	want := map[string]any{"dialect": "postgres", "soft_delete": false, "base_model": "gorm.Model"}
+	if len(got) != len(want) {
+		t.Fatalf("recordedArguments = %v, want %v", got, want)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %v, want %v", key, got[key], value)
+		}
+	}
+	if base := newModelBase(true, true, "gorm.Model"); base.generated() {
+		t.Errorf("the recorded gorm.Model names a struct to generate: %+v", base)
+	}
+}
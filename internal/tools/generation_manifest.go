@@ -0,0 +1,223 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+	"mcpgo/internal/templates"
+)
+
+// manifestFileName is the generation manifest at the root of a project, next to go.mod
+const manifestFileName = ".scaffold-manifest.json"
+
+// scaffoldMarker starts the header comment of every generated file, e.g.
+// mcpgo:scaffold tool=produce_model_boilerplate templates=v1 params=3f2a9c1e7b4d5a60
+const scaffoldMarker = "mcpgo:scaffold "
+
+// presentationArguments change how a scaffold is returned or written, not what it contains, so they are left out of its parameters
+var presentationArguments = []string{"embed_files", "explain", "output_format", "write_files", "target_dir", "dry_run", "overwrite", "force", "language", "verbosity", "verify"}
+
+// generationManifest is the content of .scaffold-manifest.json: the call that generated each file of the project
+// Tools reading it later, such as an upgrade, find there the arguments to generate a file again
+type generationManifest struct {
+	Generator string                    `json:"generator"`
+	Files     map[string]generatedFile  `json:"files"` // by path relative to the manifest
+	Calls     map[string]generationCall `json:"calls"` // by parameters hash
+}
+
+// generatedFile is what a file of the manifest was generated with
+type generatedFile struct {
+	Tool      string `json:"tool"`
+	Templates string `json:"templates"` // the template generation, e.g. v1
+	Params    string `json:"params"`    // the key of the call in calls
+	Checksum  string `json:"checksum"`  // SHA-256 of the generated content outside protected regions
+}
+
+// generationCall is a tool call that generated files, with the arguments that change its output
+type generationCall struct {
+	Tool      string         `json:"tool"`
+	Templates string         `json:"templates"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+// generationParams returns the template generation of a call and the hash of its tool and the arguments that shape
+// its files; the resolved app name is included, so a call relying on the last app used hashes like an explicit one
+func generationParams(ctx context.Context, request mcp.CallToolRequest) (string, string, map[string]any) {
+	appName := requestAppName(ctx, request)
+	arguments := map[string]any{}
+	for key, value := range request.GetArguments() {
+		if !slices.Contains(presentationArguments, key) {
+			arguments[key] = value
+		}
+	}
+	if appName != "" {
+		arguments["app_name"] = appName
+	}
+
+	version := valueOr(settings.TemplateVersion, templates.Versions[0])
+	if project, ok := state.From(ctx).Project(appName); ok && project.Options["template_version"] != "" {
+		version = project.Options["template_version"]
+	}
+
+	return version, paramsHash(request.Params.Name, version, arguments), arguments
+}
+
+// paramsHash returns the key of a call in the manifest: the start of the SHA-256 of its tool, templates and arguments
+func paramsHash(tool, version string, arguments map[string]any) string {
+	// encoding/json sorts map keys, so equal arguments always hash alike
+	data, _ := json.Marshal(map[string]any{"tool": tool, "templates": version, "arguments": arguments})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// scaffoldHeader returns the header comment of a file in its language, or false for a language without comments, such as JSON
+func scaffoldHeader(f scaffoldFile, text string) (string, bool) {
+	switch {
+	case f.Language == "go", f.Language == "templ", path.Base(f.Path) == "go.mod":
+		return "// " + text, true
+	case f.Language == "sql":
+		return "-- " + text, true
+	case f.Language == "yaml", f.Language == "dockerfile", f.Language == "makefile":
+		return "# " + text, true
+	case f.Language == "css":
+		return "/* " + text + " */", true
+	}
+	return "", false
+}
+
+// hasScaffoldHeader reports whether content already starts with a header, as the files of a composed scaffold do
+func hasScaffoldHeader(content string) bool {
+	lines := strings.SplitN(content, "\n", 4)
+	return slices.ContainsFunc(lines[:min(len(lines), 3)], func(line string) bool { return strings.Contains(line, scaffoldMarker) })
+}
+
+// withHeader adds header to content, followed by a blank line so it never becomes the doc comment of a Go package
+// templ files keep their package clause first
+func withHeader(f scaffoldFile, header string) string {
+	if f.Language == "templ" && strings.HasPrefix(f.Content, "package ") {
+		clause, rest, _ := strings.Cut(f.Content, "\n")
+		return clause + "\n\n" + header + "\n" + rest
+	}
+	return header + "\n\n" + f.Content
+}
+
+// isGenerationManifest reports whether path is the generation manifest of a project or of an app directory
+func isGenerationManifest(path string) bool {
+	return strings.HasSuffix("/"+path, "/"+manifestFileName)
+}
+
+// stampScaffold heads every file of a scaffold with the tool, template generation and parameters that generated it,
+// also in the copy spliced into the markdown, and adds the generation manifest recording them
+// The manifest is merged with the one under target_dir, if any, and with those of the scaffolds composed into s
+func stampScaffold(ctx context.Context, request mcp.CallToolRequest, markdown string, s scaffold) (string, scaffold, error) {
+	tool := request.Params.Name
+	if tool == "" || len(s.Files) == 0 {
+		return markdown, s, nil
+	}
+	version, params, arguments := generationParams(ctx, request)
+	text := fmt.Sprintf("%stool=%s templates=%s params=%s", scaffoldMarker, tool, version, params)
+
+	root := ""
+	if s.appDir {
+		root, _, _ = strings.Cut(s.Files[0].Path, "/")
+		root += "/"
+	}
+	manifestPath := root + manifestFileName
+	m := generationManifest{Generator: "mcpgo", Files: map[string]generatedFile{}, Calls: map[string]generationCall{}}
+	if dir := request.GetString("target_dir", ""); dir != "" {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(manifestPath)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return "", scaffold{}, fmt.Errorf("Could not read '%s': %v.", manifestPath, err)
+		default:
+			if err := m.merge(data); err != nil {
+				return "", scaffold{}, fmt.Errorf("Could not read '%s': %v. Fix or delete it to record the generated files again.", manifestPath, err)
+			}
+		}
+	}
+
+	for _, f := range s.Files {
+		if isGenerationManifest(f.Path) {
+			if err := m.merge([]byte(f.Content)); err != nil {
+				return "", scaffold{}, fmt.Errorf("Could not merge the generation manifest '%s': %v.", f.Path, err)
+			}
+		}
+	}
+
+	var files []scaffoldFile
+	var replacements []string
+	stamped := false
+	for _, f := range s.Files {
+		if isGenerationManifest(f.Path) {
+			continue
+		}
+		files = append(files, f)
+		rel := strings.TrimPrefix(f.Path, root)
+		if hasScaffoldHeader(f.Content) {
+			// Generated by a scaffold composed into this one, which recorded it
+			if _, ok := m.Files[rel]; ok {
+				continue
+			}
+		} else if header, ok := scaffoldHeader(f, text); ok && f.Content != "" {
+			content := withHeader(f, header)
+			replacements = append(replacements, f.Content, content)
+			files[len(files)-1].Content = content
+		}
+		m.Files[rel] = generatedFile{Tool: tool, Templates: version, Params: params, Checksum: checksum(files[len(files)-1].Content)}
+		stamped = true
+	}
+	if stamped {
+		m.Calls[params] = generationCall{Tool: tool, Templates: version, Arguments: arguments}
+	}
+	m.prune()
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", scaffold{}, fmt.Errorf("Could not encode the generation manifest: %v.", err)
+	}
+	content := string(data) + "\n"
+	files = append(files, scaffoldFile{Path: manifestPath, Language: "json", Content: content})
+
+	// Every file of the call gets the same header, so the replacements cannot cascade into each other
+	markdown = strings.NewReplacer(replacements...).Replace(markdown)
+	markdown += fmt.Sprintf("\nFinally, create or update the file at `%s`, which records the tool and arguments that generated each file; keep the entries it already has for other files:\n```json\n%s```\n", manifestPath, content)
+
+	stampedScaffold := s
+	stampedScaffold.Files = files
+	return markdown, stampedScaffold, nil
+}
+
+// merge adds the files and calls of an encoded manifest to m, replacing the entries of the same files
+func (m *generationManifest) merge(data []byte) error {
+	var other generationManifest
+	if err := json.Unmarshal(data, &other); err != nil {
+		return err
+	}
+	maps.Copy(m.Files, other.Files)
+	maps.Copy(m.Calls, other.Calls)
+	return nil
+}
+
+// prune drops the calls no file refers to anymore, once each of their files was generated again by another call
+func (m *generationManifest) prune() {
+	used := map[string]bool{}
+	for _, f := range m.Files {
+		used[f.Params] = true
+	}
+	maps.DeleteFunc(m.Calls, func(params string, _ generationCall) bool { return !used[params] })
+}
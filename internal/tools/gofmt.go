@@ -0,0 +1,28 @@
+package tools
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// formatGoFiles runs every generated Go file through go/format, and updates the copy spliced into the markdown
+// A file that does not parse is a template bug, reported instead of being handed to the client
+func formatGoFiles(markdown string, s scaffold) (string, scaffold, error) {
+	files := make([]scaffoldFile, len(s.Files))
+	for i, f := range s.Files {
+		files[i] = f
+		if f.Language != "go" || !strings.HasSuffix(f.Path, ".go") {
+			continue
+		}
+		formatted, err := format.Source([]byte(f.Content))
+		if err != nil {
+			return "", scaffold{}, fmt.Errorf("Generated file '%s' is not valid Go: %v. This is a bug in its template; please report it.", f.Path, err)
+		}
+		if content := string(formatted); content != f.Content {
+			markdown = strings.ReplaceAll(markdown, f.Content, content)
+			files[i].Content = content
+		}
+	}
+	return markdown, scaffold{Files: files, Commands: s.Commands, Notes: s.Notes, routes: s.routes, appDir: s.appDir, progress: s.progress}, nil
+}
@@ -0,0 +1,859 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"mcpgo/internal/config"
+	"mcpgo/internal/i18n"
+	"mcpgo/internal/state"
+	"mcpgo/internal/tools/toolstest"
+)
+
+// productFields is the field list most golden cases scaffold
+const productFields = `[{"name":"Name","type":"string","validate":"required,max=100"},{"name":"Price","type":"float64","check":"price >= 0"},{"name":"Active","type":"bool"}]`
+
+// init calls each golden case with the name of its tool, which the generated files are stamped with
+func init() {
+	names := map[uintptr]string{}
+	for _, t := range All() {
+		names[reflect.ValueOf(t.Handler).Pointer()] = t.Tool.Name
+	}
+	toolstest.ToolName = func(handler toolstest.Handler) string { return names[reflect.ValueOf(handler).Pointer()] }
+	toolstest.Check = checkHeadingsTranslated
+}
+
+// checkHeadingsTranslated fails a case whose tool translates its instructions when a heading of them is missing from
+// the i18n catalog, so a new tool cannot leave its titles in English
+func checkHeadingsTranslated(t *testing.T, c toolstest.Case, result *mcp.CallToolResult) {
+	t.Helper()
+	name := toolstest.ToolName(c.Handler)
+	i := slices.IndexFunc(All(), func(tool server.ServerTool) bool { return tool.Tool.Name == name })
+	if i < 0 || result.IsError || len(result.Content) == 0 {
+		return
+	}
+	// A case asking for another language already has its instructions translated
+	if _, translates := All()[i].Tool.InputSchema.Properties["language"]; !translates || c.Arguments["language"] != nil {
+		return
+	}
+	text, _ := result.Content[0].(mcp.TextContent)
+	inCode := false
+	for _, line := range strings.Split(text.Text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+		}
+		if inCode || !strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, lang := range i18n.Supported() {
+			if lang != i18n.DefaultLanguage && i18n.Translate(line, lang) == line {
+				t.Errorf("%s: the heading %q of %s has no %s translation in internal/i18n/catalog.go", c.Name, line, name, lang)
+			}
+		}
+	}
+}
+
+func TestAppGolden(t *testing.T) {
+	toolstest.Run(t, []toolstest.Case{
+		{Name: "app/default", Handler: ProduceAppBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "app/options", Handler: ProduceAppBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "request_timeout": "45s", "read_replicas": true, "transactions": true, "detect_n_plus_one": true,
+		}},
+		{Name: "app/fx", Handler: ProduceAppBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "di": "fx"}},
+		{Name: "app/missing_name", Handler: ProduceAppBoilerplateHandler, Arguments: map[string]any{}},
+	})
+}
+
+func TestWireGolden(t *testing.T) {
+	toolstest.Run(t, []toolstest.Case{
+		{Name: "wire/app", Handler: ProduceAppBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "di": "wire", "transactions": true}},
+		{Name: "wire/model", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"model_name": "Product", "fields": productFields, "verbosity": "minimal",
+		}},
+		{Name: "wire/service", Handler: ProduceServiceBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "verbosity": "minimal"}},
+		{Name: "wire/api_controller", Handler: ProduceApiControllerBoilerplateHandler, Arguments: map[string]any{"model_name": "Product"}},
+		{Name: "wire/html_controller", Handler: ProduceHtmlControllerBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "section": "routes"}},
+		{Name: "wire/app_regenerated", Handler: ProduceAppBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "di": "wire", "verbosity": "minimal"}},
+	})
+}
+
+func TestConfiguredGolden(t *testing.T) {
+	previous := settings
+	settings = config.Config{ModulePrefix: "github.com/acme", Database: "postgres", OutputFormat: "json"}
+	t.Cleanup(func() { settings = previous })
+
+	toolstest.Run(t, []toolstest.Case{
+		{Name: "config/app", Handler: ProduceAppBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "read_replicas": true, "output_format": "markdown",
+		}},
+		{Name: "config/model", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "fields": productFields}},
+	})
+}
+
+func TestModelGolden(t *testing.T) {
+	toolstest.Run(t, []toolstest.Case{
+		{Name: "model/default", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Product", "fields": productFields,
+		}},
+		{Name: "model/postgres", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Place", "dialect": "postgres", "soft_delete": false, "timestamps": false,
+			"fields": `[{"name":"Title","type":"string"},{"name":"Meta","type":"json"},{"name":"Tags","type":"[]string"},{"name":"Spot","type":"point"}]`,
+		}},
+		{Name: "model/partition_date", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Event", "dialect": "postgres", "partition_by": "date", "fields": `[{"name":"Name","type":"string"}]`,
+		}},
+		{Name: "model/partition_tenant", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Reading", "dialect": "postgres", "partition_by": "tenant", "partition_count": 4,
+			"fields": `[{"name":"TenantID","type":"uint"},{"name":"Value","type":"float64"}]`,
+		}},
+		{Name: "model/base_model", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Order", "base_model": "Base", "fields": `[{"name":"Total","type":"int"}]`,
+		}},
+		{Name: "model/structured_fields", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Customer", "fields": []any{
+				map[string]any{"name": "Email", "type": "string", "gorm": "uniqueIndex;size:255", "validate": "required,email"},
+				map[string]any{"name": "Nickname", "type": "string", "nullable": true},
+				map[string]any{"name": "BirthDate", "type": "time.Time", "nullable": true},
+			},
+		}},
+		{Name: "model/nullable_json", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Profile", "fields": []any{map[string]any{"name": "Settings", "type": "json", "nullable": true}},
+		}},
+		{Name: "model/field_types", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Payment", "dialect": "postgres",
+			"fields": `[{"name":"Reference","type":"uuid"},{"name":"Amount","type":"decimal"},{"name":"PaidAt","type":"datetime"},{"name":"Attempts","type":"Integer"},{"name":"Order","type":"*Order"}]`,
+		}},
+		{Name: "model/unknown_type", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Payment", "fields": `[{"name":"PaidAt","type":"tiemstamp"}]`,
+		}},
+		{Name: "model/reserved_name", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Type", "fields": `[{"name":"Label","type":"string"}]`,
+		}},
+		{Name: "model/base_field_collision", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Coupon", "fields": `[{"name":"Code","type":"string"},{"name":"created_at","type":"time.Time"}]`,
+		}},
+		{Name: "model/spaced_fields", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Contact", "fields": `[{"name":"first name","type":"string"},{"name":"phone-number","type":"string"}]`,
+		}},
+		{Name: "model/missing_fields", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "model_name": "Invoice"}},
+	})
+}
+
+func TestLayersGolden(t *testing.T) {
+	toolstest.Run(t, []toolstest.Case{
+		{Name: "layers/model", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Product", "fields": productFields,
+		}},
+		{Name: "layers/scopes", Handler: ProduceScopesBoilerplateHandler, Arguments: map[string]any{"model_name": "Product"}},
+		{Name: "layers/service", Handler: ProduceServiceBoilerplateHandler, Arguments: map[string]any{"model_name": "Product"}},
+		{Name: "layers/authorization", Handler: ProduceAuthorizationBoilerplateHandler, Arguments: map[string]any{"model_name": "Product"}},
+		{Name: "layers/mapper", Handler: ProduceMapperBoilerplateHandler, Arguments: map[string]any{"model_name": "Product"}},
+		{Name: "layers/api_controller", Handler: ProduceApiControllerBoilerplateHandler, Arguments: map[string]any{"model_name": "Product"}},
+		{Name: "layers/api_controller_options", Handler: ProduceApiControllerBoilerplateHandler, Arguments: map[string]any{
+			"model_name": "Product", "merge_patch": true, "content_negotiation": true, "error_format": "problem",
+			"route_prefix": "/api/v1", "route_group": "v1", "middleware": "auth.Required",
+		}},
+		{Name: "layers/html_controller", Handler: ProduceHtmlControllerBoilerplateHandler, Arguments: map[string]any{"model_name": "Product"}},
+		{Name: "layers/wiring_checks", Handler: ProduceWiringChecksBoilerplateHandler, Arguments: map[string]any{}},
+		{Name: "layers/list_components", Handler: ListScaffoldedComponentsHandler, Arguments: map[string]any{}},
+		{Name: "layers/embed_files", Handler: ProduceServiceBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "embed_files": true}},
+		{Name: "layers/output_json", Handler: ProduceServiceBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "output_format": "json"}},
+		{Name: "layers/language", Handler: ProduceServiceBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "language": "es"}},
+		{Name: "layers/service_plural", Handler: ProduceServiceBoilerplateHandler, Arguments: map[string]any{"model_name": "Category"}},
+		{Name: "layers/html_controller_section", Handler: ProduceHtmlControllerBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "section": "pages"}},
+		{Name: "layers/html_controller_unknown_section", Handler: ProduceHtmlControllerBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "section": "css"}},
+		{Name: "layers/html_controller_plural", Handler: ProduceHtmlControllerBoilerplateHandler, Arguments: map[string]any{"model_name": "Person"}},
+		{Name: "layers/full_crud", Handler: ScaffoldFullCrudHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Review", "fields": `[{"name":"Rating","type":"int","validate":"min=1,max=5"},{"name":"Body","type":"string"}]`,
+			"route_prefix": "/api",
+		}},
+		{Name: "layers/api_controller_compound", Handler: ProduceApiControllerBoilerplateHandler, Arguments: map[string]any{"model_name": "order_item"}},
+		{Name: "layers/seo", Handler: ProduceSeoBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "base_url": "https://shop.example.com/", "disallow": "/admin,/api",
+		}},
+		{Name: "layers/wizard", Handler: ProduceWizardBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "fields_per_step": 2}},
+		{Name: "layers/wizard_steps", Handler: ProduceWizardBoilerplateHandler, Arguments: map[string]any{
+			"model_name": "Product", "steps": "Details: Name; Pricing: Price,Active", "route_prefix": "/admin",
+		}},
+		{Name: "layers/wizard_unassigned_field", Handler: ProduceWizardBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "steps": "Details: Name,Price"}},
+		{Name: "layers/live_search", Handler: ProduceLiveSearchBoilerplateHandler, Arguments: map[string]any{"model_name": "Product"}},
+		{Name: "layers/live_search_alpine", Handler: ProduceLiveSearchBoilerplateHandler, Arguments: map[string]any{
+			"model_name": "Product", "library": "alpine", "debounce": 250, "dialect": "postgres", "search_fields": "Name",
+		}},
+		{Name: "layers/live_search_numeric_field", Handler: ProduceLiveSearchBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "search_fields": "Price"}},
+		{Name: "layers/scopes_searchable", Handler: ProduceScopesBoilerplateHandler, Arguments: map[string]any{"model_name": "Product"}},
+		{Name: "layers/ui_library", Handler: ProduceUiLibraryBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "layers/ui_library_adopt", Handler: ProduceUiLibraryBoilerplateHandler, Arguments: map[string]any{"app_name": "outlet", "adopt": true}},
+		{Name: "layers/ui_library_outside_project", Handler: ProduceUiLibraryBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "library_dir": "../uikit"}},
+		{Name: "layers/explain", Handler: ProduceHtmlControllerBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "explain": true}},
+		{Name: "layers/full_crud_explain", Handler: ScaffoldFullCrudHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Tag", "fields": `[{"name":"Label","type":"string"}]`, "explain": true,
+		}},
+		{Name: "layers/minimal_verbosity", Handler: ProduceLiveSearchBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "verbosity": "minimal"}},
+		{Name: "layers/verbose_verbosity", Handler: ProduceApiControllerBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "verbosity": "verbose"}},
+		{Name: "layers/unknown_verbosity", Handler: ProduceApiControllerBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "verbosity": "terse"}},
+		{Name: "layers/service_v2", Handler: ProduceServiceBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "outlet", "model_name": "Product", "template_version": "v2",
+		}},
+		{Name: "layers/api_controller_pinned_v2", Handler: ProduceApiControllerBoilerplateHandler, Arguments: map[string]any{"app_name": "outlet", "model_name": "Product"}},
+		{Name: "layers/unknown_template_version", Handler: ProduceServiceBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "outlet", "model_name": "Product", "template_version": "v9",
+		}},
+	})
+}
+
+func TestUtilitiesGolden(t *testing.T) {
+	toolstest.Run(t, []toolstest.Case{
+		{Name: "utilities/resilience", Handler: ProduceResilienceBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "dependency_name": "Payments"}},
+		{Name: "utilities/http_client", Handler: ProduceHttpClientBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "client_name": "Billing", "base_url": "https://api.example.com",
+			"endpoints": `[{"name":"GetInvoice","method":"GET","path":"/invoices/{id}"},{"name":"CreateInvoice","method":"POST","path":"/invoices"}]`,
+		}},
+		{Name: "utilities/idempotency", Handler: ProduceIdempotencyBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/event_log", Handler: ProduceEventLogBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/activity_feed", Handler: ProduceActivityFeedBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/status_page", Handler: ProduceStatusPageBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/status_page_checks", Handler: ProduceStatusPageBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "checks": "db,cache,queue", "cache_ttl": "1m"}},
+		{Name: "utilities/error_pages", Handler: ProduceErrorPagesBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/error_pages_problem", Handler: ProduceErrorPagesBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "api_prefix": "/api/v1/", "error_format": "problem"}},
+		{Name: "utilities/seo_missing_models", Handler: ProduceSeoBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/wizard_missing_steps", Handler: ProduceWizardBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "model_name": "Invoice"}},
+		{Name: "utilities/user_settings", Handler: ProduceUserSettingsBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/user_settings_options", Handler: ProduceUserSettingsBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "locales": "en, fr, pt-BR", "default_timezone": "Europe/Paris", "notifications": "comments,mentions,!weekly_digest",
+		}},
+		{Name: "utilities/user_settings_bad_timezone", Handler: ProduceUserSettingsBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "default_timezone": "Paris time"}},
+		{Name: "utilities/organizations", Handler: ProduceOrganizationsBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/organizations_options", Handler: ProduceOrganizationsBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "dialect": "postgres", "roles": "owner, admin, editor, viewer", "invitation_ttl": "72h",
+		}},
+		{Name: "utilities/organizations_bad_role", Handler: ProduceOrganizationsBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "roles": "owner,Admin"}},
+		{Name: "utilities/auth_throttle", Handler: ProduceAuthThrottleBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/auth_throttle_redis", Handler: ProduceAuthThrottleBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "store": "redis", "account_field": "username", "max_attempts": 3, "lockout": "30m", "base_delay": "2s",
+		}},
+		{Name: "utilities/auth_throttle_bad_delay", Handler: ProduceAuthThrottleBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "lockout": "1m", "base_delay": "5m"}},
+		{Name: "utilities/captcha", Handler: ProduceCaptchaBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/captcha_hcaptcha", Handler: ProduceCaptchaBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "provider": "hcaptcha", "forms": "/contact, /newsletter", "verbosity": "minimal",
+		}},
+		{Name: "utilities/captcha_bad_form", Handler: ProduceCaptchaBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "forms": "contact"}},
+		{Name: "utilities/public_pages", Handler: ProducePublicPagesBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "tagline": "Shop sells handmade goods from local makers.", "contact_to": "hello@shop.example.com",
+		}},
+		{Name: "utilities/public_pages_bad_contact_to", Handler: ProducePublicPagesBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "contact_to": "Shop <hello@shop.example.com>"}},
+		{Name: "utilities/consent", Handler: ProduceConsentBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/consent_documents", Handler: ProduceConsentBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "documents": "terms, privacy, dpa:Data Processing Agreement, acceptable-use", "version": "2026-01-15", "verbosity": "minimal",
+		}},
+		{Name: "utilities/consent_bad_version", Handler: ProduceConsentBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "version": "v 2"}},
+		{Name: "utilities/invite_only", Handler: ProduceInviteOnlyBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/invite_only_reusable", Handler: ProduceInviteOnlyBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "max_uses": 25, "code_ttl": "0", "verbosity": "minimal",
+		}},
+		{Name: "utilities/invite_only_bad_ttl", Handler: ProduceInviteOnlyBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "code_ttl": "30s"}},
+		{Name: "utilities/usage_quota", Handler: ProduceUsageQuotaBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/usage_quota_api_key", Handler: ProduceUsageQuotaBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "subject": "api_key", "period": "day", "request_quota": 500, "record_quota": 0,
+		}},
+		{Name: "utilities/archival", Handler: ProduceArchivalBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "model_name": "Product"}},
+		{Name: "utilities/archival_csv", Handler: ProduceArchivalBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Product", "destination": "csv", "column": "created_at", "max_age_days": 365, "interval": "6h",
+		}},
+		{Name: "utilities/backfill", Handler: ProduceBackfillBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "model_name": "Product", "column": "search_text"}},
+		{Name: "utilities/backfill_reindex", Handler: ProduceBackfillBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "Product", "kind": "reindex", "batch_size": 200, "rate": 0.5,
+		}},
+		{Name: "utilities/backup", Handler: ProduceBackupBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/backup_postgres_s3", Handler: ProduceBackupBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "dialect": "postgres", "storage": "s3", "retention_days": 30, "interval": "6h",
+		}},
+		{Name: "utilities/cache", Handler: ProduceCacheBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "model_name": "Product"}},
+		{Name: "utilities/cache_options", Handler: ProduceCacheBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "order_item", "capacity": 250, "ttl": "30s",
+		}},
+		{Name: "utilities/deployment", Handler: ProduceDeploymentBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/deployment_blue_green", Handler: ProduceDeploymentBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "dialect": "postgres", "strategy": "blue_green", "image": "registry.example.com/shop:1.4.2",
+		}},
+		{Name: "utilities/logging", Handler: ProduceLoggingBoilerplateHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/maintenance", Handler: ProduceMaintenanceBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "backend": "db"}},
+		{Name: "utilities/config_profiles", Handler: ProduceConfigProfilesBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "allowed_origins": "https://shop.example.com, https://admin.shop.example.com"}},
+		{Name: "utilities/query_metrics", Handler: ProduceQueryMetricsBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "slow_query_threshold": "500ms"}},
+		{Name: "utilities/startup_checks", Handler: ProduceStartupChecksBoilerplateHandler, Arguments: map[string]any{"app_name": "shop", "checks": "routes,env,migrations,templ", "required_env": "DB_DSN,SESSION_SECRET"}},
+		{Name: "utilities/response_cache", Handler: ProduceResponseCacheBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "model_name": "order_item", "ttl": "30s", "groups": `{"/reports": "10m"}`,
+		}},
+		{Name: "utilities/fix_app", Handler: FixAppHandler, Arguments: map[string]any{"app_name": "shop"}},
+		{Name: "utilities/describe_capabilities", Handler: DescribeCapabilitiesHandler, Arguments: map[string]any{"tools": "produce_model_boilerplate,undo_last_scaffold"}},
+		{Name: "utilities/describe_capabilities_unknown", Handler: DescribeCapabilitiesHandler, Arguments: map[string]any{"tools": "produce_everything"}},
+	})
+}
+
+func TestDetectConventionsGolden(t *testing.T) {
+	toolstest.Run(t, []toolstest.Case{
+		{Name: "conventions/detect", Handler: DetectConventionsHandler, Arguments: map[string]any{
+			"go_mod":   "module github.com/acme/shop\n\ngo 1.22\n\nrequire (\n\tgithub.com/labstack/echo/v4 v4.11.4\n\tgorm.io/gorm v1.25.7\n\tgo.uber.org/zap v1.27.0\n)\n",
+			"files":    `[{"path":"internal/store/user.go","content":"package store\n"},{"path":"internal/handlers/user.go","content":"package handlers\n"}]`,
+			"app_name": "shop",
+		}},
+		{Name: "conventions/model", Handler: ProduceModelBoilerplateHandler, Arguments: map[string]any{"model_name": "Product", "fields": productFields}},
+	})
+}
+
+func TestWriteDependenciesGolden(t *testing.T) {
+	previous := state.Default
+	state.Default = state.NewStore()
+	t.Cleanup(func() { state.Default = previous })
+
+	dir := t.TempDir()
+	goMod := "module shop\n\ngo 1.23\n\nrequire (\n\tgithub.com/labstack/echo/v4 v4.13.4\n\tgorm.io/gorm v1.30.0 // indirect\n)\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		name    string
+		handler toolstest.Handler
+	}{
+		{"write/dependencies_missing", ProduceQueryMetricsBoilerplateHandler},
+		{"write/dependencies_required", ProduceIdempotencyBoilerplateHandler},
+	} {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{"app_name": "shop", "write_files": true, "target_dir": dir}
+		result, err := c.handler(context.Background(), request)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		toolstest.Compare(t, c.name, strings.ReplaceAll(toolstest.Render(result), dir, "<target_dir>"))
+	}
+}
+
+// offlineGo skips a test compiling generated code under -short or without the go command, and keeps the go commands
+// it runs off the network, so the modules the code imports come from the module cache or the test fails to build it
+func offlineGo(t *testing.T) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("compiles generated code with the go command")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go command is not on the PATH")
+	}
+	t.Setenv("GOPROXY", "off")
+	t.Setenv("GOSUMDB", "off")
+	t.Setenv("GOTOOLCHAIN", "local")
+	t.Setenv("GOFLAGS", "-mod=mod")
+}
+
+// goTest runs go test on generated files, among them tests of the generated packages, in a temporary module named
+// shop requiring the pinned versions of the modules they import
+func goTest(t *testing.T, files []scaffoldFile) {
+	t.Helper()
+	offlineGo(t)
+	dir := t.TempDir()
+	if err := writeVerifyModule(dir, "shop", files); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"mod", "tidy", "-e"}, {"test", "./..."}} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOWORK=off")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go %s: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+}
+
+func TestVerifyGolden(t *testing.T) {
+	offlineGo(t)
+	toolstest.Run(t, []toolstest.Case{
+		{Name: "verify/temporary_module", Handler: ProduceResilienceBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "dependency_name": "Payments", "verify": true, "verbosity": "minimal",
+		}},
+		// The files of the app start with its directory, which is the root of the module compiled
+		{Name: "verify/app", Handler: ProduceAppBoilerplateHandler, Arguments: map[string]any{
+			"app_name": "shop", "verify": true, "verbosity": "minimal",
+		}},
+	})
+
+	// A file of the project declaring what the scaffold declares again makes go build fail on the overlay
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":                        "module shop\n\ngo 1.23\n",
+		"internal/resilience/legacy.go": "package resilience\n\nimport \"errors\"\n\nvar ErrCircuitOpen = errors.New(\"open\")\n",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"app_name": "shop", "dependency_name": "Payments", "verify": true, "target_dir": dir, "output_format": "json"}
+	result, err := ProduceResilienceBoilerplateHandler(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "internal/resilience/breaker.go")); err == nil {
+		t.Error("verify wrote the scaffold into target_dir")
+	}
+	toolstest.Compare(t, "verify/project_overlay", strings.ReplaceAll(toolstest.Render(result), dir, "<target_dir>"))
+}
+
+func TestFullCrudBuilds(t *testing.T) {
+	offlineGo(t)
+	for _, tt := range []struct {
+		name      string
+		arguments map[string]any
+	}{
+		{"defaults", nil},
+		{"problem details and negotiation", map[string]any{"error_format": "problem", "content_negotiation": true}},
+	} {
+		// Two models of one app, so the files wiring every model import their packages side by side
+		ctx := state.WithStore(context.Background(), state.NewStore())
+		app := mcp.CallToolRequest{}
+		app.Params.Arguments = map[string]any{"app_name": "shop"}
+		result, err := ProduceAppBoilerplateHandler(ctx, app)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, appFiles := withoutAppDir(result.StructuredContent.(scaffold).Files)
+		byPath := map[string]scaffoldFile{}
+		for _, f := range appFiles {
+			byPath[f.Path] = f
+		}
+		for _, model := range []map[string]any{
+			{"model_name": "Review", "fields": `[{"name":"Title","type":"string","validate":"required,max=100"},{"name":"Rating","type":"int","validate":"gte=1,lte=5"}]`},
+			{"model_name": "Tag", "fields": `[{"name":"Label","type":"string"}]`},
+		} {
+			request := mcp.CallToolRequest{}
+			request.Params.Arguments = map[string]any{"app_name": "shop"}
+			maps.Copy(request.Params.Arguments.(map[string]any), model)
+			maps.Copy(request.Params.Arguments.(map[string]any), tt.arguments)
+			result, err := ScaffoldFullCrudHandler(ctx, request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.IsError {
+				t.Fatalf("%s: %s", tt.name, resultText(result))
+			}
+			for _, f := range result.StructuredContent.(scaffold).Files {
+				byPath[f.Path] = f
+			}
+			main := byPath["cmd/web/main.go"]
+			main.Content = wireMain(t, main.Content, resultText(result))
+			byPath[main.Path] = main
+		}
+		files := slices.Collect(maps.Values(byPath))
+		v, err := verifyScaffold(context.Background(), "", "shop", files)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.Failed != "" {
+			t.Errorf("%s: %s", tt.name, verificationReport(v))
+		}
+	}
+}
+
+// wireMain follows the wiring step of a full CRUD plan on the main.go of the app tool, opening the database the
+// first time
+func wireMain(t *testing.T, main, plan string) string {
+	t.Helper()
+	_, step, ok := strings.Cut(plan, "3. Wire the layers in `cmd/web/main.go`")
+	if !ok {
+		t.Fatalf("the plan has no wiring step for main.go:\n%s", plan)
+	}
+	blocks := regexp.MustCompile("(?s)```go\n(.*?)```").FindAllStringSubmatch(step, 2)
+	deps := regexp.MustCompile("Then add `(.*?)` to the `router.Deps`").FindStringSubmatch(step)
+	if len(blocks) != 2 || deps == nil {
+		t.Fatalf("unexpected wiring step:\n%s", step)
+	}
+
+	var imports strings.Builder
+	for _, line := range strings.Split(blocks[0][1], "\n") {
+		if strings.HasPrefix(line, "\t") && !strings.Contains(main, line+"\n") {
+			imports.WriteString(line + "\n")
+		}
+	}
+	main = strings.Replace(main, "\t\"shop/internal/router\"\n", "\t\"shop/internal/router\"\n"+imports.String(), 1)
+
+	var wiring strings.Builder
+	if !strings.Contains(main, "database.Open") {
+		main = strings.Replace(main, "\t\"shop/internal/router\"\n", "\t\"shop/internal/database\"\n\t\"shop/internal/router\"\n", 1)
+		wiring.WriteString("\tdb, err := database.Open(database.ConfigFromEnv())\n\tif err != nil {\n\t\te.Logger.Fatal(err)\n\t}\n")
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(blocks[1][1], "\n"), "\n") {
+		wiring.WriteString("\t" + line + "\n")
+	}
+	main = strings.Replace(main, "\trouter.RegisterRoutes(", wiring.String()+"\trouter.RegisterRoutes(", 1)
+	return strings.Replace(main, "router.Deps{", "router.Deps{"+deps[1], 1)
+}
+
+func TestTemplateDriftGolden(t *testing.T) {
+	previous := state.Default
+	state.Default = state.NewStore()
+	t.Cleanup(func() { state.Default = previous })
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "produce_model_boilerplate"
+	request.Params.Arguments = map[string]any{"app_name": "shop", "model_name": "Product", "fields": productFields, "output_format": "json"}
+	result, err := ProduceModelBoilerplateHandler(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s scaffold
+	if err := json.Unmarshal([]byte(resultText(result)), &s); err != nil {
+		t.Fatal(err)
+	}
+	var content string
+	var m generationManifest
+	for _, f := range s.Files {
+		switch f.Path {
+		case "internal/models/product.go":
+			content = f.Content
+		case manifestFileName:
+			if err := json.Unmarshal([]byte(f.Content), &m); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	entry, _ := json.Marshal(m.Files["internal/models/product.go"])
+	arguments, _ := json.Marshal(m.Calls[m.Files["internal/models/product.go"].Params].Arguments)
+	added := strings.Replace(content, "\tActive bool    `json:\"Active\"`\n", "\tActive bool    `json:\"Active\"`\n\tSKU    string  `json:\"SKU\" gorm:\"uniqueIndex\"`\n", 1)
+	replaced := strings.Replace(content, "\tgorm.Model\n", "\tAuditedModel\n", 1)
+
+	driftCase := func(name, content, arguments, options string) toolstest.Case {
+		return toolstest.Case{Name: name, Handler: DetectTemplateDriftHandler, Arguments: map[string]any{
+			"path": "internal/models/product.go", "content": content, "manifest_entry": string(entry), "arguments": arguments, "options": options,
+		}}
+	}
+	toolstest.Run(t, []toolstest.Case{
+		driftCase("drift/unchanged", content, string(arguments), ""),
+		driftCase("drift/checksum_only", added, "", ""),
+		driftCase("drift/merge", added, string(arguments), `{"soft_delete":false}`),
+		driftCase("drift/conflict", replaced, string(arguments), `{"soft_delete":false}`),
+	})
+}
+
+func TestTemplateDriftWritesNothing(t *testing.T) {
+	previous := state.Default
+	state.Default = state.NewStore()
+	t.Cleanup(func() { state.Default = previous })
+	state.Default.SetOption("shop", "template_version", "v1")
+
+	dir := t.TempDir()
+	arguments, _ := json.Marshal(map[string]any{"app_name": "shop", "model_name": "Product", "fields": productFields, "write_files": true, "target_dir": dir, "force": true})
+	for _, tool := range []string{"produce_model_boilerplate", "undo_last_scaffold", "unknown_tool"} {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{
+			"path":           "internal/models/product.go",
+			"content":        "package models\n",
+			"manifest_entry": fmt.Sprintf(`{"tool":%q,"templates":"v1","params":"0","checksum":"0"}`, tool),
+			"arguments":      string(arguments),
+			"options":        `{"template_version":"v2"}`,
+		}
+		result, err := DetectTemplateDriftHandler(context.Background(), request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rendered := tool == "produce_model_boilerplate"; rendered == result.IsError {
+			t.Errorf("%s: error = %v, want %v: %s", tool, result.IsError, !rendered, resultText(result))
+		}
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) > 0 {
+		t.Errorf("detect_template_drift wrote %d file(s) to the target_dir of the recorded arguments", len(entries))
+	}
+	project, _ := state.Default.Project("shop")
+	if len(project.Models) > 0 || len(project.Writes) > 0 || project.Options["template_version"] != "v1" {
+		t.Errorf("detect_template_drift recorded its renders: %+v", project)
+	}
+}
+
+func TestToolAnnotations(t *testing.T) {
+	for _, tool := range All() {
+		a := tool.Tool.Annotations
+		// mcp.NewTool defaults every hint to its most cautious value, open world included
+		if a.OpenWorldHint == nil || *a.OpenWorldHint {
+			t.Errorf("%s: no annotations set", tool.Tool.Name)
+			continue
+		}
+		if _, writes := tool.Tool.InputSchema.Properties["write_files"]; writes && (*a.ReadOnlyHint || !*a.DestructiveHint || !*a.IdempotentHint) {
+			t.Errorf("%s takes write_files but is not annotated as a destructive, idempotent tool", tool.Tool.Name)
+		}
+	}
+}
+
+func TestStructuredContent(t *testing.T) {
+	for _, tool := range All() {
+		_, generates := tool.Tool.InputSchema.Properties["write_files"]
+		if generates && string(tool.Tool.RawOutputSchema) != scaffoldOutputSchema {
+			t.Errorf("%s generates files but does not declare the scaffold output schema", tool.Tool.Name)
+		}
+	}
+
+	previous := state.Default
+	state.Default = state.NewStore()
+	t.Cleanup(func() { state.Default = previous })
+	var schema struct {
+		Required   []string `json:"required"`
+		Properties struct {
+			Files struct {
+				Items struct {
+					Required []string `json:"required"`
+				} `json:"items"`
+			} `json:"files"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(scaffoldOutputSchema), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, arguments := range []map[string]any{
+		{"output_format": "markdown"},
+		{"output_format": "json"},
+		{"explain": true},
+		{"embed_files": true},
+		{"write_files": true, "target_dir": t.TempDir()},
+	} {
+		maps.Copy(arguments, map[string]any{"app_name": "shop", "model_name": "Product", "fields": productFields})
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = arguments
+		result, err := ProduceModelBoilerplateHandler(context.Background(), request)
+		if err != nil || result.IsError {
+			t.Fatalf("%v: %v %s", arguments, err, resultText(result))
+		}
+		data, _ := json.Marshal(result.StructuredContent)
+		var structured map[string]any
+		if err := json.Unmarshal(data, &structured); err != nil {
+			t.Fatalf("%v: structuredContent is not an object: %s", arguments, data)
+		}
+		for _, key := range schema.Required {
+			if _, ok := structured[key].([]any); !ok {
+				t.Errorf("%v: structuredContent lacks the %s array: %s", arguments, key, data)
+			}
+		}
+		files, _ := structured["files"].([]any)
+		if len(files) == 0 {
+			t.Fatalf("%v: no files in structuredContent", arguments)
+		}
+		for _, f := range files {
+			for _, key := range schema.Properties.Files.Items.Required {
+				if _, ok := f.(map[string]any)[key].(string); !ok {
+					t.Errorf("%v: a file lacks %s: %v", arguments, key, f)
+				}
+			}
+			if _, ok := f.(map[string]any)["content"]; ok == (arguments["explain"] == true) {
+				t.Errorf("%v: content of %v", arguments, f.(map[string]any)["path"])
+			}
+		}
+		// Clients reading only the text get the same scaffold as JSON
+		if arguments["output_format"] == "markdown" && resultText(&mcp.CallToolResult{Content: result.Content[1:]}) != string(data) {
+			t.Errorf("structuredContent differs from the JSON content block")
+		}
+	}
+}
+
+// progressSession is a client session collecting the notifications the server sends it
+type progressSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s progressSession) Initialize()                                         {}
+func (s progressSession) Initialized() bool                                   { return true }
+func (s progressSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s progressSession) SessionID() string                                   { return "progress" }
+
+func TestWriteProgress(t *testing.T) {
+	for _, tc := range []struct {
+		get   func() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error))
+		first string // the message of the first notification
+	}{
+		{GetProduceHtmlControllerBoilerplateTool, "Generated setup section"},
+		{GetScaffoldFullCrudTool, "Generated model and repository (step 1 of 3)"},
+	} {
+		previous := state.Default
+		state.Default = state.NewStore()
+		t.Cleanup(func() { state.Default = previous })
+
+		srv := server.NewMCPServer("mcpgo", "test")
+		tool, handler := tc.get()
+		srv.AddTool(tool, handler)
+		session := progressSession{notifications: make(chan mcp.JSONRPCNotification, 100)}
+		ctx := srv.WithContext(context.Background(), session)
+
+		call, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0", "id": 1, "method": "tools/call",
+			"params": map[string]any{
+				"name":      tool.Name,
+				"arguments": map[string]any{"app_name": "shop", "model_name": "Product", "fields": productFields, "write_files": true, "target_dir": t.TempDir()},
+				"_meta":     map[string]any{"progressToken": "write"},
+			},
+		})
+		response, ok := srv.HandleMessage(ctx, call).(mcp.JSONRPCResponse)
+		if result, isResult := response.Result.(*mcp.CallToolResult); !ok || !isResult || result.IsError {
+			t.Fatalf("%s: tools/call failed: %+v", tool.Name, response)
+		}
+		close(session.notifications)
+
+		var progress, totals []int
+		var messages []string
+		for n := range session.notifications {
+			progress = append(progress, n.Params.AdditionalFields["progress"].(int))
+			totals = append(totals, n.Params.AdditionalFields["total"].(int))
+			messages = append(messages, n.Params.AdditionalFields["message"].(string))
+		}
+		if len(messages) == 0 || messages[0] != tc.first || !strings.HasPrefix(messages[len(messages)-1], "Wrote ") {
+			t.Fatalf("%s: progress not reported for each step and file written: %q", tool.Name, messages)
+		}
+		for i := 1; i < len(progress); i++ {
+			if progress[i] <= progress[i-1] {
+				t.Errorf("%s: progress went from %v to %v: %q", tool.Name, progress[i-1], progress[i], messages)
+			}
+		}
+		if last := len(progress) - 1; progress[last] != totals[last] {
+			t.Errorf("%s: progress ended at %d of %d", tool.Name, progress[last], totals[last])
+		}
+	}
+}
+
+// samplingSession is a client session declaring capabilities, which answers sampling requests with reply
+type samplingSession struct {
+	progressSession
+	capabilities mcp.ClientCapabilities
+	reply        string
+	prompts      *[]string
+}
+
+func (s samplingSession) GetClientInfo() mcp.Implementation              { return mcp.Implementation{Name: "test"} }
+func (s samplingSession) SetClientInfo(mcp.Implementation)               {}
+func (s samplingSession) GetClientCapabilities() mcp.ClientCapabilities  { return s.capabilities }
+func (s samplingSession) SetClientCapabilities(c mcp.ClientCapabilities) {}
+func (s samplingSession) RequestSampling(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	*s.prompts = append(*s.prompts, mcp.GetTextFromContent(request.Messages[0].Content))
+	// Transports decode the content from JSON, so it comes back as a map
+	return &mcp.CreateMessageResult{Model: "test-model", SamplingMessage: mcp.SamplingMessage{Role: mcp.RoleAssistant, Content: map[string]any{"type": "text", "text": s.reply}}}, nil
+}
+
+func TestFixAppSampling(t *testing.T) {
+	previous := state.Default
+	state.Default = state.NewStore()
+	t.Cleanup(func() { state.Default = previous })
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"app_name": "shop", "model_name": "Product", "fields": productFields}
+	if _, err := ProduceModelBoilerplateHandler(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+
+	const buildError = "internal/models/product.go:12:2: undefined: AuditedModel"
+	for _, sampling := range []bool{true, false} {
+		var prompts []string
+		session := samplingSession{progressSession: progressSession{notifications: make(chan mcp.JSONRPCNotification, 10)}, reply: "--- a/internal/models/product.go\n+++ b/internal/models/product.go", prompts: &prompts}
+		if sampling {
+			session.capabilities.Sampling = &struct{}{}
+		}
+		srv := server.NewMCPServer("mcpgo", "test")
+		srv.EnableSampling()
+		tool, handler := GetFixAppTool()
+		srv.AddTool(tool, handler)
+		call, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0", "id": 1, "method": "tools/call",
+			"params": map[string]any{"name": tool.Name, "arguments": map[string]any{"app_name": "shop", "error_message": buildError}},
+		})
+		response, _ := srv.HandleMessage(srv.WithContext(context.Background(), session), call).(mcp.JSONRPCResponse)
+		result, ok := response.Result.(*mcp.CallToolResult)
+		if !ok || result.IsError {
+			t.Fatalf("tools/call failed: %+v", response)
+		}
+		text := resultText(result)
+
+		if !sampling {
+			if len(prompts) > 0 || strings.Contains(text, "## Suggested Patch") {
+				t.Errorf("fix_app sampled a client without the sampling capability: %q", prompts)
+			}
+			continue
+		}
+		if len(prompts) != 1 || !strings.Contains(prompts[0], buildError) || !strings.Contains(prompts[0], "type Product struct") {
+			t.Fatalf("the sampling prompt lacks the error or the model template: %q", prompts)
+		}
+		if !strings.Contains(text, "## Suggested Patch") || !strings.Contains(text, "(test-model)") || !strings.Contains(text, session.reply) {
+			t.Errorf("fix_app does not return the sampled patch:\n%s", text)
+		}
+	}
+}
+
+// elicitationSession is a client session answering elicitation requests with the values in answers
+type elicitationSession struct {
+	samplingSession
+	answers map[string]any // an answer of nil declines
+	asked   *[]string
+}
+
+func (s elicitationSession) RequestElicitation(ctx context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	schema := request.Params.RequestedSchema.(map[string]any)
+	name := schema["required"].([]string)[0]
+	*s.asked = append(*s.asked, name)
+	if s.answers[name] == nil {
+		return &mcp.ElicitationResult{ElicitationResponse: mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionDecline}}, nil
+	}
+	return &mcp.ElicitationResult{ElicitationResponse: mcp.ElicitationResponse{Action: mcp.ElicitationResponseActionAccept, Content: map[string]any{name: s.answers[name]}}}, nil
+}
+
+func TestElicitMissingParameters(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		elicitation bool
+		answers     map[string]any
+		asked       []string
+		failed      bool
+	}{
+		{"answered", true, map[string]any{"model_name": "Product", "fields": productFields}, []string{"model_name", "fields"}, false},
+		{"declined", true, map[string]any{"model_name": "Product"}, []string{"model_name", "fields"}, true},
+		{"unsupported", false, map[string]any{"model_name": "Product", "fields": productFields}, nil, true},
+	} {
+		previous := state.Default
+		state.Default = state.NewStore()
+		t.Cleanup(func() { state.Default = previous })
+
+		var asked []string
+		session := elicitationSession{samplingSession: samplingSession{progressSession: progressSession{notifications: make(chan mcp.JSONRPCNotification, 10)}}, answers: tc.answers, asked: &asked}
+		if tc.elicitation {
+			session.capabilities.Elicitation = &mcp.ElicitationCapability{}
+		}
+		srv := server.NewMCPServer("mcpgo", "test", server.WithToolHandlerMiddleware(ElicitationMiddleware), server.WithElicitation())
+		tool, handler := GetProduceModelBoilerplateTool()
+		srv.AddTool(tool, handler)
+		call, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0", "id": 1, "method": "tools/call",
+			"params": map[string]any{"name": tool.Name, "arguments": map[string]any{"app_name": "shop"}},
+		})
+		response, _ := srv.HandleMessage(srv.WithContext(context.Background(), session), call).(mcp.JSONRPCResponse)
+		result, ok := response.Result.(*mcp.CallToolResult)
+		if !ok {
+			t.Fatalf("%s: tools/call failed: %+v", tc.name, response)
+		}
+		if !slices.Equal(asked, tc.asked) {
+			t.Errorf("%s: asked for %q, want %q", tc.name, asked, tc.asked)
+		}
+		if result.IsError != tc.failed {
+			t.Errorf("%s: error = %v, want %v: %s", tc.name, result.IsError, tc.failed, resultText(result))
+		}
+		if !tc.failed && !strings.Contains(resultText(result), "type Product struct") {
+			t.Errorf("%s: the scaffold does not use the elicited arguments:\n%s", tc.name, resultText(result))
+		}
+	}
+}
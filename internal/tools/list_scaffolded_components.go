@@ -0,0 +1,333 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetListScaffoldedComponentsTool returns the tool definition for list_scaffolded_components
+func GetListScaffoldedComponentsTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("list_scaffolded_components",
+		mcp.WithDescription("Reports which models, repositories, services, controllers and routes of an application have been scaffolded so far, and what is still missing, from the project manifest or by scanning the project directory."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. Defaults to the application used last."),
+		),
+		mcp.WithString("target_dir",
+			mcp.Description("Project directory to scan instead of reading the project manifest, to include code written by hand or in earlier sessions (e.g., /home/me/src/shop)."),
+		),
+		readOnlyAnnotations,
+	)
+
+	return tool, ListScaffoldedComponentsHandler
+}
+
+// ListScaffoldedComponentsHandler handles requests to list what has been scaffolded for an application
+// The manifest knows what the tools generated; a scan shows what actually exists on disk
+func ListScaffoldedComponentsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	dir := request.GetString("target_dir", "")
+	if appName == "" && dir == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	project, known := state.From(ctx).Project(appName)
+
+	inv := inventory{Source: "the project manifest", Models: project.Models, Options: project.Options}
+	if dir != "" {
+		scanned, err := scanProject(ctx, dir, project.Options)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		inv = scanned
+	} else if !known {
+		return mcp.NewToolResultError(fmt.Sprintf("No project named '%s' has been scaffolded yet. Pass target_dir to scan its directory instead.", appName)), nil
+	}
+
+	return mcp.NewToolResultText(inv.report(appName)), nil
+}
+
+// componentColumns are the layers reported for every model, in table order
+var componentColumns = []struct{ name, label string }{
+	{"model", "Model"},
+	{"repository", "Repository"},
+	{"service", "Service"},
+	{"api_controller", "API controller"},
+	{"html_controller", "HTML controller"},
+}
+
+// inventory is what exists of an application, read from the manifest or found on disk
+type inventory struct {
+	Source  string
+	Models  []state.Model
+	Routes  []string // routes found in the code, with their file, when scanning
+	Options map[string]string
+}
+
+// report renders the inventory as a table of models, their routes and the missing pieces
+func (inv inventory) report(appName string) string {
+	var b strings.Builder
+	if appName != "" {
+		fmt.Fprintf(&b, "# Scaffolded Components of '%s'\n\n", appName)
+	} else {
+		b.WriteString("# Scaffolded Components\n\n")
+	}
+	fmt.Fprintf(&b, "Read from %s.\n\n", inv.Source)
+
+	if len(inv.Models) == 0 {
+		b.WriteString("No models yet. Start with `produce_model_boilerplate`.\n")
+	} else {
+		b.WriteString("## Models\n\n| Name |")
+		for _, c := range componentColumns {
+			fmt.Fprintf(&b, " %s |", c.label)
+		}
+		b.WriteString(" Other | Routes |\n|---|")
+		b.WriteString(strings.Repeat("---|", len(componentColumns)+2))
+		b.WriteString("\n")
+		for _, m := range inv.Models {
+			fmt.Fprintf(&b, "| %s |", m.Name)
+			for _, c := range componentColumns {
+				fmt.Fprintf(&b, " %s |", yesNo(slices.Contains(m.Components, c.name)))
+			}
+			var other []string
+			for _, component := range m.Components {
+				if !slices.ContainsFunc(componentColumns, func(c struct{ name, label string }) bool { return c.name == component }) {
+					other = append(other, component)
+				}
+			}
+			fmt.Fprintf(&b, " %s | %d |\n", valueOr(strings.Join(other, ", "), "-"), len(m.Routes))
+		}
+	}
+
+	var routes []string
+	for _, m := range inv.Models {
+		for _, route := range m.Routes {
+			routes = append(routes, fmt.Sprintf("`%s` (%s)", route, m.Name))
+		}
+	}
+	if inv.Routes != nil {
+		routes = inv.Routes // the scan lists every route found, including those of no model
+	}
+	if len(routes) > 0 {
+		b.WriteString("\n## Routes\n\n")
+		for _, route := range routes {
+			fmt.Fprintf(&b, "- %s\n", route)
+		}
+	}
+
+	if len(inv.Options) > 0 {
+		keys := make([]string, 0, len(inv.Options))
+		for key := range inv.Options {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b.WriteString("\n## Options\n\n")
+		for _, key := range keys {
+			fmt.Fprintf(&b, "- %s: `%s`\n", key, inv.Options[key])
+		}
+	}
+
+	if missing := inv.missing(); len(missing) > 0 {
+		b.WriteString("\n## Missing\n\n")
+		for _, item := range missing {
+			fmt.Fprintf(&b, "- %s\n", item)
+		}
+	}
+	return b.String()
+}
+
+// missing lists the gaps in each model's chain from model to routes, with the tool filling them
+func (inv inventory) missing() []string {
+	var items []string
+	for _, m := range inv.Models {
+		has := func(component string) bool { return slices.Contains(m.Components, component) }
+		switch {
+		case !has("model"):
+			items = append(items, fmt.Sprintf("%s: no model. Run `produce_model_boilerplate`.", m.Name))
+		case !has("repository"):
+			items = append(items, fmt.Sprintf("%s: no repository. Run `produce_model_boilerplate` to generate it with the model.", m.Name))
+		}
+		if !has("service") {
+			items = append(items, fmt.Sprintf("%s: no service. Run `produce_service_boilerplate`.", m.Name))
+		}
+		if !has("api_controller") && !has("html_controller") {
+			items = append(items, fmt.Sprintf("%s: no controller. Run `produce_api_controller_boilerplate` or `produce_html_controller_boilerplate`.", m.Name))
+		} else if len(m.Routes) == 0 {
+			items = append(items, fmt.Sprintf("%s: a controller exists but no route reaches it. Register its routes in `cmd/web/main.go`.", m.Name))
+		}
+	}
+	return items
+}
+
+func yesNo(ok bool) string {
+	if ok {
+		return "yes"
+	}
+	return "no"
+}
+
+var (
+	// routeCall matches a route registration on echo or a group, e.g. e.GET("/products/:id", ...)
+	routeCall = regexp.MustCompile(`\b[A-Za-z_][A-Za-z0-9_]*\.(GET|POST|PUT|PATCH|DELETE)\(\s*"([^"]*)"`)
+	// structType matches a struct declaration
+	structType = regexp.MustCompile(`(?m)^type\s+([A-Za-z_][A-Za-z0-9_]*)\s+struct\b`)
+)
+
+// infrastructureModels are the model files of the utility scaffolds, which are not resources
+var infrastructureModels = []string{"activity", "event", "idempotency_key", "location"}
+
+// infrastructureDirs are the package directories of the utility scaffolds, which are not resources
+var infrastructureDirs = []string{"admin", "maintenance", "status", "usage"}
+
+// scanProject finds the models and layers that exist under dir, following the package layout recorded for the app
+func scanProject(ctx context.Context, dir string, options map[string]string) (inventory, error) {
+	if err := checkTarget(dir, nil); err != nil {
+		return inventory{}, err
+	}
+	layout := func(role string) string {
+		if d := options["layout_"+role]; d != "" {
+			return d
+		}
+		for _, r := range packageRoles {
+			if r.Name == role {
+				return r.Dir
+			}
+		}
+		return ""
+	}
+	exists := func(rel ...string) bool {
+		_, err := os.Stat(filepath.Join(append([]string{dir}, rel...)...))
+		return err == nil
+	}
+
+	// Every model file and every per-model package directory names a model
+	names := map[string]string{} // lowercase name to model name
+	if entries, err := os.ReadDir(filepath.Join(dir, layout("models"))); err == nil {
+		for _, entry := range entries {
+			base := strings.TrimSuffix(entry.Name(), ".go")
+			if entry.IsDir() || base == entry.Name() || strings.HasSuffix(base, "_test") || strings.HasSuffix(base, "_enums") || slices.Contains(infrastructureModels, base) {
+				continue
+			}
+			lower := strings.ReplaceAll(base, "_", "")
+			names[lower] = naming.Pascal(base)
+			content, _ := os.ReadFile(filepath.Join(dir, layout("models"), entry.Name()))
+			for _, match := range structType.FindAllStringSubmatch(string(content), -1) {
+				if strings.ToLower(match[1]) == lower {
+					names[lower] = match[1]
+				}
+			}
+		}
+	}
+	for _, role := range []string{"repository", "service", "controllers"} {
+		entries, _ := os.ReadDir(filepath.Join(dir, layout(role)))
+		for _, entry := range entries {
+			if lower := entry.Name(); entry.IsDir() && !slices.Contains(infrastructureDirs, lower) && names[lower] == "" {
+				names[lower] = naming.Pascal(lower)
+			}
+		}
+	}
+
+	var inv inventory
+	inv.Source = fmt.Sprintf("the files under `%s`", dir)
+	lowers := make([]string, 0, len(names))
+	for lower := range names {
+		lowers = append(lowers, lower)
+	}
+	sort.Strings(lowers)
+	for _, lower := range lowers {
+		m := state.Model{Name: names[lower]}
+		for component, path := range map[string][]string{
+			"model":           {layout("models"), lower + ".go"},
+			"repository":      {layout("repository"), lower},
+			"service":         {layout("service"), lower},
+			"api_controller":  {layout("controllers"), lower, "controller.go"},
+			"html_controller": {layout("controllers"), lower, "html_controller.go"},
+			"scopes":          {layout("repository"), lower, "scopes.go"},
+			"authorization":   {layout("service"), lower, "policy.go"},
+			"merge_patch":     {layout("controllers"), lower, "patch.go"},
+			"mapper":          {"internal", "mapper", lower + ".go"},
+		} {
+			if exists(path...) {
+				m.Components = append(m.Components, component)
+			}
+		}
+		sort.Slice(m.Components, func(i, j int) bool {
+			ri, rj := componentRank(m.Components[i]), componentRank(m.Components[j])
+			return ri < rj || ri == rj && m.Components[i] < m.Components[j]
+		})
+		inv.Models = append(inv.Models, m)
+	}
+
+	// Routes are registered in main.go by default, but may live anywhere in the code
+	inv.Routes = []string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != dir && (strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		for _, match := range routeCall.FindAllStringSubmatch(string(content), -1) {
+			route := match[1] + " " + match[2]
+			inv.Routes = append(inv.Routes, fmt.Sprintf("`%s` in `%s`", route, filepath.ToSlash(rel)))
+			for i, m := range inv.Models {
+				if routeServes(match[2], strings.ToLower(m.Name)) {
+					inv.Models[i].Routes = append(inv.Models[i].Routes, route)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return inventory{}, fmt.Errorf("Could not scan '%s': %v.", dir, err)
+	}
+	return inv, nil
+}
+
+// componentRank orders scanned components like the table columns, followed by the others
+func componentRank(component string) int {
+	for i, c := range componentColumns {
+		if c.name == component {
+			return i
+		}
+	}
+	return len(componentColumns)
+}
+
+// routeServes reports whether a route path has a segment naming the model's resource, e.g. /api/products/:id for product
+func routeServes(path, lower string) bool {
+	for _, segment := range strings.Split(path, "/") {
+		if segment == lower || naming.Singular(strings.ReplaceAll(segment, "-", "")) == lower {
+			return true
+		}
+	}
+	return false
+}
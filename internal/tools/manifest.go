@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/regions"
+	"mcpgo/internal/state"
+)
+
+// outputFormatOption is shared by every produce_* tool to return a manifest for scripts instead of instructions
+var outputFormatOption = mcp.WithString("output_format",
+	mcp.Description("markdown returns instructions for an LLM followed by the scaffold as JSON; json returns only a JSON manifest of the files (path, content and the action to take), the shell commands to run and notes, for scripts and other non-LLM clients. Pass target_dir to compare the files with the project. Defaults to the output format configured for the server, or markdown."),
+	mcp.Enum("markdown", "json"),
+)
+
+// The actions of a manifest file
+const (
+	actionCreateOrUpdate = "create_or_update" // no target_dir was given to compare with
+	actionCreate         = "create"           // the file does not exist yet
+	actionUpdate         = "update"           // the file exists with other content: merge the generated content into it
+	actionMerge          = "merge"            // the file has protected regions: the content is the generated one with the regions kept
+	actionOverwrite      = "overwrite"        // the server wrote the file, or overwrite is set: the generated content replaces it
+	actionConflict       = "conflict"         // the file changed since the server wrote it: write_files refuses to replace it without force
+	actionUnchanged      = "unchanged"        // the file already has the generated content
+	actionWritten        = "written"          // write_files created the file
+	actionMerged         = "merged"           // write_files regenerated the file around its protected regions
+	actionOverwritten    = "overwritten"      // write_files replaced the file
+)
+
+// manifestFile is a generated file and what the client should do with it
+type manifestFile struct {
+	Path     string `json:"path"`
+	Language string `json:"language"`
+	Content  string `json:"content"`
+	Action   string `json:"action"`
+}
+
+// manifest is the output_format=json result of a produce_* tool
+type manifest struct {
+	Files    []manifestFile `json:"files"`
+	Commands []string       `json:"commands"`
+	Notes    []string       `json:"notes"`
+}
+
+// fileAction compares a generated file with the one under dir, returning the action to take, the existing content
+// and the content to write, which keeps the protected regions of the existing file
+// A file the server wrote is replaced unless it changed since, which is a conflict without force
+func fileAction(ctx context.Context, dir string, f scaffoldFile, options writeOptions) (action, existing, content string, err error) {
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(f.Path)))
+	existing, content = string(data), f.Content
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return actionCreate, "", content, nil
+	case err != nil:
+		return "", "", "", fmt.Errorf("Could not read '%s': %v.", f.Path, err)
+	case existing == content:
+		return actionUnchanged, existing, content, nil
+	}
+
+	recorded, tracked := state.From(ctx).FileChecksum(options.app, trackedPath(dir, f.Path))
+	if tracked && recorded != checksum(existing) && !options.force {
+		return actionConflict, existing, content, nil
+	}
+	switch {
+	case regions.Has(existing):
+		if content, err = regions.Merge(existing, f.Content); err != nil {
+			return "", "", "", fmt.Errorf("Could not keep the protected regions of '%s': %v.", f.Path, err)
+		}
+		if content == existing {
+			return actionUnchanged, existing, content, nil
+		}
+		return actionMerge, existing, content, nil
+	case tracked || options.overwrite || options.force:
+		return actionOverwrite, existing, content, nil
+	default:
+		return actionUpdate, existing, content, nil
+	}
+}
+
+// manifestResult returns the scaffold as a JSON manifest, writing its files first when write_files is set
+// Without a target_dir every file is create_or_update; with one, each file is compared with the project
+func manifestResult(ctx context.Context, request mcp.CallToolRequest, s scaffold) *mcp.CallToolResult {
+	// Scripts get empty arrays rather than null
+	m := manifest{Files: make([]manifestFile, 0, len(s.Files)), Commands: append([]string{}, s.Commands...), Notes: append([]string{}, s.Notes...)}
+	for _, f := range s.Files {
+		m.Files = append(m.Files, manifestFile{Path: f.Path, Language: f.Language, Content: f.Content, Action: actionCreateOrUpdate})
+	}
+
+	dir := request.GetString("target_dir", "")
+	write := request.GetBool("write_files", false) && !request.GetBool("dry_run", false)
+	if dir == "" && write {
+		return missingParameterResult(ctx, "target_dir", "the directory to write the generated files under, usually the project root.", nil)
+	}
+	if dir != "" {
+		if err := checkTarget(dir, s.Files); err != nil {
+			return mcp.NewToolResultError(err.Error())
+		}
+		for i, f := range s.Files {
+			action, _, content, err := fileAction(ctx, dir, f, requestWriteOptions(ctx, request))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error())
+			}
+			m.Files[i].Action, m.Files[i].Content = action, content
+		}
+	}
+	if write {
+		result, err := writeScaffold(ctx, dir, s.Files, requestWriteOptions(ctx, request), s.progress)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error())
+		}
+		if len(result.Conflicts) > 0 {
+			m.Notes = append(m.Notes, "Nothing was written: the files marked conflict changed since the server wrote them. Pass force=true to overwrite them.")
+			data, err := json.Marshal(m)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Could not encode the manifest: %v.", err))
+			}
+			return &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent(string(data))}, IsError: true}
+		}
+		written := map[string]string{actionCreate: actionWritten, actionMerge: actionMerged, actionOverwrite: actionOverwritten}
+		for i, f := range m.Files {
+			if action, ok := written[f.Action]; ok {
+				m.Files[i].Action = action
+			}
+		}
+		commands, _ := writtenCommands(dir, s)
+		m.Commands = append([]string{}, commands...)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Could not encode the manifest: %v.", err))
+	}
+	return mcp.NewToolResultStructured(m, string(data))
+}
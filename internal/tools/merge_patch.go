@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+)
+
+// mergePatchFiles renders the merge patch package, the service method applying it and the PATCH handler
+func mergePatchFiles(ctx context.Context, modelName, lowerModelName, appName, path string, errs apiErrors) ([]scaffoldFile, error) {
+	return renderFiles(ctx, mergePatchFileFormats, map[string]any{
+		"Model":        modelName,
+		"Lower":        lowerModelName,
+		"App":          appName,
+		"NewError":     errs.New,
+		"ErrorImports": errs.Imports,
+		"Path":         path,
+	})
+}
+
+// mergePatchFileFormats lists the files of the PATCH endpoint in the order they appear in the instructions
+var mergePatchFileFormats = []fileFormat{
+	{Path: "internal/mergepatch/mergepatch.go", Language: "go", Template: "api_controller/mergepatch.go"},
+	{Path: "internal/service/{{.Lower}}/patch.go", Language: "go", Template: "api_controller/patch_service.go"},
+	{Path: "internal/controllers/{{.Lower}}/patch.go", Language: "go", Template: "api_controller/patch_controller.go"},
+}
+
+// mergePatchStep explains the PATCH endpoint; it is empty unless merge patch support was requested
+func mergePatchStep(step int, modelName string, files []scaffoldFile) string {
+	if len(files) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`
+%[1]d. Add a PATCH endpoint applying JSON merge patches (RFC 7386). Unlike the PUT handler, whose pointer DTO cannot tell a missing field from one to clear, members set to `+"`null`"+` are cleared and absent members keep their stored values.
+
+   Create the file at `+"`%[3]s`"+` with the merge function:
+`+"```go"+`
+%[4]s`+"```"+`
+
+   Create the file at `+"`%[5]s`"+`, and add `+"`Patch(ctx context.Context, id uint, patch []byte) (*dto.%[2]sResponse, error)`"+` to `+"`%[2]sService`"+`:
+`+"```go"+`
+%[6]s`+"```"+`
+
+   Create the file at `+"`%[7]s`"+`, and add `+"`Patch%[2]s(c echo.Context) error`"+` to `+"`%[2]sController`"+`; the PATCH route is part of step 3:
+`+"```go"+`
+%[8]s`+"```"+`
+
+   The patch is applied to the stored record as JSON, so model fields hidden with `+"`json:\"-\"`"+` are not part of the document: copy them from the existing record before saving.
+`, step, modelName,
+		files[0].Path, files[0].Content,
+		files[1].Path, files[1].Content,
+		files[2].Path, files[2].Content)
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestMergePatchFiles(t *testing.T) {
+	files, err := mergePatchFiles(context.Background(), "Product", "product", "shop", "/products", apiErrors{Format: "problem", New: "problem.New", Imports: "\t\"shop/internal/problem\"\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{"internal/mergepatch/mergepatch.go", "internal/service/product/patch.go", "internal/controllers/product/patch.go"}
+	if len(files) != len(paths) {
+		t.Fatalf("got %d files, want %d", len(files), len(paths))
+	}
+	for i, path := range paths {
+		if files[i].Path != path {
+			t.Errorf("file %d is at %s, want %s", i, files[i].Path, path)
+		}
+	}
+	controller := files[2].Content
+	for _, want := range []string{
+		"\t\"shop/internal/mergepatch\"\n\t\"shop/internal/problem\"\n)",
+		"// PatchProduct handles PATCH /products/:id",
+		"return problem.New(http.StatusUnsupportedMediaType,",
+		"ctrl.productService.Patch(",
+	} {
+		if !strings.Contains(controller, want) {
+			t.Errorf("the PATCH handler lacks %q:\n%s", want, controller)
+		}
+	}
+	if strings.Contains(controller, "echo.NewHTTPError") {
+		t.Errorf("the PATCH handler answers with Echo errors next to problem details:\n%s", controller)
+	}
+
+	if step := mergePatchStep(4, "Product", nil); step != "" {
+		t.Errorf("no files should give no step, got:\n%s", step)
+	}
+	step := mergePatchStep(4, "Product", files)
+	for _, want := range []string{"\n4. Add a PATCH endpoint", "to `ProductService`", "`PatchProduct(c echo.Context) error`"} {
+		if !strings.Contains(step, want) {
+			t.Errorf("the PATCH step lacks %q:\n%s", want, step)
+		}
+	}
+}
+
+// mergePatchTest checks the generated merge function against the examples of RFC 7386, Appendix A
+const mergePatchTest = `package mergepatch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		original, patch, want string
+	}{
+		{` + "`" + `{"a":"b"}` + "`" + `, ` + "`" + `{"a":"c"}` + "`" + `, ` + "`" + `{"a":"c"}` + "`" + `},
+		{` + "`" + `{"a":"b"}` + "`" + `, ` + "`" + `{"b":"c"}` + "`" + `, ` + "`" + `{"a":"b","b":"c"}` + "`" + `},
+		{` + "`" + `{"a":"b"}` + "`" + `, ` + "`" + `{"a":null}` + "`" + `, ` + "`" + `{}` + "`" + `},
+		{` + "`" + `{"a":"b","b":"c"}` + "`" + `, ` + "`" + `{"a":null}` + "`" + `, ` + "`" + `{"b":"c"}` + "`" + `},
+		{` + "`" + `{"a":["b"]}` + "`" + `, ` + "`" + `{"a":"c"}` + "`" + `, ` + "`" + `{"a":"c"}` + "`" + `},
+		{` + "`" + `{"a":"c"}` + "`" + `, ` + "`" + `{"a":["b"]}` + "`" + `, ` + "`" + `{"a":["b"]}` + "`" + `},
+		{` + "`" + `{"a":{"b":"c"}}` + "`" + `, ` + "`" + `{"a":{"b":"d","c":null}}` + "`" + `, ` + "`" + `{"a":{"b":"d"}}` + "`" + `},
+		{` + "`" + `{"a":[{"b":"c"}]}` + "`" + `, ` + "`" + `{"a":[1]}` + "`" + `, ` + "`" + `{"a":[1]}` + "`" + `},
+		{` + "`" + `{"e":null}` + "`" + `, ` + "`" + `{"a":1}` + "`" + `, ` + "`" + `{"a":1,"e":null}` + "`" + `},
+		{` + "`" + `{}` + "`" + `, ` + "`" + `{"a":{"bb":{"ccc":null}}}` + "`" + `, ` + "`" + `{"a":{"bb":{}}}` + "`" + `},
+		{` + "`" + `{"id":9007199254740993}` + "`" + `, ` + "`" + `{"n":1}` + "`" + `, ` + "`" + `{"id":9007199254740993,"n":1}` + "`" + `},
+	}
+	for _, tt := range tests {
+		got, err := Apply([]byte(tt.original), []byte(tt.patch))
+		if err != nil {
+			t.Errorf("Apply(%s, %s): %v", tt.original, tt.patch, err)
+		} else if string(got) != tt.want {
+			t.Errorf("Apply(%s, %s) = %s, want %s", tt.original, tt.patch, got, tt.want)
+		}
+	}
+}
+
+func TestApplyReadOnly(t *testing.T) {
+	got, err := Apply([]byte(` + "`" + `{"ID":1,"name":"a"}` + "`" + `), []byte(` + "`" + `{"ID":2,"name":"b"}` + "`" + `), "ID")
+	if err != nil || string(got) != ` + "`" + `{"ID":1,"name":"b"}` + "`" + ` {
+		t.Errorf("got %s, %v, want the ID kept", got, err)
+	}
+}
+
+func TestApplyInvalid(t *testing.T) {
+	for _, patch := range []string{` + "`" + `["a"]` + "`" + `, ` + "`" + `null` + "`" + `, ` + "`" + `"a"` + "`" + `, ` + "`" + `{"a":` + "`" + `} {
+		if _, err := Apply([]byte(` + "`" + `{"a":"b"}` + "`" + `), []byte(patch)); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Apply with %s: got %v, want ErrInvalid", patch, err)
+		}
+	}
+}
+`
+
+func TestMergePatchApply(t *testing.T) {
+	files, err := mergePatchFiles(context.Background(), "Product", "product", "shop", "/products", apiErrors{Format: "echo", New: "echo.NewHTTPError"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	goTest(t, []scaffoldFile{
+		files[0],
+		{Path: "internal/mergepatch/mergepatch_test.go", Language: "go", Content: mergePatchTest},
+	})
+}
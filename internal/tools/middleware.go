@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"mcpgo/internal/state"
+)
+
+// TimeoutMiddleware bounds every tool call with a deadline so long scaffolds cannot run unchecked
+// Calls with verify get verifyTimeout instead, since compiling the generated code may fill a cold module cache first
+// A zero or negative timeout disables the deadline and only client cancellation applies
+func TimeoutMiddleware(timeout, verifyTimeout time.Duration) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			timeout := timeout
+			if request.GetBool("verify", false) {
+				timeout = verifyTimeout
+			}
+			if timeout <= 0 {
+				return next(ctx, request)
+			}
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return next(ctx, request)
+		}
+	}
+}
+
+// PreviewMiddleware runs the calls that only preview a scaffold on a fork of the project state, dropped once they
+// return, so planning a scaffold with explain or reviewing its diff with dry_run records neither its model, options,
+// components and routes nor the current app
+func PreviewMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		dryRun := request.GetBool("write_files", false) && request.GetBool("dry_run", false)
+		if request.GetBool("explain", false) || dryRun {
+			ctx = state.WithStore(ctx, state.From(ctx).Fork())
+		}
+		return next(ctx, request)
+	}
+}
+
+// ElicitationMiddleware asks the user, through MCP elicitation, for a required argument a tool call lacks, then
+// retries the call with it, so a scaffold proceeds instead of failing
+// Clients without elicitation, and users declining to answer, get the missing argument error as before
+func ElicitationMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var asked []string
+		for {
+			var missing missingParameter
+			result, err := next(context.WithValue(ctx, missingParameterKey{}, &missing), request)
+			// An argument asked for already was answered with a value the tool rejects again; the error explains why
+			if err != nil || result == nil || !result.IsError || missing.Name == "" || slices.Contains(asked, missing.Name) {
+				return result, err
+			}
+			value, ok := elicitParameter(ctx, request.Params.Name, missing)
+			if !ok {
+				return result, err
+			}
+			asked = append(asked, missing.Name)
+			arguments := maps.Clone(request.GetArguments())
+			if arguments == nil {
+				arguments = map[string]any{}
+			}
+			arguments[missing.Name] = value
+			request.Params.Arguments = arguments
+		}
+	}
+}
+
+// elicitParameter asks the user of the client for the value of a missing argument of tool
+// It reports false when the client does not support elicitation, or the user declines or leaves the value empty
+func elicitParameter(ctx context.Context, tool string, missing missingParameter) (string, bool) {
+	srv := server.ServerFromContext(ctx)
+	if srv == nil || clientCapabilities(ctx).Elicitation == nil {
+		return "", false
+	}
+	description := missing.Description
+	if len(missing.Known) > 0 {
+		description += " Already used: " + strings.Join(missing.Known, ", ") + "."
+	}
+	result, err := srv.RequestElicitation(ctx, mcp.ElicitationRequest{Params: mcp.ElicitationParams{
+		Message: fmt.Sprintf("%s needs the %s argument to continue.", tool, missing.Name),
+		RequestedSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				missing.Name: map[string]any{"type": "string", "title": missing.Name, "description": description},
+			},
+			"required": []string{missing.Name},
+		},
+	}})
+	if err != nil || result.Action != mcp.ElicitationResponseActionAccept {
+		return "", false
+	}
+	content, _ := result.Content.(map[string]any)
+	value, _ := content[missing.Name].(string)
+	value = strings.TrimSpace(value)
+	return value, value != ""
+}
@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"mcpgo/internal/state"
+)
+
+// toolHandler returns the handler of the registered tool named name, with the middleware of the server that changes
+// the project state it uses
+func toolHandler(t *testing.T, name string) server.ToolHandlerFunc {
+	t.Helper()
+	for _, tool := range All() {
+		if tool.Tool.Name == name {
+			return PreviewMiddleware(tool.Handler)
+		}
+	}
+	t.Fatalf("no tool named %s", name)
+	return nil
+}
+
+// persistedProject returns a context whose calls use a store saved to a manifest, and the path of the manifest
+// The store holds an app shop with a Product model scaffolded with non-default options, and is left on app shop
+func persistedProject(t *testing.T) (context.Context, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "project.json")
+	store := state.NewStore()
+	if err := store.Persist(path); err != nil {
+		t.Fatal(err)
+	}
+	store.RecordModel("shop", "Product", []state.Field{{Name: "Name", Type: "string"}})
+	store.SetModelOption("shop", "Product", "soft_delete", "true")
+	store.SetModelOption("shop", "Product", "base_model", "Base")
+	store.SetOption("shop", "error_format", "problem")
+	store.RecordComponent("shop", "Product", "service")
+	return state.WithStore(context.Background(), store), path
+}
+
+// assertManifestUnchanged fails the test when the manifest at path no longer holds before
+func assertManifestUnchanged(t *testing.T, path string, before []byte) {
+	t.Helper()
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Errorf("the project manifest changed:\n%s\nwant\n%s", after, before)
+	}
+}
+
+func TestExplainRecordsNothing(t *testing.T) {
+	ctx, path := persistedProject(t)
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, call := range []struct {
+		tool      string
+		arguments map[string]any
+	}{
+		{"produce_model_boilerplate", map[string]any{"app_name": "outlet", "model_name": "Review", "soft_delete": false, "fields": `[{"name":"Title","type":"string"}]`}},
+		{"produce_api_controller_boilerplate", map[string]any{"app_name": "shop", "model_name": "Product", "error_format": "echo"}},
+		{"scaffold_full_crud", map[string]any{"app_name": "outlet", "model_name": "Tag", "fields": `[{"name":"Label","type":"string"}]`}},
+	} {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = call.tool
+		request.Params.Arguments = call.arguments
+		call.arguments["explain"] = true
+		result, err := toolHandler(t, call.tool)(ctx, request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.IsError {
+			t.Fatalf("%s: %s", call.tool, resultText(result))
+		}
+	}
+	assertManifestUnchanged(t, path, before)
+}
+
+func TestDryRunRecordsNothing(t *testing.T) {
+	ctx, path := persistedProject(t)
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	for _, call := range []struct {
+		tool      string
+		arguments map[string]any
+	}{
+		{"produce_model_boilerplate", map[string]any{"app_name": "outlet", "model_name": "Review", "soft_delete": false, "fields": `[{"name":"Title","type":"string"}]`}},
+		{"produce_api_controller_boilerplate", map[string]any{"app_name": "shop", "model_name": "Product", "error_format": "echo"}},
+		{"scaffold_full_crud", map[string]any{"app_name": "outlet", "model_name": "Tag", "fields": `[{"name":"Label","type":"string"}]`}},
+	} {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = call.tool
+		request.Params.Arguments = call.arguments
+		call.arguments["write_files"] = true
+		call.arguments["dry_run"] = true
+		call.arguments["target_dir"] = dir
+		result, err := toolHandler(t, call.tool)(ctx, request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.IsError {
+			t.Fatalf("%s: %s", call.tool, resultText(result))
+		}
+	}
+	assertManifestUnchanged(t, path, before)
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 0 {
+		t.Errorf("the dry run wrote %v (%v)", entries, err)
+	}
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	var deadlines []time.Duration
+	handler := TimeoutMiddleware(30*time.Second, 5*time.Minute)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			deadlines = append(deadlines, 0)
+		} else {
+			deadlines = append(deadlines, time.Until(deadline).Round(time.Second))
+		}
+		return nil, nil
+	})
+	for _, arguments := range []map[string]any{{}, {"verify": true}} {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = arguments
+		handler(context.Background(), request)
+	}
+	if want := []time.Duration{30 * time.Second, 5 * time.Minute}; !slices.Equal(deadlines, want) {
+		t.Errorf("deadlines %v, want %v", deadlines, want)
+	}
+}
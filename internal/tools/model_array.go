@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"mcpgo/internal/naming"
+)
+
+// postgresArrayTypes maps the supported slice field types to their lib/pq array type and column type
+var postgresArrayTypes = map[string]struct{ goType, column string }{
+	"[]string":  {"pq.StringArray", "text[]"},
+	"[]int64":   {"pq.Int64Array", "bigint[]"},
+	"[]int":     {"pq.Int64Array", "bigint[]"},
+	"[]float64": {"pq.Float64Array", "double precision[]"},
+	"[]bool":    {"pq.BoolArray", "boolean[]"},
+}
+
+// arrayField is a model field stored in a Postgres array column
+type arrayField struct {
+	field     string // Go field name
+	jsonName  string
+	column    string
+	sliceType string // type requested by the client, e.g. []string
+	goType    string // lib/pq type used in the model, e.g. pq.StringArray
+	sqlType   string // column type, e.g. text[]
+}
+
+// modelArrayFields collects the fields declared with a slice type
+func modelArrayFields(fields []map[string]string) []arrayField {
+	var arrayFields []arrayField
+	for _, field := range fields {
+		types, ok := postgresArrayTypes[field["type"]]
+		if !ok {
+			continue
+		}
+		arrayFields = append(arrayFields, arrayField{
+			field:     naming.Pascal(field["name"]),
+			jsonName:  field["name"],
+			column:    naming.Snake(field["name"]),
+			sliceType: field["type"],
+			goType:    types.goType,
+			sqlType:   types.column,
+		})
+	}
+	return arrayFields
+}
+
+// findArrayField returns the array field named name, if any
+func findArrayField(arrayFields []arrayField, name string) (arrayField, bool) {
+	for _, f := range arrayFields {
+		if f.jsonName == name {
+			return f, true
+		}
+	}
+	return arrayField{}, false
+}
+
+// elementType returns the Go type of a single array element as the repository methods accept it
+func (f arrayField) elementType() string {
+	if f.sliceType == "[]int" {
+		return "int64" // pq.Int64Array holds int64 elements
+	}
+	return strings.TrimPrefix(f.sliceType, "[]")
+}
+
+// arrayRepositoryMethods returns the interface methods filtering on the array columns
+func arrayRepositoryMethods(modelName string, arrayFields []arrayField) string {
+	var b strings.Builder
+	for _, f := range arrayFields {
+		fmt.Fprintf(&b, "\tFindBy%[1]sContains(ctx context.Context, values ...%[2]s) ([]models.%[3]s, error)\n\tFindBy%[1]sOverlaps(ctx context.Context, values ...%[2]s) ([]models.%[3]s, error)\n", f.field, f.elementType(), modelName)
+	}
+	return b.String()
+}
+
+// arrayQueryFile renders the repository methods filtering on the array columns
+func arrayQueryFile(modelName, lowerModelName, appName string, access repositoryAccess, arrayFields []arrayField) scaffoldFile {
+	var methods strings.Builder
+	for _, f := range arrayFields {
+		fmt.Fprintf(&methods, `
+// FindBy%[2]sContains returns the records whose %[4]s contain every value (%[4]s @> values)
+func (r *%[1]sRepositoryImpl) FindBy%[2]sContains(ctx context.Context, values ...%[7]s) ([]models.%[1]s, error) {
+	var %[3]s []models.%[1]s
+	err := %[5]s.WithContext(ctx)%[6]s.Where("%[4]s @> ?", %[8]s(values)).Find(&%[3]s).Error
+	return %[3]s, err
+}
+
+// FindBy%[2]sOverlaps returns the records whose %[4]s contain at least one of the values (%[4]s && values)
+func (r *%[1]sRepositoryImpl) FindBy%[2]sOverlaps(ctx context.Context, values ...%[7]s) ([]models.%[1]s, error) {
+	var %[3]s []models.%[1]s
+	err := %[5]s.WithContext(ctx)%[6]s.Where("%[4]s && ?", %[8]s(values)).Find(&%[3]s).Error
+	return %[3]s, err
+}
+`, modelName, f.field, lowerModelName, f.column, access.DB, access.Read, f.elementType(), f.goType)
+	}
+
+	return scaffoldFile{
+		Path:     fmt.Sprintf("internal/repository/%s/array_query.go", lowerModelName),
+		Language: "go",
+		Content: fmt.Sprintf(`package repository
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+%s	"%s/internal/models"
+)
+%s`, access.Imports, appName, methods.String()),
+	}
+}
+
+// arrayStep explains the array columns, their DTO handling and the repository filters; it is empty without array fields
+func arrayStep(modelName, lowerModelName string, arrayFields []arrayField, files []scaffoldFile) string {
+	if len(arrayFields) == 0 {
+		return ""
+	}
+
+	var dtoFields, toModel, toDTO strings.Builder
+	for _, f := range arrayFields {
+		fmt.Fprintf(&dtoFields, "\t%s []%s `json:\"%s\"`\n", f.field, f.elementType(), f.jsonName)
+		fmt.Fprintf(&toModel, "\tmodel.%[1]s = %[2]s(req.%[1]s)\n", f.field, f.goType)
+		fmt.Fprintf(&toDTO, "\tresponse.%[1]s = []%[2]s(model.%[1]s)\n", f.field, f.elementType())
+	}
+
+	return fmt.Sprintf(`
+   Array fields use `+"`github.com/lib/pq`"+` array types, which map to Postgres array columns. Run `+"`go get github.com/lib/pq`"+`.
+
+   In `+"`internal/dto/%[2]s/dto.go`"+`, expose them as plain slices, and convert in the service mapping helpers:
+`+"```go"+`
+// Create%[1]sRequest, Update%[1]sRequest and %[1]sResponse
+%[3]s
+// createDTOToModel and the service Update method
+%[4]s
+// modelToDTO
+%[5]s`+"```"+`
+
+   Create the file at `+"`%[6]s`"+` to filter on the arrays; the methods are declared in `+"`%[1]sRepository`"+` below:
+`+"```go"+`
+%[7]s`+"```"+`
+`, modelName, lowerModelName, dtoFields.String(), toModel.String(), toDTO.String(), files[0].Path, files[0].Content)
+}
@@ -0,0 +1,180 @@
+package tools
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+
+	"mcpgo/internal/naming"
+)
+
+// modelBase describes the struct embedded in a generated model in place of the ID, timestamp and soft delete columns
+type modelBase struct {
+	softDelete bool
+	timestamps bool
+	name       string // name of the generated base struct, empty when gorm.Model is embedded
+}
+
+// newModelBase picks gorm.Model when it matches the requested columns, and a generated base struct otherwise
+// A name of gorm.Model, as the project state records it, is the default rather than a struct to generate
+func newModelBase(softDelete, timestamps bool, name string) modelBase {
+	if name == "gorm.Model" {
+		name = ""
+	}
+	b := modelBase{softDelete: softDelete, timestamps: timestamps, name: naming.Pascal(name)}
+	if b.name == "" && !(softDelete && timestamps) {
+		switch {
+		case timestamps:
+			b.name = "TimestampedModel"
+		case softDelete:
+			b.name = "SoftDeleteModel"
+		default:
+			b.name = "IDModel"
+		}
+	}
+	return b
+}
+
+// generated reports whether the base struct has to be generated rather than taken from GORM
+func (b modelBase) generated() bool {
+	return b.name != ""
+}
+
+// embed returns the type embedded in the model struct
+func (b modelBase) embed() string {
+	if !b.generated() {
+		return "gorm.Model"
+	}
+	return b.name
+}
+
+// imports lists the packages the model file needs for the embedded struct
+func (b modelBase) imports() []string {
+	if !b.generated() {
+		return []string{"gorm.io/gorm"}
+	}
+	return nil
+}
+
+// importBlock renders the import declaration of a generated file, including the surrounding blank lines
+// Standard library paths, listed first, get their own group
+func importBlock(paths []string) string {
+	switch len(paths) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("\nimport %q\n", paths[0])
+	}
+	var b strings.Builder
+	b.WriteString("\nimport (\n")
+	for i, path := range paths {
+		if i > 0 && !strings.Contains(paths[i-1], ".") && strings.Contains(path, ".") {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "\t%q\n", path)
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+// fieldNames lists the columns the base struct provides
+func (b modelBase) fieldNames() []string {
+	names := []string{"ID"}
+	if b.timestamps {
+		names = append(names, "CreatedAt", "UpdatedAt")
+	}
+	if b.softDelete {
+		names = append(names, "DeletedAt")
+	}
+	return names
+}
+
+// file renders the generated base struct
+func (b modelBase) file() scaffoldFile {
+	var content strings.Builder
+	content.WriteString("package models\n\n")
+	switch {
+	case b.timestamps && b.softDelete:
+		content.WriteString("import (\n\t\"time\"\n\n\t\"gorm.io/gorm\"\n)\n\n")
+	case b.timestamps:
+		content.WriteString("import \"time\"\n\n")
+	case b.softDelete:
+		content.WriteString("import \"gorm.io/gorm\"\n\n")
+	}
+	fmt.Fprintf(&content, "// %s provides the %s columns of the models embedding it\n", b.name, strings.Join(b.fieldNames(), ", "))
+	fmt.Fprintf(&content, "type %s struct {\n", b.name)
+	content.WriteString("\tID uint `gorm:\"primarykey\"`\n")
+	if b.timestamps {
+		content.WriteString("\tCreatedAt time.Time\n\tUpdatedAt time.Time\n")
+	}
+	if b.softDelete {
+		content.WriteString("\tDeletedAt gorm.DeletedAt `gorm:\"index\"`\n")
+	}
+	content.WriteString("}\n")
+
+	source := []byte(content.String())
+	if formatted, err := format.Source(source); err == nil {
+		source = formatted // aligns the fields the same way gofmt would
+	}
+	return scaffoldFile{
+		Path:     fmt.Sprintf("internal/models/%s.go", naming.Snake(b.name)),
+		Language: "go",
+		Content:  string(source),
+	}
+}
+
+// note explains the embedded base struct at the top of the model instructions
+func (b modelBase) note() string {
+	if !b.generated() {
+		return `Note: The model includes 'gorm.Model' which provides the following fields automatically:
+- ID (uint, primary key)
+- CreatedAt (time.Time)
+- UpdatedAt (time.Time)
+- DeletedAt (soft delete with index)
+
+These fields don't need to be added manually to your model.`
+	}
+
+	var note strings.Builder
+	fmt.Fprintf(&note, "Note: The model embeds '%s' instead of 'gorm.Model', which provides the following fields automatically:\n", b.name)
+	note.WriteString("- ID (uint, primary key)\n")
+	if b.timestamps {
+		note.WriteString("- CreatedAt (time.Time)\n- UpdatedAt (time.Time)\n")
+	}
+	if b.softDelete {
+		note.WriteString("- DeletedAt (soft delete with index)\n")
+	}
+	file := b.file()
+	fmt.Fprintf(&note, "\nCreate the file at `%s` with the following content if it does not exist yet; other models with the same options can embed it too:\n```go\n%s```\n\n", file.Path, file.Content)
+	if !b.softDelete {
+		note.WriteString("Without DeletedAt, the repository's Delete removes rows permanently. ")
+	}
+	note.WriteString("These fields don't need to be added manually to your model.")
+	return note.String()
+}
+
+// repositoryMethods returns the extra repository interface methods of soft-deletable models
+func (b modelBase) repositoryMethods() string {
+	if !b.softDelete {
+		return ""
+	}
+	return "\tRestore(ctx context.Context, id uint) error\n\tForceDelete(ctx context.Context, id uint) error\n"
+}
+
+// repositoryFuncs renders the Unscoped repository methods of soft-deletable models, appended to delete.go
+func (b modelBase) repositoryFuncs(modelName, db, write string) string {
+	if !b.softDelete {
+		return ""
+	}
+	return fmt.Sprintf(`
+// Restore undoes a soft delete
+func (r *%[1]sRepositoryImpl) Restore(ctx context.Context, id uint) error {
+	return %[2]s.WithContext(ctx)%[3]s.Unscoped().Model(&models.%[1]s{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// ForceDelete permanently removes the record, bypassing soft delete
+func (r *%[1]sRepositoryImpl) ForceDelete(ctx context.Context, id uint) error {
+	return %[2]s.WithContext(ctx)%[3]s.Unscoped().Delete(&models.%[1]s{}, id).Error
+}
+`, modelName, db, write)
+}
@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"mcpgo/internal/naming"
+)
+
+// fieldCheck is a database check constraint declared on a model field
+type fieldCheck struct {
+	field      string // Go field name
+	fieldType  string
+	jsonName   string
+	expr       string // SQL expression, e.g. price >= 0
+	constraint string // constraint name, e.g. chk_products_price
+	validate   string // equivalent go-playground/validator tag, empty when there is none
+}
+
+// checkComparison matches expressions like price >= 0
+var checkComparison = regexp.MustCompile(`^(\w+)\s*(>=|<=|>|<)\s*(-?\d+(?:\.\d+)?)$`)
+
+// checkLength matches expressions like length(name) <= 100
+var checkLength = regexp.MustCompile(`(?i)^(?:char_length|length)\((\w+)\)\s*(>=|<=|>|<)\s*(\d+)$`)
+
+// checkBetween matches expressions like quantity BETWEEN 1 AND 10
+var checkBetween = regexp.MustCompile(`(?i)^(\w+)\s+BETWEEN\s+(-?\d+(?:\.\d+)?)\s+AND\s+(-?\d+(?:\.\d+)?)$`)
+
+// checkIn matches expressions like status IN ('draft', 'published')
+var checkIn = regexp.MustCompile(`(?i)^(\w+)\s+IN\s*\((.+)\)$`)
+
+// checkNotEmpty matches expressions requiring a column to differ from the empty string
+var checkNotEmpty = regexp.MustCompile(`^(\w+)\s*(?:<>|!=)\s*''$`)
+
+// validatorOperators maps SQL comparison operators to validator tags
+var validatorOperators = map[string]string{">=": "gte", ">": "gt", "<=": "lte", "<": "lt"}
+
+// validatorLengthOperators maps length comparisons to validator tags, which compare the length of strings
+var validatorLengthOperators = map[string]string{">=": "min", "<=": "max", ">": "gt", "<": "lt"}
+
+// modelChecks collects the check constraints declared in the fields of a model
+func modelChecks(table string, fields []map[string]string) []fieldCheck {
+	var checks []fieldCheck
+	for _, field := range fields {
+		expr := strings.TrimSpace(field["check"])
+		if expr == "" {
+			continue
+		}
+		checks = append(checks, fieldCheck{
+			field:      naming.Pascal(field["name"]),
+			fieldType:  field["type"],
+			jsonName:   field["name"],
+			expr:       expr,
+			constraint: fmt.Sprintf("chk_%s_%s", table, naming.Snake(field["name"])),
+			validate:   checkValidateTag(expr),
+		})
+	}
+	return checks
+}
+
+// checkValidateTag translates the common forms of check expressions into a validator tag
+func checkValidateTag(expr string) string {
+	if m := checkComparison.FindStringSubmatch(expr); m != nil {
+		return validatorOperators[m[2]] + "=" + m[3]
+	}
+	if m := checkLength.FindStringSubmatch(expr); m != nil {
+		return validatorLengthOperators[m[2]] + "=" + m[3]
+	}
+	if m := checkBetween.FindStringSubmatch(expr); m != nil {
+		return "gte=" + m[2] + ",lte=" + m[3]
+	}
+	if checkNotEmpty.MatchString(expr) {
+		return "min=1"
+	}
+	if m := checkIn.FindStringSubmatch(expr); m != nil {
+		var values []string
+		for _, value := range strings.Split(m[2], ",") {
+			value = strings.Trim(strings.TrimSpace(value), "'")
+			if value == "" || strings.ContainsAny(value, " '") {
+				return "" // oneof cannot express empty values or values with spaces
+			}
+			values = append(values, value)
+		}
+		return "oneof=" + strings.Join(values, " ")
+	}
+	return ""
+}
+
+// gormSetting returns the gorm tag setting declaring the constraint
+func (c fieldCheck) gormSetting() string {
+	return fmt.Sprintf("check:%s,%s", c.constraint, c.expr)
+}
+
+// checkFiles renders the up and down migrations adding the check constraints to an existing table
+func checkFiles(table string, checks []fieldCheck) []scaffoldFile {
+	var up, down strings.Builder
+	for _, c := range checks {
+		fmt.Fprintf(&up, "ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s);\n", table, c.constraint, c.expr)
+		fmt.Fprintf(&down, "ALTER TABLE %s DROP CONSTRAINT %s;\n", table, c.constraint)
+	}
+	return []scaffoldFile{
+		{Path: fmt.Sprintf("migrations/%s_checks.up.sql", table), Language: "sql", Content: up.String()},
+		{Path: fmt.Sprintf("migrations/%s_checks.down.sql", table), Language: "sql", Content: down.String()},
+	}
+}
+
+// checksStep explains the migrations and the mirrored DTO validation; it is empty when no field declares a check
+func checksStep(modelName, lowerModelName string, checks []fieldCheck, files []scaffoldFile) string {
+	if len(checks) == 0 {
+		return ""
+	}
+
+	var createFields, updateFields strings.Builder
+	for _, c := range checks {
+		if c.validate == "" {
+			fmt.Fprintf(&createFields, "\t// %s: no validator equivalent for CHECK (%s), validate it in the service\n", c.field, c.expr)
+			fmt.Fprintf(&updateFields, "\t// %s: no validator equivalent for CHECK (%s), validate it in the service\n", c.field, c.expr)
+			continue
+		}
+		fmt.Fprintf(&createFields, "\t%s %s `json:\"%s\" validate:\"%s\"`\n", c.field, c.fieldType, c.jsonName, c.validate)
+		fmt.Fprintf(&updateFields, "\t%s *%s `json:\"%s,omitempty\" validate:\"omitempty,%s\"`\n", c.field, c.fieldType, c.jsonName, c.validate)
+	}
+
+	return fmt.Sprintf(`
+   The model declares check constraints. GORM adds them when `+"`AutoMigrate`"+` creates the table; for a table that already exists, apply these migrations:
+
+   `+"`%[3]s`"+`:
+`+"```sql"+`
+%[4]s`+"```"+`
+
+   `+"`%[5]s`"+`:
+`+"```sql"+`
+%[6]s`+"```"+`
+
+   Mirror the constraints in `+"`internal/dto/%[2]s/dto.go`"+` so invalid input is rejected with 400 before it reaches the database:
+`+"```go"+`
+type Create%[1]sRequest struct {
+%[7]s}
+
+type Update%[1]sRequest struct {
+%[8]s}
+`+"```"+`
+`, modelName, lowerModelName, files[0].Path, files[0].Content, files[1].Path, files[1].Content, createFields.String(), updateFields.String())
+}
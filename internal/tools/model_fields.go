@@ -0,0 +1,304 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+)
+
+// fieldsOption declares the fields of a model as an array of objects, so clients validate each field before calling
+var fieldsOption = mcp.WithArray("fields",
+	mcp.Required(),
+	mcp.Description("The model fields, each an object with 'name' and 'type'. Use the type 'json' for a JSON column (datatypes.JSON), and add 'struct' to store a typed document with the GORM json serializer instead. Slice types ([]string, []int64, []float64, []bool) become Postgres arrays and require dialect=postgres. The types 'point' and 'geometry' become PostGIS columns with nearest-neighbour queries and also require dialect=postgres. A JSON-encoded string of the same array is accepted too."),
+	mcp.Items(map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name":     map[string]any{"type": "string", "description": "Go name of the field (e.g., Email); its column and JSON key derive from it."},
+			"type":     map[string]any{"type": "string", "description": "Type of the field: string, int, uint, float64, bool, time.Time, uuid.UUID, decimal.Decimal or another sized number type, json, point, geometry, a Postgres array type, or the name of another model for an association. Aliases such as datetime, uuid, decimal or integer are normalized; unknown types are rejected with the closest match."},
+			"nullable": map[string]any{"type": "boolean", "description": "Store NULL when the value is absent, as a pointer type (e.g., *string). Defaults to false."},
+			"gorm":     map[string]any{"type": "string", "description": "Extra GORM tag settings separated by semicolons (e.g., uniqueIndex;size:255)."},
+			"validate": map[string]any{"type": "string", "description": "Comma-separated validator rules checked in the DTOs and HTML forms (e.g., required,email,max=100)."},
+			"pattern":  map[string]any{"type": "string", "description": "Regular expression the value must match in the DTOs and HTML forms."},
+			"check":    map[string]any{"type": "string", "description": "SQL check constraint (e.g., price >= 0 or status IN ('draft', 'published'))."},
+			"enum":     map[string]any{"type": "string", "description": "Comma-separated values allowed in the field."},
+			"struct":   map[string]any{"type": "string", "description": "Document type of a json field, stored with the GORM json serializer."},
+		},
+		"required":             []string{"name", "type"},
+		"additionalProperties": false,
+	}),
+)
+
+// requestFields reads the fields argument, given as an array of objects or as a JSON string encoding one,
+// as maps of the field settings; nullable is folded into the type and dropped
+func requestFields(request mcp.CallToolRequest) ([]map[string]string, error) {
+	var items []any
+	switch value := request.GetArguments()["fields"].(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		items = value
+	case string:
+		if strings.TrimSpace(value) == "" {
+			return nil, nil
+		}
+		if err := json.Unmarshal([]byte(value), &items); err != nil {
+			return nil, fmt.Errorf("Invalid 'fields' JSON format: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("Invalid 'fields': expected an array of objects with 'name' and 'type', got %T.", value)
+	}
+
+	fields := make([]map[string]string, 0, len(items))
+	for i, item := range items {
+		object, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("Invalid field %d in 'fields': expected an object with 'name' and 'type', got %T.", i+1, item)
+		}
+		field := map[string]string{}
+		nullable := false
+		for key, value := range object {
+			switch v := value.(type) {
+			case string:
+				field[key] = v
+			case bool:
+				if key != "nullable" {
+					return nil, fmt.Errorf("Invalid '%s' of field %d in 'fields': expected a string.", key, i+1)
+				}
+				nullable = v
+			case nil:
+			default:
+				return nil, fmt.Errorf("Invalid '%s' of field %d in 'fields': expected a string, got %v.", key, i+1, value)
+			}
+		}
+		if s, ok := field["nullable"]; ok {
+			parsed, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid 'nullable' of field %d in 'fields': expected true or false, got '%s'.", i+1, s)
+			}
+			nullable = parsed
+			delete(field, "nullable")
+		}
+		if field["name"] == "" || field["type"] == "" {
+			return nil, fmt.Errorf("Invalid field %d in 'fields': 'name' and 'type' are required.", i+1)
+		}
+		if err := checkIdentifier("field", field["name"]); err != nil {
+			return nil, err
+		}
+		// Names with spaces or dashes, such as "first name", become the Go name of the field
+		if strings.ContainsAny(field["name"], " -") {
+			field["name"] = naming.Pascal(field["name"])
+		}
+		if slices.ContainsFunc(fields, func(f map[string]string) bool { return naming.Pascal(f["name"]) == naming.Pascal(field["name"]) }) {
+			return nil, fmt.Errorf("Duplicate field '%s' in 'fields': each field needs its own Go name, and %s is taken.", field["name"], naming.Pascal(field["name"]))
+		}
+		fieldType, err := normalizeFieldType(field["name"], field["type"])
+		if err != nil {
+			return nil, err
+		}
+		field["type"] = fieldType
+		if strings.ContainsAny(field["gorm"], "`\"") {
+			return nil, fmt.Errorf("Invalid 'gorm' of field '%s': expected tag settings such as uniqueIndex;size:255, without quotes.", field["name"])
+		}
+		if nullable && !strings.HasPrefix(field["type"], "*") {
+			if !scalarFieldType(field["type"]) {
+				return nil, fmt.Errorf("Field '%s' has type %s, which cannot be nullable: only scalar types become pointers; json, array and geometry columns are nullable already.", field["name"], field["type"])
+			}
+			field["type"] = "*" + field["type"]
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// checkIdentifier returns an error when a model or field name cannot become a Go identifier: it must start with a
+// letter and hold only letters, digits, spaces, dashes and underscores
+func checkIdentifier(kind, name string) error {
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(" -_", r) {
+			return fmt.Errorf("Invalid %s name '%s': '%c' cannot appear in a Go identifier; use letters, digits and underscores, such as %s.", kind, name, r, naming.Pascal(strings.Map(identifierRune, name)))
+		}
+	}
+	identifier := naming.Pascal(name)
+	if identifier == "" {
+		return fmt.Errorf("Invalid %s name '%s': expected letters, such as Email.", kind, name)
+	}
+	if unicode.IsDigit([]rune(identifier)[0]) {
+		return fmt.Errorf("Invalid %s name '%s': Go identifiers cannot start with a digit; put a word first, such as Item%s.", kind, name, identifier)
+	}
+	return nil
+}
+
+// checkModelName reports a model name the generated code cannot use; spaces, dashes and underscores are dropped
+// by the Pascal case every layer is named after, so 'Order Item' and 'order-item' both become OrderItem
+func checkModelName(name string) error {
+	if err := checkIdentifier("model", name); err != nil {
+		return err
+	}
+	// The repositories and controllers name variables after the lowercased model, e.g. product := &models.Product{}
+	if lower := strings.ToLower(naming.Pascal(name)); naming.Reserved(lower) {
+		return fmt.Errorf("Invalid model name '%s': the generated code names variables %s, which is a Go keyword or predeclared identifier. Use a more specific name, such as %sItem or Product%s.", name, lower, naming.Pascal(name), naming.Pascal(name))
+	}
+	return nil
+}
+
+// identifierRune keeps the runes of a Go identifier and turns any other into a word break
+func identifierRune(r rune) rune {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return r
+	}
+	return ' '
+}
+
+// fieldTypes are the types a model field can have, with the package each needs imported
+// Other exported names are associations with models of the same package
+var fieldTypes = map[string]string{
+	"string": "", "bool": "", "[]byte": "",
+	"int": "", "int8": "", "int16": "", "int32": "", "int64": "",
+	"uint": "", "uint8": "", "uint16": "", "uint32": "", "uint64": "",
+	"float32": "", "float64": "",
+	"json": "", "point": "", "geometry": "",
+	"time.Time":       "time",
+	"uuid.UUID":       "github.com/google/uuid",
+	"decimal.Decimal": "github.com/shopspring/decimal",
+}
+
+// fieldTypeAliases maps other common spellings of a type, lowercased, to the type
+var fieldTypeAliases = map[string]string{
+	"text": "string", "varchar": "string", "str": "string",
+	"integer": "int", "smallint": "int16", "bigint": "int64", "long": "int64",
+	"float": "float64", "double": "float64", "number": "float64", "boolean": "bool",
+	"bytes": "[]byte", "blob": "[]byte", "binary": "[]byte",
+	"time": "time.Time", "datetime": "time.Time", "timestamp": "time.Time", "timestamptz": "time.Time", "date": "time.Time",
+	"uuid": "uuid.UUID", "guid": "uuid.UUID",
+	"decimal": "decimal.Decimal", "numeric": "decimal.Decimal", "money": "decimal.Decimal",
+	"jsonb": "json", "object": "json",
+}
+
+// normalizeFieldType returns the Go type of a requested field type, accepting aliases such as datetime or uuid,
+// pointers to nullable types, the Postgres array types and associations, and suggests the closest type otherwise
+func normalizeFieldType(name, fieldType string) (string, error) {
+	fieldType = strings.TrimSpace(fieldType)
+	if rest, ok := strings.CutPrefix(fieldType, "*"); ok {
+		inner, err := normalizeFieldType(name, rest)
+		if err != nil {
+			return "", err
+		}
+		if !scalarFieldType(inner) || strings.HasPrefix(inner, "*") {
+			return "", fmt.Errorf("Invalid type '%s' of field '%s': only scalar types and associations can be pointers.", fieldType, name)
+		}
+		return "*" + inner, nil
+	}
+	if _, ok := fieldTypes[fieldType]; ok {
+		return fieldType, nil
+	}
+	if rest, ok := strings.CutPrefix(fieldType, "[]"); ok {
+		if element, err := normalizeFieldType(name, rest); err == nil {
+			if _, ok := postgresArrayTypes["[]"+element]; ok || associationType(element) {
+				return "[]" + element, nil
+			}
+		}
+		arrays := slices.Sorted(maps.Keys(postgresArrayTypes))
+		return "", fmt.Errorf("Invalid type '%s' of field '%s': slices are %s, []byte, or a slice of another model for a has-many association.", fieldType, name, strings.Join(arrays, ", "))
+	}
+	for known := range fieldTypes {
+		if strings.EqualFold(fieldType, known) {
+			return known, nil
+		}
+	}
+	if alias, ok := fieldTypeAliases[strings.ToLower(fieldType)]; ok {
+		return alias, nil
+	}
+	if associationType(fieldType) {
+		return fieldType, nil
+	}
+
+	message := fmt.Sprintf("Invalid type '%s' of field '%s': ", fieldType, name)
+	if suggestion := closestFieldType(fieldType); suggestion != "" {
+		message += fmt.Sprintf("did you mean %s? ", suggestion)
+	}
+	return "", fmt.Errorf("%sExpected string, int, uint, float64, bool, time.Time, uuid.UUID, decimal, json, another sized number type, a Postgres array type, point, geometry, or the name of another model.", message)
+}
+
+// associationType reports whether a type names another model of the package, such as User
+func associationType(fieldType string) bool {
+	if fieldType == "" || strings.Contains(fieldType, ".") || !unicode.IsUpper([]rune(fieldType)[0]) {
+		return false
+	}
+	for _, r := range fieldType {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// closestFieldType returns the known type or alias closest to a misspelt type, or "" when none is close
+func closestFieldType(fieldType string) string {
+	lower := strings.ToLower(fieldType)
+	best, bestDistance := "", len(lower)/3+1
+	candidates := append(slices.Sorted(maps.Keys(fieldTypes)), slices.Sorted(maps.Keys(fieldTypeAliases))...)
+	for _, candidate := range candidates {
+		if d := editDistance(lower, strings.ToLower(candidate)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	if alias, ok := fieldTypeAliases[best]; ok {
+		return alias
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// fieldTypeImports lists the packages the field types need imported, in the order they first appear
+func fieldTypeImports(types []string) []string {
+	var imports []string
+	for _, fieldType := range types {
+		path := fieldTypes[strings.TrimPrefix(fieldType, "*")]
+		if path != "" && !slices.Contains(imports, path) {
+			imports = append(imports, path)
+		}
+	}
+	return imports
+}
+
+// fieldColumnType returns the GORM type setting of the field types GORM cannot map to a column by itself, or ""
+func fieldColumnType(fieldType, dialect string) string {
+	switch strings.TrimPrefix(fieldType, "*") {
+	case "uuid.UUID":
+		if dialect == "postgres" {
+			return "type:uuid"
+		}
+		return "type:char(36)"
+	case "decimal.Decimal":
+		return "type:decimal(20,8)"
+	}
+	return ""
+}
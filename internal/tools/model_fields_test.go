@@ -0,0 +1,167 @@
+package tools
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fieldsRequest returns a request whose fields argument is fields
+func fieldsRequest(fields any) mcp.CallToolRequest {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"fields": fields}
+	return request
+}
+
+func TestRequestFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields any
+		want   []map[string]string
+	}{
+		{"absent", nil, nil},
+		{"blank string", "  ", nil},
+		{"json string", `[{"name":"Email","type":"string","validate":"required,email"}]`, []map[string]string{
+			{"name": "Email", "type": "string", "validate": "required,email"},
+		}},
+		{"array", []any{map[string]any{"name": "Price", "type": "float64", "check": "price >= 0"}}, []map[string]string{
+			{"name": "Price", "type": "float64", "check": "price >= 0"},
+		}},
+		{"nullable", []any{map[string]any{"name": "Nickname", "type": "string", "nullable": true}}, []map[string]string{
+			{"name": "Nickname", "type": "*string"},
+		}},
+		{"nullable string", `[{"name":"BirthDate","type":"datetime","nullable":"true"}]`, []map[string]string{
+			{"name": "BirthDate", "type": "*time.Time"},
+		}},
+		{"not nullable", `[{"name":"Active","type":"bool","nullable":false}]`, []map[string]string{
+			{"name": "Active", "type": "bool"},
+		}},
+		{"spaced names", `[{"name":"first name","type":"text"},{"name":"phone-number","type":"varchar"}]`, []map[string]string{
+			{"name": "FirstName", "type": "string"},
+			{"name": "PhoneNumber", "type": "string"},
+		}},
+		{"null settings", `[{"name":"Code","type":"string","gorm":null}]`, []map[string]string{
+			{"name": "Code", "type": "string"},
+		}},
+	}
+	for _, tt := range tests {
+		got, err := requestFields(fieldsRequest(tt.fields))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRequestFieldsInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields any
+		want   string
+	}{
+		{"malformed json", `[{"name":"Email"`, "Invalid 'fields' JSON format"},
+		{"not an array", 42.0, "expected an array of objects"},
+		{"not an object", []any{"Email"}, "Invalid field 1 in 'fields'"},
+		{"missing type", `[{"name":"Email"}]`, "'name' and 'type' are required"},
+		{"number setting", `[{"name":"Email","type":"string","gorm":3}]`, "Invalid 'gorm' of field 1"},
+		{"bool setting", `[{"name":"Email","type":"string","validate":true}]`, "Invalid 'validate' of field 1"},
+		{"bad nullable", `[{"name":"Email","type":"string","nullable":"maybe"}]`, "expected true or false, got 'maybe'"},
+		{"punctuation", `[{"name":"e-mail!","type":"string"}]`, "'!' cannot appear in a Go identifier"},
+		{"leading digit", `[{"name":"2fa","type":"bool"}]`, "Go identifiers cannot start with a digit"},
+		{"duplicate", `[{"name":"email","type":"string"},{"name":"Email","type":"string"}]`, "Duplicate field 'Email'"},
+		{"duplicate after spacing", `[{"name":"FirstName","type":"string"},{"name":"first name","type":"string"}]`, "Duplicate field 'FirstName'"},
+		{"quoted gorm", `[{"name":"Email","type":"string","gorm":"uniqueIndex\""}]`, "without quotes"},
+		{"nullable json", `[{"name":"Settings","type":"json","nullable":true}]`, "cannot be nullable"},
+		{"nullable array", `[{"name":"Tags","type":"[]string","nullable":true}]`, "cannot be nullable"},
+		{"unknown type", `[{"name":"PaidAt","type":"tiemstamp"}]`, "did you mean time.Time?"},
+	}
+	for _, tt := range tests {
+		_, err := requestFields(fieldsRequest(tt.fields))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got error %v, want one containing %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestNormalizeFieldType(t *testing.T) {
+	for fieldType, want := range map[string]string{
+		"string":          "string",
+		" int64 ":         "int64",
+		"Integer":         "int",
+		"BOOL":            "bool",
+		"datetime":        "time.Time",
+		"*timestamp":      "*time.Time",
+		"uuid":            "uuid.UUID",
+		"money":           "decimal.Decimal",
+		"jsonb":           "json",
+		"bytes":           "[]byte",
+		"[]string":        "[]string",
+		"[]text":          "[]string",
+		"[]Tag":           "[]Tag",
+		"Order":           "Order",
+		"*Order":          "*Order",
+		"decimal.Decimal": "decimal.Decimal",
+	} {
+		got, err := normalizeFieldType("Field", fieldType)
+		if err != nil {
+			t.Errorf("normalizeFieldType(%q): %v", fieldType, err)
+		} else if got != want {
+			t.Errorf("normalizeFieldType(%q) = %q, want %q", fieldType, got, want)
+		}
+	}
+
+	for fieldType, want := range map[string]string{
+		"*json":      "only scalar types and associations can be pointers",
+		"**int":      "only scalar types and associations can be pointers",
+		"[]float32":  "slices are []bool, []float64, []int, []int64, []string",
+		"[]order":    "slices are",
+		"strng":      "did you mean string?",
+		"bolean":     "did you mean bool?",
+		"pkg.Thing":  "Expected string, int",
+		"completely": "Invalid type 'completely' of field 'Field': Expected",
+	} {
+		if _, err := normalizeFieldType("Field", fieldType); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("normalizeFieldType(%q): got error %v, want one containing %q", fieldType, err, want)
+		}
+	}
+}
+
+func TestCheckIdentifier(t *testing.T) {
+	for _, name := range []string{"Product", "order_item", "line item", "sales-person", "Item2"} {
+		if err := checkIdentifier("model", name); err != nil {
+			t.Errorf("checkIdentifier(%q): %v", name, err)
+		}
+	}
+	for name, want := range map[string]string{
+		"order.item": "Invalid model name 'order.item': '.' cannot appear in a Go identifier; use letters, digits and underscores, such as OrderItem.",
+		"___":        "Invalid model name '___': expected letters, such as Email.",
+		"3d model":   "Invalid model name '3d model': Go identifiers cannot start with a digit; put a word first, such as Item3dModel.",
+	} {
+		if err := checkIdentifier("model", name); err == nil || err.Error() != want {
+			t.Errorf("checkIdentifier(%q) = %v, want %q", name, err, want)
+		}
+	}
+}
+
+func TestFieldTypeImports(t *testing.T) {
+	got := fieldTypeImports([]string{"string", "*time.Time", "decimal.Decimal", "time.Time", "uuid.UUID"})
+	want := []string{"time", "github.com/shopspring/decimal", "github.com/google/uuid"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fieldTypeImports = %v, want %v", got, want)
+	}
+	for _, tt := range []struct{ fieldType, dialect, want string }{
+		{"uuid.UUID", "postgres", "type:uuid"},
+		{"*uuid.UUID", "sqlite", "type:char(36)"},
+		{"decimal.Decimal", "mysql", "type:decimal(20,8)"},
+		{"string", "postgres", ""},
+	} {
+		if got := fieldColumnType(tt.fieldType, tt.dialect); got != tt.want {
+			t.Errorf("fieldColumnType(%q, %q) = %q, want %q", tt.fieldType, tt.dialect, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/templates"
+)
+
+// geoField is a model field stored in a PostGIS geometry column
+type geoField struct {
+	field    string // Go field name
+	jsonName string
+	column   string
+	goType   string // Location for points, Geometry otherwise
+}
+
+// modelGeoFields collects the fields declared with the point or geometry type
+func modelGeoFields(fields []map[string]string) []geoField {
+	var geoFields []geoField
+	for _, field := range fields {
+		var goType string
+		switch field["type"] {
+		case "point":
+			goType = "Location"
+		case "geometry":
+			goType = "Geometry"
+		default:
+			continue
+		}
+		geoFields = append(geoFields, geoField{
+			field:    naming.Pascal(field["name"]),
+			jsonName: field["name"],
+			column:   naming.Snake(field["name"]),
+			goType:   goType,
+		})
+	}
+	return geoFields
+}
+
+// findGeoField returns the geometry field named name, if any
+func findGeoField(geoFields []geoField, name string) (geoField, bool) {
+	for _, f := range geoFields {
+		if f.jsonName == name {
+			return f, true
+		}
+	}
+	return geoField{}, false
+}
+
+// gormSettings returns the gorm tag settings of the field: a GiST index for nearest-neighbour queries
+func (f geoField) gormSettings(table string) []string {
+	return []string{fmt.Sprintf("index:idx_%s_%s,type:gist", table, f.column)}
+}
+
+// geoRepositoryMethods returns the interface methods of the nearest-neighbour queries
+func geoRepositoryMethods(modelName string, geoFields []geoField) string {
+	var b strings.Builder
+	for _, f := range geoFields {
+		fmt.Fprintf(&b, "\tNearestBy%s(ctx context.Context, origin models.Location, radiusMeters float64, limit int) ([]models.%s, error)\n", f.field, modelName)
+	}
+	return b.String()
+}
+
+// geoFieldFiles renders the PostGIS value types, the repository queries and the nearby endpoint
+func geoFieldFiles(ctx context.Context, modelName, lowerModelName, appName string, access repositoryAccess, geoFields []geoField) ([]scaffoldFile, error) {
+	var methods strings.Builder
+	needsGeometry := false
+	for _, f := range geoFields {
+		needsGeometry = needsGeometry || f.goType == "Geometry"
+		fmt.Fprintf(&methods, `
+// NearestBy%[2]s returns up to limit records within radiusMeters of origin, closest first
+// The <-> ordering uses the GiST index on %[4]s; ST_DWithin on geography measures the radius in meters
+func (r *%[1]sRepositoryImpl) NearestBy%[2]s(ctx context.Context, origin models.Location, radiusMeters float64, limit int) ([]models.%[1]s, error) {
+	var %[3]s []models.%[1]s
+	err := %[5]s.WithContext(ctx)%[6]s.
+		Where("ST_DWithin(%[4]s::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)", origin.Lng, origin.Lat, radiusMeters).
+		Clauses(clause.OrderBy{Expression: clause.Expr{SQL: "%[4]s <-> ST_SetSRID(ST_MakePoint(?, ?), 4326)", Vars: []any{origin.Lng, origin.Lat}}}).
+		Limit(limit).
+		Find(&%[3]s).Error
+	return %[3]s, err
+}
+`, modelName, f.field, lowerModelName, f.column, access.DB, access.Read)
+	}
+
+	data := map[string]any{
+		"Model":       modelName,
+		"Lower":       lowerModelName,
+		"LowerPlural": naming.Plural(naming.Kebab(modelName)),
+		"App":         appName,
+		"Field":       geoFields[0].field,
+	}
+	types := templates.MustRender("model/location.go", nil)
+	if needsGeometry {
+		types += templates.MustRender("model/geometry.go", nil)
+	}
+	endpoint, err := renderFiles(ctx, geoEndpointFiles, data)
+	if err != nil {
+		return nil, err
+	}
+	return []scaffoldFile{
+		{Path: "internal/models/location.go", Language: "go", Content: types},
+		{
+			Path:     fmt.Sprintf("internal/repository/%s/geo_query.go", lowerModelName),
+			Language: "go",
+			Content: fmt.Sprintf(`package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+%s	"%s/internal/models"
+)
+%s`, access.Imports, appName, methods.String()),
+		},
+		endpoint[0],
+		endpoint[1],
+	}, nil
+}
+
+// geoEndpointFiles lists the service method and handler of the nearby endpoint
+var geoEndpointFiles = []fileFormat{
+	{Path: "internal/service/{{.Lower}}/nearby.go", Language: "go", Template: "model/nearby_service.go"},
+	{Path: "internal/controllers/{{.Lower}}/nearby.go", Language: "go", Template: "model/nearby_controller.go"},
+}
+
+// geoStep explains the PostGIS setup, the value types and the nearby endpoint; it is empty without geometry fields
+func geoStep(modelName, lowerModelName string, geoFields []geoField, files []scaffoldFile) string {
+	if len(geoFields) == 0 {
+		return ""
+	}
+
+	var dtoFields strings.Builder
+	for _, f := range geoFields {
+		fmt.Fprintf(&dtoFields, "\t%s models.%s `json:\"%s\"`\n", f.field, f.goType, f.jsonName)
+	}
+
+	return fmt.Sprintf(`
+   Geometry fields are stored in PostGIS columns with SRID 4326 (longitude/latitude). Enable the extension once per database before migrating:
+`+"```sql"+`
+CREATE EXTENSION IF NOT EXISTS postgis;
+`+"```"+`
+
+   Create the file at `+"`%[3]s`"+` with the value types, which read and write PostGIS columns:
+`+"```go"+`
+%[4]s`+"```"+`
+
+   Create the file at `+"`%[5]s`"+` with the nearest-neighbour queries; the methods are declared in `+"`%[1]sRepository`"+` below:
+`+"```go"+`
+%[6]s`+"```"+`
+
+   Create the file at `+"`%[7]s`"+`, and add `+"`Nearby(ctx context.Context, origin models.Location, radiusMeters float64, limit int) ([]dto.%[1]sResponse, error)`"+` to `+"`%[1]sService`"+`:
+`+"```go"+`
+%[8]s`+"```"+`
+
+   Create the file at `+"`%[9]s`"+`, add `+"`Nearby%[1]s(c echo.Context) error`"+` to `+"`%[1]sController`"+`, and register the route before `+"`/%[12]s/:id`"+`:
+`+"```go"+`
+%[10]s`+"```"+`
+
+   `+"```go"+`
+   e.GET("/%[12]s/nearby", %[2]sController.Nearby%[1]s) // ?lat=52.52&lng=13.40&radius=1000&limit=20
+   `+"```"+`
+
+   The DTOs carry the value types as they are:
+`+"```go"+`
+%[11]s`+"```"+`
+`, modelName, lowerModelName,
+		files[0].Path, files[0].Content,
+		files[1].Path, files[1].Content,
+		files[2].Path, files[2].Content,
+		files[3].Path, files[3].Content,
+		dtoFields.String(), naming.Plural(naming.Kebab(modelName)))
+}
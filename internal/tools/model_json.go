@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"mcpgo/internal/naming"
+)
+
+// jsonField is a model field stored in a JSON column
+type jsonField struct {
+	field      string // Go field name
+	jsonName   string
+	column     string
+	structName string // typed document stored with the GORM json serializer, empty for datatypes.JSON
+}
+
+// modelJSONFields collects the fields declared with the json type
+func modelJSONFields(fields []map[string]string) []jsonField {
+	var jsonFields []jsonField
+	for _, field := range fields {
+		if field["type"] != "json" {
+			continue
+		}
+		jsonFields = append(jsonFields, jsonField{
+			field:      naming.Pascal(field["name"]),
+			jsonName:   field["name"],
+			column:     naming.Snake(field["name"]),
+			structName: naming.Pascal(field["struct"]),
+		})
+	}
+	return jsonFields
+}
+
+// findJSONField returns the JSON field named name, if any
+func findJSONField(jsonFields []jsonField, name string) (jsonField, bool) {
+	for _, f := range jsonFields {
+		if f.jsonName == name {
+			return f, true
+		}
+	}
+	return jsonField{}, false
+}
+
+// goType returns the Go type of the field in the model
+func (f jsonField) goType() string {
+	if f.structName != "" {
+		return f.structName
+	}
+	return "datatypes.JSON"
+}
+
+// gormSettings returns the gorm tag settings of the field; Postgres gets an explicit jsonb column
+func (f jsonField) gormSettings(dialect string) []string {
+	var settings []string
+	if dialect == "postgres" {
+		settings = append(settings, "type:jsonb")
+	}
+	if f.structName != "" {
+		settings = append(settings, "serializer:json")
+	}
+	return settings
+}
+
+// usesDatatypes reports whether any field needs the gorm.io/datatypes import in the model
+func usesDatatypes(jsonFields []jsonField) bool {
+	for _, f := range jsonFields {
+		if f.structName == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonDocumentTypes renders the typed documents stored in JSON columns, appended to the model file
+func jsonDocumentTypes(jsonFields []jsonField) string {
+	var b strings.Builder
+	for _, f := range jsonFields {
+		if f.structName == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\n// %s is stored as JSON in the %s column\ntype %s struct {\n\t// Add the document's fields here, e.g.\n\t// Name string `json:\"name\"`\n}\n", f.structName, f.column, f.structName)
+	}
+	return b.String()
+}
+
+// jsonRepositoryMethods returns the interface methods querying into the JSON columns
+func jsonRepositoryMethods(modelName string, jsonFields []jsonField) string {
+	var b strings.Builder
+	for _, f := range jsonFields {
+		fmt.Fprintf(&b, "\tFindBy%s(ctx context.Context, value any, keys ...string) ([]models.%s, error)\n", f.field, modelName)
+	}
+	return b.String()
+}
+
+// jsonQueryFile renders the repository methods querying into the JSON columns
+func jsonQueryFile(modelName, lowerModelName, appName string, access repositoryAccess, jsonFields []jsonField) scaffoldFile {
+	var methods strings.Builder
+	for _, f := range jsonFields {
+		fmt.Fprintf(&methods, `
+// FindBy%[2]s returns the records whose %[4]s document holds value at the key path, e.g. FindBy%[2]s(ctx, "value", "parent", "child")
+func (r *%[1]sRepositoryImpl) FindBy%[2]s(ctx context.Context, value any, keys ...string) ([]models.%[1]s, error) {
+	var %[3]s []models.%[1]s
+	err := %[5]s.WithContext(ctx)%[6]s.Where(datatypes.JSONQuery("%[4]s").Equals(value, keys...)).Find(&%[3]s).Error
+	return %[3]s, err
+}
+`, modelName, f.field, lowerModelName, f.column, access.DB, access.Read)
+	}
+
+	return scaffoldFile{
+		Path:     fmt.Sprintf("internal/repository/%s/json_query.go", lowerModelName),
+		Language: "go",
+		Content: fmt.Sprintf(`package repository
+
+import (
+	"context"
+
+	"gorm.io/datatypes"
+%s	"%s/internal/models"
+)
+%s`, access.Imports, appName, methods.String()),
+	}
+}
+
+// jsonStep explains the JSON columns, their DTO handling and the repository queries; it is empty without JSON fields
+func jsonStep(modelName, lowerModelName string, jsonFields []jsonField, files []scaffoldFile) string {
+	if len(jsonFields) == 0 {
+		return ""
+	}
+
+	var dtoFields, mapping strings.Builder
+	for _, f := range jsonFields {
+		if f.structName == "" {
+			fmt.Fprintf(&dtoFields, "\t%s json.RawMessage `json:\"%s,omitempty\"`\n", f.field, f.jsonName)
+			fmt.Fprintf(&mapping, "\tmodel.%[1]s = datatypes.JSON(req.%[1]s)\n", f.field)
+			continue
+		}
+		fmt.Fprintf(&dtoFields, "\t%s *models.%s `json:\"%s,omitempty\"`\n", f.field, f.structName, f.jsonName)
+		fmt.Fprintf(&mapping, "\tif req.%[1]s != nil {\n\t\tmodel.%[1]s = *req.%[1]s\n\t}\n", f.field)
+	}
+
+	return fmt.Sprintf(`
+   JSON fields are stored in JSON columns (JSONB on Postgres). Run `+"`go get gorm.io/datatypes`"+` for the column types and queries.
+
+   In `+"`internal/dto/%[2]s/dto.go`"+`, accept the documents in the request DTOs and copy them in the service mapping helpers:
+`+"```go"+`
+// Create%[1]sRequest and Update%[1]sRequest
+%[3]s
+// createDTOToModel and the service Update method
+%[4]s`+"```"+`
+
+   Create the file at `+"`%[5]s`"+` to query inside the documents; the methods are declared in `+"`%[1]sRepository`"+` below:
+`+"```go"+`
+%[6]s`+"```"+`
+`, modelName, lowerModelName, dtoFields.String(), mapping.String(), files[0].Path, files[0].Content)
+}
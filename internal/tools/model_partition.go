@@ -0,0 +1,243 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/templates"
+)
+
+// The partitioning options of produce_model_boilerplate, for Postgres tables expected to grow very large
+var (
+	partitionByOption = mcp.WithString("partition_by",
+		mcp.Description("Partition the Postgres table of the model: date splits it into ranges of a timestamp column, with a job creating upcoming partitions; tenant splits it into hash partitions of a tenant column. Queries filtering on the partition key only scan the matching partitions. Requires dialect=postgres. Defaults to no partitioning."),
+		mcp.Enum("date", "tenant"),
+	)
+	partitionColumnOption = mcp.WithString("partition_column",
+		mcp.Description("Column the table is partitioned by. Defaults to created_at with partition_by=date and tenant_id with partition_by=tenant; it must be a column of the model."),
+	)
+	partitionIntervalOption = mcp.WithString("partition_interval",
+		mcp.Description("With partition_by=date, the span of one partition: day, month or year. Defaults to month."),
+		mcp.Enum("day", "month", "year"),
+	)
+	partitionCountOption = mcp.WithNumber("partition_count",
+		mcp.Description("With partition_by=tenant, the number of hash partitions. Defaults to 8."),
+	)
+)
+
+// tablePartitioning is how the table of a model is split into partitions
+type tablePartitioning struct {
+	by       string // date or tenant
+	table    string
+	column   string // partition key, e.g. created_at
+	field    string // Go field name of the key, e.g. CreatedAt
+	goType   string // Go type of the key
+	interval string // date: day, month or year
+	count    int    // tenant: number of hash partitions
+}
+
+// modelPartitioning validates the partitioning options against the columns of the model; it returns nil without partition_by
+func modelPartitioning(request mcp.CallToolRequest, dialect, table string, base modelBase, fields []map[string]string) (*tablePartitioning, error) {
+	by := request.GetString("partition_by", "")
+	if by == "" {
+		return nil, nil
+	}
+	if by != "date" && by != "tenant" {
+		return nil, fmt.Errorf("Invalid 'partition_by': expected date or tenant, got '%s'.", by)
+	}
+	if dialect != "postgres" {
+		return nil, errors.New("Table partitioning is a Postgres feature. Call the tool again with dialect=postgres, or without partition_by.")
+	}
+
+	p := &tablePartitioning{by: by, table: table, interval: request.GetString("partition_interval", "month"), count: 8}
+	if by == "date" {
+		p.column = request.GetString("partition_column", "created_at")
+		if p.interval != "day" && p.interval != "month" && p.interval != "year" {
+			return nil, fmt.Errorf("Invalid 'partition_interval': expected day, month or year, got '%s'.", p.interval)
+		}
+	} else {
+		p.column = request.GetString("partition_column", "tenant_id")
+		count := request.GetFloat("partition_count", 8)
+		if count < 2 || count != float64(int(count)) {
+			return nil, fmt.Errorf("Invalid 'partition_count': expected a whole number of at least 2, got %v.", count)
+		}
+		p.count = int(count)
+	}
+
+	// The key is either a timestamp of the base struct or a declared field
+	if field, ok := map[string]string{"created_at": "CreatedAt", "updated_at": "UpdatedAt"}[p.column]; ok && base.timestamps {
+		p.field, p.goType = field, "time.Time"
+	}
+	for _, field := range fields {
+		if naming.Snake(field["name"]) == p.column {
+			p.field, p.goType = naming.Pascal(field["name"]), field["type"]
+		}
+	}
+	switch {
+	case p.field == "":
+		return nil, fmt.Errorf("Invalid 'partition_column': the model has no column '%s'. Add a field for it, or set partition_column to a column of the model.", p.column)
+	case by == "date" && p.goType != "time.Time":
+		return nil, fmt.Errorf("Invalid 'partition_column': partition_by=date needs a time.Time column, but '%s' is %s.", p.column, p.goType)
+	}
+	return p, nil
+}
+
+// partitionRepositoryMethods returns the interface methods querying a single partition
+func partitionRepositoryMethods(modelName string, p *tablePartitioning) string {
+	if p == nil {
+		return ""
+	}
+	if p.by == "date" {
+		return fmt.Sprintf("\tFind%[1]sBetween(ctx context.Context, from, to time.Time, filters map[string]interface{}) ([]models.%[2]s, error)\n", p.field, modelName)
+	}
+	return fmt.Sprintf("\tFindBy%[1]s(ctx context.Context, %[2]s %[3]s, filters map[string]interface{}) ([]models.%[4]s, error)\n\tGetBy%[1]sAndID(ctx context.Context, %[2]s %[3]s, id uint) (*models.%[4]s, error)\n", p.field, naming.Camel(p.field), p.goType, modelName)
+}
+
+// partitionFiles renders the migrations converting the table, the repository methods that prune partitions
+// and, for date partitions, the job creating upcoming partitions
+func partitionFiles(modelName, lowerModelName, appName string, access repositoryAccess, base modelBase, p *tablePartitioning) []scaffoldFile {
+	var strategy, partitions string
+	switch p.by {
+	case "date":
+		strategy = "RANGE"
+		partitions = fmt.Sprintf("-- Rows outside the partitions created by database.StartPartitionMaintenance, such as existing ones, go to the default partition\nCREATE TABLE %[1]s_default PARTITION OF %[1]s DEFAULT;\n", p.table)
+	case "tenant":
+		strategy = "HASH"
+		var b strings.Builder
+		for i := 0; i < p.count; i++ {
+			fmt.Fprintf(&b, "CREATE TABLE %[1]s_p%[2]d PARTITION OF %[1]s FOR VALUES WITH (MODULUS %[3]d, REMAINDER %[2]d);\n", p.table, i, p.count)
+		}
+		partitions = b.String()
+	}
+	var indexes string
+	if base.softDelete {
+		indexes = fmt.Sprintf("CREATE INDEX idx_%[1]s_deleted_at ON %[1]s (deleted_at);\n", p.table)
+	}
+
+	up := fmt.Sprintf(`-- Converts %[1]s, as created by AutoMigrate, into a table partitioned by %[2]s (%[3]s).
+-- Every row is copied, so run it during a maintenance window.
+BEGIN;
+ALTER TABLE %[1]s RENAME TO %[1]s_unpartitioned;
+CREATE TABLE %[1]s (LIKE %[1]s_unpartitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS) PARTITION BY %[2]s (%[3]s);
+-- The primary key of a partitioned table must include the partition key
+ALTER TABLE %[1]s ALTER COLUMN %[3]s SET NOT NULL;
+ALTER TABLE %[1]s ADD PRIMARY KEY (id, %[3]s);
+ALTER SEQUENCE %[1]s_id_seq OWNED BY %[1]s.id;
+%[4]sINSERT INTO %[1]s SELECT * FROM %[1]s_unpartitioned;
+DROP TABLE %[1]s_unpartitioned;
+%[5]sCOMMIT;
+`, p.table, strategy, p.column, partitions, indexes)
+	down := fmt.Sprintf(`-- Converts %[1]s back into a regular table; every row is copied.
+BEGIN;
+ALTER TABLE %[1]s RENAME TO %[1]s_partitioned;
+CREATE TABLE %[1]s (LIKE %[1]s_partitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS);
+ALTER TABLE %[1]s ADD PRIMARY KEY (id);
+ALTER SEQUENCE %[1]s_id_seq OWNED BY %[1]s.id;
+INSERT INTO %[1]s SELECT * FROM %[1]s_partitioned;
+DROP TABLE %[1]s_partitioned;
+%[2]sCOMMIT;
+`, p.table, indexes)
+
+	var methods string
+	imports := "\t\"context\"\n"
+	if p.by == "date" {
+		imports += "\t\"time\"\n"
+		methods = fmt.Sprintf(`
+// Find%[2]sBetween returns the records whose %[4]s is in [from, to), matching the filters like Get
+// Bounding %[4]s lets Postgres scan only the partitions covering the range
+func (r *%[1]sRepositoryImpl) Find%[2]sBetween(ctx context.Context, from, to time.Time, filters map[string]interface{}) ([]models.%[1]s, error) {
+	var %[3]s []models.%[1]s
+	err := %[5]s.WithContext(ctx)%[6]s.Where("%[4]s >= ? AND %[4]s < ?", from, to).Where(filters).Find(&%[3]s).Error
+	return %[3]s, err
+}
+`, modelName, p.field, lowerModelName, p.column, access.DB, access.Read)
+	} else {
+		if p.goType == "time.Time" {
+			imports += "\t\"time\"\n"
+		}
+		methods = fmt.Sprintf(`
+// FindBy%[2]s returns the records of one tenant, matching the filters like Get
+// Filtering on %[4]s lets Postgres scan only the partition holding the tenant
+func (r *%[1]sRepositoryImpl) FindBy%[2]s(ctx context.Context, %[7]s %[8]s, filters map[string]interface{}) ([]models.%[1]s, error) {
+	var %[3]s []models.%[1]s
+	err := %[5]s.WithContext(ctx)%[6]s.Where("%[4]s = ?", %[7]s).Where(filters).Find(&%[3]s).Error
+	return %[3]s, err
+}
+
+// GetBy%[2]sAndID returns one record of a tenant, reading a single partition instead of every one
+func (r *%[1]sRepositoryImpl) GetBy%[2]sAndID(ctx context.Context, %[7]s %[8]s, id uint) (*models.%[1]s, error) {
+	var %[3]s models.%[1]s
+	err := %[5]s.WithContext(ctx)%[6]s.Where("%[4]s = ?", %[7]s).First(&%[3]s, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &%[3]s, nil
+}
+`, modelName, p.field, lowerModelName, p.column, access.DB, access.Read, naming.Camel(p.field), p.goType)
+	}
+
+	files := []scaffoldFile{
+		{Path: fmt.Sprintf("migrations/%s_partitioning.up.sql", p.table), Language: "sql", Content: up},
+		{Path: fmt.Sprintf("migrations/%s_partitioning.down.sql", p.table), Language: "sql", Content: down},
+		{Path: fmt.Sprintf("internal/repository/%s/partition.go", lowerModelName), Language: "go", Content: fmt.Sprintf(`package repository
+
+import (
+%s
+%s	"%s/internal/models"
+)
+%s`, imports, access.Imports, appName, methods)},
+	}
+	if p.by == "date" {
+		files = append(files, scaffoldFile{Path: "internal/database/partitions.go", Language: "go", Content: templates.MustRender("model/partitions.go", nil)})
+	}
+	return files
+}
+
+// partitionIntervals maps partition_interval to the database.Interval constant of the maintenance job
+var partitionIntervals = map[string]string{"day": "database.Daily", "month": "database.Monthly", "year": "database.Yearly"}
+
+// partitionStep explains the migrations, the pruning repository methods and the partition job; it is empty without partitioning
+func partitionStep(modelName string, p *tablePartitioning, files []scaffoldFile) string {
+	if p == nil {
+		return ""
+	}
+
+	var job string
+	if p.by == "date" {
+		job = fmt.Sprintf(`
+   Create or update the file at `+"`%[1]s`"+`, and start the job creating upcoming partitions in `+"`cmd/web/main.go`"+` after the migration has run:
+`+"```go"+`
+%[2]s`+"```"+`
+
+   `+"```go"+`
+   database.StartPartitionMaintenance(context.Background(), db, 24*time.Hour,
+   	database.PartitionedTable{Name: %[3]q, Interval: %[4]s, Ahead: 3},
+   )
+   `+"```"+`
+`, files[3].Path, files[3].Content, p.table, partitionIntervals[p.interval])
+	}
+
+	kind := "range"
+	if p.by == "tenant" {
+		kind = "hash"
+	}
+	return fmt.Sprintf(`
+   The table is partitioned by %[1]s of `+"`%[2]s`"+`. `+"`AutoMigrate`"+` cannot create partitioned tables: let it create `+"`%[3]s`"+` once, then convert it with these migrations. Afterwards, `+"`AutoMigrate`"+` still adds new columns, which Postgres propagates to every partition.
+
+   `+"`%[4]s`"+`:
+`+"```sql"+`
+%[5]s`+"```"+`
+
+   `+"`%[6]s`"+`:
+`+"```sql"+`
+%[7]s`+"```"+`
+
+   Create the file at `+"`%[8]s`"+`; its methods filter on the partition key so that Postgres prunes the other partitions, and are declared in `+"`%[10]sRepository`"+` below:
+`+"```go"+`
+%[9]s`+"```"+`
+%[11]s`, kind, p.column, p.table, files[0].Path, files[0].Content, files[1].Path, files[1].Content, files[2].Path, files[2].Content, modelName, job)
+}
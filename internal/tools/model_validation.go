@@ -0,0 +1,236 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"mcpgo/internal/codegen"
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+	"mcpgo/internal/templates"
+)
+
+// validationRule matches a single validator rule such as required, email or max=100
+// Parameters cannot hold quotes or backticks, which would end the struct tag they are rendered in
+var validationRule = regexp.MustCompile("^[a-z][a-z0-9_]*(=[^,|=\"`]+)?$")
+
+// fieldValidation holds the validation rules declared on a model field
+type fieldValidation struct {
+	field     string // Go field name
+	fieldType string
+	jsonName  string
+	rules     []string // validator rules, e.g. required, min=3
+	pattern   string   // regular expression, checked with the regexp rule
+}
+
+// fieldValidations collects the validation rules of the fields, rejecting malformed rules and patterns
+func fieldValidations(fields []state.Field) ([]fieldValidation, error) {
+	var validations []fieldValidation
+	for _, field := range fields {
+		if field.Validate == "" && field.Pattern == "" {
+			continue
+		}
+		v := fieldValidation{field: naming.Pascal(field.Name), fieldType: field.Type, jsonName: field.Name, pattern: field.Pattern}
+		for _, rule := range strings.Split(field.Validate, ",") {
+			rule = strings.TrimSpace(rule)
+			if rule == "" {
+				continue
+			}
+			if !validationRule.MatchString(rule) {
+				return nil, fmt.Errorf("Invalid 'validate' rule '%s' on field '%s': expected a validator rule such as required, email, min=3 or max=100.", rule, field.Name)
+			}
+			v.rules = append(v.rules, rule)
+		}
+		if strings.Contains(v.pattern, "`") {
+			return nil, fmt.Errorf("Invalid 'pattern' on field '%s': backticks cannot appear in a struct tag.", field.Name)
+		}
+		if v.pattern != "" {
+			if _, err := regexp.Compile(v.pattern); err != nil {
+				return nil, fmt.Errorf("Invalid 'pattern' on field '%s': %v", field.Name, err)
+			}
+		}
+		validations = append(validations, v)
+	}
+	return validations, nil
+}
+
+// findValidation returns the validation rules of the field named name, if any
+func findValidation(validations []fieldValidation, name string) (fieldValidation, bool) {
+	for _, v := range validations {
+		if v.jsonName == name {
+			return v, true
+		}
+	}
+	return fieldValidation{}, false
+}
+
+// recordedFields returns the fields recorded for a model of appName, or nil when the model is unknown
+func recordedFields(ctx context.Context, appName, modelName string) []state.Field {
+	if project, ok := state.From(ctx).Project(appName); ok {
+		for _, model := range project.Models {
+			if model.Name == modelName {
+				return model.Fields
+			}
+		}
+	}
+	return nil
+}
+
+// recordedFieldNames lists the names of the fields, as the requests refer to them
+func recordedFieldNames(fields []state.Field) []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// rule returns the value of a rule such as max=100, and whether the field declares it
+func (v fieldValidation) rule(name string) (string, bool) {
+	for _, rule := range v.rules {
+		key, value, _ := strings.Cut(rule, "=")
+		if key == name {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// validateRules returns the validator rules of the field; update requests skip absent fields instead of requiring them
+func (v fieldValidation) validateRules(update bool) string {
+	var rules []string
+	if update {
+		rules = append(rules, "omitempty")
+	}
+	for _, rule := range v.rules {
+		if update && rule == "required" {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if v.pattern != "" {
+		// validator splits rules on commas and pipes, which it accepts hex-escaped inside parameters
+		pattern := strings.NewReplacer(",", "0x2C", "|", "0x7C").Replace(v.pattern)
+		rules = append(rules, "regexp="+pattern)
+	}
+	return strings.Join(rules, ",")
+}
+
+// scalarFieldType reports whether the DTOs can carry the field type as it is; JSON, array and geometry fields have their own steps
+func scalarFieldType(fieldType string) bool {
+	switch fieldType {
+	case "json", "point", "geometry":
+		return false
+	}
+	return !strings.HasPrefix(fieldType, "[]")
+}
+
+// requestDTOFields renders the create and update request fields with their validate tags, aligned as gofmt would
+func requestDTOFields(fields []state.Field, validations []fieldValidation) (create, update string) {
+	createFields := []codegen.Field{}
+	updateFields := []codegen.Field{{Name: "ID", Type: "uint", Tags: []codegen.Tag{{Key: "json", Value: "id"}, {Key: "validate", Value: "required"}}}}
+	for _, field := range fields {
+		if !scalarFieldType(field.Type) {
+			continue
+		}
+		name := naming.Pascal(field.Name)
+		createTags := []codegen.Tag{{Key: "json", Value: field.Name}}
+		updateTags := []codegen.Tag{{Key: "json", Value: field.Name + ",omitempty"}}
+		if v, ok := findValidation(validations, field.Name); ok {
+			createTags = append(createTags, codegen.Tag{Key: "validate", Value: v.validateRules(false)})
+			updateTags = append(updateTags, codegen.Tag{Key: "validate", Value: v.validateRules(true)})
+		}
+		createFields = append(createFields, codegen.Field{Name: name, Type: field.Type, Tags: createTags})
+		updateFields = append(updateFields, codegen.Field{Name: name, Type: "*" + strings.TrimPrefix(field.Type, "*"), Tags: updateTags})
+	}
+	// The fields were checked when the model was scaffolded, so they always render
+	create, _ = codegen.Fields(createFields)
+	update, _ = codegen.Fields(updateFields)
+	return create, update
+}
+
+// formInputs renders the templ form fields of the validated fields, mirroring the rules as HTML attributes
+func formInputs(validations []fieldValidation) string {
+	var b strings.Builder
+	for _, v := range validations {
+		inputType := "input.TypeText"
+		value := "item." + v.field
+		var attributes []string
+		numeric := strings.HasPrefix(v.fieldType, "int") || strings.HasPrefix(v.fieldType, "uint") || strings.HasPrefix(v.fieldType, "float")
+		switch {
+		case numeric:
+			inputType = "input.TypeNumber"
+			value = fmt.Sprintf("fmt.Sprint(item.%s)", v.field)
+			if strings.HasPrefix(v.fieldType, "float") {
+				attributes = append(attributes, `"step": "any"`)
+			}
+		case v.fieldType != "string":
+			continue // booleans and times use checkboxes and date pickers
+		}
+		if _, ok := v.rule("email"); ok {
+			inputType = "input.TypeEmail"
+		}
+		if _, ok := v.rule("url"); ok {
+			inputType = "input.TypeURL"
+		}
+
+		lower, upper := "minlength", "maxlength"
+		if numeric {
+			lower, upper = "min", "max"
+		}
+		if value, ok := v.rule("len"); ok {
+			attributes = append(attributes, fmt.Sprintf("%q: %q", lower, value), fmt.Sprintf("%q: %q", upper, value))
+		}
+		for _, r := range []struct{ rule, attribute string }{{"min", lower}, {"gte", lower}, {"max", upper}, {"lte", upper}} {
+			if value, ok := v.rule(r.rule); ok {
+				attributes = append(attributes, fmt.Sprintf("%q: %q", r.attribute, value))
+			}
+		}
+		if v.pattern != "" {
+			attributes = append(attributes, fmt.Sprintf(`"pattern": %s`, strconv.Quote(v.pattern)))
+		}
+
+		fmt.Fprintf(&b, "<div class=\"space-y-2\">\n\t<label for=\"%[1]s\" class=\"block text-sm font-medium\">%[2]s</label>\n\t@input.Input(input.Props{\n\t\tType: %[3]s,\n\t\tId: \"%[1]s\",\n\t\tName: \"%[1]s\",\n\t\tValue: %[4]s,\n", v.jsonName, v.field, inputType, value)
+		if _, ok := v.rule("required"); ok {
+			b.WriteString("\t\tRequired: true,\n")
+		}
+		if len(attributes) > 0 {
+			fmt.Fprintf(&b, "\t\tAttributes: templ.Attributes{%s},\n", strings.Join(attributes, ", "))
+		}
+		fmt.Fprintf(&b, "\t})\n\tif errorMsg, ok := errors[\"%s\"]; ok {\n\t\t<p class=\"text-destructive text-sm mt-1\">{ errorMsg }</p>\n\t}\n</div>\n", v.jsonName)
+	}
+	return b.String()
+}
+
+// validationFile renders the echo validator registering the regexp rule and mapping failures to field messages
+func validationFile() scaffoldFile {
+	return scaffoldFile{Path: "internal/validation/validation.go", Language: "go", Content: templates.MustRender("model/validation.go", nil)}
+}
+
+// validationStep explains the validator setup and the generated DTO fields; it is empty without validation rules
+func validationStep(modelName, lowerModelName string, fields []state.Field, validations []fieldValidation, files []scaffoldFile) string {
+	if len(validations) == 0 {
+		return ""
+	}
+
+	create, update := requestDTOFields(fields, validations)
+	return fmt.Sprintf(`
+   The fields declare validation rules. Run `+"`go get github.com/go-playground/validator/v10`"+`, and create the file at `+"`%[3]s`"+`:
+`+"```go"+`
+%[4]s`+"```"+`
+
+   Register it in `+"`cmd/web/main.go`"+` with `+"`e.Validator = validation.New()`"+`, and call `+"`c.Validate(req)`"+` right after `+"`c.Bind(req)`"+` in the create and update handlers. The API handlers return the error as is (400 with a message per field); the HTML handlers re-render the form with `+"`validation.FieldErrors(err)`"+`.
+
+   The service tool generates these request fields in `+"`internal/dto/%[2]s/dto.go`"+`:
+`+"```go"+`
+type Create%[1]sRequest struct {
+%[5]s}
+
+type Update%[1]sRequest struct {
+%[6]s}
+`+"```"+`
+`, modelName, lowerModelName, files[0].Path, files[0].Content, create, update)
+}
@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"mcpgo/internal/state"
+)
+
+func TestFieldValidations(t *testing.T) {
+	validations, err := fieldValidations([]state.Field{
+		{Name: "Name", Type: "string", Validate: "required, max=100,"},
+		{Name: "Active", Type: "bool"},
+		{Name: "Code", Type: "string", Pattern: `^[A-Z]{3}$`},
+		{Name: "email", Type: "string", Validate: "omitempty,email"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []fieldValidation{
+		{field: "Name", fieldType: "string", jsonName: "Name", rules: []string{"required", "max=100"}},
+		{field: "Code", fieldType: "string", jsonName: "Code", pattern: `^[A-Z]{3}$`},
+		{field: "Email", fieldType: "string", jsonName: "email", rules: []string{"omitempty", "email"}},
+	}
+	if !reflect.DeepEqual(validations, want) {
+		t.Errorf("fieldValidations = %+v, want %+v", validations, want)
+	}
+
+	for _, tt := range []struct {
+		field state.Field
+		want  string
+	}{
+		{state.Field{Name: "Name", Validate: "Required"}, "Invalid 'validate' rule 'Required' on field 'Name'"},
+		{state.Field{Name: "Name", Validate: "min=3|max=5"}, "Invalid 'validate' rule 'min=3|max=5'"},
+		{state.Field{Name: "Name", Validate: "oneof=a=b"}, "Invalid 'validate' rule 'oneof=a=b'"},
+		{state.Field{Name: "Name", Validate: "max=100`"}, "Invalid 'validate' rule 'max=100`'"},
+		{state.Field{Name: "Name", Validate: `oneof="a b"`}, "Invalid 'validate' rule 'oneof=\"a b\"'"},
+		{state.Field{Name: "Code", Pattern: "^`x`$"}, "Invalid 'pattern' on field 'Code': backticks cannot appear in a struct tag."},
+		{state.Field{Name: "Code", Pattern: "^[A-Z$"}, "Invalid 'pattern' on field 'Code': error parsing regexp"},
+	} {
+		if _, err := fieldValidations([]state.Field{tt.field}); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("fieldValidations(%+v): got error %v, want one containing %q", tt.field, err, tt.want)
+		}
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	tests := []struct {
+		name           string
+		v              fieldValidation
+		create, update string
+	}{
+		{"rules", fieldValidation{rules: []string{"required", "min=3", "max=100"}}, "required,min=3,max=100", "omitempty,min=3,max=100"},
+		{"optional", fieldValidation{rules: []string{"email"}}, "email", "omitempty,email"},
+		{"pattern", fieldValidation{rules: []string{"required"}, pattern: `^[a-z]+$`}, "required,regexp=^[a-z]+$", "omitempty,regexp=^[a-z]+$"},
+		{"escaped pattern", fieldValidation{pattern: `^(a|b){1,3}$`}, "regexp=^(a0x7Cb){10x2C3}$", "omitempty,regexp=^(a0x7Cb){10x2C3}$"},
+	}
+	for _, tt := range tests {
+		if got := tt.v.validateRules(false); got != tt.create {
+			t.Errorf("%s: create rules = %q, want %q", tt.name, got, tt.create)
+		}
+		if got := tt.v.validateRules(true); got != tt.update {
+			t.Errorf("%s: update rules = %q, want %q", tt.name, got, tt.update)
+		}
+	}
+}
+
+func TestRequestDTOFields(t *testing.T) {
+	fields := []state.Field{
+		{Name: "Name", Type: "string", Validate: "required,max=100"},
+		{Name: "Nickname", Type: "*string"},
+		{Name: "Meta", Type: "json"},
+		{Name: "Tags", Type: "[]string"},
+	}
+	validations, err := fieldValidations(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	create, update := requestDTOFields(fields, validations)
+	wantCreate := "\tName     string  `json:\"Name\" validate:\"required,max=100\"`\n" +
+		"\tNickname *string `json:\"Nickname\"`\n"
+	wantUpdate := "\tID       uint    `json:\"id\" validate:\"required\"`\n" +
+		"\tName     *string `json:\"Name,omitempty\" validate:\"omitempty,max=100\"`\n" +
+		"\tNickname *string `json:\"Nickname,omitempty\"`\n"
+	if create != wantCreate {
+		t.Errorf("create request fields:\n%s\nwant\n%s", create, wantCreate)
+	}
+	if update != wantUpdate {
+		t.Errorf("update request fields:\n%s\nwant\n%s", update, wantUpdate)
+	}
+}
+
+func TestFormInputs(t *testing.T) {
+	validations, err := fieldValidations([]state.Field{
+		{Name: "email", Type: "string", Validate: "required,email,max=255"},
+		{Name: "Code", Type: "string", Validate: "len=3", Pattern: `^[A-Z]+$`},
+		{Name: "Price", Type: "float64", Validate: "gte=0"},
+		{Name: "Active", Type: "bool", Validate: "required"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := formInputs(validations)
+	for _, want := range []string{
+		"Type: input.TypeEmail,\n\t\tId: \"email\",",
+		"\t\tRequired: true,\n\t\tAttributes: templ.Attributes{\"maxlength\": \"255\"},",
+		`templ.Attributes{"minlength": "3", "maxlength": "3", "pattern": "^[A-Z]+$"}`,
+		"Type: input.TypeNumber,\n\t\tId: \"Price\",\n\t\tName: \"Price\",\n\t\tValue: fmt.Sprint(item.Price),",
+		`templ.Attributes{"step": "any", "min": "0"}`,
+	} {
+		if !strings.Contains(inputs, want) {
+			t.Errorf("the form inputs lack %q:\n%s", want, inputs)
+		}
+	}
+	if strings.Contains(inputs, `"Active"`) {
+		t.Errorf("the form inputs render the boolean field as a text input:\n%s", inputs)
+	}
+}
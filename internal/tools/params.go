@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// missingParameter is an argument a tool call lacks, recorded for ElicitationMiddleware to ask the user for
+type missingParameter struct {
+	Name        string
+	Description string
+	Known       []string // values already used in this session
+}
+
+// missingParameterKey is the context key of the missingParameter a tool call records
+type missingParameterKey struct{}
+
+// missingParameterResult builds an error result that tells the client exactly which argument is missing
+// The argument is also recorded in the context, so a client supporting elicitation is asked for it and the call retried
+func missingParameterResult(ctx context.Context, name, description string, known []string) *mcp.CallToolResult {
+	if missing, ok := ctx.Value(missingParameterKey{}).(*missingParameter); ok {
+		*missing = missingParameter{Name: name, Description: description, Known: known}
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Missing required argument '%s': %s\n", name, description))
+	if len(known) > 0 {
+		b.WriteString(fmt.Sprintf("Values already used in this session: %s\n", strings.Join(known, ", ")))
+	}
+	b.WriteString("Ask the user for this value and call the tool again with it set.")
+	return mcp.NewToolResultError(b.String())
+}
+
+// requestAppName returns the app_name argument, falling back to the application used last
+// The fallback survives restarts when the server persists its project manifest
+func requestAppName(ctx context.Context, request mcp.CallToolRequest) string {
+	if appName := request.GetString("app_name", ""); appName != "" {
+		return appName
+	}
+	return state.From(ctx).CurrentApp()
+}
+
+// missingAppNameResult reports a missing app_name argument, suggesting applications scaffolded so far
+func missingAppNameResult(ctx context.Context) *mcp.CallToolResult {
+	return missingParameterResult(ctx, "app_name", "the name of the application, which is also its Go module path (e.g., myapp).", state.From(ctx).Apps())
+}
+
+// requestModelName returns the model_name argument, or the result reporting it missing or unusable
+// Every tool taking a model reads it here, so a name is rejected or normalized the same way whichever tool gets it first
+func requestModelName(ctx context.Context, request mcp.CallToolRequest, appName string) (string, *mcp.CallToolResult) {
+	modelName := request.GetString("model_name", "")
+	if modelName == "" {
+		return "", missingModelNameResult(ctx, appName)
+	}
+	if err := checkModelName(modelName); err != nil {
+		return "", mcp.NewToolResultError(err.Error())
+	}
+	return modelName, nil
+}
+
+// missingModelNameResult reports a missing model_name argument, suggesting models already scaffolded for appName
+func missingModelNameResult(ctx context.Context, appName string) *mcp.CallToolResult {
+	var known []string
+	if project, ok := state.From(ctx).Project(appName); ok {
+		for _, model := range project.Models {
+			known = append(known, model.Name)
+		}
+	}
+	return missingParameterResult(ctx, "model_name", "the name of the model (e.g., User, Product).", known)
+}
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+	"mcpgo/internal/templates"
+)
+
+// errorFormatOption is shared by the tools generating API handlers
+var errorFormatOption = mcp.WithString("error_format",
+	mcp.Description("How API errors are rendered: echo for Echo's default {\"message\": ...} body, or problem for RFC 7807 application/problem+json bodies (type, title, status, detail, instance and a list of invalid fields). Defaults to the choice recorded for the app, or echo."),
+	mcp.Enum("echo", "problem"),
+)
+
+// apiErrors holds the code fragments controlling how generated API handlers report errors
+type apiErrors struct {
+	Format  string // echo or problem
+	New     string // function building an error from a status and a message
+	Imports string // extra import lines, including indentation and newlines
+}
+
+// newAPIErrors returns the requested error format, falling back to the one recorded for the app
+func newAPIErrors(ctx context.Context, request mcp.CallToolRequest, appName string) apiErrors {
+	format := "echo"
+	if project, ok := state.From(ctx).Project(appName); ok && project.Options["error_format"] != "" {
+		format = project.Options["error_format"]
+	}
+	format = request.GetString("error_format", format)
+	if format != "problem" {
+		return apiErrors{Format: "echo", New: "echo.NewHTTPError"}
+	}
+	return apiErrors{Format: format, New: "problem.New", Imports: fmt.Sprintf("\t\"%s/internal/problem\"\n", appName)}
+}
+
+// problemFile renders the problem details package and its Echo error handler
+func problemFile() scaffoldFile {
+	return scaffoldFile{Path: "internal/problem/problem.go", Language: "go", Content: templates.MustRender("api_controller/problem.go", nil)}
+}
+
+// problemStep explains the problem details package; it is empty for Echo's default error bodies
+func (e apiErrors) problemStep(step int) string {
+	if e.Format != "problem" {
+		return ""
+	}
+	file := problemFile()
+	return fmt.Sprintf(`
+%[1]d. Create the file at `+"`%[2]s`"+` so every error is rendered as `+"`application/problem+json`"+`:
+`+"```go"+`
+%[3]s`+"```"+`
+
+   Install the handler in `+"`cmd/web/main.go`"+`, right after `+"`e := echo.New()`"+`:
+   `+"```go"+`
+   e.HTTPErrorHandler = problem.ErrorHandler
+   `+"```"+`
+
+   Handlers return `+"`problem.New(status, detail)`"+`; errors from Echo itself (unknown routes, bind failures) and from `+"`c.Validate`"+` are converted too, with invalid fields listed under `+"`errors`"+`. Details of 5xx errors are logged and never sent to the client.
+`, step, file.Path, file.Content)
+}
@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+func TestNewAPIErrors(t *testing.T) {
+	store := state.NewStore()
+	store.SetOption("shop", "error_format", "problem")
+	ctx := state.WithStore(context.Background(), store)
+
+	tests := []struct {
+		name, app, requested string
+		want                 apiErrors
+	}{
+		{"default", "outlet", "", apiErrors{Format: "echo", New: "echo.NewHTTPError"}},
+		{"requested", "outlet", "problem", apiErrors{Format: "problem", New: "problem.New", Imports: "\t\"outlet/internal/problem\"\n"}},
+		{"recorded", "shop", "", apiErrors{Format: "problem", New: "problem.New", Imports: "\t\"shop/internal/problem\"\n"}},
+		{"requested over recorded", "shop", "echo", apiErrors{Format: "echo", New: "echo.NewHTTPError"}},
+		{"unknown", "outlet", "xml", apiErrors{Format: "echo", New: "echo.NewHTTPError"}},
+	}
+	for _, tt := range tests {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{}
+		if tt.requested != "" {
+			request.Params.Arguments = map[string]any{"error_format": tt.requested}
+		}
+		if got := newAPIErrors(ctx, request, tt.app); got != tt.want {
+			t.Errorf("%s: newAPIErrors = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestProblemStep(t *testing.T) {
+	if step := (apiErrors{Format: "echo"}).problemStep(4); step != "" {
+		t.Errorf("Echo's error bodies need no step, got:\n%s", step)
+	}
+	step := (apiErrors{Format: "problem"}).problemStep(4)
+	for _, want := range []string{"\n4. Create the file at `internal/problem/problem.go`", "package problem", "e.HTTPErrorHandler = problem.ErrorHandler"} {
+		if !strings.Contains(step, want) {
+			t.Errorf("the problem details step lacks %q:\n%s", want, step)
+		}
+	}
+}
+
+// problemTest checks the responses of the generated problem details handler
+const problemTest = `package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		err    error
+		want   Problem
+	}{
+		{"problem", http.MethodGet, New(http.StatusNotFound, "no product 7"),
+			Problem{Type: "about:blank", Title: "Not Found", Status: 404, Detail: "no product 7", Instance: "/products/7"}},
+		{"echo error", http.MethodPost, echo.NewHTTPError(http.StatusConflict, "the code is taken"),
+			Problem{Type: "about:blank", Title: "Conflict", Status: 409, Detail: "the code is taken", Instance: "/products/7"}},
+		{"field errors", http.MethodPut, echo.NewHTTPError(http.StatusBadRequest, map[string]string{"name": "is required", "email": "must be an email"}),
+			Problem{Type: "about:blank", Title: "Bad Request", Status: 400, Detail: "The request has invalid fields.", Instance: "/products/7",
+				Errors: []FieldError{{Field: "email", Message: "must be an email"}, {Field: "name", Message: "is required"}}}},
+		{"internal error", http.MethodGet, errors.New("connection refused"),
+			Problem{Type: "about:blank", Title: "Internal Server Error", Status: 500, Instance: "/products/7"}},
+		{"head", http.MethodHead, New(http.StatusGone, "deleted"), Problem{}},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		e.HTTPErrorHandler = ErrorHandler
+		e.Any("/products/:id", func(c echo.Context) error { return tt.err })
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(tt.method, "/products/7", nil))
+
+		if got := rec.Header().Get(echo.HeaderContentType); got != ContentType {
+			t.Errorf("%s: Content-Type %q, want %q", tt.name, got, ContentType)
+		}
+		if tt.method == http.MethodHead {
+			if rec.Code != http.StatusGone || rec.Body.Len() != 0 {
+				t.Errorf("%s: got %d with %q, want 410 without a body", tt.name, rec.Code, rec.Body)
+			}
+			continue
+		}
+		if rec.Code != tt.want.Status {
+			t.Errorf("%s: status %d, want %d", tt.name, rec.Code, tt.want.Status)
+		}
+		var got Problem
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("%s: %v in %s", tt.name, err, rec.Body)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUnknownRoute(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	want := ` + "`" + `{"type":"about:blank","title":"Not Found","status":404,"detail":"Not Found","instance":"/missing"}` + "`" + `
+	if rec.Code != http.StatusNotFound || rec.Body.String() != want+"\n" {
+		t.Errorf("got %d with %s, want 404 with %s", rec.Code, rec.Body, want)
+	}
+}
+`
+
+func TestProblemHandlerResponses(t *testing.T) {
+	goTest(t, []scaffoldFile{
+		problemFile(),
+		{Path: "internal/problem/problem_test.go", Language: "go", Content: problemTest},
+	})
+}
@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceActivityFeedBoilerplateTool returns the tool definition for produce_activity_feed_boilerplate
+func GetProduceActivityFeedBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_activity_feed_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a live /admin/activity feed: GORM callbacks recording every create, update and delete across all models in an activities table, an in-process hub, a server-sent events stream and a templ page following it with EventSource."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithNumber("recent",
+			mcp.Description("Number of activities shown when the page loads and kept on screen as new ones arrive. Defaults to 50."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceActivityFeedBoilerplateHandler
+}
+
+// ProduceActivityFeedBoilerplateHandler handles requests to generate the admin activity feed
+// It creates the activity model and recorder, the SSE hub and controller, and the feed page
+func ProduceActivityFeedBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	recent := request.GetFloat("recent", 50)
+	if recent < 1 || recent != float64(int(recent)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'recent': expected a positive whole number, got %v.", recent)), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "activity_feed", "true")
+
+	args := []any{
+		appName, // %[1]s
+	}
+	files, err := renderFiles(ctx, activityFeedFiles, map[string]any{"App": appName, "Recent": strconv.Itoa(int(recent))})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Activity Feed Scaffold Instructions
+
+To scaffold the live admin activity feed for the application '%[1]s', please perform the following steps:
+
+1. Create or update the file at `+"`internal/models/activity.go`"+` with the following content:
+`+"```go"+`
+%[2]s`+"```"+`
+
+2. Create or update the file at `+"`internal/activity/hub.go`"+` with the following content:
+`+"```go"+`
+%[3]s`+"```"+`
+
+3. Create or update the file at `+"`internal/activity/recorder.go`"+` with the following content:
+`+"```go"+`
+%[4]s`+"```"+`
+
+4. Create or update the file at `+"`internal/controllers/admin/activity.go`"+` with the following content:
+`+"```go"+`
+%[5]s`+"```"+`
+
+5. Create or update the file at `+"`ui/pages/admin/activity.templ`"+` with the following content:
+`+"```templ"+`
+%[6]s`+"```"+`
+
+6. Wire it up in `+"`cmd/web/main.go`"+` after opening the database:
+   `+"```go"+`
+   if err := db.AutoMigrate(&models.Activity{}); err != nil {
+   	e.Logger.Fatal("failed to migrate activities", err)
+   }
+   hub := activity.NewHub()
+   if err := activity.Register(db, hub); err != nil {
+   	e.Logger.Fatal("failed to register activity callbacks", err)
+   }
+
+   activityController := admincontroller.NewActivityController(db, hub)
+   admin := e.Group("/admin") // add your admin authorization middleware here
+   admin.GET("/activity", activityController.Page)
+   admin.GET("/activity/stream", activityController.Stream)
+   `+"```"+`
+
+   Every create, update and delete made through GORM is recorded in the same transaction and pushed to the open feeds. To show who made a change, wrap the request context in your authentication middleware:
+   `+"```go"+`
+   c.SetRequest(c.Request().WithContext(activity.WithActor(c.Request().Context(), user.Email)))
+   `+"```"+`
+`, append(args, fileContents(files)...)...) // %[2]s onwards: file contents
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{"templ generate"},
+		Notes: []string{
+			"Add models.Activity to AutoMigrate, call activity.Register and mount the /admin/activity routes in cmd/web/main.go.",
+			"Protect the /admin group with your admin authorization middleware: the feed shows changes across all models.",
+			"If the app uses the Timeout middleware, add \"/admin/activity/stream\": 0 to TimeoutConfig.Routes so the stream is not cut off.",
+			"The hub is in-process: with several instances, each feed shows the changes made through its own instance until it reconnects and catches up from the table.",
+		},
+	}), nil
+}
+
+// activityFeedFiles lists the activity feed files in the order they appear in the instructions
+var activityFeedFiles = []fileFormat{
+	{Path: "internal/models/activity.go", Language: "go", Template: "activity_feed/activity.go"},
+	{Path: "internal/activity/hub.go", Language: "go", Template: "activity_feed/hub.go"},
+	{Path: "internal/activity/recorder.go", Language: "go", Template: "activity_feed/recorder.go"},
+	{Path: "internal/controllers/admin/activity.go", Language: "go", Template: "activity_feed/controller.go"},
+	{Path: "ui/pages/admin/activity.templ", Language: "templ", Template: "activity_feed/activity.templ"},
+}
@@ -6,6 +6,9 @@ import (
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
 )
 
 // GetProduceApiControllerBoilerplateTool returns the tool definition for produce_api_controller_boilerplate
@@ -13,12 +16,37 @@ func GetProduceApiControllerBoilerplateTool() (mcp.Tool, func(ctx context.Contex
 	tool := mcp.NewTool("produce_api_controller_boilerplate",
 		mcp.WithDescription("Instructs the LLM to output an example boilerplate for a new API controller for a given model."),
 		mcp.WithString("app_name",
-			mcp.Description("The name of the application. This is used to output an example of correct import paths."),
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
 		),
 		mcp.WithString("model_name",
 			mcp.Required(),
 			mcp.Description("The name of the model for which to output an example a controller (e.g., User, Product)."),
 		),
+		mcp.WithBoolean("merge_patch",
+			mcp.Description("Also generate a PATCH /<model>s/:id handler applying JSON merge patches (RFC 7386), where null clears a field and absent fields are kept. Defaults to false."),
+		),
+		mcp.WithBoolean("content_negotiation",
+			mcp.Description("Let the list and get handlers answer in JSON, CSV or XML depending on the Accept header, using generated export serializers. Defaults to false."),
+		),
+		routePrefixOption,
+		resourcePathOption,
+		routeGroupOption,
+		middlewareOption,
+		errorFormatOption,
+		templateVersionOption,
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
 	)
 
 	return tool, ProduceApiControllerBoilerplateHandler
@@ -27,17 +55,114 @@ func GetProduceApiControllerBoilerplateTool() (mcp.Tool, func(ctx context.Contex
 // ProduceApiControllerBoilerplateHandler handles requests to generate boilerplate for an API controller
 // It creates controller files with CRUD operations for a given model
 func ProduceApiControllerBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	appName := request.GetString("app_name", "") // Default app name if not provided
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
 	if appName == "" {
-		return mcp.NewToolResultError("App name is required"), nil
+		return missingAppNameResult(ctx), nil
+	}
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
 	}
-	modelName, err := request.RequireString("model_name")
+
+	titleModelName := naming.Pascal(modelName)
+	lowerModelName := strings.ToLower(titleModelName)
+	mount, err := newRoutes(request, titleModelName)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error getting 'model_name': %v", err.Error())), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	titleModelName := strings.Title(modelName)
-	lowerModelName := strings.ToLower(modelName)
+	version, err := templateVersion(ctx, request, appName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	state.From(ctx).RecordComponent(appName, titleModelName, "api_controller")
+	errs := newAPIErrors(ctx, request, appName)
+	state.From(ctx).SetOption(appName, "error_format", errs.Format)
+
+	mergePatch := request.GetBool("merge_patch", false)
+	apiRoutes := apiControllerRoutes(titleModelName, lowerModelName, mergePatch)
+	state.From(ctx).RecordRoutes(appName, titleModelName, mount.list(apiRoutes))
+
+	step := 4
+	problemStep := errs.problemStep(step)
+	if problemStep != "" {
+		step++
+	}
+	var patchFiles []scaffoldFile
+	if mergePatch {
+		var err error
+		if patchFiles, err = mergePatchFiles(ctx, titleModelName, lowerModelName, appName, mount.Path, errs); err != nil {
+			return nil, err
+		}
+		state.From(ctx).RecordComponent(appName, titleModelName, "merge_patch")
+	}
+	patchStep := mergePatchStep(step, titleModelName, patchFiles)
+	if patchStep != "" {
+		step++
+	}
+	negotiate := newNegotiation(request.GetBool("content_negotiation", false), appName)
+	if len(negotiate.Files) > 0 {
+		state.From(ctx).RecordComponent(appName, titleModelName, "content_negotiation")
+	}
+
+	var routeFiles []scaffoldFile
+	var routesStep, note string
+	if usesFx(ctx, appName) {
+		fxFile := fxRoutesFile(appName, titleModelName, lowerModelName, mount, apiRoutes, false)
+		routeFiles = append(routeFiles, fxFile)
+		routesStep = fmt.Sprintf("3. Register the routes in `%s`; fx calls it with the controller once it is provided:\n```go\n%s```\n", fxFile.Path, fxFile.Content)
+		note = fxProviderNote(fmt.Sprintf("`%scontrollers.New%sController` to Controllers", lowerModelName, titleModelName), fmt.Sprintf("`%s` to Routes", fxRegister(titleModelName, false)))
+	} else {
+		recordRouterRoutes(ctx, appName, titleModelName, "api_controller", mount, apiRoutes)
+		router := routerFile(ctx, appName, appName, "internal/router/router.go")
+		routeFiles = append(routeFiles, router)
+		routesStep = "3. " + routerStep(ctx, appName, router, titleModelName+"Controller", fmt.Sprintf("%scontrollers.New%sController(%sService)", lowerModelName, titleModelName, lowerModelName))
+		note = "Build the controller once in cmd/web/main.go; internal/router/router.go registers the routes of every controller."
+		if usesWire(ctx, appName) {
+			note = wireProviderNote(fmt.Sprintf("`%scontrollers.New%sController` to Providers", lowerModelName, titleModelName))
+		}
+	}
+
+	args := []any{
+		titleModelName,                   // %[1]s
+		lowerModelName,                   // %[2]s
+		titleModelName,                   // %[3]s
+		lowerModelName,                   // %[4]s
+		appName,                          // %[5]s
+		errs.New,                         // %[6]s
+		errs.Imports,                     // %[7]s
+		problemStep,                      // %[8]s
+		patchStep,                        // %[9]s
+		negotiate.Imports,                // %[10]s
+		negotiate.ListReturn,             // %[11]s
+		negotiate.GetReturn,              // %[12]s
+		negotiate.step(step, mount.Path), // %[13]s
+		routesStep,                       // %[14]s
+	}
+	files, err := renderFiles(ctx, versionFiles(version, apiControllerFiles), map[string]any{
+		"Model":         titleModelName,
+		"Lower":         lowerModelName,
+		"App":           appName,
+		"NewError":      errs.New,
+		"ErrorImports":  errs.Imports,
+		"ExportImports": negotiate.Imports,
+		"ListReturn":    negotiate.ListReturn,
+		"GetReturn":     negotiate.GetReturn,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if errs.Format == "problem" {
+		files = append(files, problemFile())
+	}
+	files = append(files, patchFiles...)
+	files = append(files, negotiate.Files...)
+	files = append(files, routeFiles...)
 
 	response := fmt.Sprintf(`
 # API Controller Scaffold Instructions
@@ -51,177 +176,59 @@ To scaffold the API controller for model '%[1]s', please perform the following s
 
    a. `+"`controller.go`"+` (interface and constructor):
 `+"```go"+`
-package controllers
-
-import (
-	"github.com/labstack/echo/v4"
-	"%[5]s/internal/service"
-	"%[5]s/internal/dto"
-)
-
-type %[3]sController interface {
-	Create%[3]s(c echo.Context) error
-	Update%[3]s(c echo.Context) error
-	Delete%[3]s(c echo.Context) error
-	List%[3]s(c echo.Context) error // New: List method
-	Get%[3]sByID(c echo.Context) error // New: GetByID method
-}
-
-type %[3]sControllerImpl struct {
-	%[4]sService service.%[3]sService
-}
-
-func New%[3]sController(%[4]sService service.%[3]sService) %[3]sController {
-	return &%[3]sControllerImpl{%[4]sService: %[4]sService}
-}
-`+"```"+`
+%[15]s`+"```"+`
 
    b. `+"`create.go`"+` (Create method - JSON request & response):
 `+"```go"+`
-package controllers
-
-import (
-	"net/http"
-
-	"github.com/labstack/echo/v4"
-	"%[5]s/internal/dto"
-)
-
-func (ctrl *%[3]sControllerImpl) Create%[3]s(c echo.Context) error {
-	req := new(dto.Create%[3]sRequest)
-	if err := c.Bind(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
-	}
-	// Add validation here if needed
-	result, err := ctrl.%[4]sService.Create(c.Request().Context(), req)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-	return c.JSON(http.StatusCreated, result)
-}
-`+"```"+`
+%[16]s`+"```"+`
 
    c. `+"`update.go`"+` (Update method - JSON request & response):
 `+"```go"+`
-package controllers
-
-import (
-	"net/http"
-	"strconv"
-
-	"github.com/labstack/echo/v4"
-	"%[5]s/internal/dto"
-)
-
-func (ctrl *%[3]sControllerImpl) Update%[3]s(c echo.Context) error {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
-	}
-
-	req := new(dto.Update%[3]sRequest)
-	if err := c.Bind(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
-	}
-	req.ID = uint(id)
-
-	// Add validation here if needed
-	result, err := ctrl.%[4]sService.Update(c.Request().Context(), req)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-	return c.JSON(http.StatusOK, result)
-}
-`+"```"+`
+%[17]s`+"```"+`
 
    d. `+"`delete.go`"+` (Delete method - JSON request & response):
 `+"```go"+`
-package controllers
-
-import (
-	"net/http"
-	"strconv"
-
-	"github.com/labstack/echo/v4"
-)
-
-func (ctrl *%[3]sControllerImpl) Delete%[3]s(c echo.Context) error {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
-	}
-	if err := ctrl.%[4]sService.Delete(c.Request().Context(), uint(id)); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-	return c.NoContent(http.StatusNoContent)
-}
-`+"```"+`
+%[18]s`+"```"+`
 
    e. `+"`list.go`"+` (List method - JSON request & response):
 `+"```go"+`
-package controllers
-
-import (
-	"net/http"
-	"strconv"
-
-	"github.com/labstack/echo/v4"
-)
-
-func (ctrl *%[3]sControllerImpl) List%[3]s(c echo.Context) error {
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.QueryParam("page"))
-	if page <= 0 {
-		page = 1
-	}
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-	if limit <= 0 {
-		limit = 10
-	}
-
-	// You might want to parse query parameters for filtering here
-	filters := make(map[string]interface{}) 
-	// Example: filters["name"] = c.QueryParam("name")
-
-	result, err := ctrl.%[4]sService.List(c.Request().Context(), page, limit, filters)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
-	}
-	return c.JSON(http.StatusOK, result)
-}
-`+"```"+`
+%[19]s`+"```"+`
 
    f. `+"`get_by_id.go`"+` (GetByID method - JSON request & response):
 `+"```go"+`
-package controllers
+%[20]s`+"```"+`
 
-import (
-	"net/http"
-	"strconv"
+%[14]s%[8]s%[9]s%[13]s`, append(args, fileContents(files)...)...) // %[15]s onwards: file contents
 
-	"github.com/labstack/echo/v4"
-)
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{fmt.Sprintf("mkdir -p internal/controllers/%s", lowerModelName)},
+		Notes:    []string{note},
+		routes:   mount.list(apiRoutes),
+	}), nil
+}
 
-func (ctrl *%[3]sControllerImpl) Get%[3]sByID(c echo.Context) error {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+// apiControllerRoutes lists the routes of a model's API controller in registration order
+func apiControllerRoutes(model, lower string, mergePatch bool) []route {
+	controller := lower + "Controller."
+	list := []route{
+		{"POST", "", controller + "Create" + model},
+		{"GET", "/:id", controller + "Get" + model + "ByID"},
+		{"GET", "", controller + "List" + model},
+		{"PUT", "/:id", controller + "Update" + model},
 	}
-
-	result, err := ctrl.%[4]sService.GetByID(c.Request().Context(), uint(id))
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	if mergePatch {
+		list = append(list, route{"PATCH", "/:id", controller + "Patch" + model})
 	}
-	return c.JSON(http.StatusOK, result)
+	return append(list, route{"DELETE", "/:id", controller + "Delete" + model})
 }
-`+"```"+`
-`,
-		titleModelName, // %[1]s
-		lowerModelName, // %[2]s
-		titleModelName, // %[3]s
-		lowerModelName, // %[4]s
-		appName,        // %[5]s - Hardcoded for now, ideally passed from generateAppBoilerplateHandler
-	)
 
-	return mcp.NewToolResultText(response), nil
+// apiControllerFiles lists the files of an API controller in the order they appear in the instructions
+var apiControllerFiles = []fileFormat{
+	{Path: "internal/controllers/{{.Lower}}/controller.go", Language: "go", Template: "api_controller/controller.go"},
+	{Path: "internal/controllers/{{.Lower}}/create.go", Language: "go", Template: "api_controller/create.go"},
+	{Path: "internal/controllers/{{.Lower}}/update.go", Language: "go", Template: "api_controller/update.go"},
+	{Path: "internal/controllers/{{.Lower}}/delete.go", Language: "go", Template: "api_controller/delete.go"},
+	{Path: "internal/controllers/{{.Lower}}/list.go", Language: "go", Template: "api_controller/list.go"},
+	{Path: "internal/controllers/{{.Lower}}/get_by_id.go", Language: "go", Template: "api_controller/get_by_id.go"},
 }
@@ -3,8 +3,13 @@ package tools
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
 )
 
 // GetProduceAppBoilerplateTool returns the tool definition for produce_app_boilerplate
@@ -15,6 +20,27 @@ func GetProduceAppBoilerplateTool() (mcp.Tool, func(ctx context.Context, request
 			mcp.Required(),
 			mcp.Description("The name of the application."),
 		),
+		mcp.WithString("request_timeout",
+			mcp.Description("Default deadline of every HTTP request in the generated app as a Go duration (e.g., 30s, 1m). Defaults to 30s; REQUEST_TIMEOUT overrides it at runtime."),
+		),
+		readReplicasOption,
+		transactionsOption,
+		nPlusOneOption,
+		diOption,
+		dialectOption,
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
 	)
 
 	return tool, ProduceAppBoilerplateHandler
@@ -23,11 +49,139 @@ func GetProduceAppBoilerplateTool() (mcp.Tool, func(ctx context.Context, request
 // ProduceAppBoilerplateHandler handles requests to scaffold a new Echo web application
 // It returns detailed instructions for creating the application structure and files
 func ProduceAppBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	appName, err := request.RequireString("app_name")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := request.GetString("app_name", "")
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	requestTimeout, err := time.ParseDuration(request.GetString("request_timeout", "30s"))
+	if err != nil || requestTimeout <= 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'request_timeout': expected a positive Go duration such as 30s or 1m, got '%s'.", request.GetString("request_timeout", ""))), nil
+	}
+
+	readReplicas := request.GetBool("read_replicas", false)
+	transactions := request.GetBool("transactions", false)
+	nPlusOne := request.GetBool("detect_n_plus_one", false)
+	di := request.GetString("di", "none")
+	if di != "none" && di != "fx" && di != "wire" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'di' '%s': expected none, fx or wire.", di)), nil
+	}
+
+	dialect := appDialect(ctx, request, appName)
+	if dialect != "sqlite" && dialect != "postgres" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'dialect' '%s': expected sqlite or postgres.", dialect)), nil
+	}
+	module := appModule(ctx, appName)
+
+	state.From(ctx).RecordApp(appName)
+	if module != appName {
+		state.From(ctx).SetOption(appName, "module", module)
+	}
+	state.From(ctx).SetOption(appName, "dialect", dialect)
+	state.From(ctx).SetOption(appName, "request_timeout", requestTimeout.String())
+	state.From(ctx).SetOption(appName, "read_replicas", strconv.FormatBool(readReplicas))
+	state.From(ctx).SetOption(appName, "transactions", strconv.FormatBool(transactions))
+	state.From(ctx).SetOption(appName, "detect_n_plus_one", strconv.FormatBool(nPlusOne))
+	state.From(ctx).SetOption(appName, "di", di)
+
+	databaseDefaults := "a local SQLite file"
+	if dialect == "postgres" {
+		databaseDefaults = "a local Postgres database named `" + appName + "`"
+	}
+	args := []any{appName, dialect, module, databaseDefaults, appName, appName, goDuration(requestTimeout)}
+	data := map[string]any{"App": appName, "Module": module, "Driver": dialect, "RequestTimeout": goDuration(requestTimeout), "ReadReplicas": readReplicas, "Transactions": transactions, "NPlusOne": nPlusOne}
+	mainFiles := appFiles
+	switch di {
+	case "fx":
+		mainFiles = appFxFiles
+	case "wire":
+		mainFiles = appWireFiles
+	}
+	files, err := renderFiles(ctx, mainFiles, data)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error getting 'app_name': %v", err.Error())), nil
+		return nil, err
 	}
 
+	optionalSteps := ""
+	if di != "fx" {
+		router := routerFile(ctx, appName, module, appName+"/internal/router/router.go")
+		files = append(files, router)
+		handover := "`main.go` hands its controllers to `router.RegisterRoutes` through `router.Deps`. The controller tools regenerate this file with the routes of every controller of the app, so `main.go` only gains the constructor of each new controller."
+		if di == "wire" {
+			handover = "`NewEcho` hands the controllers wire provides to `router.RegisterRoutes` through `router.Deps`. The controller tools regenerate this file with the routes of every controller of the app, so `providers.go` only gains the constructor of each new controller."
+		}
+		optionalSteps += fmt.Sprintf(appRouterStepFormat, appName, router.Content, handover)
+	}
+	if readReplicas {
+		replicaFiles, err := renderFiles(ctx, appReplicaFiles, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, replicaFiles...)
+		optionalSteps += fmt.Sprintf(appReplicaStepFormat, appName, replicaFiles[0].Content)
+	}
+	if transactions {
+		transactionFiles, err := renderFiles(ctx, appTransactionFiles, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, transactionFiles...)
+		optionalSteps += fmt.Sprintf(appTransactionStepFormat, appName, transactionFiles[0].Content, transactionFiles[1].Content)
+	}
+	if nPlusOne {
+		nPlusOneFiles, err := renderFiles(ctx, appNPlusOneFiles, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, nPlusOneFiles...)
+		optionalSteps += fmt.Sprintf(appNPlusOneStepFormat, appName, nPlusOneFiles[0].Content, nPlusOneFiles[1].Content)
+	}
+	bootstrapStep, integrateSection := fmt.Sprintf(appBootstrapStepFormat, appName, module, dialect), appIntegrateFormat
+	// with fx or wire, NewDB and NewEcho register what the optional steps add to main.go
+	var wired []string
+	if readReplicas {
+		wired = append(wired, "registers the read replicas")
+	}
+	if transactions {
+		wired = append(wired, "registers the transaction middleware")
+	}
+	if nPlusOne {
+		wired = append(wired, "registers the N+1 query detector")
+	}
+	if len(wired) > 0 {
+		wired[0] = "\n   `NewDB` and `NewEcho` already do what the steps above describe for `main.go`: " + wired[0]
+		wired[len(wired)-1] += "."
+	}
+	if di == "fx" {
+		for key, value := range fxProviderData(ctx, appName, module) {
+			data[key] = value
+		}
+		wiringFiles, err := renderFiles(ctx, appFxWiringFiles, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, wiringFiles...)
+		bootstrapStep = fmt.Sprintf(appFxStepFormat, appName, wiringFiles[0].Content, wiringFiles[1].Content, strings.Join(wired, " and "))
+		integrateSection = appFxIntegrateFormat
+	}
+	if di == "wire" {
+		for key, value := range wireProviderData(ctx, appName, module) {
+			data[key] = value
+		}
+		wiringFiles, err := renderFiles(ctx, appWireWiringFiles, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, wiringFiles...)
+		bootstrapStep = fmt.Sprintf(appWireStepFormat, appName, wiringFiles[0].Content, wiringFiles[1].Content, wiringFiles[2].Content, strings.Join(wired, " and "))
+		integrateSection = appWireIntegrateFormat
+	}
+	args = append(args, optionalSteps, bootstrapStep, integrateSection) // %[8]s to %[10]s
+
 	response := fmt.Sprintf(`
 # Echo Web Application Scaffold Instructions
 
@@ -38,104 +192,29 @@ To scaffold the Echo web application '%[1]s', please perform the following steps
 
 2. Create or update the file at `+"`%[1]s/cmd/web/main.go`"+` with the following content:
 `+"```go"+`
-package main
+%[11]s`+"```"+`
 
-import (
-	"net/http"
-
-	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
-)
+3. Create or update the file at `+"`%[1]s/internal/middleware/timeout.go`"+` with the following content:
+`+"```go"+`
+%[12]s`+"```"+`
 
-func main() {
-	e := echo.New()
-	e.Use(middleware.Logger())
-	e.Use(middleware.Recover())
-	e.GET("/", hello)
-	e.Logger.Fatal(e.Start(":1323"))
-}
+   Every request gets a context deadline (default `+"`%[7]s`"+`, overridable with the `+"`REQUEST_TIMEOUT`"+` environment variable). Slow routes get their own budget through `+"`Routes`"+`, keyed by route path.
+   Controllers pass `+"`c.Request().Context()`"+` to services and repositories call `+"`db.WithContext(ctx)`"+`, so a query still running when the deadline passes is cancelled and the client receives 503 instead of the server hanging.
 
-func hello(c echo.Context) error {
-	return c.String(http.StatusOK, "Hello, World!")
-}
-`+"```"+`
+4. Create or update the file at `+"`%[1]s/internal/database/database.go`"+` with the following content:
+`+"```go"+`
+%[13]s`+"```"+`
 
-3. Initialize the Go module and fetch dependencies:
-   `+"`cd %[1]s && go mod init %[1]s && go get github.com/labstack/echo/v4 && go mod tidy`"+`
+   `+"`Open`"+` replaces a bare `+"`gorm.Open`"+` call: it tunes the `+"`sql.DB`"+` pool, pings the database with exponential backoff before the server starts, and logs queries slower than `+"`DB_SLOW_QUERY_THRESHOLD`"+`.
+   All settings are read from `+"`DB_*`"+` environment variables by `+"`ConfigFromEnv`"+`; the defaults suit %[4]s.
+%[8]s
+5. Initialize the Go module and fetch dependencies:
+   `+"`cd %[1]s && go mod init %[3]s && go get github.com/labstack/echo/v4 && go mod tidy`"+`
 
-4. To run the server, navigate to the application directory and execute:
+6. To run the server, navigate to the application directory and execute:
    `+"`cd %[1]s && go run ./cmd/web`"+`
 
-5. Bootstrap dependencies in `+"`%[1]s/cmd/web/main.go`"+`:
-   After creating models, repositories, services, and controllers, you will need to create or update `+"`%[1]s/cmd/web/main.go`"+` to bootstrap these dependencies.
-   This typically involves:
-   - Importing `+"`gorm.io/driver/sqlite`"+` (or your chosen database driver) and `+"`gorm.io/gorm`"+`.
-   - Initializing the database connection (e.g., `+"`db, err := gorm.Open(sqlite.Open(\"gorm.db\"), &gorm.Config{})`"+`).
-   - Auto-migrating your models (e.g., `+"`db.AutoMigrate(&models.YourModel{})`"+`).
-   - Creating instances of your repositories (e.g., `+"`userRepo := repository.NewUserRepository(db)`"+`).
-   - Creating instances of your services (e.g., `+"`userService := service.NewUserService(userRepo)`"+`).
-   - Creating instances of your controllers, injecting services (e.g., `+"`userController := controllers.NewUserController(userService)`"+`).
-   - Registering routes for your controllers (e.g., `+"`e.POST(\"/users\", userController.CreateUser)`"+`).
-
-   Here's an example of how `+"`%[1]s/cmd/web/main.go`"+` might look after adding a 'User' model with service layer:
-   `+"```go"+`
-package main
-
-import (
-	"net/http"
-
-	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
-
-	"%[6]s/internal/models"
-	"%[6]s/internal/repository"
-	"%[6]s/internal/service"
-	"%[6]s/internal/controllers"
-)
-
-func main() {
-	e := echo.New()
-	e.Use(middleware.Logger())
-	e.Use(middleware.Recover())
-
-	// Database initialization
-	db, err := gorm.Open(sqlite.Open("gorm.db"), &gorm.Config{})
-	if err != nil {
-		e.Logger.Fatal("failed to connect database", err)
-	}
-
-	// Auto-migrate models
-	err = db.AutoMigrate(&models.User{}) // Add all your models here
-	if err != nil {
-		e.Logger.Fatal("failed to auto migrate models", err)
-	}
-
-	// Initialize repositories
-	userRepo := repository.NewUserRepository(db)
-
-	// Initialize services
-	userService := service.NewUserService(userRepo)
-
-	// Initialize controllers
-	userController := controllers.NewUserController(userService)
-
-	// Routes
-	e.GET("/", hello)
-	e.POST("/users", userController.CreateUser)
-	e.GET("/users/:id", userController.GetUserByID) // Example for GetByID
-	e.GET("/users", userController.ListUsers)       // Example for List
-	e.PUT("/users/:id", userController.UpdateUser)
-	e.DELETE("/users/:id", userController.DeleteUser)
-
-	e.Logger.Fatal(e.Start(":1323"))
-}
-
-func hello(c echo.Context) error {
-	return c.String(http.StatusOK, "Hello, World!")
-}
-`+"```"+`
+%[9]s
 
 ## Next Steps: Building Your Application Components
 
@@ -181,21 +260,12 @@ produce_html_controller_boilerplate app_name="%[1]s" model_name="User" template_
 
 This will create controllers that render HTML templates and handle form submissions.
 
-### 4. Integrate Components
-
-After generating these components, update your `+"`cmd/web/main.go`"+` file to:
-- Import all the necessary packages
-- Initialize the database connection
-- Auto-migrate your models
-- Create instances of repositories, services, and controllers
-- Register routes for your controllers
-
-### 5. Add Dependencies
+%[10]s### 5. Add Dependencies
 
 Don't forget to add the required dependencies:
 
 `+"```"+`
-cd %[1]s && go get gorm.io/gorm gorm.io/driver/sqlite github.com/labstack/echo/v4
+cd %[1]s && go get gorm.io/gorm gorm.io/driver/%[2]s github.com/labstack/echo/v4
 `+"```"+`
 
 ### 6. Run and Test
@@ -208,7 +278,307 @@ cd %[1]s && go run ./cmd/web
 
 Test your endpoints using a tool like curl, Postman, or a web browser depending on your controller type.
 
-`, appName, appName, appName, appName, appName, appName)
+`, append(args, fileContents(files)...)...) // %[11]s onwards: file contents
+
+	commands := []string{
+		fmt.Sprintf("mkdir -p %s/cmd/web", appName),
+		fmt.Sprintf("cd %s && go mod init %s && go get github.com/labstack/echo/v4 && go mod tidy", appName, module),
+		fmt.Sprintf("cd %s && go run ./cmd/web", appName),
+	}
+	notes := []string{
+		"Database pool size, connection lifetime, startup retries and the slow-query threshold come from DB_* environment variables (see internal/database/database.go).",
+		"Every request carries a context deadline; pass c.Request().Context() down to repositories so slow queries are cancelled.",
+		"After creating models, repositories, services and controllers, bootstrap them in cmd/web/main.go.",
+		"Next recommended step: use produce_model_boilerplate to create your data models.",
+	}
+	if di == "fx" {
+		commands = append(commands[:len(commands)-1], fmt.Sprintf("cd %s && go get go.uber.org/fx", appName), commands[len(commands)-1])
+		notes[2] = "After creating models, repositories, services and controllers, add their constructors to the provider sets in internal/app/providers.go; cmd/web/main.go does not change."
+	}
+	if di == "wire" {
+		commands = append(commands[:len(commands)-1], "go install github.com/google/wire/cmd/wire@latest", fmt.Sprintf("cd %s && go get github.com/google/wire && wire ./internal/app", appName), commands[len(commands)-1])
+		notes[2] = "After creating models, repositories, services and controllers, add their constructors to Providers in internal/app/providers.go and run wire ./internal/app; cmd/web/main.go does not change."
+	}
+	if readReplicas {
+		commands = append(commands[:2], fmt.Sprintf("cd %s && go get gorm.io/plugin/dbresolver", appName), commands[2])
+		notes = append(notes, "Read replicas are listed in DB_REPLICA_DSNS; repositories generated for this app route reads to them and writes to the primary.")
+	}
+	if transactions && di == "none" {
+		notes = append(notes, "Register middleware.Transaction(db) after opening the database; repositories generated for this app use the request transaction from the context.")
+	}
+	if nPlusOne {
+		if di == "none" {
+			notes = append(notes, "Register the database.NPlusOneDetector plugin and middleware.DetectNPlusOne after opening the database, before any transaction middleware.")
+		}
+		notes = append(notes, "The N+1 detector warns when a request runs the same query DB_N_PLUS_ONE_THRESHOLD times (default 3) from one call site; it is off when APP_ENV=production.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: commands,
+		Notes:    notes,
+		appDir:   true,
+	}), nil
+}
+
+// goDuration renders d as a Go expression in the largest whole unit
+func goDuration(d time.Duration) string {
+	switch {
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%d * time.Minute", d/time.Minute)
+	case d%time.Second == 0:
+		return fmt.Sprintf("%d * time.Second", d/time.Second)
+	default:
+		return fmt.Sprintf("%d * time.Millisecond", d/time.Millisecond)
+	}
+}
+
+// appFiles lists the files of a new application
+var appFiles = []fileFormat{
+	{Path: "{{.App}}/cmd/web/main.go", Language: "go", Template: "app/main.go"},
+	{Path: "{{.App}}/internal/middleware/timeout.go", Language: "go", Template: "app/timeout.go"},
+	{Path: "{{.App}}/internal/database/database.go", Language: "go", Template: "app/database.go"},
+}
+
+// appFxFiles replaces appFiles when the app is wired with uber/fx
+var appFxFiles = []fileFormat{
+	{Path: "{{.App}}/cmd/web/main.go", Language: "go", Template: "app/fx_main.go"},
+	{Path: "{{.App}}/internal/middleware/timeout.go", Language: "go", Template: "app/timeout.go"},
+	{Path: "{{.App}}/internal/database/database.go", Language: "go", Template: "app/database.go"},
+}
+
+// appFxWiringFiles lists the module and the provider sets of an app wired with uber/fx
+var appFxWiringFiles = []fileFormat{
+	{Path: "{{.App}}/internal/app/app.go", Language: "go", Template: "app/fx_app.go"},
+	{Path: "{{.App}}/internal/app/providers.go", Language: "go", Template: "app/fx_providers.go"},
+}
+
+// appWireFiles replaces appFiles when the app is wired with google/wire
+var appWireFiles = []fileFormat{
+	{Path: "{{.App}}/cmd/web/main.go", Language: "go", Template: "app/wire_main.go"},
+	{Path: "{{.App}}/internal/middleware/timeout.go", Language: "go", Template: "app/timeout.go"},
+	{Path: "{{.App}}/internal/database/database.go", Language: "go", Template: "app/database.go"},
+}
+
+// appWireWiringFiles lists the constructors, the provider set and the injector of an app wired with google/wire
+var appWireWiringFiles = []fileFormat{
+	{Path: "{{.App}}/internal/app/app.go", Language: "go", Template: "app/wire_app.go"},
+	{Path: "{{.App}}/internal/app/providers.go", Language: "go", Template: "app/wire_providers.go"},
+	{Path: "{{.App}}/internal/app/wire.go", Language: "go", Template: "app/wire_inject.go"},
+}
+
+// appReplicaFiles lists the files added when the app routes reads to replicas
+var appReplicaFiles = []fileFormat{
+	{Path: "{{.App}}/internal/database/replicas.go", Language: "go", Template: "app/replicas.go"},
+}
+
+// appTransactionFiles lists the files added when mutating requests run in a transaction
+var appTransactionFiles = []fileFormat{
+	{Path: "{{.App}}/internal/database/tx.go", Language: "go", Template: "app/tx.go"},
+	{Path: "{{.App}}/internal/middleware/transaction.go", Language: "go", Template: "app/transaction.go"},
+}
+
+// appNPlusOneFiles lists the files added when repeated queries are reported in development
+var appNPlusOneFiles = []fileFormat{
+	{Path: "{{.App}}/internal/database/nplusone.go", Language: "go", Template: "app/nplusone.go"},
+	{Path: "{{.App}}/internal/middleware/nplusone.go", Language: "go", Template: "app/nplusone_middleware.go"},
+}
+
+// appRouterStepFormat explains where the routes are registered; %[1]s is the app name, %[2]s the file content, %[3]s what hands the controllers over
+const appRouterStepFormat = `
+   **Routes**: create or update the file at ` + "`%[1]s/internal/router/router.go`" + ` with the following content:
+` + "```go" + `
+%[2]s` + "```" + `
+
+   %[3]s
+`
+
+// appNPlusOneStepFormat explains how to enable N+1 query detection; %[1]s is the app name, %[2]s and %[3]s the file contents
+const appNPlusOneStepFormat = `
+   **N+1 query detection**: create or update the file at ` + "`%[1]s/internal/database/nplusone.go`" + ` with the following content:
+` + "```go" + `
+%[2]s` + "```" + `
+
+   Then create or update the file at ` + "`%[1]s/internal/middleware/nplusone.go`" + ` with the following content:
+` + "```go" + `
+%[3]s` + "```" + `
+
+   Register both right after opening the database in ` + "`cmd/web/main.go`" + `, before any transaction middleware so its transaction carries the tracked context:
+   ` + "```go" + `
+   if threshold := database.NPlusOneThreshold(); threshold > 0 {
+   	if err := db.Use(database.NPlusOneDetector{}); err != nil {
+   		e.Logger.Fatal("failed to register the N+1 query detector", err)
+   	}
+   	e.Use(appmiddleware.DetectNPlusOne(threshold))
+   }
+   ` + "```" + `
+   Repositories generated for this app pass the request context to ` + "`db.WithContext`" + `, so each of their queries is counted per request. When the same SQL runs ` + "`DB_N_PLUS_ONE_THRESHOLD`" + ` times (default 3) from one call site, the request logs a warning with the statement, the count, and the repository line and its caller: load the association with ` + "`Preload`" + ` or ` + "`Joins`" + ` there. Detection is off when ` + "`APP_ENV=production`" + `.
+`
+
+// appTransactionStepFormat explains how to enable per-request transactions; %[1]s is the app name, %[2]s and %[3]s the file contents
+const appTransactionStepFormat = `
+   **Per-request transactions**: create or update the file at ` + "`%[1]s/internal/database/tx.go`" + ` with the following content:
+` + "```go" + `
+%[2]s` + "```" + `
+
+   Then create or update the file at ` + "`%[1]s/internal/middleware/transaction.go`" + ` with the following content:
+` + "```go" + `
+%[3]s` + "```" + `
+
+   Register the middleware right after opening the database in ` + "`cmd/web/main.go`" + `:
+   ` + "```go" + `
+   e.Use(appmiddleware.Transaction(db))
+   ` + "```" + `
+   Every POST, PUT, PATCH and DELETE request then runs in one transaction that commits only when the handler succeeds. Repositories generated for this app call ` + "`database.FromContext(ctx, r.db)`" + `, so all repositories used by one request share that transaction and their writes are atomic.
+`
+
+// appReplicaStepFormat explains how to enable replica routing; %[1]s is the app name and %[2]s the replicas.go content
+const appReplicaStepFormat = `
+   **Read replicas**: create or update the file at ` + "`%[1]s/internal/database/replicas.go`" + ` with the following content:
+` + "```go" + `
+%[2]s` + "```" + `
+
+   Register the replicas right after opening the database in ` + "`cmd/web/main.go`" + `, then run ` + "`go get gorm.io/plugin/dbresolver`" + `:
+   ` + "```go" + `
+   cfg := database.ConfigFromEnv()
+   db, err := database.Open(cfg)
+   // handle err
+   if err := database.UseReplicas(db, cfg, database.ReplicaConfigFromEnv()); err != nil {
+   	e.Logger.Fatal("failed to configure read replicas", err)
+   }
+   ` + "```" + `
+   Queries then go to a replica from ` + "`DB_REPLICA_DSNS`" + ` (comma-separated) and writes to the primary. Repositories generated for this app pin each call explicitly with ` + "`dbresolver.Read`" + ` or ` + "`dbresolver.Write`" + `.
+`
+
+// appBootstrapStepFormat explains how to wire the components by hand in main.go; %[1]s is the app name, %[2]s its module path and %[3]s the database driver
+const appBootstrapStepFormat = `7. Bootstrap dependencies in ` + "`%[1]s/cmd/web/main.go`" + `:
+   After creating models, repositories, services, and controllers, you will need to create or update ` + "`%[1]s/cmd/web/main.go`" + ` to bootstrap these dependencies.
+   This typically involves:
+   - Importing ` + "`%[2]s/internal/database`" + `, which wraps ` + "`gorm.io/driver/%[3]s`" + ` (or your chosen database driver) and ` + "`gorm.io/gorm`" + `.
+   - Initializing the database connection (e.g., ` + "`db, err := database.Open(database.ConfigFromEnv())`" + ` from step 4).
+   - Auto-migrating your models (e.g., ` + "`db.AutoMigrate(&models.YourModel{})`" + `).
+   - Importing the repository, service and controllers of each model, which are packages of their own, under names of their own (e.g., ` + "`userrepository \"%[2]s/internal/repository/user\"`" + `).
+   - Creating instances of your repositories (e.g., ` + "`userRepo := userrepository.NewUserRepository(db)`" + `).
+   - Creating instances of your services (e.g., ` + "`userService := userservice.NewUserService(userRepo)`" + `).
+   - Creating instances of your controllers, injecting services (e.g., ` + "`userController := usercontrollers.NewUserController(userService)`" + `).
+   - Passing your controllers to the routes in ` + "`internal/router`" + ` (e.g., ` + "`router.Deps{UserController: userController}`" + `).
+
+   Here's an example of how ` + "`%[1]s/cmd/web/main.go`" + ` might look after adding a 'User' model with service layer:
+   ` + "```go" + `
+package main
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"%[2]s/internal/database"
+	"%[2]s/internal/models"
+	userrepository "%[2]s/internal/repository/user"
+	userservice "%[2]s/internal/service/user"
+	usercontrollers "%[2]s/internal/controllers/user"
+	appmiddleware "%[2]s/internal/middleware"
+	"%[2]s/internal/router"
+)
+
+func main() {
+	e := echo.New()
+	e.Use(middleware.Logger())
+	e.Use(middleware.Recover())
+	e.Use(appmiddleware.Timeout(appmiddleware.TimeoutConfig{Default: requestTimeout()})) // requestTimeout from step 2
+
+	// Database initialization: pool tuning, startup ping with retry and slow-query logging
+	db, err := database.Open(database.ConfigFromEnv())
+	if err != nil {
+		e.Logger.Fatal("failed to connect database", err)
+	}
+
+	// Auto-migrate models
+	err = db.AutoMigrate(&models.User{}) // Add all your models here
+	if err != nil {
+		e.Logger.Fatal("failed to auto migrate models", err)
+	}
 
-	return mcp.NewToolResultText(response), nil
+	// Initialize repositories
+	userRepo := userrepository.NewUserRepository(db)
+
+	// Initialize services
+	userService := userservice.NewUserService(userRepo)
+
+	// Routes: internal/router registers those of every controller in Deps
+	e.GET("/", hello)
+	router.RegisterRoutes(e, router.Deps{
+		UserController: usercontrollers.NewUserController(userService),
+	})
+
+	e.Logger.Fatal(e.Start(":1323"))
+}
+
+func hello(c echo.Context) error {
+	return c.String(http.StatusOK, "Hello, World!")
 }
+` + "```" + ``
+
+// appIntegrateFormat closes the next steps with the manual wiring of the components
+const appIntegrateFormat = `### 4. Integrate Components
+
+After generating these components, update your ` + "`cmd/web/main.go`" + ` file to:
+- Import all the necessary packages
+- Initialize the database connection
+- Auto-migrate your models
+- Create instances of repositories, services, and controllers
+- Pass the controllers to ` + "`router.RegisterRoutes`" + ` through ` + "`router.Deps`" + `; the controller tools add their routes to ` + "`internal/router/router.go`" + `
+
+`
+
+// appFxStepFormat explains the uber/fx wiring; %[1]s is the app name, %[2]s and %[3]s the file contents, %[4]s what NewDB and NewEcho already register
+const appFxStepFormat = `7. Wire the dependencies with uber/fx: ` + "`%[1]s/cmd/web/main.go`" + ` only runs ` + "`app.Module`" + `. Create or update the file at ` + "`%[1]s/internal/app/app.go`" + ` with the following content:
+` + "```go" + `
+%[2]s` + "```" + `
+
+   Then create or update the file at ` + "`%[1]s/internal/app/providers.go`" + ` with the following content:
+` + "```go" + `
+%[3]s` + "```" + `
+
+   fx calls every constructor of the provider sets once, in dependency order: ` + "`*gorm.DB`" + ` goes to the repositories, the repositories to the services and the services to the controllers, which the functions in ` + "`Routes`" + ` receive to register their routes. A missing constructor is reported when the app starts, naming the type nobody provides.
+   The model, service and controller tools tell you which line to add to ` + "`providers.go`" + ` for this app; running this tool again regenerates it from the project manifest. Fetch fx with ` + "`cd %[1]s && go get go.uber.org/fx`" + `.%[4]s`
+
+// appFxIntegrateFormat closes the next steps of an app wired with uber/fx
+const appFxIntegrateFormat = `### 4. Integrate Components
+
+After generating these components, add them to ` + "`internal/app/providers.go`" + `:
+- The model to ` + "`Models`" + `, so it is auto-migrated
+- The repository, service and controller constructors to ` + "`Repositories`" + `, ` + "`Services`" + ` and ` + "`Controllers`" + `
+- The controller's route function to ` + "`Routes`" + `
+
+` + "`cmd/web/main.go`" + ` does not change as models are added.
+
+`
+
+// appWireStepFormat explains the google/wire wiring; %[1]s is the app name, %[2]s to %[4]s the file contents, %[5]s what NewDB and NewEcho already register
+const appWireStepFormat = `7. Wire the dependencies with google/wire: ` + "`%[1]s/cmd/web/main.go`" + ` only calls ` + "`app.InitializeServer`" + `. Create or update the file at ` + "`%[1]s/internal/app/app.go`" + ` with the following content:
+` + "```go" + `
+%[2]s` + "```" + `
+
+   Then create or update the file at ` + "`%[1]s/internal/app/providers.go`" + ` with the following content:
+` + "```go" + `
+%[3]s` + "```" + `
+
+   And create the injector at ` + "`%[1]s/internal/app/wire.go`" + `, which only the wire command compiles:
+` + "```go" + `
+%[4]s` + "```" + `
+
+   ` + "`wire ./internal/app`" + ` writes ` + "`wire_gen.go`" + ` next to it, calling every constructor of ` + "`Providers`" + ` in dependency order: ` + "`*gorm.DB`" + ` goes to the repositories, the repositories to the services and the services to the controllers, which fill ` + "`router.Deps`" + `. A missing constructor is reported by wire before the app compiles, naming the type nobody provides; commit ` + "`wire_gen.go`" + ` and run wire again after changing ` + "`providers.go`" + `.
+   The model, service and controller tools tell you which line to add to ` + "`providers.go`" + ` for this app; running this tool again regenerates it from the project manifest. Install wire with ` + "`go install github.com/google/wire/cmd/wire@latest`" + `, then run ` + "`cd %[1]s && go get github.com/google/wire && wire ./internal/app`" + `.%[5]s`
+
+// appWireIntegrateFormat closes the next steps of an app wired with google/wire
+const appWireIntegrateFormat = `### 4. Integrate Components
+
+After generating these components, add them to ` + "`internal/app/providers.go`" + ` and run ` + "`wire ./internal/app`" + `:
+- The model to ` + "`Models`" + `, so it is auto-migrated
+- The repository, service and controller constructors to ` + "`Providers`" + `
+
+The controller tools add the routes to ` + "`internal/router/router.go`" + `, and wire fills ` + "`router.Deps`" + ` with the controllers. ` + "`cmd/web/main.go`" + ` does not change as models are added.
+
+`
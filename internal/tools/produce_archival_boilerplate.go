@@ -0,0 +1,215 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// columnIdentifier matches the unquoted column names the generated SQL can use as is
+var columnIdentifier = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// GetProduceArchivalBoilerplateTool returns the tool definition for produce_archival_boilerplate
+func GetProduceArchivalBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_archival_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a scheduled archival job for a model: records older than a configurable age move in batches from the hot table to an archive table, or to CSV objects in object storage, with admin endpoints to list and restore archived records and to run the job on demand."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("model_name",
+			mcp.Required(),
+			mcp.Description("The name of the model whose old records are archived (e.g., Order)."),
+		),
+		mcp.WithString("destination",
+			mcp.Description("Where archived records go: table (a <table>_archive table with the same columns plus archived_at) or csv (one CSV object per batch in a Store, such as an S3 bucket). Defaults to table."),
+			mcp.Enum("table", "csv"),
+		),
+		mcp.WithString("column",
+			mcp.Description("Timestamp column compared with the cutoff. Defaults to updated_at, so records untouched for max_age_days are archived; restoring a record updates it."),
+		),
+		mcp.WithNumber("max_age_days",
+			mcp.Description("Age in days after which records are archived. Defaults to 90."),
+		),
+		mcp.WithNumber("batch_size",
+			mcp.Description("Number of records moved per transaction or object. Defaults to 1000."),
+		),
+		mcp.WithString("interval",
+			mcp.Description("How often the job runs, as a Go duration. Defaults to 24h."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceArchivalBoilerplateHandler
+}
+
+// ProduceArchivalBoilerplateHandler handles requests to generate the archival job of a model
+// It creates the archive model or object store, the archiver with its schedule, and the admin controller
+func ProduceArchivalBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
+	}
+	titleModelName := naming.Pascal(modelName)
+	lowerModelName := strings.ToLower(titleModelName)
+	tableName := naming.Plural(naming.Snake(modelName))
+
+	destination := request.GetString("destination", "table")
+	if destination != "table" && destination != "csv" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'destination': expected table or csv, got '%s'.", destination)), nil
+	}
+	column := request.GetString("column", "updated_at")
+	if !columnIdentifier.MatchString(column) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'column': expected a column name such as updated_at, got '%s'.", column)), nil
+	}
+	timestamps := modelTimestamps(ctx, appName, titleModelName)
+	if (column == "created_at" || column == "updated_at") && !timestamps {
+		return mcp.NewToolResultError(fmt.Sprintf("The model '%s' was scaffolded without timestamps, so it has no %s column. Pass the column of one of its time.Time fields.", titleModelName, column)), nil
+	}
+	maxAgeDays := request.GetFloat("max_age_days", 90)
+	if maxAgeDays < 1 || maxAgeDays != float64(int(maxAgeDays)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'max_age_days': expected a positive whole number, got %v.", maxAgeDays)), nil
+	}
+	batchSize := request.GetFloat("batch_size", 1000)
+	if batchSize < 1 || batchSize != float64(int(batchSize)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'batch_size': expected a positive whole number, got %v.", batchSize)), nil
+	}
+	interval, err := time.ParseDuration(request.GetString("interval", "24h"))
+	if err != nil || interval <= 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'interval': expected a positive Go duration such as 24h, got '%s'.", request.GetString("interval", ""))), nil
+	}
+
+	state.From(ctx).RecordComponent(appName, titleModelName, "archival")
+	state.From(ctx).SetModelOption(appName, titleModelName, "archival", destination)
+
+	formats := archivalTableFiles
+	setup := fmt.Sprintf("if err := db.AutoMigrate(&models.Archived%[1]s{}); err != nil {\n   \te.Logger.Fatal(\"failed to migrate archived %[2]s records\", err)\n   }\n   %[2]sArchiver := archive.New%[1]sArchiver(db)", titleModelName, lowerModelName)
+	restoreRoute := fmt.Sprintf("admin.POST(\"/archive/%s/:id/restore\", %sArchiveController.Restore)", tableName, lowerModelName)
+	if destination == "csv" {
+		formats = archivalCSVFiles
+		setup = fmt.Sprintf("// Replace DirStore with a Store writing to your bucket\n   %[2]sArchiver := archive.New%[1]sArchiver(db, archive.DirStore{Dir: \"archive\"})", titleModelName, lowerModelName)
+		restoreRoute = fmt.Sprintf("admin.POST(\"/archive/%s/restore\", %sArchiveController.Restore) // ?key=<object key>", tableName, lowerModelName)
+	}
+
+	args := []any{
+		titleModelName,       // %[1]s
+		lowerModelName,       // %[2]s
+		appName,              // %[3]s
+		setup,                // %[4]s
+		goDuration(interval), // %[5]s
+		tableName,            // %[6]s
+		restoreRoute,         // %[7]s
+		column,               // %[8]s
+	}
+	files, err := renderFiles(ctx, formats, map[string]any{
+		"App":        appName,
+		"Model":      titleModelName,
+		"Lower":      lowerModelName,
+		"Table":      tableName,
+		"Column":     column,
+		"MaxAgeDays": strconv.Itoa(int(maxAgeDays)),
+		"BatchSize":  strconv.Itoa(int(batchSize)),
+		"Touch":      column == "updated_at",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var steps strings.Builder
+	for i, f := range files {
+		fmt.Fprintf(&steps, "%d. Create or update the file at `%s` with the following content:\n```%s\n%s```\n\n", i+2, f.Path, f.Language, f.Content)
+	}
+
+	response := fmt.Sprintf(`
+# Archival Scaffold Instructions
+
+To scaffold the archival job for model '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/archive internal/controllers/admin`"+`
+
+%[9]s%[10]d. Wire it up in `+"`cmd/web/main.go`"+` after opening the database:
+   `+"```go"+`
+   %[4]s
+   %[2]sArchiver.Start(context.Background(), %[5]s)
+
+   %[2]sArchiveController := admincontroller.New%[1]sArchiveController(%[2]sArchiver)
+   admin := e.Group("/admin") // add your admin authorization middleware here
+   admin.GET("/archive/%[6]s", %[2]sArchiveController.List)
+   %[7]s
+   admin.POST("/archive/%[6]s/run", %[2]sArchiveController.Run)
+   `+"```"+`
+
+   Every run moves the records whose `+"`%[8]s`"+` is older than `+"`MaxAge`"+` out of `+"`%[6]s`"+` in batches of `+"`BatchSize`"+`, soft-deleted ones included, so the hot table and its indexes stay small. Adjust both fields on the archiver to tune it.
+`, append(args, steps.String(), len(files)+2)...)
+
+	notes := []string{
+		"Run the archiver on a single instance, or behind a lock: concurrent runs pick the same batch.",
+		"Add an index on " + tableName + " (" + column + ") so each run finds old records without scanning the table.",
+	}
+	if column != "updated_at" {
+		notes = append(notes, fmt.Sprintf("Restored records keep their %s, so the next run archives them again; update it when restoring, or restore only records you need briefly.", column))
+	}
+	if destination == "csv" {
+		notes = append(notes, "Each CSV cell holds the JSON value of a field; fields tagged json:\"-\" are not archived, so give them a JSON name or archive to a table instead.")
+	} else {
+		notes = append(notes, fmt.Sprintf("Add models.Archived%s to AutoMigrate; it embeds the model, so new columns reach the archive table too.", titleModelName))
+	}
+	if project, ok := state.From(ctx).Project(appName); ok {
+		for _, m := range project.Models {
+			if m.Name == titleModelName && m.Options["partition_by"] == "date" {
+				notes = append(notes, fmt.Sprintf("%s is partitioned by date: detaching and dropping old partitions is cheaper than deleting rows, once their data has been archived.", tableName))
+			}
+		}
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, provide the archiver and the controller in internal/app/app.go, and start the archiver and register the routes in an fx.Invoke function.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{"mkdir -p internal/archive internal/controllers/admin"},
+		Notes:    notes,
+	}), nil
+}
+
+// archivalTableFiles lists the files of the archive table destination in the order they appear in the instructions
+var archivalTableFiles = []fileFormat{
+	{Path: "internal/models/{{.Lower}}_archive.go", Language: "go", Template: "archival/archive_model.go"},
+	{Path: "internal/archive/{{.Lower}}.go", Language: "go", Template: "archival/archiver_table.go"},
+	{Path: "internal/controllers/admin/{{.Lower}}_archive.go", Language: "go", Template: "archival/controller_table.go"},
+}
+
+// archivalCSVFiles lists the files of the CSV object destination in the order they appear in the instructions
+var archivalCSVFiles = []fileFormat{
+	{Path: "internal/archive/store.go", Language: "go", Template: "archival/store.go"},
+	{Path: "internal/archive/{{.Lower}}.go", Language: "go", Template: "archival/archiver_csv.go"},
+	{Path: "internal/controllers/admin/{{.Lower}}_archive.go", Language: "go", Template: "archival/controller_csv.go"},
+}
@@ -0,0 +1,231 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceAuthThrottleBoilerplateTool returns the tool definition for produce_auth_throttle_boilerplate
+func GetProduceAuthThrottleBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_auth_throttle_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output throttling for the login and registration endpoints: per-IP and per-account failure counters kept in the database or Redis, an exponential delay between failed logins, lockouts once a limit is reached, an audit log of attempts, and admin endpoints to read the log and lift locks. Unlike API rate limiting, only failed attempts count against a login."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("store",
+			mcp.Description("Where the counters are kept: database (an auth_throttles table) or redis (REDIS_URL). The audit log is always in the database. Defaults to database."),
+			mcp.Enum("database", "redis"),
+		),
+		mcp.WithString("account_field",
+			mcp.Description("The form or JSON field of the login request naming the account. Defaults to email."),
+		),
+		mcp.WithNumber("max_attempts",
+			mcp.Description("Failed logins of one account before it is locked. Defaults to 5."),
+		),
+		mcp.WithNumber("ip_max_attempts",
+			mcp.Description("Failed logins from one IP, whatever the account, before it is locked; keep it well above max_attempts for users behind a shared address. Defaults to 30."),
+		),
+		mcp.WithNumber("registration_max_attempts",
+			mcp.Description("Registrations from one IP, successful or not, before it is locked. Defaults to 10."),
+		),
+		mcp.WithString("lockout",
+			mcp.Description("How long a lock lasts, and how long failures are remembered after the last one, as a Go duration. Defaults to 15m."),
+		),
+		mcp.WithString("base_delay",
+			mcp.Description("Wait after a failed login, doubled by each further failure up to the lockout, as a Go duration. Defaults to 1s."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceAuthThrottleBoilerplateHandler
+}
+
+// ProduceAuthThrottleBoilerplateHandler handles requests to generate the throttling of the auth endpoints
+// It creates the throttle with the chosen store, the audit log, the middleware and the admin controller
+func ProduceAuthThrottleBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	store := request.GetString("store", "database")
+	if store != "database" && store != "redis" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'store': expected database or redis, got '%s'.", store)), nil
+	}
+	accountField := request.GetString("account_field", "email")
+	if !columnIdentifier.MatchString(accountField) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'account_field' '%s': expected a snake_case field name such as email.", accountField)), nil
+	}
+	attempts := map[string]int{}
+	for _, param := range []struct {
+		name     string
+		fallback float64
+	}{{"max_attempts", 5}, {"ip_max_attempts", 30}, {"registration_max_attempts", 10}} {
+		value := request.GetFloat(param.name, param.fallback)
+		if value < 1 || value != float64(int(value)) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid '%s': expected a positive whole number, got %v.", param.name, value)), nil
+		}
+		attempts[param.name] = int(value)
+	}
+	lockoutValue := request.GetString("lockout", "15m")
+	lockout, err := time.ParseDuration(lockoutValue)
+	if err != nil || lockout < time.Second {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'lockout': expected a Go duration of at least 1s such as 15m, got '%s'.", lockoutValue)), nil
+	}
+	baseDelay, err := time.ParseDuration(request.GetString("base_delay", "1s"))
+	if err != nil || baseDelay <= 0 || baseDelay > lockout {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'base_delay': expected a positive Go duration no longer than the lockout, got '%s'.", request.GetString("base_delay", ""))), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "auth_throttle_store", store)
+
+	redis := store == "redis"
+	formats := []fileFormat{
+		{Path: "internal/models/auth_throttle.go", Language: "go", Template: "auth_throttle/auth_throttle.go"},
+		{Path: "internal/throttle/throttle.go", Language: "go", Template: "auth_throttle/throttle.go"},
+		{Path: "internal/throttle/audit.go", Language: "go", Template: "auth_throttle/audit.go"},
+		{Path: "internal/throttle/store_" + store + ".go", Language: "go", Template: "auth_throttle/store_" + store + ".go"},
+		{Path: "internal/middleware/auth_throttle.go", Language: "go", Template: "auth_throttle/middleware.go"},
+		{Path: "internal/controllers/auththrottle/controller.go", Language: "go", Template: "auth_throttle/controller.go"},
+	}
+	files, err := renderFiles(ctx, formats, map[string]any{
+		"App":                  appName,
+		"Redis":                redis,
+		"AccountField":         accountField,
+		"AccountAttempts":      strconv.Itoa(attempts["max_attempts"]),
+		"IPAttempts":           strconv.Itoa(attempts["ip_max_attempts"]),
+		"RegistrationAttempts": strconv.Itoa(attempts["registration_max_attempts"]),
+		"Lockout":              goDuration(lockout),
+		"BaseDelay":            goDuration(baseDelay),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	storeStep := "`internal/throttle/store_database.go`" + ` with the counters in the auth_throttles table:`
+	migrate := "&models.AuthThrottle{}, &models.AuthEvent{}"
+	setup := `throttleStore := throttle.NewDatabaseStore(db)
+   throttleStore.StartPruning(context.Background(), throttle.DefaultLimits.Lockout, time.Hour)`
+	if redis {
+		storeStep = "`internal/throttle/store_redis.go`" + ` with the counters in Redis:`
+		migrate = "&models.AuthEvent{}"
+		setup = `redisOptions, err := redis.ParseURL(os.Getenv("REDIS_URL"))
+   if err != nil {
+   	e.Logger.Fatal("invalid REDIS_URL", err)
+   }
+   throttleStore := throttle.NewRedisStore(redis.NewClient(redisOptions))`
+	}
+	args := []any{
+		appName,                                // %[1]s
+		storeStep,                              // %[2]s
+		migrate,                                // %[3]s
+		setup,                                  // %[4]s
+		strconv.Itoa(attempts["max_attempts"]), // %[5]s
+		lockoutValue,                           // %[6]s
+		accountField,                           // %[7]s
+	}
+
+	response := fmt.Sprintf(`
+# Auth Throttle Scaffold Instructions
+
+To scaffold the throttling of the login and registration endpoints for the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/throttle internal/controllers/auththrottle`"+`
+
+2. Create or update the file at `+"`internal/models/auth_throttle.go`"+` with the following content:
+`+"```go"+`
+%[8]s`+"```"+`
+
+3. Create or update the file at `+"`internal/throttle/throttle.go`"+` with the limits and the throttle:
+`+"```go"+`
+%[9]s`+"```"+`
+
+4. Create or update the file at `+"`internal/throttle/audit.go`"+` with the audit log:
+`+"```go"+`
+%[10]s`+"```"+`
+
+5. Create or update the file at %[2]s
+`+"```go"+`
+%[11]s`+"```"+`
+
+6. Create or update the file at `+"`internal/middleware/auth_throttle.go`"+` with the following content:
+`+"```go"+`
+%[12]s`+"```"+`
+
+7. Create or update the file at `+"`internal/controllers/auththrottle/controller.go`"+` with the following content:
+`+"```go"+`
+%[13]s`+"```"+`
+
+8. Wire it up in `+"`cmd/web/main.go`"+` after opening the database:
+   `+"```go"+`
+   if err := db.AutoMigrate(%[3]s); err != nil {
+   	e.Logger.Fatal("failed to migrate auth throttle tables", err)
+   }
+   %[4]s
+   authAudit := throttle.NewAudit(db)
+   authThrottle := throttle.New(throttleStore, authAudit, throttle.DefaultLimits)
+   authThrottleController := auththrottlecontroller.NewAuthThrottleController(authThrottle, authAudit)
+
+   // Add the middleware to your own login and registration routes
+   e.POST("/login", authController.Login, appmiddleware.AuthThrottle(authThrottle, throttle.Login))
+   e.POST("/register", authController.Register, appmiddleware.AuthThrottle(authThrottle, throttle.Registration))
+
+   admin := e.Group("/admin") // add your admin authorization middleware here
+   admin.GET("/auth/events", authThrottleController.Events)
+   admin.DELETE("/auth/locks", authThrottleController.Unlock)
+   `+"```"+`
+
+   An account is locked for %[6]s after %[5]s failed logins, read from the `+"`%[7]s`"+` field of the request. Before that, each failure doubles the wait before the next attempt, which gets 429 Too Many Requests with a `+"`Retry-After`"+` header until it passes.
+`, append(args, fileContents(files)...)...) // %[8]s onwards: file contents
+
+	notes := []string{
+		"Add the auth throttle models to AutoMigrate, create the throttle and put appmiddleware.AuthThrottle on the login and registration routes in cmd/web/main.go.",
+		"The login handler must answer a failed sign-in with a 4xx status (401 or 422): the middleware counts failures from the status, and a 2xx or 3xx answer clears the account's failures.",
+		"Attempts are keyed by c.RealIP(): behind a proxy or load balancer, set e.IPExtractor (e.g. echo.ExtractIPFromXFFHeader()) so every client is not counted as the proxy's address.",
+		"A locked account can be tried again once the lock expires, or unlocked by an admin with DELETE /admin/auth/locks?account=...; lock the admin routes behind your authorization middleware.",
+		"The audit log keeps the submitted account names, including mistyped ones; delete old auth_events rows according to your retention policy.",
+	}
+	if redis {
+		notes = append(notes, "Set REDIS_URL (e.g. redis://localhost:6379/0); every instance of the app shares the counters through it.")
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, provide the store, throttle.NewAudit, throttle.New and auththrottlecontroller.NewAuthThrottleController in internal/app/app.go, and register the middleware and routes in an fx.Invoke function.")
+	}
+	if usesWire(ctx, appName) {
+		notes = append(notes, "With google/wire, add the store, throttle.NewAudit, throttle.New and auththrottlecontroller.NewAuthThrottleController to Providers, take them in NewEcho to register the middleware and routes, and run wire ./internal/app.")
+	}
+
+	commands := []string{"mkdir -p internal/throttle internal/controllers/auththrottle"}
+	if redis {
+		commands = append(commands, "go get github.com/redis/go-redis/v9")
+	}
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: commands,
+		Notes:    notes,
+		routes:   []string{"GET /admin/auth/events", "DELETE /admin/auth/locks"},
+	}), nil
+}
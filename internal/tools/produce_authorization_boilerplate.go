@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceAuthorizationBoilerplateTool returns the tool definition for produce_authorization_boilerplate
+func GetProduceAuthorizationBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_authorization_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output an ownership policy for a model: records carry an owner ID set on create, only the owner or an admin may update or delete them, and list and get are scoped to the current user, who is read from the authentication middleware."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("model_name",
+			mcp.Required(),
+			mcp.Description("The name of the model to protect (e.g., Post, Invoice)."),
+		),
+		mcp.WithString("owner_field",
+			mcp.Description("Name of the model field holding the owner's user ID. Defaults to OwnerID."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceAuthorizationBoilerplateHandler
+}
+
+// ProduceAuthorizationBoilerplateHandler handles requests to generate ownership checks for a model
+// It creates the shared authz package, the model's policy and the service methods enforcing it
+func ProduceAuthorizationBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
+	}
+	ownerField := request.GetString("owner_field", "OwnerID")
+	if !token.IsIdentifier(ownerField) || !unicode.IsUpper([]rune(ownerField)[0]) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'owner_field' '%s': expected an exported Go field name such as OwnerID.", ownerField)), nil
+	}
+
+	titleModelName := naming.Pascal(modelName)
+	lowerModelName := strings.ToLower(titleModelName)
+	ownerColumn := naming.Snake(ownerField)
+
+	state.From(ctx).RecordComponent(appName, titleModelName, "authorization")
+	state.From(ctx).SetModelOption(appName, titleModelName, "owner_field", ownerField)
+
+	args := []any{
+		titleModelName, // %[1]s
+		lowerModelName, // %[2]s
+		appName,        // %[3]s
+		ownerField,     // %[4]s
+		ownerColumn,    // %[5]s
+	}
+	files, err := renderFiles(ctx, authorizationFiles, map[string]any{
+		"Model":       titleModelName,
+		"Lower":       lowerModelName,
+		"App":         appName,
+		"Owner":       ownerField,
+		"OwnerColumn": ownerColumn,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Authorization Policy Scaffold Instructions
+
+To scaffold the ownership policy for model '%[1]s', please perform the following steps:
+
+1. Add the owner to the model struct in `+"`internal/models/%[2]s.go`"+` and migrate:
+   `+"```go"+`
+   %[4]s uint `+"`gorm:\"index;not null\" json:\"%[5]s\"`"+`
+   `+"```"+`
+   Do not add it to the create or update request DTOs: the owner is always the signed-in user.
+
+2. Create or update the file at `+"`internal/authz/authz.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+
+3. Create or update the file at `+"`internal/service/%[2]s/policy.go`"+` with the following content:
+`+"```go"+`
+%[7]s`+"```"+`
+
+4. Add the policy to `+"`%[1]sServiceImpl`"+` in `+"`internal/service/%[2]s/service.go`"+`. Its zero value is ready to use, so `+"`New%[1]sService`"+` does not change:
+   `+"```go"+`
+   type %[1]sServiceImpl struct {
+   	%[2]sRepo repository.%[1]sRepository
+   	policy %[1]sPolicy
+   }
+   `+"```"+`
+
+5. Replace `+"`internal/service/%[2]s/create.go`"+` with the following content:
+`+"```go"+`
+%[8]s`+"```"+`
+
+6. Replace `+"`internal/service/%[2]s/get_by_id.go`"+` with the following content:
+`+"```go"+`
+%[9]s`+"```"+`
+
+7. Replace `+"`internal/service/%[2]s/list.go`"+` with the following content:
+`+"```go"+`
+%[10]s`+"```"+`
+
+8. Replace `+"`internal/service/%[2]s/update.go`"+` with the following content:
+`+"```go"+`
+%[11]s`+"```"+`
+
+9. Replace `+"`internal/service/%[2]s/delete.go`"+` with the following content:
+`+"```go"+`
+%[12]s`+"```"+`
+
+10. Register the middleware in `+"`cmd/web/main.go`"+`, right after your authentication middleware:
+   `+"```go"+`
+   e.Use(authz.Middleware())
+   `+"```"+`
+   Your authentication middleware must call `+"`c.Set(\"user_id\", user.ID)`"+` (a uint) and `+"`c.Set(\"is_admin\", user.Admin)`"+`, the same keys the request logging scaffold reads.
+
+11. In the %[1]s controller handlers, report policy errors with their status instead of 500:
+   `+"```go"+`
+   return echo.NewHTTPError(authz.Status(err), err.Error())
+   `+"```"+`
+`, append(args, fileContents(files)...)...) // %[6]s onwards: file contents
+
+	notes := []string{
+		fmt.Sprintf("Add %s to models.%s and migrate; existing rows need an owner before the column can be NOT NULL.", ownerField, titleModelName),
+		"Set user_id and is_admin in your authentication middleware and register authz.Middleware() after it.",
+		"Anonymous requests get 401 from every service method: keep public endpoints out of the protected service or give them their own methods.",
+	}
+	if project, ok := state.From(ctx).Project(appName); ok {
+		for _, model := range project.Models {
+			if model.Name == titleModelName && slices.Contains(model.Components, "scopes") {
+				notes = append(notes, fmt.Sprintf("%sService.Search bypasses the filters: start its scopes with s.policy.Scope(ctx) so searches are scoped to the current user too.", titleModelName))
+			}
+		}
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Notes: notes,
+	}), nil
+}
+
+// authorizationFiles lists the authorization files of a model in the order they appear in the instructions
+var authorizationFiles = []fileFormat{
+	{Path: "internal/authz/authz.go", Language: "go", Template: "authorization/authz.go"},
+	{Path: "internal/service/{{.Lower}}/policy.go", Language: "go", Template: "authorization/policy.go"},
+	{Path: "internal/service/{{.Lower}}/create.go", Language: "go", Template: "authorization/create.go"},
+	{Path: "internal/service/{{.Lower}}/get_by_id.go", Language: "go", Template: "authorization/get_by_id.go"},
+	{Path: "internal/service/{{.Lower}}/list.go", Language: "go", Template: "authorization/list.go"},
+	{Path: "internal/service/{{.Lower}}/update.go", Language: "go", Template: "authorization/update.go"},
+	{Path: "internal/service/{{.Lower}}/delete.go", Language: "go", Template: "authorization/delete.go"},
+}
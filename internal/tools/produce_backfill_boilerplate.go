@@ -0,0 +1,180 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceBackfillBoilerplateTool returns the tool definition for produce_backfill_boilerplate
+func GetProduceBackfillBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_backfill_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a cmd/backfill command and a backfill job for a model: records are walked in primary key order in rate-limited batches, each committed with a checkpoint so an interrupted run resumes where it stopped, with progress logging. Use it to populate a new column or reindex search for existing rows."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("model_name",
+			mcp.Required(),
+			mcp.Description("The name of the model whose existing records are backfilled (e.g., Product)."),
+		),
+		mcp.WithString("kind",
+			mcp.Description("What the job does with each batch: column (compute and store a new column of every record whose column is NULL, soft-deleted ones included) or reindex (send the records to a search index). Defaults to column."),
+			mcp.Enum("column", "reindex"),
+		),
+		mcp.WithString("column",
+			mcp.Description("The new column to populate, e.g. slug. Required when kind is column."),
+		),
+		mcp.WithString("job",
+			mcp.Description("Name of the job, passed to the command as -job. Defaults to populate_<table>_<column> or reindex_<table>."),
+		),
+		mcp.WithNumber("batch_size",
+			mcp.Description("Default number of records per batch and transaction. Defaults to 500."),
+		),
+		mcp.WithNumber("rate",
+			mcp.Description("Default maximum number of batches per second, to spare the database; 0 disables the limit. Defaults to 5."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceBackfillBoilerplateHandler
+}
+
+// ProduceBackfillBoilerplateHandler handles requests to generate a backfill job of a model
+// It creates the shared runner, the job and the command running jobs by name
+func ProduceBackfillBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
+	}
+	titleModelName := naming.Pascal(modelName)
+	lowerModelName := strings.ToLower(titleModelName)
+	tableName := naming.Plural(naming.Snake(modelName))
+
+	kind := request.GetString("kind", "column")
+	if kind != "column" && kind != "reindex" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'kind': expected column or reindex, got '%s'.", kind)), nil
+	}
+	column, job := request.GetString("column", ""), "reindex_"+tableName
+	if kind == "column" {
+		if column == "" {
+			return missingParameterResult(ctx, "column", "the new column of "+tableName+" to populate, e.g. slug", nil), nil
+		}
+		if !columnIdentifier.MatchString(column) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'column': expected a column name such as slug, got '%s'.", column)), nil
+		}
+		job = "populate_" + tableName + "_" + column
+	}
+	job = request.GetString("job", job)
+	if !columnIdentifier.MatchString(job) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'job': expected a snake_case name such as %s, got '%s'.", "populate_"+tableName+"_slug", job)), nil
+	}
+	batchSize := request.GetFloat("batch_size", 500)
+	if batchSize < 1 || batchSize != float64(int(batchSize)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'batch_size': expected a positive whole number, got %v.", batchSize)), nil
+	}
+	rate := request.GetFloat("rate", 5)
+	if rate < 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'rate': expected 0 or more batches per second, got %v.", rate)), nil
+	}
+
+	state.From(ctx).RecordComponent(appName, titleModelName, "backfill")
+	state.From(ctx).SetModelOption(appName, titleModelName, "backfill_"+job, kind)
+
+	field := naming.Pascal(column)
+	files, err := renderFiles(ctx, backfillFiles[kind], map[string]any{
+		"App":       appName,
+		"Model":     titleModelName,
+		"Lower":     lowerModelName,
+		"Plural":    naming.Plural(titleModelName),
+		"Job":       job,
+		"Column":    column,
+		"Field":     field,
+		"BatchSize": strconv.Itoa(int(batchSize)),
+		"Rate":      strconv.FormatFloat(rate, 'f', -1, 64),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var steps strings.Builder
+	for i, f := range files {
+		fmt.Fprintf(&steps, "%d. Create or update the file at `%s` with the following content:\n```%s\n%s```\n\n", i+2, f.Path, f.Language, f.Content)
+	}
+	when := "after creating or changing the search index"
+	implement := fmt.Sprintf("Implement `index%[1]s` in `internal/backfill/%[2]s.go` with your search client, sending each batch in one bulk request.", naming.Plural(titleModelName), job)
+	if kind == "column" {
+		implement = fmt.Sprintf("Add `%[3]s` to `%[4]s` as a nullable column first (a pointer field of `models.%[1]s`), then implement `compute%[1]s%[5]s` in `internal/backfill/%[2]s.go`. The job selects the records whose `%[3]s` is still NULL; once it is done, new records get the value from your application code and the column can be made NOT NULL.", titleModelName, job, column, tableName, field)
+		when = "right after deploying the migration that adds the column"
+	}
+
+	response := fmt.Sprintf(`
+# Backfill Scaffold Instructions
+
+To scaffold the backfill job '%[1]s' for model '%[2]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p cmd/backfill internal/backfill`"+`
+
+%[3]s%[4]d. %[5]s
+
+%[6]d. Run the job, for instance %[7]s:
+   `+"`go run ./cmd/backfill -job %[1]s`"+`
+
+   Each batch is processed in a transaction that also saves the job's checkpoint in `+"`backfill_checkpoints`"+`, so stopping the command (Ctrl-C or SIGTERM) and running it again resumes after the last committed batch. `+"`-batch-size`"+` and `+"`-rate`"+` override the job's defaults for one run, and `+"`-restart`"+` starts over from the first record.
+`, job, titleModelName, steps.String(), len(files)+2, implement, len(files)+3, when)
+
+	notes := []string{
+		"Run one instance of a job at a time: concurrent runs of the same job overwrite each other's checkpoint.",
+		"Progress is logged after every batch with the throughput and the estimated time left; lower -rate if the database struggles while the job runs.",
+		"Add jobs by running this tool again: every job file registers itself, and cmd/backfill/main.go and internal/backfill/backfill.go stay the same.",
+	}
+	if kind == "reindex" {
+		notes = append(notes, "Soft-deleted records are skipped; remove them from the index where your application deletes them.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{"mkdir -p cmd/backfill internal/backfill", "go run ./cmd/backfill -job " + job},
+		Notes:    notes,
+	}), nil
+}
+
+// backfillFiles lists the files of each kind of job in the order they appear in the instructions
+var backfillFiles = map[string][]fileFormat{
+	"column": {
+		{Path: "internal/backfill/backfill.go", Language: "go", Template: "backfill/backfill.go"},
+		{Path: "internal/backfill/{{.Job}}.go", Language: "go", Template: "backfill/job_column.go"},
+		{Path: "cmd/backfill/main.go", Language: "go", Template: "backfill/main.go"},
+	},
+	"reindex": {
+		{Path: "internal/backfill/backfill.go", Language: "go", Template: "backfill/backfill.go"},
+		{Path: "internal/backfill/{{.Job}}.go", Language: "go", Template: "backfill/job_reindex.go"},
+		{Path: "cmd/backfill/main.go", Language: "go", Template: "backfill/main.go"},
+	},
+}
@@ -0,0 +1,161 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceBackupBoilerplateTool returns the tool definition for produce_backup_boilerplate
+func GetProduceBackupBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_backup_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output database backups for an application: a backup package taking consistent snapshots (pg_dump for Postgres, VACUUM INTO for SQLite), storing them in a directory or an S3 bucket and deleting those past a retention period, a scheduled job in the web server, and a cmd/backup command to take, list, prune and restore backups."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		dialectOption,
+		mcp.WithString("storage",
+			mcp.Description("Where backups are kept: dir (files under BACKUP_DIR, e.g. a mounted volume) or s3 (BACKUP_BUCKET, also for S3-compatible services). Defaults to dir."),
+			mcp.Enum("dir", "s3"),
+		),
+		mcp.WithNumber("retention_days",
+			mcp.Description("Days a backup is kept; the newest backup is never deleted. Defaults to 14; BACKUP_RETENTION_DAYS overrides it at runtime."),
+		),
+		mcp.WithString("interval",
+			mcp.Description("How often the scheduled job takes a backup, as a Go duration. Defaults to 24h."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceBackupBoilerplateHandler
+}
+
+// ProduceBackupBoilerplateHandler handles requests to generate the backups of an application
+// It creates the backup package for the app's database and storage, and the command managing backups
+func ProduceBackupBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	dialect := appDialect(ctx, request, appName)
+	if dialect != "sqlite" && dialect != "postgres" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'dialect' '%s': expected sqlite or postgres.", dialect)), nil
+	}
+	storage := request.GetString("storage", "dir")
+	if storage != "dir" && storage != "s3" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'storage': expected dir or s3, got '%s'.", storage)), nil
+	}
+	retentionDays := request.GetFloat("retention_days", 14)
+	if retentionDays < 1 || retentionDays != float64(int(retentionDays)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'retention_days': expected a positive whole number, got %v.", retentionDays)), nil
+	}
+	interval, err := time.ParseDuration(request.GetString("interval", "24h"))
+	if err != nil || interval <= 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'interval': expected a positive Go duration such as 24h, got '%s'.", request.GetString("interval", ""))), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "backup_storage", storage)
+
+	formats := []fileFormat{
+		{Path: "internal/backup/backup.go", Language: "go", Template: "backup/backup.go"},
+		{Path: "internal/backup/dump.go", Language: "go", Template: "backup/dump_" + dialect + ".go"},
+		{Path: "internal/backup/store.go", Language: "go", Template: "backup/store_" + storage + ".go"},
+		{Path: "cmd/backup/main.go", Language: "go", Template: "backup/main.go"},
+	}
+	files, err := renderFiles(ctx, formats, map[string]any{
+		"App":           appName,
+		"Driver":        dialect,
+		"RetentionDays": strconv.Itoa(int(retentionDays)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var steps strings.Builder
+	for i, f := range files {
+		fmt.Fprintf(&steps, "%d. Create or update the file at `%s` with the following content:\n```%s\n%s```\n\n", i+2, f.Path, f.Language, f.Content)
+	}
+	snapshot := "`VACUUM INTO` copies a consistent snapshot of the database file while the application keeps running; the copy is gzipped on its way to the store."
+	restore := "Stop the application first: the restore replaces the database file."
+	if dialect == "postgres" {
+		snapshot = "`pg_dump` reads a consistent snapshot without blocking writers and streams it in its compressed custom format to the store; the server taking backups needs `pg_dump` and `pg_restore` of the database's major version or later."
+		restore = "`pg_restore --clean --single-transaction` replaces the objects of the dump, so a failed restore leaves the database as it was."
+	}
+	commands := []string{"mkdir -p internal/backup cmd/backup"}
+	if storage == "s3" {
+		commands = append(commands, "go get github.com/aws/aws-sdk-go-v2/config github.com/aws/aws-sdk-go-v2/service/s3 github.com/aws/aws-sdk-go-v2/feature/s3/manager")
+	}
+	commands = append(commands, "go run ./cmd/backup run")
+
+	response := fmt.Sprintf(`
+# Backup Scaffold Instructions
+
+To scaffold database backups for the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/backup cmd/backup`"+`
+
+%[2]s%[3]d. Schedule the backups in `+"`cmd/web/main.go`"+` after opening the database:
+   `+"```go"+`
+   backupStore, err := backup.StoreFromEnv(context.Background())
+   if err != nil {
+   	e.Logger.Fatal("failed to configure the backup store", err)
+   }
+   backup.New(db, backupStore, backup.ConfigFromEnv(database.ConfigFromEnv().DSN)).Start(context.Background(), %[4]s)
+   `+"```"+`
+
+   %[5]s Each backup is stored under `+"`<BACKUP_PREFIX><timestamp>`"+`, then the backups older than `+"`BACKUP_RETENTION_DAYS`"+` are deleted, keeping at least the newest one.
+
+%[6]d. Manage backups from the command line:
+   `+"```"+`
+   go run ./cmd/backup run                  # take a backup now
+   go run ./cmd/backup list                 # newest first
+   go run ./cmd/backup restore -yes         # restore the newest backup, or pass -key
+   `+"```"+`
+
+   %[7]s Practise a restore into a scratch database regularly: a backup that was never restored is not a disaster-recovery plan.
+`, appName, steps.String(), len(files)+2, goDuration(interval), snapshot, len(files)+3, restore)
+	if storage == "s3" {
+		response += "\n   Set `BACKUP_BUCKET` and the usual `AWS_REGION` and credentials variables; `AWS_ENDPOINT_URL` points the store at an S3-compatible service. Fetch the SDK with `go get github.com/aws/aws-sdk-go-v2/config github.com/aws/aws-sdk-go-v2/service/s3 github.com/aws/aws-sdk-go-v2/feature/s3/manager`.\n"
+	}
+
+	notes := []string{
+		"Run the scheduled job on a single instance, or drop it and run `go run ./cmd/backup run` from cron or a Kubernetes CronJob instead.",
+		"Use a bucket lifecycle rule or object lock as a second line of defence: the retention of this job deletes backups with the application's own credentials.",
+	}
+	if storage == "dir" {
+		notes = append(notes, "A backup directory on the database host does not survive the loss of the host; mount a volume that is copied elsewhere, or use storage=s3.")
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, start the backup job in an fx.Invoke function of internal/app/app.go that receives the *gorm.DB.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: commands,
+		Notes:    notes,
+	}), nil
+}
@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceCacheBoilerplateTool returns the tool definition for produce_cache_boilerplate
+func GetProduceCacheBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_cache_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a generic in-memory LRU cache package with a TTL and a load-on-miss helper that lets concurrent misses share one database call, plus a service decorator caching a model's GetByID and invalidating it on update and delete, for caching without running Redis."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("model_name",
+			mcp.Required(),
+			mcp.Description("The name of the model whose service reads are cached (e.g., Product, Category)."),
+		),
+		mcp.WithNumber("capacity",
+			mcp.Description("Maximum number of records kept in memory; the least recently used one is evicted beyond it. Defaults to 1000."),
+		),
+		mcp.WithString("ttl",
+			mcp.Description("How long a cached record is served before it is read again, as a Go duration. Defaults to 5m."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceCacheBoilerplateHandler
+}
+
+// ProduceCacheBoilerplateHandler handles requests to generate the in-memory cache package
+// It creates the cache and a decorator of the model's service reading through it
+func ProduceCacheBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
+	}
+	capacity := request.GetFloat("capacity", 1000)
+	if capacity < 1 || capacity != float64(int(capacity)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'capacity': expected a positive whole number, got %v.", capacity)), nil
+	}
+	ttlValue := request.GetString("ttl", "5m")
+	ttl, err := time.ParseDuration(ttlValue)
+	if err != nil || ttl < time.Millisecond {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'ttl': expected a positive Go duration such as 5m, got '%s'.", ttlValue)), nil
+	}
+
+	titleModelName := naming.Pascal(modelName)
+	lowerModelName := strings.ToLower(titleModelName)
+
+	state.From(ctx).RecordComponent(appName, titleModelName, "cache")
+
+	wiring := fmt.Sprintf("Wrap the service where it is bootstrapped in `cmd/web/main.go`, so the controllers read through the cache:\n   ```go\n   %[2]sService := %[2]sservice.NewCached%[1]sService(%[2]sservice.New%[1]sService(%[2]sRepo))\n   ```", titleModelName, lowerModelName)
+	if usesFx(ctx, appName) {
+		wiring = fmt.Sprintf("Decorate the service in `internal/app/app.go`, so everything depending on %[1]sService reads through the cache:\n   ```go\n   var Module = fx.Options(\n   \t// ...\n   \tServices,\n   \tfx.Decorate(%[2]sservice.NewCached%[1]sService),\n   \t// ...\n   )\n   ```", titleModelName, lowerModelName)
+	}
+
+	args := []any{
+		titleModelName,              // %[1]s
+		lowerModelName,              // %[2]s
+		strconv.Itoa(int(capacity)), // %[3]s
+		wiring,                      // %[4]s
+	}
+	files, err := renderFiles(ctx, cacheFiles, map[string]any{
+		"App":      appName,
+		"Model":    titleModelName,
+		"Lower":    lowerModelName,
+		"Capacity": strconv.Itoa(int(capacity)),
+		"TTL":      goDuration(ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# In-Memory Cache Scaffold Instructions
+
+To scaffold an in-memory cache of the model '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/cache internal/service/%[2]s`"+`
+
+2. Create or update the file at `+"`internal/cache/cache.go`"+` with the following content:
+`+"```go"+`
+%[5]s`+"```"+`
+
+   The cache keeps at most %[3]s records and evicts the least recently used one beyond that. GetOrLoad calls the loader once for all the concurrent misses of a key, so a popular record expiring sends a single query to the database instead of one per request.
+
+3. Create or update the file at `+"`internal/service/%[2]s/cached.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+
+4. %[4]s
+`, append(args, fileContents(files)...)...) // %[5]s onwards: file contents
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{fmt.Sprintf("mkdir -p internal/cache internal/service/%s", lowerModelName)},
+		Notes: []string{
+			fmt.Sprintf("Each instance caches on its own: with more than one replica, an update invalidates the cache of the instance serving it only, so the others serve the old %s for up to %s. Keep the TTL short, or use a shared cache such as Redis, when that matters.", lowerModelName, ttlValue),
+			fmt.Sprintf("Callers share the cached *dto.%sResponse: copy it before changing it.", titleModelName),
+			"Invalidate the cache wherever else the records change, e.g. in jobs or services writing to the repository directly.",
+		},
+	}), nil
+}
+
+// cacheFiles lists the cache files in the order they appear in the instructions
+var cacheFiles = []fileFormat{
+	{Path: "internal/cache/cache.go", Language: "go", Template: "cache/cache.go"},
+	{Path: "internal/service/{{.Lower}}/cached.go", Language: "go", Template: "cache/cached.go"},
+}
@@ -0,0 +1,226 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceCaptchaBoilerplateTool returns the tool definition for produce_captcha_boilerplate
+func GetProduceCaptchaBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_captcha_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output CAPTCHA protection for public forms: a verifier calling the hCaptcha or Cloudflare Turnstile siteverify endpoint, middleware rejecting submissions whose challenge is not confirmed, and a templ widget to drop into the login, registration and contact forms. Runs on the provider's test keys until the real ones are set."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("provider",
+			mcp.Description("The CAPTCHA service: turnstile (Cloudflare Turnstile) or hcaptcha. Defaults to turnstile."),
+			mcp.Enum("turnstile", "hcaptcha"),
+		),
+		mcp.WithString("forms",
+			mcp.Description("Comma-separated paths of the forms whose POST route the middleware protects (e.g., /login,/register,/contact). Defaults to /login,/register,/contact."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceCaptchaBoilerplateHandler
+}
+
+// captchaProvider holds what differs between the CAPTCHA services: their endpoints, widget and test keys
+type captchaProvider struct {
+	Name          string
+	VerifyURL     string
+	ScriptURL     string
+	ResponseField string
+	WidgetClass   string
+	TestSiteKey   string
+	TestSecretKey string
+	Domains       string // hosts the Content-Security-Policy must allow scripts and frames from
+}
+
+// captchaProviders are the supported CAPTCHA services, by the name of the provider argument
+var captchaProviders = map[string]captchaProvider{
+	"turnstile": {
+		Name:          "Cloudflare Turnstile",
+		VerifyURL:     "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+		ScriptURL:     "https://challenges.cloudflare.com/turnstile/v0/api.js",
+		ResponseField: "cf-turnstile-response",
+		WidgetClass:   "cf-turnstile",
+		TestSiteKey:   "1x00000000000000000000AA",
+		TestSecretKey: "1x0000000000000000000000000000000AA",
+		Domains:       "https://challenges.cloudflare.com",
+	},
+	"hcaptcha": {
+		Name:          "hCaptcha",
+		VerifyURL:     "https://api.hcaptcha.com/siteverify",
+		ScriptURL:     "https://js.hcaptcha.com/1/api.js",
+		ResponseField: "h-captcha-response",
+		WidgetClass:   "h-captcha",
+		TestSiteKey:   "10000000-ffff-ffff-ffff-000000000001",
+		TestSecretKey: "0x0000000000000000000000000000000000000000",
+		Domains:       "https://hcaptcha.com https://*.hcaptcha.com",
+	},
+}
+
+// captchaFormHandlers are the handlers of the forms other scaffolds and the usual auth setup produce, by path
+var captchaFormHandlers = map[string]string{
+	"/login":    "authController.Login",
+	"/register": "authController.Register",
+	"/contact":  "contactController.Submit",
+}
+
+// ProduceCaptchaBoilerplateHandler handles requests to generate CAPTCHA protection for public forms
+// It creates the verifier, the middleware and the widget, and shows the routes of the forms to protect
+func ProduceCaptchaBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	providerName := request.GetString("provider", "turnstile")
+	provider, ok := captchaProviders[providerName]
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'provider': expected turnstile or hcaptcha, got '%s'.", providerName)), nil
+	}
+	var forms []string
+	for _, path := range splitArguments(request.GetString("forms", "/login,/register,/contact")) {
+		if !strings.HasPrefix(path, "/") || strings.ContainsAny(path, " \"\n") {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid path '%s' in 'forms': expected the path of a form starting with /, such as /contact.", path)), nil
+		}
+		forms = append(forms, path)
+	}
+	if len(forms) == 0 {
+		return missingParameterResult(ctx, "forms", "the paths of the forms to protect (e.g., /login,/register,/contact).", nil), nil
+	}
+
+	project, _ := state.From(ctx).Project(appName)
+	throttled := project.Options["auth_throttle_store"] != ""
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "captcha", providerName)
+
+	files, err := renderFiles(ctx, captchaFiles, map[string]any{
+		"App":           appName,
+		"Provider":      provider.Name,
+		"VerifyURL":     provider.VerifyURL,
+		"ScriptURL":     provider.ScriptURL,
+		"ResponseField": provider.ResponseField,
+		"WidgetClass":   provider.WidgetClass,
+		"TestSiteKey":   provider.TestSiteKey,
+		"TestSecretKey": provider.TestSecretKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var routes strings.Builder
+	for _, path := range forms {
+		handler, known := captchaFormHandlers[path]
+		if !known {
+			handler = "handler"
+		}
+		middleware := "appmiddleware.Captcha(captchaVerifier)"
+		if throttled && (path == "/login" || path == "/register") {
+			kind := map[string]string{"/login": "throttle.Login", "/register": "throttle.Registration"}[path]
+			middleware = fmt.Sprintf("appmiddleware.AuthThrottle(authThrottle, %s), %s", kind, middleware)
+		}
+		fmt.Fprintf(&routes, "   e.POST(%q, %s, %s)\n", path, handler, middleware)
+	}
+	args := []any{
+		appName,                                 // %[1]s
+		provider.Name,                           // %[2]s
+		routes.String(),                         // %[3]s
+		"`" + strings.Join(forms, "`, `") + "`", // %[4]s
+	}
+
+	response := fmt.Sprintf(`
+# CAPTCHA Scaffold Instructions
+
+To protect the public forms of the application '%[1]s' with %[2]s, please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/captcha ui/modules`"+`
+
+2. Create or update the file at `+"`internal/captcha/captcha.go`"+` with the verifier:
+`+"```go"+`
+%[5]s`+"```"+`
+
+3. Create or update the file at `+"`internal/middleware/captcha.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+
+4. Create or update the file at `+"`ui/modules/captcha.templ`"+` with the widget:
+`+"```templ"+`
+%[7]s`+"```"+`
+
+5. Wire it up in `+"`cmd/web/main.go`"+`:
+   `+"```go"+`
+   captchaVerifier := captcha.FromEnv()
+   if captchaVerifier.Testing() {
+   	e.Logger.Warn("CAPTCHA_SITE_KEY and CAPTCHA_SECRET_KEY are not set: the test keys accept every submission")
+   }
+   e.Use(appmiddleware.CaptchaSiteKey(captchaVerifier))
+
+   // Add the middleware to the POST routes of the forms, keeping their handlers
+%[3]s   `+"```"+`
+
+6. Render the widget in each of the forms posting to %[4]s, just before the submit button:
+   `+"```templ"+`
+   @modules.Captcha()
+   `+"```"+`
+
+7. Generate the templ code:
+   `+"`templ generate`"+`
+
+   A submission without a solved challenge, or with one the provider rejects, gets 422 Unprocessable Entity before the handler runs.
+`, append(args, fileContents(files)...)...) // %[5]s onwards: file contents
+
+	notes := []string{
+		"Set CAPTCHA_SITE_KEY and CAPTCHA_SECRET_KEY to the keys of the site in the " + provider.Name + " dashboard; until then the provider's test keys accept every submission.",
+		"If the app sends a Content-Security-Policy, allow " + provider.Domains + " in script-src and frame-src, or the widget cannot load.",
+		"Forms submitted with JSON instead of a form body must send the token in the " + provider.ResponseField + " form field, or verify it with captchaVerifier.Verify in their handler.",
+	}
+	if throttled {
+		notes = append(notes, "appmiddleware.AuthThrottle comes before appmiddleware.Captcha on the login and registration routes, so a submission failing the challenge counts as a failed attempt.")
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, provide captcha.FromEnv in internal/app/app.go, and register the middleware in an fx.Invoke function.")
+	}
+	if usesWire(ctx, appName) {
+		notes = append(notes, "With google/wire, add captcha.FromEnv to Providers, take the verifier in NewEcho to register the middleware, and run wire ./internal/app.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			"mkdir -p internal/captcha ui/modules",
+			"templ generate",
+		},
+		Notes: notes,
+	}), nil
+}
+
+// captchaFiles lists the CAPTCHA files in the order they appear in the instructions
+var captchaFiles = []fileFormat{
+	{Path: "internal/captcha/captcha.go", Language: "go", Template: "captcha/captcha.go"},
+	{Path: "internal/middleware/captcha.go", Language: "go", Template: "captcha/middleware.go"},
+	{Path: "ui/modules/captcha.templ", Language: "templ", Template: "captcha/captcha.templ"},
+}
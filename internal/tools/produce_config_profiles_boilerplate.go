@@ -0,0 +1,168 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// appEnvs are the environments a generated app has a configuration profile for
+var appEnvs = []string{"development", "staging", "production"}
+
+// GetProduceConfigProfilesBoilerplateTool returns the tool definition for produce_config_profiles_boilerplate
+func GetProduceConfigProfilesBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_config_profiles_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output configuration profiles for development, staging and production selected by APP_ENV: text or JSON logs, how much GORM logs, the origins CORS allows and whether pprof and /debug/config are served, each overridable by an environment variable. Includes the main.go wiring and a Dockerfile baking APP_ENV into the image."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("default_env",
+			mcp.Description("Profile used when APP_ENV is unset: development, staging or production. Defaults to development, so a local go run needs no setup; the Dockerfile sets production."),
+		),
+		mcp.WithString("allowed_origins",
+			mcp.Description("Comma-separated origins allowed by CORS in staging and production (e.g., https://shop.example.com). Defaults to none, keeping the API same-origin; development allows any origin and CORS_ALLOW_ORIGINS overrides both at runtime."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceConfigProfilesBoilerplateHandler
+}
+
+// ProduceConfigProfilesBoilerplateHandler handles requests to generate the environment profiles of an application
+// It creates the config package and the Dockerfile, and explains how main.go selects the profile
+func ProduceConfigProfilesBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	defaultEnv := strings.ToLower(request.GetString("default_env", "development"))
+	if !slices.Contains(appEnvs, defaultEnv) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'default_env' '%s': expected %s.", defaultEnv, strings.Join(appEnvs, ", "))), nil
+	}
+	var origins []string
+	for _, origin := range strings.Split(request.GetString("allowed_origins", ""), ",") {
+		origin = strings.TrimSpace(origin)
+		if origin == "" {
+			continue
+		}
+		if origin == "*" || !(strings.HasPrefix(origin, "https://") || strings.HasPrefix(origin, "http://")) || strings.Count(origin, "/") > 2 {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid origin '%s' in 'allowed_origins': expected a scheme and host without a path, such as https://%s.example.com.", origin, appName)), nil
+		}
+		origins = append(origins, strconv.Quote(origin))
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "default_env", defaultEnv)
+
+	dialect := appDialect(ctx, request, appName)
+	args := []any{
+		appName,    // %[1]s
+		defaultEnv, // %[2]s
+	}
+	files, err := renderFiles(ctx, configProfilesFiles, map[string]any{
+		"App":          appName,
+		"Driver":       dialect,
+		"DefaultEnv":   defaultEnv,
+		"AllowOrigins": strings.Join(origins, ", "),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Configuration Profiles Scaffold Instructions
+
+To scaffold per-environment configuration for the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/config`"+`
+
+2. Create or update the file at `+"`internal/config/profile.go`"+` with the following content:
+`+"```go"+`
+%[3]s`+"```"+`
+
+3. Create or update the file at `+"`internal/config/echo.go`"+` with the following content:
+`+"```go"+`
+%[4]s`+"```"+`
+
+4. Select the profile at the top of `+"`main`"+` in `+"`cmd/web/main.go`"+`, let it set up the server in place of `+"`e.Use(middleware.Logger())`"+`, and apply its GORM log level once the database is open:
+   `+"```go"+`
+   profile, err := config.Load()
+   if err != nil {
+   	log.Fatal(err)
+   }
+   e := echo.New()
+   config.Apply(e, profile)
+   e.Use(middleware.Recover())
+   // ...
+   db, err := database.Open(database.ConfigFromEnv())
+   if err != nil {
+   	e.Logger.Fatal("failed to connect database", err)
+   }
+   db.Logger = db.Logger.LogMode(profile.DBLogLevel)
+   `+"```"+`
+
+5. Create or update the file at `+"`Dockerfile`"+` with the following content:
+`+"```dockerfile"+`
+%[5]s`+"```"+`
+
+   | | development | staging | production |
+   |---|---|---|---|
+   | Logs (`+"`LOG_FORMAT`"+`) | text | json | json |
+   | GORM (`+"`DB_LOG_LEVEL`"+`) | every statement | slow statements and errors | errors |
+   | CORS (`+"`CORS_ALLOW_ORIGINS`"+`) | any origin | allowed origins | allowed origins, never * |
+   | `+"`/debug/pprof`"+` and `+"`/debug/config`"+` (`+"`DEBUG_ENDPOINTS`"+`) | on | on | off |
+
+   Without APP_ENV the app runs as %[2]s, and an unknown APP_ENV or override stops it at startup with the expected values. Build a staging image with `+"`docker build --build-arg APP_ENV=staging -t %[1]s:staging .`"+`, or set APP_ENV on the container.
+`, append(args, fileContents(files)...)...) // %[3]s onwards: file contents
+
+	notes := []string{
+		"Code reading APP_ENV directly, such as the N+1 query detector, treats only production specially; keep the environment names of the profiles.",
+		"Keep /debug off the public internet in staging: pprof exposes the memory and goroutines of the process.",
+	}
+	if defaultEnv != "development" {
+		notes = append(notes, fmt.Sprintf("Without APP_ENV the app runs as %s; set APP_ENV=development for local runs.", defaultEnv))
+	}
+	if dialect == "sqlite" {
+		notes = append(notes, "The SQLite driver needs cgo, so the image builds with CGO_ENABLED=1 on the distroless base image, which ships the C library; mount a volume for the database file.")
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, add config.Load to the providers in internal/app/app.go, take config.Profile in NewEcho and NewDB, and call config.Apply and db.Logger.LogMode there.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{"mkdir -p internal/config"},
+		Notes:    notes,
+	}), nil
+}
+
+// configProfilesFiles lists the configuration profile files in the order they appear in the instructions
+var configProfilesFiles = []fileFormat{
+	{Path: "internal/config/profile.go", Language: "go", Template: "config_profiles/profile.go"},
+	{Path: "internal/config/echo.go", Language: "go", Template: "config_profiles/echo.go"},
+	{Path: "Dockerfile", Language: "dockerfile", Template: "config_profiles/Dockerfile"},
+}
@@ -0,0 +1,221 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceConsentBoilerplateTool returns the tool definition for produce_consent_boilerplate
+func GetProduceConsentBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_consent_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output terms and privacy consent tracking: versioned policy documents, a ConsentRecord model keeping which version each user accepted and when, middleware sending signed-in users to an acceptance page whenever a document has a new version, and templ pages displaying the documents and collecting the acceptance."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("documents",
+			mcp.Description("Comma-separated slugs of the policy documents users must accept, each optionally followed by its title after a colon (e.g., terms,privacy,dpa:Data Processing Agreement). Defaults to terms,privacy."),
+		),
+		mcp.WithString("version",
+			mcp.Description("The current version of the documents, such as a date (e.g., 2026-01-15); changing it later makes every user accept them again. Defaults to 1."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceConsentBoilerplateHandler
+}
+
+var (
+	// documentSlug matches the slug of a policy document such as privacy or cookie-policy
+	documentSlug = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	// documentVersion matches the version of a policy document such as 2 or 2026-01-15
+	documentVersion = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,31}$`)
+)
+
+// documentTitles are the titles of the usual policy documents, by slug
+var documentTitles = map[string]string{
+	"terms":   "Terms of Service",
+	"privacy": "Privacy Policy",
+	"cookies": "Cookie Policy",
+	"aup":     "Acceptable Use Policy",
+	"dpa":     "Data Processing Agreement",
+}
+
+// consentExemptPrefixes are the paths reachable before accepting the documents: the documents themselves,
+// signing out, and what the pages load
+var consentExemptPrefixes = []string{"/legal", "/logout", "/assets", "/health"}
+
+// ProduceConsentBoilerplateHandler handles requests to generate terms and privacy consent tracking
+// The documents are recorded for the app, so the registration form can ask for the same ones
+func ProduceConsentBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	var documents []map[string]string
+	var slugs []string
+	for _, part := range splitArguments(request.GetString("documents", "terms,privacy")) {
+		slug, title, titled := strings.Cut(part, ":")
+		slug, title = strings.TrimSpace(slug), strings.TrimSpace(title)
+		if !documentSlug.MatchString(slug) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid document '%s' in 'documents': expected a lowercase slug such as privacy or cookie-policy.", slug)), nil
+		}
+		if !titled {
+			title = documentTitles[slug]
+		}
+		if title == "" {
+			title = publicTitle(slug)
+		}
+		if strings.ContainsAny(title, "\"{}<>\\") {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid title '%s' in 'documents': expected plain text without quotes, braces or angle brackets.", title)), nil
+		}
+		if slices.Contains(slugs, slug) {
+			continue
+		}
+		slugs = append(slugs, slug)
+		documents = append(documents, map[string]string{"Slug": slug, "Title": title})
+	}
+	if len(documents) == 0 {
+		return missingParameterResult(ctx, "documents", "the slugs of the policy documents users must accept (e.g., terms,privacy).", nil), nil
+	}
+	version := request.GetString("version", "1")
+	if !documentVersion.MatchString(version) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'version': expected up to 32 letters, digits, dots, dashes or underscores such as 2026-01-15, got '%s'.", version)), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "consent_documents", strings.Join(slugs, ","))
+
+	exempt := make([]string, len(consentExemptPrefixes))
+	for i, prefix := range consentExemptPrefixes {
+		exempt[i] = strconv.Quote(prefix)
+	}
+	files, err := renderFiles(ctx, consentFiles, map[string]any{
+		"App":       appName,
+		"Documents": documents,
+		"Version":   version,
+		"Exempt":    strings.Join(exempt, ", "),
+	})
+	if err != nil {
+		return nil, err
+	}
+	args := []any{
+		appName, // %[1]s
+		"`/legal/" + strings.Join(slugs, "`, `/legal/") + "`", // %[2]s
+	}
+
+	response := fmt.Sprintf(`
+# Consent Tracking Scaffold Instructions
+
+To track the acceptance of the policy documents of the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/consent internal/controllers/consent ui/pages/consent`"+`
+
+2. Create or update the file at `+"`internal/models/consent_record.go`"+` with the following content:
+`+"```go"+`
+%[3]s`+"```"+`
+
+3. Create or update the file at `+"`internal/consent/documents.go`"+` with the current versions of the documents:
+`+"```go"+`
+%[4]s`+"```"+`
+
+4. Create or update the file at `+"`internal/consent/consent.go`"+` with the store of the acceptances:
+`+"```go"+`
+%[5]s`+"```"+`
+
+5. Create or update the file at `+"`internal/middleware/consent.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+
+6. Create or update the file at `+"`internal/controllers/consent/controller.go`"+` with the following content:
+`+"```go"+`
+%[7]s`+"```"+`
+
+7. Create or update the file at `+"`ui/pages/consent/consent.templ`"+` with the document and acceptance pages:
+`+"```templ"+`
+%[8]s`+"```"+`
+
+8. Create or update the file at `+"`ui/pages/consent/documents.templ`"+` with the text of the documents:
+`+"```templ"+`
+%[9]s`+"```"+`
+
+9. Wire it up in `+"`cmd/web/main.go`"+` after opening the database:
+   `+"```go"+`
+   if err := db.AutoMigrate(&models.ConsentRecord{}); err != nil {
+   	e.Logger.Fatal("failed to migrate consent records", err)
+   }
+   consentStore := consent.NewStore(db)
+   consentController := consentcontroller.NewConsentController(consentStore)
+
+   // After the authentication middleware, which sets c.Get("user_id")
+   e.Use(appmiddleware.RequireConsent(consentStore))
+   e.GET("/legal/accept", consentController.Form)
+   e.POST("/legal/accept", consentController.Accept)
+   e.GET("/legal/:document", consentController.Show)
+   e.GET("/api/consents", consentController.History)
+   `+"```"+`
+
+   The documents are public at %[2]s. When you change the text of a document, change its `+"`Version`"+` in `+"`internal/consent/documents.go`"+`: signed-in users are then redirected to `+"`/legal/accept`"+` until they accept it, and API requests get 403 with the pending documents.
+
+10. Generate the templ code:
+   `+"`templ generate`"+`
+`, append(args, fileContents(files)...)...) // %[3]s onwards: file contents
+
+	notes := []string{
+		"Record the acceptance at sign-up: add a required checkbox linking to the documents to the registration form, and call consentStore.Accept(ctx, user.ID, consent.Documents, c.RealIP(), c.Request().UserAgent()) once the user is created.",
+		"Consent records are only ever added; keep them when a user deletes their account for as long as you may need to prove what they agreed to.",
+		"Paths under " + strings.Join(consentExemptPrefixes, ", ") + " stay reachable before acceptance; add to middleware.ConsentExemptPrefixes any other path users need meanwhile, such as account deletion.",
+		"RequireConsent reads the records of the user on every request; the index on (user_id, document) keeps that cheap, but cache Pending if it shows up in your metrics.",
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, provide consent.NewStore and consentcontroller.NewConsentController in internal/app/app.go, and register the middleware and routes in an fx.Invoke function.")
+	}
+	if usesWire(ctx, appName) {
+		notes = append(notes, "With google/wire, add consent.NewStore and consentcontroller.NewConsentController to Providers, take them in NewEcho to register the middleware and routes, and run wire ./internal/app.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			"mkdir -p internal/consent internal/controllers/consent ui/pages/consent",
+			"templ generate",
+		},
+		Notes:  notes,
+		routes: []string{"GET /legal/accept", "POST /legal/accept", "GET /legal/:document", "GET /api/consents"},
+	}), nil
+}
+
+// consentFiles lists the consent tracking files in the order they appear in the instructions
+var consentFiles = []fileFormat{
+	{Path: "internal/models/consent_record.go", Language: "go", Template: "consent/consent_record.go"},
+	{Path: "internal/consent/documents.go", Language: "go", Template: "consent/documents.go"},
+	{Path: "internal/consent/consent.go", Language: "go", Template: "consent/consent.go"},
+	{Path: "internal/middleware/consent.go", Language: "go", Template: "consent/middleware.go"},
+	{Path: "internal/controllers/consent/controller.go", Language: "go", Template: "consent/controller.go"},
+	{Path: "ui/pages/consent/consent.templ", Language: "templ", Template: "consent/consent.templ"},
+	{Path: "ui/pages/consent/documents.templ", Language: "templ", Template: "consent/documents.templ"},
+}
@@ -0,0 +1,202 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceDeploymentBoilerplateTool returns the tool definition for produce_deployment_boilerplate
+func GetProduceDeploymentBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_deployment_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output zero-downtime Kubernetes deployment configs for an application: a rolling update or blue/green Deployment with readiness probes, a preStop hook timed with the server's graceful shutdown, a Service, and a Job applying the migrations before each release."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		dialectOption,
+		mcp.WithString("strategy",
+			mcp.Description("How a release replaces the running pods: rolling (new pods are started and become ready before old ones stop) or blue_green (two Deployments side by side, with the Service switched from one to the other). Defaults to rolling."),
+			mcp.Enum("rolling", "blue_green"),
+		),
+		mcp.WithString("image",
+			mcp.Description("Container image of the web server, e.g. registry.example.com/shop:1.4.2. Defaults to <app_name>:latest."),
+		),
+		mcp.WithNumber("replicas",
+			mcp.Description("Number of pods serving traffic. Defaults to 3."),
+		),
+		mcp.WithString("shutdown_timeout",
+			mcp.Description("How long in-flight requests may take to finish after SIGTERM, as a Go duration in whole seconds. Defaults to 20s; SHUTDOWN_TIMEOUT overrides it at runtime."),
+		),
+		mcp.WithString("drain_delay",
+			mcp.Description("How long the preStop hook keeps a terminating pod serving while it is removed from the Service endpoints, as a Go duration in whole seconds. Defaults to 5s."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceDeploymentBoilerplateHandler
+}
+
+// ProduceDeploymentBoilerplateHandler handles requests to generate the Kubernetes deployment of an application
+// It creates the manifests of the chosen strategy and the graceful shutdown of the web server they rely on
+func ProduceDeploymentBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	dialect := appDialect(ctx, request, appName)
+	if dialect != "sqlite" && dialect != "postgres" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'dialect' '%s': expected sqlite or postgres.", dialect)), nil
+	}
+	strategy := request.GetString("strategy", "rolling")
+	if strategy != "rolling" && strategy != "blue_green" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'strategy': expected rolling or blue_green, got '%s'.", strategy)), nil
+	}
+	image := request.GetString("image", appName+":latest")
+	if image == "" || strings.ContainsAny(image, " \t\n\"'") {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'image': expected a container image reference such as registry.example.com/%s:1.0.0, got '%s'.", appName, image)), nil
+	}
+	replicas := request.GetFloat("replicas", 3)
+	if replicas < 1 || replicas != float64(int(replicas)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'replicas': expected a positive whole number, got %v.", replicas)), nil
+	}
+	shutdown, err := wholeSeconds(request, "shutdown_timeout", "20s")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	drain, err := wholeSeconds(request, "drain_delay", "5s")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "deployment_strategy", strategy)
+
+	data := map[string]any{
+		"App":             appName,
+		"Name":            appName,
+		"Image":           image,
+		"Replicas":        strconv.Itoa(int(replicas)),
+		"Track":           "",
+		"Idle":            "",
+		"ShutdownSeconds": strconv.Itoa(shutdown),
+		"DrainSeconds":    strconv.Itoa(drain),
+		"GracePeriod":     strconv.Itoa(drain + shutdown + 5),
+	}
+	var files []scaffoldFile
+	// render adds one file rendered with data as it stands, since the deployment of each track renders it again
+	render := func(format fileFormat) error {
+		rendered, err := renderFiles(ctx, []fileFormat{format}, data)
+		files = append(files, rendered...)
+		return err
+	}
+	if !usesFx(ctx, appName) {
+		if err := render(fileFormat{Path: "cmd/web/serve.go", Language: "go", Template: "deployment/serve.go"}); err != nil {
+			return nil, err
+		}
+	}
+	if strategy == "blue_green" {
+		for _, track := range []string{"blue", "green"} {
+			data["Name"], data["Track"] = appName+"-"+track, track
+			if err := render(fileFormat{Path: "deploy/k8s/deployment-" + track + ".yaml", Language: "yaml", Template: "deployment/deployment.yaml"}); err != nil {
+				return nil, err
+			}
+		}
+		data["Track"], data["Idle"] = "blue", "green"
+	} else if err := render(fileFormat{Path: "deploy/k8s/deployment.yaml", Language: "yaml", Template: "deployment/deployment.yaml"}); err != nil {
+		return nil, err
+	}
+	if err := render(fileFormat{Path: "deploy/k8s/service.yaml", Language: "yaml", Template: "deployment/service.yaml"}); err != nil {
+		return nil, err
+	}
+	if dialect == "postgres" {
+		if err := render(fileFormat{Path: "deploy/k8s/migrate-job.yaml", Language: "yaml", Template: "deployment/migrate_job.yaml"}); err != nil {
+			return nil, err
+		}
+	}
+
+	var steps strings.Builder
+	for i, f := range files {
+		fmt.Fprintf(&steps, "%d. Create or update the file at `%s` with the following content:\n```%s\n%s```\n\n", i+2, f.Path, f.Language, f.Content)
+	}
+
+	shutdownStep := "Serve through the graceful shutdown in `cmd/web/main.go`, and register the probe endpoint:\n   ```go\n   e.GET(\"/healthz\", healthz)\n   serve(e, \":1323\") // in place of e.Logger.Fatal(e.Start(\":1323\"))\n   ```"
+	if usesFx(ctx, appName) {
+		shutdownStep = fmt.Sprintf("The server in `internal/app/app.go` already shuts down in its OnStop hook when fx receives SIGTERM. Give it the time in `cmd/web/main.go` and register the probe endpoint in `NewEcho`:\n   ```go\n   fx.New(app.Module, fx.StopTimeout(%d*time.Second)).Run()\n\n   e.GET(\"/healthz\", func(c echo.Context) error { return c.NoContent(http.StatusOK) })\n   ```", shutdown)
+	}
+
+	migrate, roll := "", "roll the Deployment"
+	if dialect == "postgres" {
+		roll = "migrate first, then roll the Deployment"
+		migrate = fmt.Sprintf("   kubectl delete job %[1]s-migrate --ignore-not-found\n   kubectl apply -f deploy/k8s/migrate-job.yaml\n   kubectl wait --for=condition=complete job/%[1]s-migrate --timeout=10m\n", appName)
+	}
+	release := fmt.Sprintf("Release a new image: %[3]s, which waits for each new pod to be ready before stopping an old one:\n   ```\n%[2]s   kubectl set image deployment/%[1]s web=<image>\n   kubectl rollout status deployment/%[1]s   # kubectl rollout undo deployment/%[1]s to go back\n   ```", appName, migrate, roll)
+	if strategy == "blue_green" {
+		release = fmt.Sprintf("Release a new image to the idle track (green while blue is live), check it through the `%[1]s-preview` Service, then switch the live Service to it:\n   ```\n%[2]s   kubectl set image deployment/%[1]s-green web=<image>\n   kubectl rollout status deployment/%[1]s-green\n   kubectl patch service %[1]s -p '{\"spec\":{\"selector\":{\"app\":\"%[1]s\",\"track\":\"green\"}}}'\n   kubectl patch service %[1]s-preview -p '{\"spec\":{\"selector\":{\"app\":\"%[1]s\",\"track\":\"blue\"}}}'\n   ```\n\n   Switching back is the same patch with the tracks swapped, as long as the old track still runs. Scale it down with `kubectl scale deployment/%[1]s-blue --replicas=0` once the release is trusted.", appName, migrate)
+	}
+
+	response := fmt.Sprintf(`
+# Deployment Scaffold Instructions
+
+To scaffold zero-downtime Kubernetes deployments for the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p deploy/k8s`"+`
+
+%[2]s%[3]d. %[4]s
+
+   When a pod is stopped, the preStop hook keeps it serving for %[5]ds while the endpoints controller and load balancers stop routing to it. SIGTERM follows, and the server stops accepting connections and waits up to %[6]ds for in-flight requests; terminationGracePeriodSeconds leaves 5s on top of both before the pod is killed.
+
+%[7]d. %[8]s
+`, appName, steps.String(), len(files)+2, shutdownStep, drain, shutdown, len(files)+3, release)
+
+	notes := []string{
+		fmt.Sprintf("Create the `%s-database` Secret with the `dsn` key read by the web server before applying the manifests.", appName),
+		"The preStop sleep action needs Kubernetes 1.30 or later; on older clusters use an exec hook running sleep, which the image must then contain.",
+	}
+	if dialect == "postgres" {
+		notes = append(notes,
+			"The migrate Job runs golang-migrate from the application image: copy the CLI and the migrations into it, e.g. `COPY --from=migrate/migrate:v4 /usr/local/bin/migrate /usr/local/bin/migrate` and `COPY migrations /app/migrations`, and add a `url` key with the database URL (postgres://...) to the Secret.",
+			"Old and new pods run side by side during a release, so each migration must work with the previous version of the code: add columns and tables first, and drop them only in a later release. Remove AutoMigrate from cmd/web/main.go so pods do not race the Job.",
+		)
+	} else {
+		notes = append(notes, "SQLite keeps the database in a file of one pod, so every replica and track would have its own data; switch the app to dialect=postgres before running more than one pod.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{"mkdir -p deploy/k8s", "kubectl apply -f deploy/k8s/"},
+		Notes:    notes,
+	}), nil
+}
+
+// wholeSeconds reads a duration option that must be a positive number of whole seconds
+func wholeSeconds(request mcp.CallToolRequest, name, fallback string) (int, error) {
+	value := request.GetString(name, fallback)
+	d, err := time.ParseDuration(value)
+	if err != nil || d < time.Second || d%time.Second != 0 {
+		return 0, fmt.Errorf("Invalid '%s': expected a Go duration in whole seconds such as %s, got '%s'.", name, fallback, value)
+	}
+	return int(d / time.Second), nil
+}
@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceErrorPagesBoilerplateTool returns the tool definition for produce_error_pages_boilerplate
+func GetProduceErrorPagesBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_error_pages_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output not-found (404) and method-not-allowed (405) templ pages in the base layout of an HTML app, and the Echo error handler rendering them for browsers and answering in JSON under the API prefix, instead of Echo's plain {\"message\":\"Not Found\"} bodies. Other errors keep the handler installed before it."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("api_prefix",
+			mcp.Description("Path prefix of the JSON API, whose unknown routes are answered in JSON even from a browser (e.g., /api). Defaults to /api; pass an empty string when the app has no API."),
+		),
+		errorFormatOption,
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceErrorPagesBoilerplateHandler
+}
+
+// ProduceErrorPagesBoilerplateHandler handles requests to generate the 404 and 405 pages of an HTML application
+// It creates the templ pages and the error handler choosing between them and JSON
+func ProduceErrorPagesBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	apiPrefix := strings.TrimSuffix(request.GetString("api_prefix", "/api"), "/")
+	if apiPrefix != "" && (!strings.HasPrefix(apiPrefix, "/") || strings.ContainsAny(apiPrefix, " :*\"")) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'api_prefix' '%s': expected a static path starting with /, such as /api.", apiPrefix)), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "error_pages", "true")
+
+	install := "e.HTTPErrorHandler = httperror.Handler(e.HTTPErrorHandler)"
+	if newAPIErrors(ctx, request, appName).Format == "problem" {
+		install = "e.HTTPErrorHandler = httperror.Handler(problem.ErrorHandler)"
+	}
+	apiAnswer := "Requests under `" + apiPrefix + "`, and clients that do not accept HTML, get JSON from the handler installed before, with a message naming the method and path"
+	if apiPrefix == "" {
+		apiAnswer = "Clients that do not accept HTML get JSON from the handler installed before, with a message naming the method and path"
+	}
+
+	args := []any{
+		appName,   // %[1]s
+		install,   // %[2]s
+		apiAnswer, // %[3]s
+	}
+	files, err := renderFiles(ctx, errorPagesFiles, map[string]any{"App": appName, "APIPrefix": apiPrefix})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Error Pages Scaffold Instructions
+
+To scaffold the not-found and method-not-allowed pages of the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p ui/pages/errors internal/httperror`"+`
+
+2. Create or update the file at `+"`ui/pages/errors/errors.templ`"+` with the following content:
+`+"```templ"+`
+%[4]s`+"```"+`
+
+3. Create or update the file at `+"`internal/httperror/handler.go`"+` with the following content:
+`+"```go"+`
+%[5]s`+"```"+`
+
+4. Install the handler in `+"`cmd/web/main.go`"+`, right after `+"`e := echo.New()`"+`:
+   `+"```go"+`
+   %[2]s
+   `+"```"+`
+
+5. Generate the templ code:
+   `+"`templ generate`"+`
+
+   Browsers following a dead link get the 404 page in the base layout, with the navbar and a link home, and a GET of a form target gets the 405 page listing the methods the route accepts, from the `+"`Allow`"+` header Echo sets. Both keep their status code, so crawlers and monitors still see the error.
+   %[3]s, so the API keeps one error format.
+`, append(args, fileContents(files)...)...) // %[4]s onwards: file contents
+
+	notes := []string{
+		"The pages use layouts.BaseLayout from produce_html_controller_boilerplate; scaffold an HTML controller first, or replace it with your own layout.",
+		"Errors other than 404 and 405, such as those returned by handlers, still go to the handler installed before httperror.Handler.",
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, install the handler in NewEcho in internal/app/app.go, right after echo.New().")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			"mkdir -p ui/pages/errors internal/httperror",
+			"templ generate",
+		},
+		Notes: notes,
+	}), nil
+}
+
+// errorPagesFiles lists the error page files in the order they appear in the instructions
+var errorPagesFiles = []fileFormat{
+	{Path: "ui/pages/errors/errors.templ", Language: "templ", Template: "error_pages/errors.templ"},
+	{Path: "internal/httperror/handler.go", Language: "go", Template: "error_pages/handler.go"},
+}
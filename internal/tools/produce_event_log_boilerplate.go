@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceEventLogBoilerplateTool returns the tool definition for produce_event_log_boilerplate
+func GetProduceEventLogBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_event_log_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a database-backed event log for small apps: an events table, a Publish helper usable inside transactions, and a polling dispatcher delivering events at least once to registered handlers, without a message broker."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("poll_interval",
+			mcp.Description("How often an idle dispatcher polls the events table, as a Go duration (e.g., 500ms). Defaults to 1s."),
+		),
+		mcp.WithNumber("batch_size",
+			mcp.Description("Maximum number of events claimed per poll. Defaults to 100."),
+		),
+		mcp.WithNumber("max_attempts",
+			mcp.Description("Number of failed deliveries after which an event is marked dead and no longer retried. Defaults to 10."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceEventLogBoilerplateHandler
+}
+
+// ProduceEventLogBoilerplateHandler handles requests to generate a database-backed event log
+// It creates the event model, the publish helper and the dispatcher with its handler registry
+func ProduceEventLogBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	pollInterval, err := time.ParseDuration(request.GetString("poll_interval", "1s"))
+	if err != nil || pollInterval <= 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'poll_interval': expected a positive Go duration such as 1s or 500ms, got '%s'.", request.GetString("poll_interval", ""))), nil
+	}
+	batchSize := request.GetFloat("batch_size", 100)
+	if batchSize < 1 || batchSize != float64(int(batchSize)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'batch_size': expected a positive whole number, got %v.", batchSize)), nil
+	}
+	maxAttempts := request.GetFloat("max_attempts", 10)
+	if maxAttempts < 1 || maxAttempts != float64(int(maxAttempts)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'max_attempts': expected a positive whole number, got %v.", maxAttempts)), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "event_log_poll_interval", pollInterval.String())
+	state.From(ctx).SetOption(appName, "event_log_max_attempts", strconv.Itoa(int(maxAttempts)))
+
+	args := []any{
+		appName,                        // %[1]s
+		goDuration(pollInterval),       // %[2]s
+		strconv.Itoa(int(batchSize)),   // %[3]s
+		strconv.Itoa(int(maxAttempts)), // %[4]s
+	}
+	files, err := renderFiles(ctx, eventLogFiles, map[string]any{"App": appName})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Event Log Scaffold Instructions
+
+To scaffold a database-backed event log for the application '%[1]s', please perform the following steps:
+
+1. Create or update the file at `+"`internal/models/event.go`"+` with the following content:
+`+"```go"+`
+%[5]s`+"```"+`
+
+2. Create or update the file at `+"`internal/events/publish.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+
+3. Create or update the file at `+"`internal/events/dispatcher.go`"+` with the following content:
+`+"```go"+`
+%[7]s`+"```"+`
+
+4. Wire it up in `+"`cmd/web/main.go`"+` after opening the database:
+   `+"```go"+`
+   if err := db.AutoMigrate(&models.Event{}); err != nil {
+   	e.Logger.Fatal("failed to migrate events", err)
+   }
+   dispatcher := events.NewDispatcher(db, events.Options{
+   	PollInterval: %[2]s,
+   	BatchSize:    %[3]s,
+   	MaxAttempts:  %[4]s,
+   })
+   dispatcher.Handle("user.registered", func(ctx context.Context, event models.Event) error {
+   	var payload struct{ UserID uint `+"`json:\"user_id\"`"+` }
+   	if err := events.Decode(event, &payload); err != nil {
+   		return err
+   	}
+   	// Send the welcome email, update a projection, call a webhook...
+   	return nil
+   })
+   go dispatcher.Run(ctx) // ctx is cancelled on shutdown
+   `+"```"+`
+
+5. Publish events in the same transaction as the change they describe, so an event is stored if and only if the change is committed:
+   `+"```go"+`
+   err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+   	if err := tx.Create(&user).Error; err != nil {
+   		return err
+   	}
+   	return events.Publish(ctx, tx, "user.registered", map[string]any{"user_id": user.ID})
+   })
+   `+"```"+`
+
+   Delivery is at least once: a handler may see the same event again after a crash or a lease expiry, so make handlers idempotent, e.g. by recording the event ID with the side effect. Failed events are retried with exponential backoff and marked dead after %[4]s attempts; several instances of the app can run dispatchers against the same database.
+`, append(args, fileContents(files)...)...) // %[5]s onwards: file contents
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Notes: []string{
+			"Add models.Event to AutoMigrate, register the handlers and start the dispatcher in cmd/web/main.go.",
+			"Handlers must be idempotent: events are delivered at least once.",
+			"Call events.Prune periodically to delete processed events; dead events are kept for inspection and can be retried by clearing dead_at and attempts.",
+		},
+	}), nil
+}
+
+// eventLogFiles lists the event log files in the order they appear in the instructions
+var eventLogFiles = []fileFormat{
+	{Path: "internal/models/event.go", Language: "go", Template: "event_log/event.go"},
+	{Path: "internal/events/publish.go", Language: "go", Template: "event_log/publish.go"},
+	{Path: "internal/events/dispatcher.go", Language: "go", Template: "event_log/dispatcher.go"},
+}
@@ -0,0 +1,264 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceHttpClientBoilerplateTool returns the tool definition for produce_http_client_boilerplate
+func GetProduceHttpClientBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_http_client_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a typed client package for an external HTTP API (request/response structs, error handling, context support) that is injected into services like a repository."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("client_name",
+			mcp.Required(),
+			mcp.Description("The name of the external API (e.g., Stripe, Weather)."),
+		),
+		mcp.WithString("base_url",
+			mcp.Required(),
+			mcp.Description("The default base URL of the API (e.g., https://api.example.com/v1)."),
+		),
+		mcp.WithString("endpoints",
+			mcp.Required(),
+			mcp.Description(`A JSON array of endpoints, each with 'name', 'method', 'path' (use {param} for path parameters) and optional 'request_fields' and 'response_fields' arrays of {"name","type"} objects.`),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceHttpClientBoilerplateHandler
+}
+
+// clientEndpoint describes a single endpoint of an external API
+type clientEndpoint struct {
+	Name           string        `json:"name"`
+	Method         string        `json:"method"`
+	Path           string        `json:"path"`
+	RequestFields  []state.Field `json:"request_fields"`
+	ResponseFields []state.Field `json:"response_fields"`
+}
+
+// pathParamPattern matches {param} placeholders in endpoint paths
+var pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// ProduceHttpClientBoilerplateHandler handles requests to generate a typed HTTP client
+// It creates the client constructor, request/response types and one method per endpoint
+func ProduceHttpClientBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	clientName := request.GetString("client_name", "")
+	if clientName == "" {
+		return missingParameterResult(ctx, "client_name", "the name of the external API (e.g., Stripe, Weather).", nil), nil
+	}
+	baseURL := request.GetString("base_url", "")
+	if baseURL == "" {
+		return missingParameterResult(ctx, "base_url", "the base URL of the external API (e.g., https://api.example.com/v1).", nil), nil
+	}
+	endpointsJSON := request.GetString("endpoints", "")
+	if endpointsJSON == "" {
+		return missingParameterResult(ctx, "endpoints", `a JSON array of endpoints (e.g., [{"name":"GetInvoice","method":"GET","path":"/invoices/{id}","response_fields":[{"name":"amount","type":"int64"}]}]).`, nil), nil
+	}
+
+	var endpoints []clientEndpoint
+	if err := json.Unmarshal([]byte(endpointsJSON), &endpoints); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'endpoints' JSON format: %v", err.Error())), nil
+	}
+	for i, e := range endpoints {
+		if e.Name == "" || e.Path == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid endpoint #%d: 'name' and 'path' are required.", i+1)), nil
+		}
+		endpoints[i].Name = naming.Pascal(e.Name)
+		endpoints[i].Method = strings.ToUpper(e.Method)
+		if endpoints[i].Method == "" {
+			endpoints[i].Method = http.MethodGet
+		}
+	}
+
+	titleClientName := naming.Pascal(clientName)
+	lowerClientName := strings.ToLower(clientName)
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "http_client_"+lowerClientName, baseURL)
+
+	args := []any{
+		titleClientName,                 // %[1]s
+		lowerClientName,                 // %[2]s
+		appName,                         // %[3]s
+		baseURL,                         // %[4]s
+		clientTypes(endpoints),          // %[5]s
+		clientInterface(endpoints),      // %[6]s
+		clientMethods(endpoints),        // %[7]s
+		clientNeedsURLImport(endpoints), // %[8]s
+	}
+	files, err := renderFiles(ctx, httpClientFiles, map[string]any{
+		"Client":    titleClientName,
+		"Lower":     lowerClientName,
+		"BaseURL":   baseURL,
+		"Types":     clientTypes(endpoints),
+		"Interface": clientInterface(endpoints),
+		"Methods":   clientMethods(endpoints),
+		"Imports":   clientNeedsURLImport(endpoints),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# HTTP Client Scaffold Instructions
+
+To scaffold the typed client for the '%[1]s' API, please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/clients/%[2]s`"+`
+
+2. For each of the following, create or update the file in `+"`internal/clients/%[2]s/`"+` as needed:
+
+   a. `+"`client.go`"+` (interface, constructor and error handling):
+`+"```go"+`
+%[9]s`+"```"+`
+
+   b. `+"`types.go`"+` (request and response structs):
+`+"```go"+`
+%[10]s`+"```"+`
+
+   c. `+"`endpoints.go`"+` (one method per endpoint):
+`+"```go"+`
+%[11]s`+"```"+`
+
+3. Inject the client into the services that need it, exactly like a repository:
+   `+"```go"+`
+   %[2]sClient := %[2]s.New%[1]sClient(%[2]s.DefaultBaseURL, &http.Client{Timeout: 10 * time.Second})
+   orderService := orderservice.NewOrderService(orderRepo, %[2]sClient)
+   `+"```"+`
+   Services depend on the `+"`%[2]s.Client`"+` interface, so tests can replace it with a fake.
+`, append(args, fileContents(files)...)...) // %[9]s onwards: file contents
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{fmt.Sprintf("mkdir -p internal/clients/%s", lowerClientName)},
+		Notes: []string{
+			"Non-2xx responses are returned as *APIError with the status code and body.",
+			"Wrap calls with the resilience helpers from produce_resilience_boilerplate if the API is unreliable.",
+		},
+	}), nil
+}
+
+// clientTypes renders the request and response structs of every endpoint
+func clientTypes(endpoints []clientEndpoint) string {
+	var b strings.Builder
+	for _, e := range endpoints {
+		if len(e.RequestFields) > 0 {
+			b.WriteString(clientStruct(e.Name+"Request", fmt.Sprintf("is the body sent by %s", e.Name), e.RequestFields))
+		}
+		if len(e.ResponseFields) > 0 {
+			b.WriteString(clientStruct(e.Name+"Response", fmt.Sprintf("is the body returned by %s", e.Name), e.ResponseFields))
+		}
+	}
+	return b.String()
+}
+
+// clientStruct renders a JSON struct with one field per entry
+func clientStruct(name, doc string, fields []state.Field) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\n// %s %s\ntype %s struct {\n", name, doc, name))
+	for _, f := range fields {
+		b.WriteString(fmt.Sprintf("\t%s %s `json:\"%s\"`\n", naming.Pascal(f.Name), f.Type, f.Name))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// clientSignature renders the parameters and results of an endpoint method
+func clientSignature(e clientEndpoint) string {
+	params := []string{"ctx context.Context"}
+	for _, m := range pathParamPattern.FindAllStringSubmatch(e.Path, -1) {
+		params = append(params, m[1]+" string")
+	}
+	if len(e.RequestFields) > 0 {
+		params = append(params, fmt.Sprintf("req *%sRequest", e.Name))
+	}
+	results := "error"
+	if len(e.ResponseFields) > 0 {
+		results = fmt.Sprintf("(*%sResponse, error)", e.Name)
+	}
+	return fmt.Sprintf("%s(%s) %s", e.Name, strings.Join(params, ", "), results)
+}
+
+// clientInterface renders the interface methods of every endpoint
+func clientInterface(endpoints []clientEndpoint) string {
+	var b strings.Builder
+	for _, e := range endpoints {
+		b.WriteString(fmt.Sprintf("\t%s\n", clientSignature(e)))
+	}
+	return b.String()
+}
+
+// clientMethods renders the implementation of every endpoint
+func clientMethods(endpoints []clientEndpoint) string {
+	var b strings.Builder
+	for _, e := range endpoints {
+		path := fmt.Sprintf("%q", e.Path)
+		if pathParamPattern.MatchString(e.Path) {
+			path = pathParamPattern.ReplaceAllString(fmt.Sprintf("%q", e.Path), `"+url.PathEscape($1)+"`)
+			path = strings.TrimSuffix(strings.TrimPrefix(path, `""+`), `+""`)
+		}
+		body := "nil"
+		if len(e.RequestFields) > 0 {
+			body = "req"
+		}
+
+		b.WriteString(fmt.Sprintf("\nfunc (c *ClientImpl) %s {\n", clientSignature(e)))
+		if len(e.ResponseFields) > 0 {
+			b.WriteString(fmt.Sprintf("\tvar out %sResponse\n", e.Name))
+			b.WriteString(fmt.Sprintf("\tif err := c.do(ctx, %q, %s, %s, &out); err != nil {\n\t\treturn nil, err\n\t}\n\treturn &out, nil\n}\n", e.Method, path, body))
+		} else {
+			b.WriteString(fmt.Sprintf("\treturn c.do(ctx, %q, %s, %s, nil)\n}\n", e.Method, path, body))
+		}
+	}
+	return b.String()
+}
+
+// clientNeedsURLImport returns the net/url import line when an endpoint has path parameters
+func clientNeedsURLImport(endpoints []clientEndpoint) string {
+	for _, e := range endpoints {
+		if pathParamPattern.MatchString(e.Path) {
+			return "\t\"net/url\"\n"
+		}
+	}
+	return ""
+}
+
+// httpClientFiles lists the files of a typed HTTP client in the order they appear in the instructions
+var httpClientFiles = []fileFormat{
+	{Path: "internal/clients/{{.Lower}}/client.go", Language: "go", Template: "http_client/client.go"},
+	{Path: "internal/clients/{{.Lower}}/types.go", Language: "go", Template: "http_client/types.go"},
+	{Path: "internal/clients/{{.Lower}}/endpoints.go", Language: "go", Template: "http_client/endpoints.go"},
+}
@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceIdempotencyBoilerplateTool returns the tool definition for produce_idempotency_boilerplate
+func GetProduceIdempotencyBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_idempotency_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output Idempotency-Key handling for mutating endpoints: a response-cache table, middleware replaying stored responses for retried requests, and TTL cleanup."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("ttl",
+			mcp.Description("How long stored responses are replayed, as a Go duration (e.g., 24h). Defaults to 24h."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceIdempotencyBoilerplateHandler
+}
+
+// ProduceIdempotencyBoilerplateHandler handles requests to generate Idempotency-Key middleware
+// It creates the key model, the replaying middleware and the expired-key cleanup
+func ProduceIdempotencyBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	ttl, err := time.ParseDuration(request.GetString("ttl", "24h"))
+	if err != nil || ttl <= 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'ttl': expected a positive Go duration such as 24h, got '%s'.", request.GetString("ttl", ""))), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "idempotency_ttl", ttl.String())
+
+	args := []any{
+		appName,         // %[1]s
+		goDuration(ttl), // %[2]s
+	}
+	files, err := renderFiles(ctx, idempotencyFiles, map[string]any{"App": appName})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Idempotency Key Scaffold Instructions
+
+To scaffold Idempotency-Key handling for the application '%[1]s', please perform the following steps:
+
+1. Create or update the file at `+"`internal/models/idempotency_key.go`"+` with the following content:
+`+"```go"+`
+%[3]s`+"```"+`
+
+2. Create or update the file at `+"`internal/middleware/idempotency.go`"+` with the following content:
+`+"```go"+`
+%[4]s`+"```"+`
+
+3. Create or update the file at `+"`internal/middleware/idempotency_cleanup.go`"+` with the following content:
+`+"```go"+`
+%[5]s`+"```"+`
+
+4. Wire it up in `+"`cmd/web/main.go`"+` after opening the database:
+   `+"```go"+`
+   if err := db.AutoMigrate(&models.IdempotencyKey{}); err != nil {
+   	e.Logger.Fatal("failed to migrate idempotency keys", err)
+   }
+   appmiddleware.StartIdempotencyCleanup(context.Background(), db, time.Hour)
+
+   // Apply to every mutating route, or only to a group such as /payments
+   e.Use(appmiddleware.Idempotency(db, %[2]s))
+   `+"```"+`
+
+   Clients send a unique `+"`Idempotency-Key`"+` header with POST, PUT, PATCH or DELETE requests. A retry with the same key and body replays the stored status and body; the same key with a different body is rejected with 422, and a retry while the first request is still running gets 409.
+`, append(args, fileContents(files)...)...) // %[3]s onwards: file contents
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Notes: []string{
+			"Add models.IdempotencyKey to AutoMigrate and start the cleanup loop in cmd/web/main.go.",
+			"If per-request transactions are enabled, register Idempotency before Transaction so a replayed response never opens a transaction.",
+			"Responses with a 5xx status are not stored, so the client can retry them with the same key.",
+		},
+	}), nil
+}
+
+// idempotencyFiles lists the idempotency files in the order they appear in the instructions
+var idempotencyFiles = []fileFormat{
+	{Path: "internal/models/idempotency_key.go", Language: "go", Template: "idempotency/idempotency_key.go"},
+	{Path: "internal/middleware/idempotency.go", Language: "go", Template: "idempotency/idempotency.go"},
+	{Path: "internal/middleware/idempotency_cleanup.go", Language: "go", Template: "idempotency/idempotency_cleanup.go"},
+}
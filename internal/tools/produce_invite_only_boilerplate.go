@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceInviteOnlyBoilerplateTool returns the tool definition for produce_invite_only_boilerplate
+func GetProduceInviteOnlyBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_invite_only_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output an invite-only mode for a soft launch: an InviteCode model with limited uses and an expiry, middleware letting registrations through only with a valid code, admin endpoints to mint and revoke codes and invite people from the waitlist, and a waitlist page capturing the emails of visitors. INVITE_ONLY=false opens registration at launch without removing the code."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithNumber("max_uses",
+			mcp.Description("Registrations a code allows when minted without its own limit. Defaults to 1."),
+		),
+		mcp.WithString("code_ttl",
+			mcp.Description("How long a code stays valid when minted without its own expiry, as a Go duration; 0 never expires. Defaults to 720h."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceInviteOnlyBoilerplateHandler
+}
+
+// ProduceInviteOnlyBoilerplateHandler handles requests to generate the invite-only mode
+// It creates the models, the service, the registration middleware, the controller and the waitlist page
+func ProduceInviteOnlyBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	maxUses := request.GetFloat("max_uses", 1)
+	if maxUses < 1 || maxUses != float64(int(maxUses)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'max_uses': expected a positive whole number, got %v.", maxUses)), nil
+	}
+	ttlValue := request.GetString("code_ttl", "720h")
+	ttl, err := time.ParseDuration(ttlValue)
+	if err != nil || ttl < 0 || (ttl > 0 && ttl < time.Minute) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'code_ttl': expected 0 or a Go duration of at least 1m such as 720h, got '%s'.", ttlValue)), nil
+	}
+
+	project, _ := state.From(ctx).Project(appName)
+	captcha := project.Options["captcha"] != ""
+	throttled := project.Options["auth_throttle_store"] != ""
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "invite_only", "true")
+
+	files, err := renderFiles(ctx, inviteOnlyFiles, map[string]any{
+		"App":     appName,
+		"MaxUses": strconv.Itoa(int(maxUses)),
+		"TTL":     goDuration(ttl),
+		"Captcha": captcha,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	register := `   e.POST("/register", authController.Register, appmiddleware.InviteOnly(inviteService))`
+	if throttled {
+		register = `   e.POST("/register", authController.Register, appmiddleware.AuthThrottle(authThrottle, throttle.Registration), appmiddleware.InviteOnly(inviteService))`
+	}
+	waitlist := `   e.POST("/waitlist", invitesController.JoinWaitlist)`
+	if captcha {
+		waitlist = `   e.POST("/waitlist", invitesController.JoinWaitlist, appmiddleware.Captcha(captchaVerifier))`
+	}
+	expiry := "never expire"
+	if ttl > 0 {
+		expiry = "expire after " + ttlValue
+	}
+	args := []any{
+		appName,                    // %[1]s
+		register,                   // %[2]s
+		waitlist,                   // %[3]s
+		strconv.Itoa(int(maxUses)), // %[4]s
+		expiry,                     // %[5]s
+	}
+
+	response := fmt.Sprintf(`
+# Invite-Only Scaffold Instructions
+
+To put the registration of the application '%[1]s' behind invite codes, please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/invites internal/controllers/invites ui/pages/waitlist`"+`
+
+2. Create or update the file at `+"`internal/models/invite.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+
+3. Create or update the file at `+"`internal/invites/invites.go`"+` with the codes and the waitlist:
+`+"```go"+`
+%[7]s`+"```"+`
+
+4. Create or update the file at `+"`internal/middleware/invite_only.go`"+` with the following content:
+`+"```go"+`
+%[8]s`+"```"+`
+
+5. Create or update the file at `+"`internal/controllers/invites/controller.go`"+` with the following content:
+`+"```go"+`
+%[9]s`+"```"+`
+
+6. Create or update the file at `+"`ui/pages/waitlist/waitlist.templ`"+` with the waitlist page:
+`+"```templ"+`
+%[10]s`+"```"+`
+
+7. Wire it up in `+"`cmd/web/main.go`"+` after opening the database:
+   `+"```go"+`
+   if err := db.AutoMigrate(&models.InviteCode{}, &models.WaitlistEntry{}); err != nil {
+   	e.Logger.Fatal("failed to migrate invite tables", err)
+   }
+   inviteService := invites.NewService(db)
+   invitesController := invitescontroller.NewInvitesController(inviteService)
+
+   // Add the middleware to your own registration route, keeping its handler
+%[2]s
+
+   e.GET("/waitlist", invitesController.WaitlistPage)
+%[3]s
+
+   admin := e.Group("/admin") // add your admin authorization middleware here
+   admin.GET("/invites", invitesController.List)
+   admin.POST("/invites", invitesController.Mint)
+   admin.DELETE("/invites/:id", invitesController.Revoke)
+   admin.GET("/waitlist", invitesController.Waitlist)
+   admin.POST("/waitlist/:id/invite", invitesController.InviteFromWaitlist)
+   `+"```"+`
+
+8. Add the code to your registration form, filled in from the link of the invitation:
+   `+"```templ"+`
+   <input id="invite_code" name="invite_code" value={ inviteCode } required autocomplete="off"/>
+   `+"```"+`
+   where the registration page handler passes `+"`c.QueryParam(\"invite\")`"+` as `+"`inviteCode`"+`.
+
+9. Generate the templ code:
+   `+"`templ generate`"+`
+
+   Codes minted without their own limits allow %[4]s registration(s) and %[5]s. A registration without a valid code is redirected to `+"`/waitlist`"+`, or gets 403 when it does not accept HTML.
+`, append(args, fileContents(files)...)...) // %[6]s onwards: file contents
+
+	notes := []string{
+		"Mint codes with POST /admin/invites {\"count\": 10, \"max_uses\": 1, \"ttl\": \"168h\", \"note\": \"beta testers\"}, and invite the waitlist in order with POST /admin/waitlist/:id/invite, which returns the link to send.",
+		"Send the invitations yourself, e.g. with the mailer of produce_public_pages_boilerplate: the waitlist only records who asked.",
+		"Set INVITE_ONLY=false to open registration at launch; the codes and the waitlist stay in the database.",
+		"The code is read from the invite_code form field; a registration posting JSON must send it as a form field too, or call inviteService.Redeem in its handler.",
+		"Lock the admin routes behind your authorization middleware.",
+	}
+	if captcha {
+		notes = append(notes, "The waitlist form renders the CAPTCHA widget, and its POST route takes the middleware of produce_captcha_boilerplate.")
+	}
+	if throttled {
+		notes = append(notes, "appmiddleware.AuthThrottle comes before appmiddleware.InviteOnly on the registration route, so guessing codes counts against the registration limit.")
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, provide invites.NewService and invitescontroller.NewInvitesController in internal/app/app.go, and register the middleware and routes in an fx.Invoke function.")
+	}
+	if usesWire(ctx, appName) {
+		notes = append(notes, "With google/wire, add invites.NewService and invitescontroller.NewInvitesController to Providers, take them in NewEcho to register the middleware and routes, and run wire ./internal/app.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			"mkdir -p internal/invites internal/controllers/invites ui/pages/waitlist",
+			"templ generate",
+		},
+		Notes: notes,
+		routes: []string{
+			"GET /waitlist", "POST /waitlist",
+			"GET /admin/invites", "POST /admin/invites", "DELETE /admin/invites/:id",
+			"GET /admin/waitlist", "POST /admin/waitlist/:id/invite",
+		},
+	}), nil
+}
+
+// inviteOnlyFiles lists the invite-only files in the order they appear in the instructions
+var inviteOnlyFiles = []fileFormat{
+	{Path: "internal/models/invite.go", Language: "go", Template: "invite_only/invite.go"},
+	{Path: "internal/invites/invites.go", Language: "go", Template: "invite_only/invites.go"},
+	{Path: "internal/middleware/invite_only.go", Language: "go", Template: "invite_only/middleware.go"},
+	{Path: "internal/controllers/invites/controller.go", Language: "go", Template: "invite_only/controller.go"},
+	{Path: "ui/pages/waitlist/waitlist.templ", Language: "templ", Template: "invite_only/waitlist.templ"},
+}
@@ -0,0 +1,259 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceLiveSearchBoilerplateTool returns the tool definition for produce_live_search_boilerplate
+func GetProduceLiveSearchBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_live_search_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a search-as-you-type box for the list page of a model: a debounced input (HTMX or Alpine.js) querying a /<model>s/search endpoint that renders the matching table rows, swapped into the table body. The search goes through the Search method and scopes of produce_scopes_boilerplate, with a new Matching scope looking the term up in text columns."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("model_name",
+			mcp.Required(),
+			mcp.Description("The name of the model whose list page gets the search box (e.g., Product, Article)."),
+		),
+		mcp.WithString("search_fields",
+			mcp.Description("Comma-separated string fields the term is looked up in (e.g., Name,Sku). Defaults to the string fields recorded for the model."),
+		),
+		mcp.WithString("library",
+			mcp.Description("Library driving the input: htmx, which swaps the rows from hx-* attributes, or alpine, which fetches them from the Alpine.js the base layout already loads. Defaults to htmx."),
+			mcp.Enum("htmx", "alpine"),
+		),
+		mcp.WithNumber("debounce",
+			mcp.Description("Milliseconds to wait after the last key before searching. Defaults to 300."),
+		),
+		dialectOption,
+		routePrefixOption,
+		resourcePathOption,
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceLiveSearchBoilerplateHandler
+}
+
+// ProduceLiveSearchBoilerplateHandler handles requests to generate a search-as-you-type box for a model's list page
+// The searched fields are recorded for the model, so the scopes tool keeps the term filter when it runs again
+func ProduceLiveSearchBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
+	}
+	titleModelName := naming.Pascal(modelName)
+	lowerModelName := strings.ToLower(titleModelName)
+
+	mount, err := newRoutes(request, titleModelName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	library := request.GetString("library", "htmx")
+	if library != "htmx" && library != "alpine" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'library' '%s': expected htmx or alpine.", library)), nil
+	}
+	debounce := request.GetFloat("debounce", 300)
+	if debounce < 0 || debounce != float64(int(debounce)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'debounce': expected a whole number of milliseconds, got %v.", debounce)), nil
+	}
+	dialect := appDialect(ctx, request, appName)
+
+	fields := recordedFields(ctx, appName, titleModelName)
+	var names []string
+	for _, field := range fields {
+		if strings.TrimPrefix(field.Type, "*") == "string" {
+			names = append(names, field.Name)
+		}
+	}
+	if list := request.GetString("search_fields", ""); list != "" {
+		names = nil
+		for _, name := range splitArguments(list) {
+			if err := checkIdentifier("field", name); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if i := slices.IndexFunc(fields, func(f state.Field) bool { return f.Name == name }); len(fields) > 0 && i < 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("Unknown field '%s' in 'search_fields': expected one of %s.", name, strings.Join(recordedFieldNames(fields), ", "))), nil
+			} else if i >= 0 && strings.TrimPrefix(fields[i].Type, "*") != "string" {
+				return mcp.NewToolResultError(fmt.Sprintf("Field '%s' is a %s: only string fields can be searched with LIKE.", name, fields[i].Type)), nil
+			}
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return missingParameterResult(ctx, "search_fields", fmt.Sprintf("the string fields of '%s' the search term is looked up in (e.g., Name,Sku).", titleModelName), recordedFieldNames(fields)), nil
+	}
+
+	state.From(ctx).RecordComponent(appName, titleModelName, "live_search")
+	state.From(ctx).SetModelOption(appName, titleModelName, "search_columns", strings.Join(names, ","))
+	searchRoutes := []route{{"GET", "/search", lowerModelName + "SearchController.Search"}}
+	state.From(ctx).RecordRoutes(appName, titleModelName, mount.list(searchRoutes))
+
+	columns := make([]string, len(names))
+	var cells strings.Builder
+	for i, name := range names {
+		columns[i] = strconv.Quote(naming.Snake(name))
+		fmt.Fprintf(&cells, "\t\t\t<td class=\"px-6 py-4 whitespace-nowrap text-sm\">{ item.%s }</td>\n", naming.Pascal(name))
+	}
+	like := "LIKE"
+	if dialect == "postgres" {
+		like = "ILIKE"
+	}
+
+	scopes := deriveScopes(titleModelName, lowerModelName, fields)
+	scopes.searchable = true
+	data := map[string]any{
+		"Model":         titleModelName,
+		"Lower":         lowerModelName,
+		"Plural":        naming.Plural(titleModelName),
+		"App":           appName,
+		"Path":          mount.Path,
+		"Columns":       strings.Join(columns, ", "),
+		"Like":          like,
+		"Library":       library,
+		"Debounce":      strconv.Itoa(int(debounce)),
+		"Cells":         cells.String(),
+		"Span":          strconv.Itoa(len(names) + 2),
+		"QueryFields":   scopes.queryFields(),
+		"ServiceScopes": scopes.serviceScopes(),
+	}
+	files, err := renderFiles(ctx, liveSearchFiles, data)
+	if err != nil {
+		return nil, err
+	}
+
+	script := "The base layout already loads Alpine.js, which drives the input."
+	if library == "htmx" {
+		script = "Load HTMX in the `<head>` of `ui/layouts/base.templ`: `<script src=\"https://unpkg.com/htmx.org@2.0.4\"></script>`."
+	}
+	routesStep := fmt.Sprintf("Register the route in `cmd/web/main.go`:\n```go\n%sSearchController := %scontrollers.New%sSearchController(%sService)\n%s```\n", lowerModelName, lowerModelName, titleModelName, lowerModelName, mount.block(searchRoutes))
+	if !usesFx(ctx, appName) {
+		recordRouterRoutes(ctx, appName, titleModelName, "live_search", mount, searchRoutes)
+		router := routerFile(ctx, appName, appName, "internal/router/router.go")
+		files = append(files, router)
+		routesStep = routerStep(ctx, appName, router, titleModelName+"SearchController", fmt.Sprintf("%scontrollers.New%sSearchController(%sService)", lowerModelName, titleModelName, lowerModelName))
+	}
+	args := []any{
+		titleModelName, // %[1]s
+		lowerModelName, // %[2]s
+		mount.Path,     // %[3]s
+		routesStep,     // %[4]s
+		script,         // %[5]s
+	}
+
+	response := fmt.Sprintf(`
+# Live Search Scaffold Instructions
+
+To scaffold the search box of the %[1]s list page, please perform the following steps:
+
+1. Create the file at `+"`internal/repository/%[2]s/matching.go`"+` with the Matching scope:
+`+"```go"+`
+%[6]s`+"```"+`
+
+2. Replace `+"`internal/service/%[2]s/search.go`"+`, which now filters on the search term:
+`+"```go"+`
+%[7]s`+"```"+`
+
+3. Create the file at `+"`internal/controllers/%[2]s/search_controller.go`"+` with the following content:
+`+"```go"+`
+%[8]s`+"```"+`
+
+4. Create the file at `+"`ui/pages/%[2]s/search.templ`"+` with the search box and the table rows:
+`+"```templ"+`
+%[9]s`+"```"+`
+
+5. Use them in `+"`ui/pages/%[2]s/index.templ`"+`: add `+"`@SearchInput()`"+` above the table, and replace the loop in the table body with the rows, giving the body the id the search box swaps:
+   `+"```templ"+`
+   <tbody id="%[2]s-rows" class="bg-card divide-y divide-border">
+   	@Rows(items)
+   </tbody>
+   `+"```"+`
+   %[5]s
+
+6. %[4]s
+7. Generate the templ code:
+   `+"`templ generate`"+`
+
+   Typing in the box requests `+"`%[3]s/search?q=...`"+` and swaps the rows it returns; an empty box brings back the first page.
+`, append(args, fileContents(files)...)...) // %[6]s onwards: file contents
+
+	notes := []string{
+		fmt.Sprintf("The table body now shows the ID and %s; add the other columns to Rows and to the table header together.", strings.Join(names, ", ")),
+		"LIKE with a leading wildcard scans the whole table; past a few hundred thousand rows, move to a full-text index such as a Postgres tsvector or SQLite FTS5.",
+	}
+	if !slices.Contains(modelComponents(ctx, appName, titleModelName), "scopes") {
+		notes = append(notes, fmt.Sprintf("Matching, Search, Find and Count come with the query scopes: run produce_scopes_boilerplate for %s first, and it will keep the search term.", titleModelName))
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, fxProviderNote(fmt.Sprintf("`%scontrollers.New%sSearchController` to Controllers", lowerModelName, titleModelName)))
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			"templ generate",
+		},
+		Notes:  notes,
+		routes: mount.list(searchRoutes),
+	}), nil
+}
+
+// searchColumns returns the fields of a model the live search looks the term up in, or nil without a live search
+func searchColumns(ctx context.Context, appName, modelName string) []string {
+	if project, ok := state.From(ctx).Project(appName); ok {
+		for _, m := range project.Models {
+			if m.Name == modelName && m.Options["search_columns"] != "" {
+				return strings.Split(m.Options["search_columns"], ",")
+			}
+		}
+	}
+	return nil
+}
+
+// modelComponents returns the components scaffolded for a model of appName
+func modelComponents(ctx context.Context, appName, modelName string) []string {
+	if project, ok := state.From(ctx).Project(appName); ok {
+		for _, m := range project.Models {
+			if m.Name == modelName {
+				return m.Components
+			}
+		}
+	}
+	return nil
+}
+
+// liveSearchFiles lists the live search files in the order they appear in the instructions
+var liveSearchFiles = []fileFormat{
+	{Path: "internal/repository/{{.Lower}}/matching.go", Language: "go", Template: "live_search/matching.go"},
+	{Path: "internal/service/{{.Lower}}/search.go", Language: "go", Template: "scopes/search.go"},
+	{Path: "internal/controllers/{{.Lower}}/search_controller.go", Language: "go", Template: "live_search/search_controller.go"},
+	{Path: "ui/pages/{{.Lower}}/search.templ", Language: "templ", Template: "live_search/search.templ"},
+}
@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// defaultSensitiveFields are masked in logged bodies when the client does not name its own
+const defaultSensitiveFields = "password,token,secret,authorization,card_number,cvv"
+
+// GetProduceLoggingBoilerplateTool returns the tool definition for produce_logging_boilerplate
+func GetProduceLoggingBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_logging_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output structured request logging middleware (method, route, status, latency, user and tenant IDs, optionally sampled and masked bodies) writing to slog instead of Echo's default access log."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithNumber("body_sample_rate",
+			mcp.Description("Fraction of requests (0 to 1) whose request and response bodies are logged. Defaults to 0 (bodies are never logged)."),
+		),
+		mcp.WithString("sensitive_fields",
+			mcp.Description("Comma-separated JSON keys whose values are masked in logged bodies. Defaults to "+defaultSensitiveFields+"."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceLoggingBoilerplateHandler
+}
+
+// ProduceLoggingBoilerplateHandler handles requests to generate structured request logging
+// It creates the slog logger, the body masking helper and the request logging middleware
+func ProduceLoggingBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	sampleRate := request.GetFloat("body_sample_rate", 0)
+	if sampleRate < 0 || sampleRate > 1 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'body_sample_rate': expected a number between 0 and 1, got %v.", sampleRate)), nil
+	}
+
+	var sensitiveFields []string
+	for _, field := range strings.Split(request.GetString("sensitive_fields", defaultSensitiveFields), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			sensitiveFields = append(sensitiveFields, strconv.Quote(strings.ToLower(field)))
+		}
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "logging", "slog")
+
+	args := []any{
+		appName, // %[1]s
+		strconv.FormatFloat(sampleRate, 'f', -1, 64), // %[2]s
+		strings.Join(sensitiveFields, ", "),          // %[3]s
+	}
+	files, err := renderFiles(ctx, loggingFiles, map[string]any{"App": appName, "SensitiveFields": strings.Join(sensitiveFields, ", ")})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Request Logging Scaffold Instructions
+
+To scaffold structured request logging for the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/logging internal/middleware`"+`
+
+2. For each of the following, create or update the file in `+"`internal/logging/`"+` as needed:
+
+   a. `+"`logger.go`"+` (slog JSON logger):
+`+"```go"+`
+%[4]s`+"```"+`
+
+   b. `+"`mask.go`"+` (masking of sensitive body fields):
+`+"```go"+`
+%[5]s`+"```"+`
+
+3. Create or update the file at `+"`internal/middleware/request_log.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+
+4. Replace Echo's access log in `+"`cmd/web/main.go`"+`:
+   `+"```go"+`
+   logger := logging.New()
+   slog.SetDefault(logger)
+
+   // e.Use(middleware.Logger()) is no longer needed
+   e.Use(appmiddleware.RequestLogger(logger, appmiddleware.RequestLogConfig{
+   	BodySampleRate: %[2]s,
+   	MaxBodyBytes:   4096,
+   }))
+   `+"```"+`
+
+   User and tenant IDs are read from `+"`c.Get(\"user_id\")`"+` and `+"`c.Get(\"tenant_id\")`"+`, so set them in your authentication middleware. Bodies are only logged for the sampled fraction of requests, and always pass through `+"`logging.MaskJSON`"+` first.
+`, append(args, fileContents(files)...)...) // %[4]s onwards: file contents
+
+	notes := []string{
+		"Register RequestLogger instead of Echo's middleware.Logger().",
+		"Set LOG_LEVEL=debug to include debug records; the default level is info.",
+	}
+	if note := loggerNote(ctx, appName); note != "" {
+		notes = append(notes, note)
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{"mkdir -p internal/logging internal/middleware"},
+		Notes:    notes,
+	}), nil
+}
+
+// loggingFiles lists the logging files in the order they appear in the instructions
+var loggingFiles = []fileFormat{
+	{Path: "internal/logging/logger.go", Language: "go", Template: "logging/logger.go"},
+	{Path: "internal/logging/mask.go", Language: "go", Template: "logging/mask.go"},
+	{Path: "internal/middleware/request_log.go", Language: "go", Template: "logging/request_log.go"},
+}
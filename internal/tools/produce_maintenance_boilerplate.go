@@ -0,0 +1,179 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceMaintenanceBoilerplateTool returns the tool definition for produce_maintenance_boilerplate
+func GetProduceMaintenanceBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_maintenance_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a maintenance mode switch: middleware returning 503 with a templ maintenance page or JSON payload for every non-admin route, and an admin endpoint to toggle it."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("backend",
+			mcp.Description("Where the maintenance switch is stored. 'env' seeds an in-memory switch from MAINTENANCE_MODE or the -maintenance flag (per instance); 'db' stores it in the database so every instance shares it. Defaults to env."),
+			mcp.Enum("env", "db"),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceMaintenanceBoilerplateHandler
+}
+
+// ProduceMaintenanceBoilerplateHandler handles requests to generate maintenance mode
+// It creates the switch store, the 503 middleware, the maintenance page and the admin toggle
+func ProduceMaintenanceBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	backend := request.GetString("backend", "env")
+	if backend != "env" && backend != "db" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'backend': expected env or db, got '%s'.", backend)), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "maintenance_backend", backend)
+
+	args := []any{appName} // %[1]s
+	data := map[string]any{"App": appName}
+	files, err := renderFiles(ctx, maintenanceFiles, data)
+	if err != nil {
+		return nil, err
+	}
+
+	storeStep := maintenanceEnvStoreStep
+	if backend == "db" {
+		dbFiles, err := renderFiles(ctx, maintenanceDBFiles, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, dbFiles...)
+		storeStep = fmt.Sprintf(maintenanceDBStoreStep, dbFiles[0].Content)
+	}
+	args = append(args, storeStep) // %[2]s
+
+	response := fmt.Sprintf(`
+# Maintenance Mode Scaffold Instructions
+
+To scaffold maintenance mode for the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/maintenance internal/middleware internal/controllers/maintenance ui/pages/maintenance`"+`
+
+2. Create or update the file at `+"`internal/maintenance/maintenance.go`"+` with the following content:
+`+"```go"+`
+%[3]s`+"```"+`
+
+3. Create or update the file at `+"`internal/middleware/maintenance.go`"+` with the following content:
+`+"```go"+`
+%[4]s`+"```"+`
+
+4. Create or update the file at `+"`ui/pages/maintenance/maintenance.templ`"+` with the following content:
+`+"```templ"+`
+%[5]s`+"```"+`
+
+5. Create or update the file at `+"`internal/controllers/maintenance/controller.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+%[2]s
+   Browsers (requests accepting `+"`text/html`"+`) get the rendered maintenance page; API clients get `+"`{\"error\": \"maintenance\", \"message\": ...}`"+`. Both responses are 503 with a `+"`Retry-After`"+` header. Routes under `+"`/admin`"+` and `+"`/health`"+` stay available so the switch can be turned off again.
+`, append(args, fileContents(files)...)...) // %[3]s onwards: file contents
+
+	commands := []string{
+		"mkdir -p internal/maintenance internal/middleware internal/controllers/maintenance ui/pages/maintenance",
+		"templ generate",
+	}
+	notes := []string{
+		"Register middleware.Maintenance before the routes it should block.",
+		"Protect the /admin group with your authentication middleware; the toggle endpoint does not check permissions itself.",
+	}
+	if backend == "db" {
+		notes = append(notes, "Add maintenance.Setting to AutoMigrate in cmd/web/main.go.")
+	} else {
+		notes = append(notes, "The env backend is per instance: toggling it through the admin endpoint only affects the instance that served the request.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: commands,
+		Notes:    notes,
+	}), nil
+}
+
+// maintenanceFiles lists the maintenance files shared by every backend in the order they appear in the instructions
+var maintenanceFiles = []fileFormat{
+	{Path: "internal/maintenance/maintenance.go", Language: "go", Template: "maintenance/maintenance.go"},
+	{Path: "internal/middleware/maintenance.go", Language: "go", Template: "maintenance/middleware.go"},
+	{Path: "ui/pages/maintenance/maintenance.templ", Language: "templ", Template: "maintenance/maintenance.templ"},
+	{Path: "internal/controllers/maintenance/controller.go", Language: "go", Template: "maintenance/controller.go"},
+}
+
+// maintenanceDBFiles lists the extra files of the database backend
+var maintenanceDBFiles = []fileFormat{
+	{Path: "internal/maintenance/db_store.go", Language: "go", Template: "maintenance/db_store.go"},
+}
+
+// maintenanceEnvStoreStep wires the in-memory switch into main.go
+const maintenanceEnvStoreStep = `
+6. Wire it up in ` + "`cmd/web/main.go`" + `:
+   ` + "```go" + `
+   maintenanceFlag := flag.Bool("maintenance", false, "start in maintenance mode")
+   flag.Parse()
+
+   // MAINTENANCE_MODE=true also starts the instance in maintenance mode
+   maintenanceStore := maintenance.NewMemoryStore(*maintenanceFlag || maintenance.EnabledFromEnv(), "")
+   e.Use(appmiddleware.Maintenance(maintenanceStore))
+
+   maintenanceController := maintenancecontroller.NewMaintenanceController(maintenanceStore)
+   admin := e.Group("/admin") // add your authentication middleware here
+   admin.GET("/maintenance", maintenanceController.GetMaintenance)
+   admin.PUT("/maintenance", maintenanceController.UpdateMaintenance)
+   ` + "```" + `
+`
+
+// maintenanceDBStoreStep renders the database-backed store and wires it into main.go; %[1]s is the db_store.go content
+const maintenanceDBStoreStep = `
+6. Create or update the file at ` + "`internal/maintenance/db_store.go`" + ` with the following content:
+` + "```go" + `
+%[1]s` + "```" + `
+
+7. Wire it up in ` + "`cmd/web/main.go`" + ` after opening the database:
+   ` + "```go" + `
+   if err := db.AutoMigrate(&maintenance.Setting{}); err != nil {
+   	e.Logger.Fatal("failed to migrate maintenance settings", err)
+   }
+   maintenanceStore := maintenance.NewDBStore(db, 5*time.Second)
+   e.Use(appmiddleware.Maintenance(maintenanceStore))
+
+   maintenanceController := maintenancecontroller.NewMaintenanceController(maintenanceStore)
+   admin := e.Group("/admin") // add your authentication middleware here
+   admin.GET("/maintenance", maintenanceController.GetMaintenance)
+   admin.PUT("/maintenance", maintenanceController.UpdateMaintenance)
+   ` + "```" + `
+
+   The store caches the switch for five seconds, so every instance picks up a toggle within that time without querying the database on each request.
+`
@@ -0,0 +1,530 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"strings"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/codegen"
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceMapperBoilerplateTool returns the tool definition for produce_mapper_boilerplate
+func GetProduceMapperBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_mapper_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output an internal/mapper package with complete, field-by-field conversions between a model and its DTOs (including enums and relations), reused by the service and the API and HTML controllers instead of inline modelToDTO helpers."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("model_name",
+			mcp.Required(),
+			mcp.Description("The name of the model for which to output the mapper (e.g., User, Product)."),
+		),
+		mcp.WithString("fields",
+			mcp.Description("A JSON array of objects with 'name' and 'type' keys, and optionally 'enum' (string), the comma-separated values allowed in a string field. A type naming another model (User, *User or []Tag) is mapped as a relation. Defaults to the fields recorded by produce_model_boilerplate in this session."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceMapperBoilerplateHandler
+}
+
+// ProduceMapperBoilerplateHandler handles requests to generate the mapper between a model and its DTOs
+// Every field gets an explicit conversion, so nothing is left as a commented example
+func ProduceMapperBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
+	}
+
+	titleModelName := naming.Pascal(modelName)
+	lowerModelName := strings.ToLower(titleModelName)
+
+	fields, err := scopeFields(ctx, request, appName, titleModelName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'fields' JSON format: %v", err.Error())), nil
+	}
+	if len(fields) == 0 {
+		return missingParameterResult(ctx, "fields", fmt.Sprintf(`a JSON array of objects with 'name' and 'type' keys (e.g., [{"name":"email","type":"string"}]), since no fields were recorded for model '%s'.`, titleModelName), nil), nil
+	}
+	validations, err := fieldValidations(fields)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	state.From(ctx).RecordComponent(appName, titleModelName, "mapper")
+
+	m := newModelMapper(titleModelName, appName, fields, modelTimestamps(ctx, appName, titleModelName))
+	files := []scaffoldFile{m.file()}
+	if enums := m.enumFile(); enums.Content != "" {
+		files = append(files, enums)
+	}
+
+	pluralModelName := naming.Plural(titleModelName)
+	args := []any{
+		titleModelName,           // %[1]s
+		lowerModelName,           // %[2]s
+		appName,                  // %[3]s
+		m.enumStep(files),        // %[4]s
+		m.dtoFields(validations), // %[5]s
+		m.relationNote(),         // %[6]s
+		files[0].Content,         // %[7]s
+		pluralModelName,          // %[8]s
+	}
+	response := fmt.Sprintf(`
+# Mapper Scaffold Instructions
+
+To scaffold the mapper between model '%[1]s' and its DTOs, please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/mapper`"+`
+
+2. Create or update the file at `+"`internal/mapper/%[2]s.go`"+` with the following content:
+`+"```go"+`
+%[7]s`+"```"+`
+%[4]s
+3. Make sure `+"`internal/dto/%[2]s/dto.go`"+` declares the fields the mapper reads and writes:
+`+"```go"+`
+%[5]s`+"```"+`
+
+4. Use the mapper instead of the inline helpers, so the service and both controllers convert the same way:
+   - In the service, replace the body of `+"`modelToDTO`"+` with `+"`response := mapper.%[1]sToResponse(model); return &response`"+`, build new records with `+"`mapper.Create%[1]sRequestToModel(req)`"+` (returning its error), apply updates with `+"`mapper.ApplyUpdate%[1]sRequest(model, req)`"+` in place of the nil checks, and convert lists with `+"`mapper.%[8]sToResponses`"+`.
+   - In the HTML controller's Create handler, re-render a failed form with `+"`item := mapper.Create%[1]sRequestToResponse(req)`"+` instead of mapping the request by hand.
+   - API controllers keep calling the service, which now returns mapper output.
+%[6]s`, args...)
+
+	notes := []string{
+		fmt.Sprintf("Remove modelToDTO and createDTOToModel from the %s service once it calls the mapper.", titleModelName),
+	}
+	if len(m.enums()) > 0 {
+		notes = append(notes, fmt.Sprintf("Change the enum fields of models.%s to their generated types.", titleModelName))
+	}
+	if len(m.relations()) > 0 {
+		notes = append(notes, "Generate the mapper of every related model, and preload the relations in the repository.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{"mkdir -p internal/mapper"},
+		Notes:    notes,
+	}), nil
+}
+
+// mapperFieldKind tells how a field converts between the model and its DTOs
+type mapperFieldKind int
+
+const (
+	mapScalar       mapperFieldKind = iota
+	mapEnum                         // string in the DTOs, named string type in the model
+	mapJSON                         // datatypes.JSON in the model, json.RawMessage in the DTOs
+	mapDocument                     // typed JSON document shared by the model and the DTOs
+	mapArray                        // lib/pq array in the model, plain slice in the DTOs
+	mapGeo                          // PostGIS value type shared by the model and the DTOs
+	mapRelation                     // embedded related model
+	mapRelationPtr                  // optional related model
+	mapRelationMany                 // has-many or many-to-many relation
+)
+
+// mapperField is a model field with everything needed to convert it
+type mapperField struct {
+	name     string // Go field name
+	jsonName string
+	kind     mapperFieldKind
+	goType   string // type of the field in the DTO package
+	related  string // model name of a relation
+	array    arrayField
+	enumType string
+	values   []string // enum values
+}
+
+// modelMapper derives the mapper of a model from its fields
+type modelMapper struct {
+	model      string
+	appName    string
+	timestamps bool
+	fields     []mapperField
+}
+
+// newModelMapper classifies the fields of a model
+func newModelMapper(modelName, appName string, fields []state.Field, timestamps bool) modelMapper {
+	m := modelMapper{model: modelName, appName: appName, timestamps: timestamps}
+	for _, field := range fields {
+		f := mapperField{name: naming.Pascal(field.Name), jsonName: field.Name, goType: field.Type}
+		related := strings.TrimPrefix(strings.TrimPrefix(field.Type, "[]"), "*")
+		switch {
+		case field.Type == "json" && field.Struct != "":
+			f.kind, f.goType = mapDocument, "models."+naming.Pascal(field.Struct)
+		case field.Type == "json":
+			f.kind, f.goType = mapJSON, "json.RawMessage"
+		case field.Type == "point":
+			f.kind, f.goType = mapGeo, "models.Location"
+		case field.Type == "geometry":
+			f.kind, f.goType = mapGeo, "models.Geometry"
+		case postgresArrayTypes[field.Type].goType != "":
+			f.kind = mapArray
+			f.array = modelArrayFields([]map[string]string{{"name": field.Name, "type": field.Type}})[0]
+			f.goType = "[]" + f.array.elementType()
+		case field.Enum != "":
+			f.kind, f.goType = mapEnum, "string"
+			f.enumType = modelName + f.name
+			for _, value := range strings.Split(field.Enum, ",") {
+				if value = strings.TrimSpace(value); value != "" {
+					f.values = append(f.values, value)
+				}
+			}
+		case related != "" && unicode.IsUpper([]rune(related)[0]) && !strings.Contains(related, "."):
+			f.related = related
+			switch {
+			case strings.HasPrefix(field.Type, "[]"):
+				f.kind, f.goType = mapRelationMany, "[]"+related+"Response"
+			case strings.HasPrefix(field.Type, "*"):
+				f.kind, f.goType = mapRelationPtr, "*"+related+"Response"
+			default:
+				f.kind, f.goType = mapRelation, related+"Response"
+			}
+		}
+		m.fields = append(m.fields, f)
+	}
+	return m
+}
+
+// enums returns the enum fields
+func (m modelMapper) enums() []mapperField {
+	var enums []mapperField
+	for _, f := range m.fields {
+		if f.kind == mapEnum {
+			enums = append(enums, f)
+		}
+	}
+	return enums
+}
+
+// relations returns the relation fields
+func (m modelMapper) relations() []mapperField {
+	var relations []mapperField
+	for _, f := range m.fields {
+		if f.kind >= mapRelation {
+			relations = append(relations, f)
+		}
+	}
+	return relations
+}
+
+// requestField reports whether the field is accepted in create and update requests; relations are set through their foreign keys
+func (f mapperField) requestField() bool {
+	return f.kind < mapRelation
+}
+
+// toResponse returns the expression converting the model field for the response, empty when it needs statements
+func (f mapperField) toResponse() string {
+	switch f.kind {
+	case mapEnum:
+		return fmt.Sprintf("string(m.%s)", f.name)
+	case mapJSON:
+		return fmt.Sprintf("json.RawMessage(m.%s)", f.name)
+	case mapArray:
+		return fmt.Sprintf("%s(m.%s)", f.goType, f.name)
+	case mapRelation:
+		return fmt.Sprintf("%sToResponse(&m.%s)", f.related, f.name)
+	case mapRelationMany:
+		return fmt.Sprintf("%sToResponses(m.%s)", naming.Plural(f.related), f.name)
+	case mapRelationPtr:
+		return ""
+	}
+	return "m." + f.name
+}
+
+// toModel returns the expression converting a request value into the model field type
+func (f mapperField) toModel(value string) string {
+	switch f.kind {
+	case mapJSON:
+		return fmt.Sprintf("datatypes.JSON(%s)", value)
+	case mapArray:
+		return fmt.Sprintf("%s(%s)", f.array.goType, value)
+	}
+	return value
+}
+
+// pointerInRequests reports whether the request DTOs carry the field as a pointer; slices and raw JSON are nil when absent
+func (f mapperField) pointerInRequests(update bool) bool {
+	switch f.kind {
+	case mapJSON, mapArray:
+		return false
+	case mapDocument:
+		return true
+	}
+	return update
+}
+
+// file renders the mapper of the model
+func (m modelMapper) file() scaffoldFile {
+	var std, external []string
+	for _, f := range m.fields {
+		switch f.kind {
+		case mapJSON:
+			std = appendUnique(std, "encoding/json")
+			external = appendUnique(external, "gorm.io/datatypes")
+		case mapArray:
+			external = appendUnique(external, "github.com/lib/pq")
+		}
+	}
+	external = append(external, m.appName+"/internal/dto/"+strings.ToLower(m.model), m.appName+"/internal/models")
+
+	var b strings.Builder
+	b.WriteString("package mapper\n\nimport (\n")
+	for _, path := range std {
+		fmt.Fprintf(&b, "\t%q\n", path)
+	}
+	if len(std) > 0 {
+		b.WriteString("\n")
+	}
+	for _, path := range external {
+		fmt.Fprintf(&b, "\t%q\n", path)
+	}
+	b.WriteString(")\n\n")
+
+	// model to response
+	fmt.Fprintf(&b, "// %[1]sToResponse converts a %[1]s into its API representation\nfunc %[1]sToResponse(m *models.%[1]s) dto.%[1]sResponse {\n\tresponse := dto.%[1]sResponse{\n\t\tID: m.ID,\n", m.model)
+	if m.timestamps {
+		b.WriteString("\t\tCreatedAt: m.CreatedAt,\n\t\tUpdatedAt: m.UpdatedAt,\n")
+	}
+	for _, f := range m.fields {
+		if expr := f.toResponse(); expr != "" {
+			fmt.Fprintf(&b, "\t\t%s: %s,\n", f.name, expr)
+		}
+	}
+	b.WriteString("\t}\n")
+	for _, f := range m.fields {
+		if f.kind == mapRelationPtr {
+			fmt.Fprintf(&b, "\tif m.%[1]s != nil {\n\t\t%[2]s := %[3]sToResponse(m.%[1]s)\n\t\tresponse.%[1]s = &%[2]s\n\t}\n", f.name, naming.Camel(f.name), f.related)
+		}
+	}
+	b.WriteString("\treturn response\n}\n\n")
+
+	// list to responses
+	fmt.Fprintf(&b, "// %[2]sToResponses converts a list of %[1]s records\nfunc %[2]sToResponses(ms []models.%[1]s) []dto.%[1]sResponse {\n\tresponses := make([]dto.%[1]sResponse, len(ms))\n\tfor i := range ms {\n\t\tresponses[i] = %[1]sToResponse(&ms[i])\n\t}\n\treturn responses\n}\n\n", m.model, naming.Plural(m.model))
+
+	// create request to model
+	fmt.Fprintf(&b, "// Create%[1]sRequestToModel builds a new %[1]s from a create request, rejecting unknown enum values\nfunc Create%[1]sRequestToModel(req *dto.Create%[1]sRequest) (*models.%[1]s, error) {\n\tm := &models.%[1]s{\n", m.model)
+	for _, f := range m.fields {
+		if f.requestField() && f.kind != mapEnum && !f.pointerInRequests(false) {
+			fmt.Fprintf(&b, "\t\t%s: %s,\n", f.name, f.toModel("req."+f.name))
+		}
+	}
+	b.WriteString("\t}\n")
+	for _, f := range m.fields {
+		switch {
+		case f.kind == mapEnum:
+			fmt.Fprintf(&b, "\t%[1]s, err := models.Parse%[2]s(req.%[3]s)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\tm.%[3]s = %[1]s\n", naming.Camel(f.name), f.enumType, f.name)
+		case f.requestField() && f.pointerInRequests(false):
+			fmt.Fprintf(&b, "\tif req.%[1]s != nil {\n\t\tm.%[1]s = *req.%[1]s\n\t}\n", f.name)
+		}
+	}
+	b.WriteString("\treturn m, nil\n}\n\n")
+
+	// update request onto model
+	fmt.Fprintf(&b, "// ApplyUpdate%[1]sRequest copies the fields present in an update request onto m, leaving absent fields untouched\nfunc ApplyUpdate%[1]sRequest(m *models.%[1]s, req *dto.Update%[1]sRequest) error {\n", m.model)
+	for _, f := range m.fields {
+		switch {
+		case !f.requestField():
+		case f.kind == mapEnum:
+			fmt.Fprintf(&b, "\tif req.%[3]s != nil {\n\t\t%[1]s, err := models.Parse%[2]s(*req.%[3]s)\n\t\tif err != nil {\n\t\t\treturn err\n\t\t}\n\t\tm.%[3]s = %[1]s\n\t}\n", naming.Camel(f.name), f.enumType, f.name)
+		case f.pointerInRequests(true):
+			fmt.Fprintf(&b, "\tif req.%[1]s != nil {\n\t\tm.%[1]s = *req.%[1]s\n\t}\n", f.name)
+		default:
+			fmt.Fprintf(&b, "\tif req.%[1]s != nil {\n\t\tm.%[1]s = %[2]s\n\t}\n", f.name, f.toModel("req."+f.name))
+		}
+	}
+	b.WriteString("\treturn nil\n}\n\n")
+
+	// create request back to response, for forms
+	fmt.Fprintf(&b, "// Create%[1]sRequestToResponse echoes a create request as a response, to re-render a form that failed to save\nfunc Create%[1]sRequestToResponse(req *dto.Create%[1]sRequest) *dto.%[1]sResponse {\n\tresponse := &dto.%[1]sResponse{\n", m.model)
+	for _, f := range m.fields {
+		if f.requestField() && !f.pointerInRequests(false) {
+			fmt.Fprintf(&b, "\t\t%[1]s: req.%[1]s,\n", f.name)
+		}
+	}
+	b.WriteString("\t}\n")
+	for _, f := range m.fields {
+		if f.requestField() && f.pointerInRequests(false) {
+			fmt.Fprintf(&b, "\tif req.%[1]s != nil {\n\t\tresponse.%[1]s = *req.%[1]s\n\t}\n", f.name)
+		}
+	}
+	b.WriteString("\treturn response\n}\n")
+
+	return scaffoldFile{
+		Path:     fmt.Sprintf("internal/mapper/%s.go", strings.ToLower(m.model)),
+		Language: "go",
+		Content:  formatSource(b.String()),
+	}
+}
+
+// enumFile renders the enum types of the model; its content is empty without enum fields
+func (m modelMapper) enumFile() scaffoldFile {
+	enums := m.enums()
+	file := scaffoldFile{Path: fmt.Sprintf("internal/models/%s_enums.go", naming.Snake(m.model)), Language: "go"}
+	if len(enums) == 0 {
+		return file
+	}
+
+	var b strings.Builder
+	b.WriteString("package models\n\nimport \"fmt\"\n")
+	for _, f := range enums {
+		fmt.Fprintf(&b, "\n// %[1]s is the set of values allowed in %[2]s.%[3]s\ntype %[1]s string\n\nconst (\n", f.enumType, m.model, f.name)
+		var constants []string
+		for _, value := range f.values {
+			constant := f.enumType + enumConstName(value)
+			constants = append(constants, constant)
+			fmt.Fprintf(&b, "\t%s %s = %q\n", constant, f.enumType, value)
+		}
+		fmt.Fprintf(&b, ")\n\n// Parse%[1]s returns the %[1]s matching value, or an error listing the allowed values\nfunc Parse%[1]s(value string) (%[1]s, error) {\n\tswitch v := %[1]s(value); v {\n\tcase %[2]s:\n\t\treturn v, nil\n\t}\n\treturn \"\", fmt.Errorf(\"invalid %[3]s %%q: must be one of %[4]s\", value)\n}\n", f.enumType, strings.Join(constants, ", "), f.jsonName, strings.Join(f.values, ", "))
+	}
+	file.Content = formatSource(b.String())
+	return file
+}
+
+// enumStep explains the enum types; it is empty without enum fields
+func (m modelMapper) enumStep(files []scaffoldFile) string {
+	enums := m.enums()
+	if len(enums) == 0 {
+		return ""
+	}
+	var fields strings.Builder
+	for _, f := range enums {
+		fmt.Fprintf(&fields, "\t%s %s `json:\"%s\"`\n", f.name, f.enumType, f.jsonName)
+	}
+	return fmt.Sprintf(`
+   The enum fields get named string types. Create the file at `+"`%[1]s`"+`:
+`+"```go"+`
+%[2]s`+"```"+`
+
+   and change the fields of `+"`models.%[3]s`"+` to these types; GORM stores them as plain strings:
+`+"```go"+`
+%[4]s`+"```"+`
+`, files[1].Path, files[1].Content, m.model, fields.String())
+}
+
+// dtoFields renders the request and response DTOs the mapper expects, including validate tags of validated fields
+func (m modelMapper) dtoFields(validations []fieldValidation) string {
+	jsonTag := func(name string) codegen.Tag { return codegen.Tag{Key: "json", Value: name} }
+	validateTag := func(rules string) codegen.Tag { return codegen.Tag{Key: "validate", Value: rules} }
+	create := []codegen.Field{}
+	update := []codegen.Field{{Name: "ID", Type: "uint", Tags: []codegen.Tag{jsonTag("id"), validateTag("required")}}}
+	response := []codegen.Field{{Name: "ID", Type: "uint", Tags: []codegen.Tag{jsonTag("id")}}}
+	if m.timestamps {
+		response = append(response,
+			codegen.Field{Name: "CreatedAt", Type: "time.Time", Tags: []codegen.Tag{jsonTag("created_at")}},
+			codegen.Field{Name: "UpdatedAt", Type: "time.Time", Tags: []codegen.Tag{jsonTag("updated_at")}})
+	}
+	for _, f := range m.fields {
+		response = append(response, codegen.Field{Name: f.name, Type: f.goType, Tags: []codegen.Tag{jsonTag(f.jsonName)}})
+		if !f.requestField() {
+			continue
+		}
+
+		createTags, updateTags := []codegen.Tag{jsonTag(f.jsonName)}, []codegen.Tag{jsonTag(f.jsonName + ",omitempty")}
+		if v, ok := findValidation(validations, f.jsonName); ok {
+			createTags = append(createTags, validateTag(v.validateRules(false)))
+			updateTags = append(updateTags, validateTag(v.validateRules(true)))
+		} else if f.kind == mapEnum {
+			createTags = append(createTags, validateTag("required,oneof="+strings.Join(f.values, " ")))
+			updateTags = append(updateTags, validateTag("omitempty,oneof="+strings.Join(f.values, " ")))
+		}
+		createType, updateType := f.goType, f.goType
+		if f.pointerInRequests(false) {
+			createType = "*" + f.goType
+		}
+		if f.pointerInRequests(true) {
+			updateType = "*" + f.goType
+		}
+		create = append(create, codegen.Field{Name: f.name, Type: createType, Tags: createTags})
+		update = append(update, codegen.Field{Name: f.name, Type: updateType, Tags: updateTags})
+	}
+	// The fields come from a model scaffolded before, whose names and types were checked then
+	createFields, _ := codegen.Fields(create)
+	updateFields, _ := codegen.Fields(update)
+	responseFields, _ := codegen.Fields(response)
+	return fmt.Sprintf("type Create%[1]sRequest struct {\n%[2]s}\n\ntype Update%[1]sRequest struct {\n%[3]s}\n\ntype %[1]sResponse struct {\n%[4]s}\n",
+		m.model, createFields, updateFields, responseFields)
+}
+
+// relationNote explains where the related mappers come from; it is empty without relations
+func (m modelMapper) relationNote() string {
+	relations := m.relations()
+	if len(relations) == 0 {
+		return ""
+	}
+	var names []string
+	for _, f := range relations {
+		names = append(names, "`"+f.name+"`")
+	}
+	return fmt.Sprintf(`
+   The relations %[1]s are converted with the mappers of their models, which live in the same package: run this tool for each related model too. Preload them in the repository (e.g. `+"`db.Preload(\"%[2]s\")`"+`), or they are mapped as empty values. Requests set relations through their foreign key fields, never as nested objects.
+`, strings.Join(names, ", "), relations[0].name)
+}
+
+// enumConstName turns an enum value into the suffix of its constant, e.g. in_progress becomes InProgress
+func enumConstName(value string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// appendUnique appends the values missing from list
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			found = found || existing == value
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// formatSource formats generated Go source the way gofmt would, returning it unchanged if it does not parse
+func formatSource(source string) string {
+	formatted, err := format.Source([]byte(source))
+	if err != nil {
+		return source
+	}
+	return string(formatted)
+}
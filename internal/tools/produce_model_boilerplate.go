@@ -2,11 +2,16 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/codegen"
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
 )
 
 // GetProduceModelBoilerplateTool returns the tool definition for produce_model_boilerplate
@@ -14,16 +19,42 @@ func GetProduceModelBoilerplateTool() (mcp.Tool, func(ctx context.Context, reque
 	tool := mcp.NewTool("produce_model_boilerplate",
 		mcp.WithDescription("Instructs the LLM to output an example boilerplate for a new GORM-compatible model and its repository files."),
 		mcp.WithString("app_name",
-			mcp.Description("The name of the application. This is used to output an example of correct import paths."),
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
 		),
 		mcp.WithString("model_name",
 			mcp.Required(),
 			mcp.Description("The name of the model (e.g., User, Product)."),
 		),
-		mcp.WithString("fields",
-			mcp.Required(),
-			mcp.Description("A JSON array of objects, where each object has 'name' (string) and 'type' (string) for the model fields."),
+		fieldsOption,
+		dialectOption,
+		mcp.WithBoolean("soft_delete",
+			mcp.Description("Give the model a DeletedAt column so Delete only hides records, and add Restore and ForceDelete to the repository. Defaults to true."),
+		),
+		mcp.WithBoolean("timestamps",
+			mcp.Description("Give the model CreatedAt and UpdatedAt columns. Defaults to true."),
 		),
+		mcp.WithString("base_model",
+			mcp.Description("Name of the base struct embedded in the model instead of gorm.Model (e.g., BaseModel). Defaults to gorm.Model, or to a generated base struct when soft_delete or timestamps is disabled."),
+		),
+		partitionByOption,
+		partitionColumnOption,
+		partitionIntervalOption,
+		partitionCountOption,
+		readReplicasOption,
+		transactionsOption,
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
 	)
 
 	return tool, ProduceModelBoilerplateHandler
@@ -32,65 +63,222 @@ func GetProduceModelBoilerplateTool() (mcp.Tool, func(ctx context.Context, reque
 // ProduceModelBoilerplateHandler handles requests to generate boilerplate for a GORM-compatible model
 // It creates the model struct and repository files with CRUD operations
 func ProduceModelBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	appName := request.GetString("app_name", "") // Default app name if not provided
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
 	if appName == "" {
-		return mcp.NewToolResultError("App name is required"), nil
+		return missingAppNameResult(ctx), nil
 	}
-	modelName, err := request.RequireString("model_name")
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error getting 'model_name': %v", err.Error())), nil
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
 	}
 
-	fieldsJSON, err := request.RequireString("fields") // Assuming fields are passed as a JSON string
+	fields, err := requestFields(request)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error getting 'fields': %v", err.Error())), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
-
-	var fields []map[string]string // Use string for name and type
-	err = json.Unmarshal([]byte(fieldsJSON), &fields)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'fields' JSON format: %v", err.Error())), nil
+	if len(fields) == 0 {
+		return missingParameterResult(ctx, "fields", `an array of objects with 'name' and 'type' keys (e.g., [{"name":"email","type":"string"}]).`, nil), nil
 	}
 
 	// Generate struct fields
-	structFields := []string{}
+	dialect := appDialect(ctx, request, appName)
+	tableName := naming.Plural(naming.Snake(modelName))
+	checks := modelChecks(tableName, fields)
+	jsonFields := modelJSONFields(fields)
+	arrayFields := modelArrayFields(fields)
+	if len(arrayFields) > 0 && dialect != "postgres" {
+		return mcp.NewToolResultError(fmt.Sprintf("Field '%s' has type %s, which is stored as a Postgres array. Call the tool again with dialect=postgres, or use a json field instead.", arrayFields[0].jsonName, arrayFields[0].sliceType)), nil
+	}
+	geoFields := modelGeoFields(fields)
+	if len(geoFields) > 0 && dialect != "postgres" {
+		return mcp.NewToolResultError(fmt.Sprintf("Field '%s' is stored in a PostGIS geometry column. Call the tool again with dialect=postgres.", geoFields[0].jsonName)), nil
+	}
+	base := newModelBase(request.GetBool("soft_delete", true), request.GetBool("timestamps", true), request.GetString("base_model", ""))
+	for _, field := range fields {
+		if column := naming.Pascal(field["name"]); slices.Contains(base.fieldNames(), column) {
+			return mcp.NewToolResultError(fmt.Sprintf("Field '%s' collides with %s.%s: the embedded struct already provides %s. Remove the field, or rename it (e.g., %s%s).", field["name"], base.embed(), column, strings.Join(base.fieldNames(), ", "), naming.Pascal(modelName), column)), nil
+		}
+	}
+	structFields := []codegen.Field{{Type: base.embed()}}
+	stateFields := []state.Field{}
 	for _, field := range fields {
 		name := field["name"]
 		fieldType := field["type"]
-		structFields = append(structFields, fmt.Sprintf("\t%s %s `json:\"%s\"`", strings.Title(name), fieldType, name))
+		goType := fieldType
+		var gormSettings []string
+		if jf, ok := findJSONField(jsonFields, name); ok {
+			goType = jf.goType()
+			gormSettings = append(gormSettings, jf.gormSettings(dialect)...)
+		}
+		if af, ok := findArrayField(arrayFields, name); ok {
+			goType = af.goType
+			gormSettings = append(gormSettings, "type:"+af.sqlType)
+		}
+		if gf, ok := findGeoField(geoFields, name); ok {
+			goType = gf.goType
+			gormSettings = append(gormSettings, gf.gormSettings(tableName)...)
+		}
+		for _, check := range checks {
+			if check.jsonName == name {
+				gormSettings = append(gormSettings, check.gormSetting())
+			}
+		}
+		if columnType := fieldColumnType(fieldType, dialect); columnType != "" && !strings.Contains(field["gorm"], "type:") {
+			gormSettings = append(gormSettings, columnType)
+		}
+		for _, setting := range strings.Split(field["gorm"], ";") {
+			if setting = strings.TrimSpace(setting); setting != "" {
+				gormSettings = append(gormSettings, setting)
+			}
+		}
+		tags := []codegen.Tag{{Key: "json", Value: name}}
+		if len(gormSettings) > 0 {
+			tags = append(tags, codegen.Tag{Key: "gorm", Value: strings.Join(gormSettings, ";")})
+		}
+		structFields = append(structFields, codegen.Field{Name: naming.Pascal(name), Type: goType, Tags: tags})
+		stateFields = append(stateFields, state.Field{Name: name, Type: fieldType, Check: field["check"], Validate: field["validate"], Pattern: field["pattern"], Struct: field["struct"], Enum: field["enum"], Gorm: field["gorm"]})
 	}
 
-	modelContent := fmt.Sprintf(`package models
+	validations, err := fieldValidations(stateFields)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-import "gorm.io/gorm"
+	partitioning, err := modelPartitioning(request, dialect, tableName, base, fields)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	// Standard library imports go first, the packages of third-party field types after GORM
+	var types, stdImports, fieldPackages []string
+	for _, field := range fields {
+		types = append(types, field["type"])
+	}
+	modelImports := base.imports()
+	for _, path := range fieldTypeImports(types) {
+		if strings.Contains(path, ".") {
+			modelImports = append(modelImports, path)
+			fieldPackages = append(fieldPackages, path)
+		} else {
+			stdImports = append(stdImports, path)
+		}
+	}
+	modelImports = append(stdImports, modelImports...)
+	if usesDatatypes(jsonFields) {
+		modelImports = append(modelImports, "gorm.io/datatypes")
+	}
+	if len(arrayFields) > 0 {
+		modelImports = append(modelImports, "github.com/lib/pq")
+	}
+	modelContent, err := codegen.File{
+		Package: "models",
+		Imports: modelImports,
+		Decls: []codegen.Decl{
+			codegen.Struct{Name: naming.Pascal(modelName), Fields: structFields},
+			// The raw SQL of the migrations, checks and utilities names the table with naming.Plural, whose
+			// irregular plurals GORM's own inflection does not share (e.g., heroes, not heros), so the model pins it
+			codegen.Source(fmt.Sprintf("// TableName is the table of %[1]s, as the raw SQL of its migrations and queries names it\nfunc (%[1]s) TableName() string { return %[2]q }\n", naming.Pascal(modelName), tableName)),
+			codegen.Source(jsonDocumentTypes(jsonFields)),
+		},
+	}.Source()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Model '%s' cannot be generated: %v.", modelName, err)), nil
+	}
 
-type %s struct {
-	gorm.Model
-%s
-}
-`, strings.Title(modelName), strings.Join(structFields, "\n"))
+	titleModelName := naming.Pascal(modelName)
+	lowerModelName := strings.ToLower(titleModelName)
+
+	state.From(ctx).RecordModel(appName, titleModelName, stateFields)
+	state.From(ctx).SetModelOption(appName, titleModelName, "soft_delete", strconv.FormatBool(base.softDelete))
+	state.From(ctx).SetModelOption(appName, titleModelName, "timestamps", strconv.FormatBool(base.timestamps))
+	state.From(ctx).SetModelOption(appName, titleModelName, "base_model", base.embed())
+	state.From(ctx).SetOption(appName, "dialect", dialect)
+	access := newRepositoryAccess(ctx, request, appName)
+
+	var migrationFiles []scaffoldFile
+	if len(checks) > 0 {
+		migrationFiles = checkFiles(tableName, checks)
+	}
+	var jsonFiles []scaffoldFile
+	if len(jsonFields) > 0 {
+		jsonFiles = append(jsonFiles, jsonQueryFile(titleModelName, lowerModelName, appName, access, jsonFields))
+	}
+	var arrayFiles []scaffoldFile
+	if len(arrayFields) > 0 {
+		arrayFiles = append(arrayFiles, arrayQueryFile(titleModelName, lowerModelName, appName, access, arrayFields))
+	}
+	var geoFiles []scaffoldFile
+	if len(geoFields) > 0 {
+		var err error
+		if geoFiles, err = geoFieldFiles(ctx, titleModelName, lowerModelName, appName, access, geoFields); err != nil {
+			return nil, err
+		}
+	}
+	var validationFiles []scaffoldFile
+	if len(validations) > 0 {
+		validationFiles = append(validationFiles, validationFile())
+	}
 
-	titleModelName := strings.Title(modelName)
-	lowerModelName := strings.ToLower(modelName)
+	var partitioningFiles []scaffoldFile
+	if partitioning != nil {
+		partitioningFiles = partitionFiles(titleModelName, lowerModelName, appName, access, base, partitioning)
+		state.From(ctx).SetModelOption(appName, titleModelName, "partition_by", partitioning.by)
+	}
+
+	repositoryMethods := base.repositoryMethods() + jsonRepositoryMethods(titleModelName, jsonFields) + arrayRepositoryMethods(titleModelName, arrayFields) + geoRepositoryMethods(titleModelName, geoFields) + partitionRepositoryMethods(titleModelName, partitioning)
+
+	args := []any{
+		titleModelName,    // %[1]s
+		lowerModelName,    // %[2]s
+		modelContent,      // %[3]s
+		titleModelName,    // %[4]s
+		lowerModelName,    // %[5]s
+		appName,           // %[6]s - Hardcoded for now, ideally passed from generateAppBoilerplateHandler
+		access.Read,       // %[7]s
+		access.Write,      // %[8]s
+		access.Imports,    // %[9]s
+		access.DB,         // %[10]s
+		base.note(),       // %[11]s
+		repositoryMethods, // %[12]s
+		base.repositoryFuncs(titleModelName, access.DB, access.Write),                             // %[13]s
+		checksStep(titleModelName, lowerModelName, checks, migrationFiles),                        // %[14]s
+		jsonStep(titleModelName, lowerModelName, jsonFields, jsonFiles),                           // %[15]s
+		arrayStep(titleModelName, lowerModelName, arrayFields, arrayFiles),                        // %[16]s
+		geoStep(titleModelName, lowerModelName, geoFields, geoFiles),                              // %[17]s
+		validationStep(titleModelName, lowerModelName, stateFields, validations, validationFiles), // %[18]s
+		partitionStep(titleModelName, partitioning, partitioningFiles),                            // %[19]s
+	}
+	repositoryFiles, err := renderFiles(ctx, modelRepositoryFiles, map[string]any{
+		"Model":         titleModelName,
+		"Lower":         lowerModelName,
+		"App":           appName,
+		"Read":          access.Read,
+		"Write":         access.Write,
+		"AccessImports": access.Imports,
+		"DB":            access.DB,
+		"Methods":       repositoryMethods,
+		"BaseFuncs":     base.repositoryFuncs(titleModelName, access.DB, access.Write),
+		"Time":          partitioning != nil && partitioning.goType == "time.Time",
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	response := fmt.Sprintf(`
 # Model and Repository Scaffold Instructions
 
 To scaffold the model '%[1]s' and its repository, please perform the following steps:
 
-Note: The model includes 'gorm.Model' which provides the following fields automatically:
-- ID (uint, primary key)
-- CreatedAt (time.Time)
-- UpdatedAt (time.Time)
-- DeletedAt (soft delete with index)
-
-These fields don't need to be added manually to your model.
+%[11]s
 
 1. Create or update the file at `+"`internal/models/%[2]s.go`"+` with the following content:
 `+"```go"+`
 %[3]s
 `+"```"+`
-
+%[14]s%[15]s%[16]s%[17]s%[18]s%[19]s
 2. Create the repository directory (or ensure it exists):
    `+"`mkdir -p internal/repository/%[2]s`"+`
 
@@ -98,102 +286,34 @@ These fields don't need to be added manually to your model.
 
    a. `+"`repo.go`"+` (constructor and interface for dependency injection):
 `+"```go"+`
-package repository
-
-import (
-	"context"
-	"gorm.io/gorm"
-	"%[6]s/internal/models"
-)
-
-type %[4]sRepository interface {
-	Create(ctx context.Context, %[5]s *models.%[4]s) error
-	Update(ctx context.Context, %[5]s *models.%[4]s) error
-	Delete(ctx context.Context, id uint) error
-	Get(ctx context.Context, filters map[string]interface{}) ([]models.%[4]s, error)
-}
-
-type %[4]sRepositoryImpl struct {
-	db *gorm.DB
-}
-
-func New%[4]sRepository(db *gorm.DB) %[4]sRepository {
-	return &%[4]sRepositoryImpl{db: db}
-}
-`+"```"+`
+%[20]s`+"```"+`
 
    b. `+"`create.go`"+` (Create method):
 `+"```go"+`
-package repository
-
-import (
-	"context"
-	"%[6]s/internal/models"
-)
-
-func (r *%[4]sRepositoryImpl) Create(ctx context.Context, %[5]s *models.%[4]s) error {
-	return r.db.WithContext(ctx).Create(%[5]s).Error
-}
-`+"```"+`
+%[21]s`+"```"+`
 
    c. `+"`update.go`"+` (Update method):
 `+"```go"+`
-package repository
-
-import (
-	"context"
-	"%[6]s/internal/models"
-)
-
-func (r *%[4]sRepositoryImpl) Update(ctx context.Context, %[5]s *models.%[4]s) error {
-	return r.db.WithContext(ctx).Save(%[5]s).Error
-}
-`+"```"+`
+%[22]s`+"```"+`
 
    d. `+"`delete.go`"+` (Delete method):
 `+"```go"+`
-package repository
-
-import (
-	"context"
-	"%[6]s/internal/models"
-)
-
-func (r *%[4]sRepositoryImpl) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&models.%[4]s{}, id).Error
-}
-`+"```"+`
+%[23]s`+"```"+`
 
    e. `+"`get.go`"+` (Get method - many-to-many with filtering):
 `+"```go"+`
-package repository
-
-import (
-	"context"
-	"fmt"
-	"%[6]s/internal/models"
-)
-
-func (r *%[4]sRepositoryImpl) Get(ctx context.Context, filters map[string]interface{}) ([]models.%[4]s, error) {
-	var %[5]s []models.%[4]s
-	query := r.db.WithContext(ctx)
-	for key, value := range filters {
-		query = query.Where(fmt.Sprintf("%%s = ?", key), value)
-	}
-	err := query.Find(&%[5]s).Error
-	return %[5]s, err
-}
-`+"```"+`
+%[24]s`+"```"+`
 
 4. Bootstrap dependencies in `+"`cmd/web/main.go`"+`:
    After creating models, repositories, services, and controllers, you will need to create or update `+"`cmd/web/main.go`"+` to bootstrap these dependencies.
    This typically involves:
-   - Importing `+"`gorm.io/driver/sqlite`"+` (or your chosen database driver) and `+"`gorm.io/gorm`"+`.
-   - Initializing the database connection (e.g., `+"`db, err := gorm.Open(sqlite.Open(\"gorm.db\"), &gorm.Config{})`"+`).
+   - Importing `+"`%[6]s/internal/database`"+`, which wraps `+"`gorm.io/driver/sqlite`"+` (or your chosen database driver) and `+"`gorm.io/gorm`"+`.
+   - Initializing the database connection (e.g., `+"`db, err := database.Open(database.ConfigFromEnv())`"+`, generated by `+"`start_here_produce_app_boilerplate`"+`).
    - Auto-migrating your models (e.g., `+"`db.AutoMigrate(&models.YourModel{})`"+`).
-   - Creating instances of your repositories (e.g., `+"`userRepo := repository.NewUserRepository(db)`"+`).
-   - Creating instances of your services, injecting repositories (e.g., `+"`userService := service.NewUserService(userRepo)`"+`).
-   - Creating instances of your controllers, injecting services (e.g., `+"`userController := controllers.NewUserController(userService)`"+`).
+   - Importing the repository, service and controllers of each model, which are packages of their own, under names of their own (e.g., `+"`userrepository \"%[6]s/internal/repository/user\"`"+`).
+   - Creating instances of your repositories (e.g., `+"`userRepo := userrepository.NewUserRepository(db)`"+`).
+   - Creating instances of your services, injecting repositories (e.g., `+"`userService := userservice.NewUserService(userRepo)`"+`).
+   - Creating instances of your controllers, injecting services (e.g., `+"`userController := usercontrollers.NewUserController(userService)`"+`).
    - Registering routes for your controllers (e.g., `+"`e.POST(\"/users\", userController.CreateUser)`"+`).
 
    **Important Note**: It is recommended to use a service layer between your controllers and repositories. Controllers should not communicate directly with repositories. Instead, controllers should use services, and services should use repositories. This promotes better separation of concerns and makes your code more maintainable.
@@ -207,13 +327,12 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
 
+	"%[6]s/internal/database"
 	"%[6]s/internal/models"
-	"%[6]s/internal/repository"
-	"%[6]s/internal/service"
-	"%[6]s/internal/controllers"
+	userrepository "%[6]s/internal/repository/user"
+	userservice "%[6]s/internal/service/user"
+	usercontrollers "%[6]s/internal/controllers/user"
 )
 
 func main() {
@@ -221,8 +340,8 @@ func main() {
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 
-	// Database initialization
-	db, err := gorm.Open(sqlite.Open("gorm.db"), &gorm.Config{})
+	// Database initialization: pool tuning, startup ping with retry and slow-query logging
+	db, err := database.Open(database.ConfigFromEnv())
 	if err != nil {
 		e.Logger.Fatal("failed to connect database", err)
 	}
@@ -234,13 +353,13 @@ func main() {
 	}
 
 	// Initialize repositories
-	userRepo := repository.NewUserRepository(db)
+	userRepo := userrepository.NewUserRepository(db)
 
 	// Initialize services
-	userService := service.NewUserService(userRepo)
+	userService := userservice.NewUserService(userRepo)
 
 	// Initialize controllers
-	userController := controllers.NewUserController(userService)
+	userController := usercontrollers.NewUserController(userService)
 
 	// Routes
 	e.GET("/", hello)
@@ -257,14 +376,49 @@ func hello(c echo.Context) error {
 	return c.String(http.StatusOK, "Hello, World!")
 }
 `+"```"+`
-`,
-		titleModelName, // %[1]s
-		lowerModelName, // %[2]s
-		modelContent,   // %[3]s
-		titleModelName, // %[4]s
-		lowerModelName, // %[5]s
-		appName,        // %[6]s - Hardcoded for now, ideally passed from generateAppBoilerplateHandler
-	)
+`, append(args, fileContents(repositoryFiles)...)...) // %[20]s onwards: repository file contents
+
+	files := []scaffoldFile{{Path: fmt.Sprintf("internal/models/%s.go", lowerModelName), Language: "go", Content: modelContent}}
+	if base.generated() {
+		files = append(files, base.file())
+	}
+	files = append(files, migrationFiles...)
+	files = append(files, jsonFiles...)
+	files = append(files, arrayFiles...)
+	files = append(files, geoFiles...)
+	files = append(files, validationFiles...)
+	files = append(files, partitioningFiles...)
+	files = append(files, repositoryFiles...)
+	notes := []string{
+		fmt.Sprintf("The model embeds %s, which provides %s.", base.embed(), strings.Join(base.fieldNames(), ", ")),
+		"Bootstrap the repository in cmd/web/main.go and inject it into a service rather than using it from controllers directly.",
+	}
+	if partitioning != nil {
+		notes = append(notes, fmt.Sprintf("The primary key of %s becomes (id, %s), and Postgres requires every unique index of a partitioned table to include %s as well.", tableName, partitioning.column, partitioning.column))
+	}
+	if usesFx(ctx, appName) {
+		notes[1] = fxProviderNote(fmt.Sprintf("`&models.%s{}` to Models", titleModelName), fmt.Sprintf("`%srepository.New%sRepository` to Repositories", lowerModelName, titleModelName))
+	}
+	if usesWire(ctx, appName) {
+		notes[1] = wireProviderNote(fmt.Sprintf("`&models.%s{}` to Models", titleModelName), fmt.Sprintf("`%srepository.New%sRepository` to Providers", lowerModelName, titleModelName))
+	}
+	commands := []string{fmt.Sprintf("mkdir -p internal/repository/%s", lowerModelName)}
+	for _, path := range fieldPackages {
+		commands = append(commands, "go get "+path)
+	}
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: commands,
+		Notes:    notes,
+	}), nil
+}
 
-	return mcp.NewToolResultText(response), nil
+// modelRepositoryFiles lists the repository files of a model in the order they appear in the instructions
+// They are templates rather than codegen declarations, so that a project can override them like any other file
+var modelRepositoryFiles = []fileFormat{
+	{Path: "internal/repository/{{.Lower}}/repo.go", Language: "go", Template: "model/repo.go"},
+	{Path: "internal/repository/{{.Lower}}/create.go", Language: "go", Template: "model/create.go"},
+	{Path: "internal/repository/{{.Lower}}/update.go", Language: "go", Template: "model/update.go"},
+	{Path: "internal/repository/{{.Lower}}/delete.go", Language: "go", Template: "model/delete.go"},
+	{Path: "internal/repository/{{.Lower}}/get.go", Language: "go", Template: "model/get.go"},
 }
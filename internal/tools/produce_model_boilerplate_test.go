@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// callModel runs produce_model_boilerplate for shop on a store of its own, with arguments over app_name shop
+func callModel(t *testing.T, arguments map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"app_name": "shop"}
+	for key, value := range arguments {
+		request.Params.Arguments.(map[string]any)[key] = value
+	}
+	result, err := ProduceModelBoilerplateHandler(state.WithStore(context.Background(), state.NewStore()), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestModelRejected(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments map[string]any
+		want      string
+	}{
+		{"keyword model", map[string]any{"model_name": "Type", "fields": `[{"name":"Label","type":"string"}]`},
+			"the generated code names variables type, which is a Go keyword or predeclared identifier"},
+		{"predeclared model", map[string]any{"model_name": "error", "fields": `[{"name":"Code","type":"int"}]`},
+			"names variables error"},
+		{"punctuated model", map[string]any{"model_name": "order.item", "fields": `[{"name":"Label","type":"string"}]`},
+			"Invalid model name 'order.item'"},
+		{"gorm.Model field", map[string]any{"model_name": "Coupon", "fields": `[{"name":"created_at","type":"time.Time"}]`},
+			"Field 'created_at' collides with gorm.Model.CreatedAt"},
+		{"primary key", map[string]any{"model_name": "Coupon", "fields": `[{"name":"ID","type":"uint"}]`},
+			"collides with gorm.Model.ID"},
+		{"generated base field", map[string]any{"model_name": "Coupon", "soft_delete": false, "fields": `[{"name":"UpdatedAt","type":"time.Time"}]`},
+			"collides with TimestampedModel.UpdatedAt"},
+		{"named base field", map[string]any{"model_name": "Coupon", "base_model": "Base", "fields": `[{"name":"deleted at","type":"time.Time"}]`},
+			"collides with Base.DeletedAt"},
+		{"array without postgres", map[string]any{"model_name": "Post", "fields": `[{"name":"Tags","type":"[]string"}]`},
+			"Field 'Tags' has type []string, which is stored as a Postgres array"},
+		{"geometry without postgres", map[string]any{"model_name": "Shop", "dialect": "mysql", "fields": `[{"name":"Location","type":"point"}]`},
+			"Field 'Location' is stored in a PostGIS geometry column"},
+		{"invalid field", map[string]any{"model_name": "Post", "fields": `[{"name":"Title","type":"strng"}]`},
+			"did you mean string?"},
+		{"invalid rule", map[string]any{"model_name": "Post", "fields": `[{"name":"Title","type":"string","validate":"Required"}]`},
+			"Invalid 'validate' rule 'Required' on field 'Title'"},
+	}
+	for _, tt := range tests {
+		result := callModel(t, tt.arguments)
+		if text := resultText(result); !result.IsError || !strings.Contains(text, tt.want) {
+			t.Errorf("%s: got %q, want an error containing %q", tt.name, text, tt.want)
+		}
+	}
+}
+
+func TestModelWithoutBaseFields(t *testing.T) {
+	// Without soft deletes and timestamps, only the ID is embedded, so the other gorm.Model names are free
+	result := callModel(t, map[string]any{
+		"model_name": "Coupon", "soft_delete": false, "timestamps": false,
+		"fields": `[{"name":"CreatedAt","type":"time.Time"},{"name":"DeletedAt","type":"*time.Time"}]`,
+	})
+	if result.IsError {
+		t.Fatal(resultText(result))
+	}
+	if text := resultText(result); !strings.Contains(text, "\tIDModel\n") || !strings.Contains(text, "CreatedAt time.Time") {
+		t.Errorf("the model does not embed IDModel next to its own CreatedAt:\n%s", text)
+	}
+
+	if result := callModel(t, map[string]any{
+		"model_name": "Post", "dialect": "postgres", "fields": `[{"name":"Tags","type":"[]string"},{"name":"Spot","type":"point"}]`,
+	}); result.IsError {
+		t.Errorf("postgres arrays and geometry were rejected: %s", resultText(result))
+	}
+}
+
+func TestModelNameSharedByTools(t *testing.T) {
+	for _, tool := range All() {
+		if _, ok := tool.Tool.InputSchema.Properties["model_name"]; !ok || tool.Tool.Name == "fix_app" {
+			continue
+		}
+		for name, want := range map[string]string{
+			"type":       "Invalid model name 'type': the generated code names variables type",
+			"order.item": "Invalid model name 'order.item'",
+			"my-model":   "",
+			"Order Item": "",
+		} {
+			request := mcp.CallToolRequest{}
+			request.Params.Name = tool.Tool.Name
+			request.Params.Arguments = map[string]any{"app_name": "shop", "model_name": name, "fields": `[{"name":"Label","type":"string"}]`}
+			result, err := tool.Handler(state.WithStore(context.Background(), state.NewStore()), request)
+			if err != nil {
+				t.Fatalf("%s: %v", tool.Tool.Name, err)
+			}
+			text := resultText(result)
+			if want != "" && (!result.IsError || !strings.Contains(text, want)) {
+				t.Errorf("%s with model_name %q: got %q, want an error containing %q", tool.Tool.Name, name, text, want)
+			}
+			if want == "" && strings.Contains(text, "Invalid model name") {
+				t.Errorf("%s rejected model_name %q: %s", tool.Tool.Name, name, text)
+			}
+		}
+	}
+}
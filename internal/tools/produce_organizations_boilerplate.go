@@ -0,0 +1,226 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceOrganizationsBoilerplateTool returns the tool definition for produce_organizations_boilerplate
+func GetProduceOrganizationsBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_organizations_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output an organization membership subsystem: Organization, Membership and Invitation models, a service creating organizations, inviting users by emailed token and changing member roles, middleware resolving the current organization of each request into c.Get(\"tenant_id\"), GORM hooks and a scope keeping tenant_id records to it, JSON endpoints and an org-switcher component."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		dialectOption,
+		mcp.WithString("roles",
+			mcp.Description("Comma-separated membership roles from the most to the least privileged (e.g., owner,admin,editor,viewer). The first is given to the creator of an organization, the second may invite and manage members, and the last is given to invitees by default. Defaults to owner,admin,member."),
+		),
+		mcp.WithString("invitation_ttl",
+			mcp.Description("How long an invitation can be accepted, as a Go duration. Defaults to 168h."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceOrganizationsBoilerplateHandler
+}
+
+// roleName matches a membership role such as billing_admin
+var roleName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ProduceOrganizationsBoilerplateHandler handles requests to generate organizations and their memberships
+// The roles are recorded for the app, so the multi-tenancy scaffolds can check the same ones
+func ProduceOrganizationsBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	dialect := appDialect(ctx, request, appName)
+	if dialect != "sqlite" && dialect != "postgres" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'dialect' '%s': expected sqlite or postgres.", dialect)), nil
+	}
+
+	var roles []string
+	for _, role := range strings.Split(request.GetString("roles", "owner,admin,member"), ",") {
+		role = strings.TrimSpace(role)
+		if !roleName.MatchString(role) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid role '%s' in 'roles': expected comma-separated snake_case names such as owner,admin,member.", role)), nil
+		}
+		if !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	ttl, err := time.ParseDuration(request.GetString("invitation_ttl", "168h"))
+	if err != nil || ttl <= 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'invitation_ttl': expected a positive Go duration such as 168h, got '%s'.", request.GetString("invitation_ttl", ""))), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "roles", strings.Join(roles, ","))
+
+	// A single role is owner, manager and default at once
+	manager := roles[0]
+	if len(roles) > 1 {
+		manager = roles[1]
+	}
+	quoted := make([]string, len(roles))
+	for i, role := range roles {
+		quoted[i] = strconv.Quote(role)
+	}
+	args := []any{
+		appName,         // %[1]s
+		roles[0],        // %[2]s
+		manager,         // %[3]s
+		goDuration(ttl), // %[4]s
+	}
+	files, err := renderFiles(ctx, organizationsFiles, map[string]any{
+		"App":           appName,
+		"Roles":         strings.Join(quoted, ", "),
+		"Owner":         roles[0],
+		"Manager":       manager,
+		"Default":       roles[len(roles)-1],
+		"InvitationTTL": goDuration(ttl),
+		"Postgres":      dialect == "postgres",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Organizations Scaffold Instructions
+
+To scaffold organizations and their memberships for the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/organizations internal/controllers/organizations ui/modules`"+`
+
+2. Create or update the file at `+"`internal/models/organization.go`"+` with the following content:
+`+"```go"+`
+%[5]s`+"```"+`
+
+3. Create or update the file at `+"`internal/organizations/roles.go`"+` with the roles, from the most to the least privileged:
+`+"```go"+`
+%[6]s`+"```"+`
+
+4. Create or update the file at `+"`internal/organizations/service.go`"+` with the memberships and invitations:
+`+"```go"+`
+%[7]s`+"```"+`
+
+5. Create or update the file at `+"`internal/organizations/mailer.go`"+` with the invitation emails:
+`+"```go"+`
+%[8]s`+"```"+`
+
+6. Create or update the file at `+"`internal/organizations/context.go`"+` with the scope and hooks keeping records to the current organization:
+`+"```go"+`
+%[9]s`+"```"+`
+
+7. Create or update the file at `+"`internal/middleware/organization.go`"+` with the following content:
+`+"```go"+`
+%[10]s`+"```"+`
+
+8. Create or update the file at `+"`internal/controllers/organizations/controller.go`"+` with the following content:
+`+"```go"+`
+%[11]s`+"```"+`
+
+9. Create or update the file at `+"`ui/modules/org_switcher.templ`"+` with the organization switcher:
+`+"```templ"+`
+%[12]s`+"```"+`
+
+10. Wire it up in `+"`cmd/web/main.go`"+` after opening the database:
+   `+"```go"+`
+   if err := db.AutoMigrate(&models.Organization{}, &models.Membership{}, &models.Invitation{}); err != nil {
+   	e.Logger.Fatal("failed to migrate organizations", err)
+   }
+   if err := organizations.RegisterHooks(db); err != nil {
+   	e.Logger.Fatal("failed to register organization hooks", err)
+   }
+   orgService := organizations.NewService(db, organizations.NewMailer())
+   orgController := orgcontroller.NewOrganizationsController(orgService)
+
+   // After the authentication middleware, which sets c.Get("user_id")
+   e.Use(appmiddleware.Organization(orgService))
+   e.GET("/organizations", orgController.List)
+   e.POST("/organizations", orgController.Create)
+   e.POST("/organizations/switch", orgController.Switch)
+   e.GET("/organization/members", orgController.Members)
+   e.POST("/organization/invitations", orgController.Invite, appmiddleware.RequireRole(organizations.ManagerRole))
+   e.PUT("/organization/members/:user_id", orgController.SetRole, appmiddleware.RequireRole(organizations.ManagerRole))
+   e.DELETE("/organization/members/:user_id", orgController.Remove)
+   e.GET("/invitations/:token", orgController.Accept)
+   `+"```"+`
+
+   Every request of a member then carries `+"`c.Get(\"tenant_id\")`"+`, the ID of the current organization. Give models owned by an organization a `+"`TenantID uint`"+` field: records created with the request context get it filled in, and `+"`db.Scopes(organizations.Scope(ctx))`"+` keeps queries to them. Routes only %[3]s and more privileged roles may use take `+"`appmiddleware.RequireRole(organizations.ManagerRole)`"+`; %[2]s is the role of whoever creates an organization, and invitations expire after %[4]s.
+
+11. Generate the templ code:
+   `+"`templ generate`"+`
+`, append(args, fileContents(files)...)...) // %[5]s onwards: file contents
+
+	notes := []string{
+		"Add the Organization, Membership and Invitation models to AutoMigrate, call organizations.RegisterHooks, register middleware.Organization after the authentication middleware and mount the routes in cmd/web/main.go.",
+		"Invitation links are APP_BASE_URL/invitations/<token>; set SMTP_ADDR, SMTP_FROM, SMTP_USERNAME and SMTP_PASSWORD to email them, otherwise they are logged.",
+		"The hooks fill tenant_id only for records created with the request context (db.WithContext(ctx)); records created elsewhere, such as by jobs, must set it themselves.",
+		"Render modules.OrgSwitcher in the navbar with one modules.OrgOption per membership of orgService.Memberships and the OrganizationID of organizations.FromContext(ctx).",
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, provide organizations.NewMailer, organizations.NewService and orgcontroller.NewOrganizationsController in internal/app/app.go, and register the hooks, middleware and routes in an fx.Invoke function.")
+	}
+	if usesWire(ctx, appName) {
+		notes = append(notes, "With google/wire, add organizations.NewMailer, organizations.NewService and orgcontroller.NewOrganizationsController to Providers, take them in NewEcho to register the hooks, middleware and routes, and run wire ./internal/app.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			"mkdir -p internal/organizations internal/controllers/organizations ui/modules",
+			"templ generate",
+		},
+		Notes: notes,
+		routes: []string{
+			"GET /organizations",
+			"POST /organizations",
+			"POST /organizations/switch",
+			"GET /organization/members",
+			"POST /organization/invitations",
+			"PUT /organization/members/:user_id",
+			"DELETE /organization/members/:user_id",
+			"GET /invitations/:token",
+		},
+	}), nil
+}
+
+// organizationsFiles lists the organizations files in the order they appear in the instructions
+var organizationsFiles = []fileFormat{
+	{Path: "internal/models/organization.go", Language: "go", Template: "organizations/organization.go"},
+	{Path: "internal/organizations/roles.go", Language: "go", Template: "organizations/roles.go"},
+	{Path: "internal/organizations/service.go", Language: "go", Template: "organizations/service.go"},
+	{Path: "internal/organizations/mailer.go", Language: "go", Template: "organizations/mailer.go"},
+	{Path: "internal/organizations/context.go", Language: "go", Template: "organizations/context.go"},
+	{Path: "internal/middleware/organization.go", Language: "go", Template: "organizations/middleware.go"},
+	{Path: "internal/controllers/organizations/controller.go", Language: "go", Template: "organizations/controller.go"},
+	{Path: "ui/modules/org_switcher.templ", Language: "templ", Template: "organizations/org_switcher.templ"},
+}
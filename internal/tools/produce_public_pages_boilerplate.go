@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"path"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProducePublicPagesBoilerplateTool returns the tool definition for produce_public_pages_boilerplate
+func GetProducePublicPagesBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_public_pages_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output the public pages of the application: a home and an about page in the base layout, and a contact form emailing its messages through a small SMTP mailer, with validation, a honeypot against bots and the CAPTCHA widget when produce_captcha_boilerplate was used."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("tagline",
+			mcp.Description("One sentence describing the application, shown on the home page and in its description meta tag. Defaults to a placeholder to replace."),
+		),
+		mcp.WithString("contact_to",
+			mcp.Description("The email address receiving the messages of the contact form; CONTACT_TO overrides it at runtime. Defaults to contact@example.com."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProducePublicPagesBoilerplateHandler
+}
+
+// ProducePublicPagesBoilerplateHandler handles requests to generate the home, about and contact pages
+// It creates the pages, their controllers and the mailer the contact form sends with, and shows the routes to add
+func ProducePublicPagesBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	title := publicTitle(appName)
+	tagline := strings.TrimSpace(request.GetString("tagline", title+" helps you get things done."))
+	if tagline == "" || strings.ContainsAny(tagline, "\"{}<>\n") {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'tagline': expected one sentence without quotes, braces, angle brackets or line breaks, got '%s'.", tagline)), nil
+	}
+	contactTo := request.GetString("contact_to", "contact@example.com")
+	if address, err := mail.ParseAddress(contactTo); err != nil || address.Address != contactTo {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'contact_to': expected an email address such as contact@example.com, got '%s'.", contactTo)), nil
+	}
+
+	project, _ := state.From(ctx).Project(appName)
+	captcha := project.Options["captcha"] != ""
+	state.From(ctx).RecordApp(appName)
+
+	files, err := renderFiles(ctx, publicPagesFiles, map[string]any{
+		"App":       appName,
+		"Title":     title,
+		"Tagline":   tagline,
+		"ContactTo": contactTo,
+		"Captcha":   captcha,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contactRoute := `   e.POST("/contact", contactController.Submit)`
+	if captcha {
+		contactRoute = `   e.POST("/contact", contactController.Submit, appmiddleware.Captcha(captchaVerifier))`
+	}
+	args := []any{
+		appName,      // %[1]s
+		contactRoute, // %[2]s
+	}
+
+	response := fmt.Sprintf(`
+# Public Pages Scaffold Instructions
+
+To add the home, about and contact pages to the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/mailer internal/controllers/pages internal/controllers/contact ui/pages/public`"+`
+
+2. Create or update the file at `+"`internal/mailer/mailer.go`"+` with the mailer:
+`+"```go"+`
+%[3]s`+"```"+`
+
+3. Create or update the file at `+"`internal/controllers/pages/pages_controller.go`"+` with the following content:
+`+"```go"+`
+%[4]s`+"```"+`
+
+4. Create or update the file at `+"`internal/controllers/contact/contact_controller.go`"+` with the following content:
+`+"```go"+`
+%[5]s`+"```"+`
+
+5. Create or update the file at `+"`ui/pages/public/home.templ`"+` with the following content:
+`+"```templ"+`
+%[6]s`+"```"+`
+
+6. Create or update the file at `+"`ui/pages/public/about.templ`"+` with the following content:
+`+"```templ"+`
+%[7]s`+"```"+`
+
+7. Create or update the file at `+"`ui/pages/public/contact.templ`"+` with the contact form:
+`+"```templ"+`
+%[8]s`+"```"+`
+
+8. Wire it up in `+"`cmd/web/main.go`"+`, replacing the hello route:
+   `+"```go"+`
+   pagesController := pagescontroller.NewPagesController()
+   contactController := contactcontroller.NewContactController(mailer.New())
+
+   e.GET("/", pagesController.Home)
+   e.GET("/about", pagesController.About)
+   e.GET("/contact", contactController.Form)
+%[2]s
+   `+"```"+`
+
+9. Generate the templ code:
+   `+"`templ generate`"+`
+
+   A valid message is emailed with the visitor as Reply-To and the browser is redirected to /contact?sent=1, so reloading the page does not send it twice.
+`, append(args, fileContents(files)...)...) // %[3]s onwards: file contents
+
+	notes := []string{
+		"Set SMTP_ADDR (host:port), SMTP_FROM and, if the server needs them, SMTP_USERNAME and SMTP_PASSWORD; without SMTP_ADDR the mailer logs the messages instead of sending them.",
+		"Set CONTACT_TO to send the messages to another address than " + contactTo + " without changing the code.",
+		"Replace the placeholder text of the home and about pages with your own.",
+	}
+	if captcha {
+		notes = append(notes, "The contact form renders the CAPTCHA widget, and its POST route takes the middleware of produce_captcha_boilerplate.")
+	} else {
+		notes = append(notes, "The contact form only has a honeypot field against bots; run produce_captcha_boilerplate to add a CAPTCHA to it.")
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, provide mailer.New, pagescontroller.NewPagesController and contactcontroller.NewContactController in internal/app/app.go, and register the routes in an fx.Invoke function.")
+	}
+	if usesWire(ctx, appName) {
+		notes = append(notes, "With google/wire, add mailer.New, pagescontroller.NewPagesController and contactcontroller.NewContactController to Providers, take the controllers in NewEcho to register the routes, and run wire ./internal/app.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			"mkdir -p internal/mailer internal/controllers/pages internal/controllers/contact ui/pages/public",
+			"templ generate",
+		},
+		Notes:  notes,
+		routes: []string{"GET /", "GET /about", "GET /contact", "POST /contact"},
+	}), nil
+}
+
+// publicTitle returns the name of the app as shown to visitors, e.g. Acme Shop for github.com/acme/acme-shop
+func publicTitle(appName string) string {
+	words := naming.Words(path.Base(appName))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	if len(words) == 0 {
+		return appName
+	}
+	return strings.Join(words, " ")
+}
+
+// publicPagesFiles lists the public pages files in the order they appear in the instructions
+var publicPagesFiles = []fileFormat{
+	{Path: "internal/mailer/mailer.go", Language: "go", Template: "public_pages/mailer.go"},
+	{Path: "internal/controllers/pages/pages_controller.go", Language: "go", Template: "public_pages/pages_controller.go"},
+	{Path: "internal/controllers/contact/contact_controller.go", Language: "go", Template: "public_pages/contact_controller.go"},
+	{Path: "ui/pages/public/home.templ", Language: "templ", Template: "public_pages/home.templ"},
+	{Path: "ui/pages/public/about.templ", Language: "templ", Template: "public_pages/about.templ"},
+	{Path: "ui/pages/public/contact.templ", Language: "templ", Template: "public_pages/contact.templ"},
+}
@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceQueryMetricsBoilerplateTool returns the tool definition for produce_query_metrics_boilerplate
+func GetProduceQueryMetricsBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_query_metrics_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a GORM plugin recording the duration of every database statement in a Prometheus histogram labeled by table and operation, counting failed statements, and logging the statements slower than a threshold with the code that ran them, plus the /metrics endpoint exposing them."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("slow_query_threshold",
+			mcp.Description("Duration above which a statement is logged, as a Go duration (e.g., 200ms, 1s). Defaults to 200ms; DB_SLOW_QUERY_LOG_THRESHOLD overrides it at runtime."),
+		),
+		mcp.WithString("metrics_path",
+			mcp.Description("Route serving the Prometheus metrics. Defaults to /metrics."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceQueryMetricsBoilerplateHandler
+}
+
+// ProduceQueryMetricsBoilerplateHandler handles requests to generate database query metrics
+// It creates the GORM plugin and explains how to register it and expose the metrics
+func ProduceQueryMetricsBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	threshold, err := time.ParseDuration(request.GetString("slow_query_threshold", "200ms"))
+	if err != nil || threshold <= 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'slow_query_threshold': expected a positive Go duration such as 200ms or 1s, got '%s'.", request.GetString("slow_query_threshold", ""))), nil
+	}
+	metricsPath := request.GetString("metrics_path", "/metrics")
+	if !strings.HasPrefix(metricsPath, "/") {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'metrics_path' '%s': expected a route starting with /, such as /metrics.", metricsPath)), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "query_metrics", "prometheus")
+
+	args := []any{
+		appName,            // %[1]s
+		threshold.String(), // %[2]s
+		metricsPath,        // %[3]s
+	}
+	files, err := renderFiles(ctx, queryMetricsFiles, map[string]any{"App": appName, "SlowThreshold": goDuration(threshold)})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Database Query Metrics Scaffold Instructions
+
+To scaffold database query metrics for the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/database`"+`
+
+2. Create or update the file at `+"`internal/database/metrics.go`"+` with the following content:
+`+"```go"+`
+%[4]s`+"```"+`
+
+   It uses `+"`envDuration`"+` from `+"`internal/database/database.go`"+`, generated with the app.
+
+3. Register the plugin right after opening the database in `+"`cmd/web/main.go`"+`, and expose the metrics:
+   `+"```go"+`
+   if err := db.Use(database.NewQueryMetrics()); err != nil {
+   	e.Logger.Fatal("failed to register the query metrics", err)
+   }
+   e.GET("%[3]s", echo.WrapHandler(promhttp.Handler()))
+   `+"```"+`
+
+4. Fetch the Prometheus client:
+   `+"`go get github.com/prometheus/client_golang/prometheus`"+`
+
+   Every statement run through GORM, including those of the generated repositories, is recorded in `+"`db_query_duration_seconds`"+` labeled by `+"`table`"+` and `+"`operation`"+` (create, query, update, delete, row or raw), and failed statements in `+"`db_query_errors_total`"+`. Statements slower than %[2]s are logged with their duration, rows, the file and line that ran them, and their SQL with placeholders instead of values.
+   Chart the slowest tables with `+"`histogram_quantile(0.95, sum by (le, table, operation) (rate(db_query_duration_seconds_bucket[5m])))`"+`.
+`, append(args, fileContents(files)...)...) // %[4]s onwards: file contents
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			"mkdir -p internal/database",
+			"go get github.com/prometheus/client_golang/prometheus",
+		},
+		Notes: []string{
+			fmt.Sprintf("Register the plugin with db.Use(database.NewQueryMetrics()) after opening the database, and serve promhttp.Handler() on %s.", metricsPath),
+			"GORM's own logger also reports slow queries; set DB_SLOW_QUERY_THRESHOLD=0 to keep only the report of the plugin, which names the table and operation.",
+			fmt.Sprintf("Keep %s off the public internet, e.g. behind the authentication middleware or on an internal port.", metricsPath),
+		},
+	}), nil
+}
+
+// queryMetricsFiles lists the query metrics files in the order they appear in the instructions
+var queryMetricsFiles = []fileFormat{
+	{Path: "internal/database/metrics.go", Language: "go", Template: "query_metrics/metrics.go"},
+}
@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceResilienceBoilerplateTool returns the tool definition for produce_resilience_boilerplate
+func GetProduceResilienceBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_resilience_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a resilience package (retry with jittered backoff, circuit breaker, timeout wrapper) and an example service wrapping an external HTTP dependency with it."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("dependency_name",
+			mcp.Description("The name of the external API the example service calls (e.g., Payments, Geocoder). Defaults to External."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceResilienceBoilerplateHandler
+}
+
+// ProduceResilienceBoilerplateHandler handles requests to generate the resilience package
+// It creates retry, circuit breaker and timeout helpers plus a service example composing them
+func ProduceResilienceBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	dependencyName := request.GetString("dependency_name", "External")
+
+	titleDependencyName := naming.Pascal(dependencyName)
+	lowerDependencyName := strings.ToLower(dependencyName)
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "resilience", "true")
+
+	args := []any{
+		appName,             // %[1]s
+		titleDependencyName, // %[2]s
+		lowerDependencyName, // %[3]s
+	}
+	files, err := renderFiles(ctx, resilienceFiles, map[string]any{
+		"App":        appName,
+		"Dependency": titleDependencyName,
+		"Lower":      lowerDependencyName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Resilience Scaffold Instructions
+
+To scaffold the resilience package and a service calling '%[2]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/resilience internal/service/%[3]s`"+`
+
+2. For each of the following, create or update the file in `+"`internal/resilience/`"+` as needed:
+
+   a. `+"`retry.go`"+` (retry with exponential backoff and full jitter):
+`+"```go"+`
+%[4]s`+"```"+`
+
+   b. `+"`breaker.go`"+` (circuit breaker):
+`+"```go"+`
+%[5]s`+"```"+`
+
+   c. `+"`timeout.go`"+` (timeout wrapper):
+`+"```go"+`
+%[6]s`+"```"+`
+
+3. Create or update the file at `+"`internal/service/%[3]s/client.go`"+` with the following content:
+`+"```go"+`
+%[7]s`+"```"+`
+
+   The service composes the helpers from the outside in: the circuit breaker fails fast while the dependency is down, each retry attempt gets its own timeout, and only network errors and 5xx/429 responses are retried.
+
+4. Bootstrap the service in `+"`cmd/web/main.go`"+` and inject it wherever it is needed, like repositories:
+   `+"```go"+`
+   %[3]sService := %[3]sservice.New%[2]sService("https://api.example.com", &http.Client{Timeout: 30 * time.Second})
+   `+"```"+`
+`, append(args, fileContents(files)...)...) // %[4]s onwards: file contents
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{fmt.Sprintf("mkdir -p internal/resilience internal/service/%s", lowerDependencyName)},
+		Notes: []string{
+			"Only retry idempotent calls, or send an idempotency key with retried POSTs.",
+			"Share one circuit breaker per dependency so every caller sees the same state.",
+		},
+	}), nil
+}
+
+// resilienceFiles lists the resilience files in the order they appear in the instructions
+var resilienceFiles = []fileFormat{
+	{Path: "internal/resilience/retry.go", Language: "go", Template: "resilience/retry.go"},
+	{Path: "internal/resilience/breaker.go", Language: "go", Template: "resilience/breaker.go"},
+	{Path: "internal/resilience/timeout.go", Language: "go", Template: "resilience/timeout.go"},
+	{Path: "internal/service/{{.Lower}}/client.go", Language: "go", Template: "resilience/client.go"},
+}
@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceResponseCacheBoilerplateTool returns the tool definition for produce_response_cache_boilerplate
+func GetProduceResponseCacheBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_response_cache_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output HTTP response caching for safe GET routes: an in-memory response store, an Echo middleware caching route groups for a configurable time with Cache-Control, Vary and ETag headers, and GORM callbacks purging a model's routes when it is created, updated or deleted."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("model_name",
+			mcp.Required(),
+			mcp.Description("The name of the model whose routes are cached and purged when it changes (e.g., Product, Category)."),
+		),
+		mcp.WithString("ttl",
+			mcp.Description("How long the responses of the model's routes are cached, as a Go duration. Defaults to 1m."),
+		),
+		mcp.WithString("groups",
+			mcp.Description(`A JSON object of other route groups to cache, mapping a path prefix to a Go duration, e.g. {"/reports": "10m"}. They are not purged on writes and only expire.`),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceResponseCacheBoilerplateHandler
+}
+
+// ProduceResponseCacheBoilerplateHandler handles requests to generate HTTP response caching
+// It creates the response store, the caching middleware and the callbacks purging it on writes
+func ProduceResponseCacheBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
+	}
+	ttlValue := request.GetString("ttl", "1m")
+	ttl, err := time.ParseDuration(ttlValue)
+	if err != nil || ttl < time.Second {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'ttl': expected a Go duration of at least one second such as 1m, got '%s'.", ttlValue)), nil
+	}
+
+	titleModelName := naming.Pascal(modelName)
+	path := "/" + naming.Plural(naming.Kebab(titleModelName))
+	table := naming.Plural(naming.Snake(titleModelName))
+
+	groups := map[string]time.Duration{path: ttl}
+	if groupsJSON := request.GetString("groups", ""); groupsJSON != "" {
+		var extra map[string]string
+		if err := json.Unmarshal([]byte(groupsJSON), &extra); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'groups' JSON format: %v", err.Error())), nil
+		}
+		for prefix, value := range extra {
+			d, err := time.ParseDuration(value)
+			if !strings.HasPrefix(prefix, "/") || err != nil || d < time.Second {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid 'groups' entry %q: %q: expected a path prefix starting with / and a Go duration of at least one second.", prefix, value)), nil
+			}
+			groups[prefix] = d
+		}
+	}
+	var groupLines strings.Builder
+	for _, prefix := range slices.Sorted(maps.Keys(groups)) {
+		fmt.Fprintf(&groupLines, "   \t\t%q: %s,\n", prefix, goDuration(groups[prefix]))
+	}
+
+	state.From(ctx).RecordComponent(appName, titleModelName, "response_cache")
+
+	args := []any{
+		titleModelName,      // %[1]s
+		path,                // %[2]s
+		table,               // %[3]s
+		groupLines.String(), // %[4]s
+	}
+	files, err := renderFiles(ctx, responseCacheFiles, map[string]any{"App": appName})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Response Cache Scaffold Instructions
+
+To scaffold HTTP response caching for the routes of model '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/httpcache`"+`
+
+2. Create or update the file at `+"`internal/httpcache/store.go`"+` with the following content:
+`+"```go"+`
+%[5]s`+"```"+`
+
+3. Create or update the file at `+"`internal/httpcache/middleware.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+
+4. Create or update the file at `+"`internal/httpcache/invalidate.go`"+` with the following content:
+`+"```go"+`
+%[7]s`+"```"+`
+
+5. Wire it up in `+"`cmd/web/main.go`"+` after opening the database, before registering the routes:
+   `+"```go"+`
+   responses := httpcache.NewStore(10000)
+   if err := httpcache.Invalidate(db, responses, map[string][]string{
+   	%[3]q: {%[2]q},
+   }); err != nil {
+   	e.Logger.Fatal("failed to register cache invalidation", err)
+   }
+   e.Use(httpcache.Middleware(httpcache.Config{
+   	Store: responses,
+   	Groups: map[string]time.Duration{
+%[4]s   	},
+   }))
+   `+"```"+`
+
+   Only GET and HEAD requests of the groups are cached, and only 200 responses are stored. Requests with an Authorization header or cookies skip the cache and get `+"`Cache-Control: private, no-cache`"+`, so a user never receives another user's response. Every create, update or delete of %[1]s records through GORM purges `+"`%[2]s`"+` and everything below it.
+`, append(args, fileContents(files)...)...) // %[5]s onwards: file contents
+
+	notes := []string{
+		"Only cache routes whose response is the same for every anonymous client; set Config.Skip to exclude others, or to let signed-in requests through when their pages are not personalised.",
+		fmt.Sprintf("Purging runs when the statement completes, before its transaction commits: a request in between may cache the old %s until the TTL expires, so keep the TTL short for data that changes in transactions.", naming.Camel(titleModelName)),
+		"The store is in-process: with several instances, writes purge the cache of the instance handling them only, and the others serve their copy until it expires.",
+		"Writes made without GORM, e.g. raw SQL in migrations, do not purge the cache.",
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, create the store and register the middleware and callbacks in an fx.Invoke function of internal/app/app.go that receives *echo.Echo and *gorm.DB, before Routes.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{"mkdir -p internal/httpcache"},
+		Notes:    notes,
+	}), nil
+}
+
+// responseCacheFiles lists the response cache files in the order they appear in the instructions
+var responseCacheFiles = []fileFormat{
+	{Path: "internal/httpcache/store.go", Language: "go", Template: "response_cache/store.go"},
+	{Path: "internal/httpcache/middleware.go", Language: "go", Template: "response_cache/middleware.go"},
+	{Path: "internal/httpcache/invalidate.go", Language: "go", Template: "response_cache/invalidate.go"},
+}
@@ -0,0 +1,292 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceScopesBoilerplateTool returns the tool definition for produce_scopes_boilerplate
+func GetProduceScopesBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_scopes_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output reusable GORM query scopes for a model (Active, CreatedBetween, ByTenant, Paginate) derived from its fields, plus service and controller examples composing them instead of ad-hoc filter maps."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("model_name",
+			mcp.Required(),
+			mcp.Description("The name of the model for which to output scopes (e.g., User, Product)."),
+		),
+		mcp.WithString("fields",
+			mcp.Description("A JSON array of objects with 'name' and 'type' keys. Defaults to the fields recorded by produce_model_boilerplate in this session."),
+		),
+		readReplicasOption,
+		transactionsOption,
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceScopesBoilerplateHandler
+}
+
+// ProduceScopesBoilerplateHandler handles requests to generate query scopes for a model's repository
+// Active and ByTenant are only generated when the model has a matching field
+func ProduceScopesBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
+	}
+
+	titleModelName := naming.Pascal(modelName)
+	lowerModelName := strings.ToLower(titleModelName)
+
+	fields, err := scopeFields(ctx, request, appName, titleModelName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'fields' JSON format: %v", err.Error())), nil
+	}
+
+	state.From(ctx).RecordComponent(appName, titleModelName, "scopes")
+
+	scopes := deriveScopes(titleModelName, lowerModelName, fields)
+	scopes.searchable = len(searchColumns(ctx, appName, titleModelName)) > 0
+	access := newRepositoryAccess(ctx, request, appName)
+	args := []any{
+		titleModelName,             // %[1]s
+		lowerModelName,             // %[2]s
+		appName,                    // %[3]s
+		scopes.activeScope(),       // %[4]s
+		scopes.tenantScope(),       // %[5]s
+		scopes.queryFields(),       // %[6]s
+		scopes.serviceScopes(),     // %[7]s
+		scopes.controllerFilters(), // %[8]s
+		access.Read,                // %[9]s
+		access.Imports,             // %[10]s
+		access.DB,                  // %[11]s
+	}
+	files, err := renderFiles(ctx, scopeFiles, map[string]any{
+		"Model":             titleModelName,
+		"Lower":             lowerModelName,
+		"App":               appName,
+		"ActiveScope":       scopes.activeScope(),
+		"TenantScope":       scopes.tenantScope(),
+		"QueryFields":       scopes.queryFields(),
+		"ServiceScopes":     scopes.serviceScopes(),
+		"ControllerFilters": scopes.controllerFilters(),
+		"Read":              access.Read,
+		"AccessImports":     access.Imports,
+		"DB":                access.DB,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Query Scopes Scaffold Instructions
+
+To scaffold the query scopes for model '%[1]s', please perform the following steps:
+
+1. Create or update the file at `+"`internal/repository/%[2]s/scopes.go`"+` with the following content:
+`+"```go"+`
+%[12]s`+"```"+`
+
+2. Create or update the file at `+"`internal/repository/%[2]s/find.go`"+` with the following content:
+`+"```go"+`
+%[13]s`+"```"+`
+
+3. Create or update the file at `+"`internal/service/%[2]s/search.go`"+` with the following content:
+`+"```go"+`
+%[14]s`+"```"+`
+
+4. Replace `+"`internal/controllers/%[2]s/list.go`"+` with the following content:
+`+"```go"+`
+%[15]s`+"```"+`
+
+5. Add the new methods to the interfaces:
+   - `+"`%[1]sRepository`"+`: `+"`Find(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) ([]models.%[1]s, error)`"+` and `+"`Count(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) (int64, error)`"+`
+   - `+"`%[1]sService`"+`: `+"`Search(ctx context.Context, q %[1]sQuery) (*dto.List%[1]sResponse, error)`"+`
+`, append(args, fileContents(files)...)...) // %[12]s onwards: file contents
+
+	notes := append(scopes.notes(),
+		fmt.Sprintf("Add Find and Count to %sRepository and Search to %sService.", titleModelName, titleModelName),
+		"Compose scopes with db.Scopes(...) instead of passing filter maps to Get.",
+	)
+	if !modelTimestamps(ctx, appName, titleModelName) {
+		notes = append(notes, fmt.Sprintf("%s was generated without timestamps: remove CreatedBetween and the from/to filters, which need a created_at column.", titleModelName))
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Notes: notes,
+	}), nil
+}
+
+// scopeFields returns the fields from the request, falling back to the fields recorded for the model
+func scopeFields(ctx context.Context, request mcp.CallToolRequest, appName, modelName string) ([]state.Field, error) {
+	if fieldsJSON := request.GetString("fields", ""); fieldsJSON != "" {
+		var fields []state.Field
+		if err := json.Unmarshal([]byte(fieldsJSON), &fields); err != nil {
+			return nil, err
+		}
+		return fields, nil
+	}
+	if project, ok := state.From(ctx).Project(appName); ok {
+		for _, model := range project.Models {
+			if model.Name == modelName {
+				return model.Fields, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// modelScopes records which field-dependent scopes a model supports
+type modelScopes struct {
+	model        string
+	lower        string
+	activeColumn string // boolean column, or "status" for a string status column
+	activeValue  string // Go literal the active column is compared with
+	tenantColumn string
+	tenantType   string
+	searchable   bool // the live search scaffold added the Matching scope
+}
+
+// deriveScopes inspects model fields for an active flag/status and a tenant key
+func deriveScopes(model, lower string, fields []state.Field) modelScopes {
+	s := modelScopes{model: model, lower: lower}
+	for _, f := range fields {
+		column := naming.Snake(f.Name)
+		switch {
+		case s.activeColumn == "" && f.Type == "bool" && (column == "active" || column == "is_active" || column == "enabled"):
+			s.activeColumn, s.activeValue = column, "true"
+		case s.activeColumn == "" && f.Type == "string" && column == "status":
+			s.activeColumn, s.activeValue = column, `"active"`
+		case s.tenantColumn == "" && column == "tenant_id":
+			s.tenantColumn, s.tenantType = column, f.Type
+		}
+	}
+	return s
+}
+
+// activeScope renders the Active scope, or nothing when the model has no active flag or status
+func (s modelScopes) activeScope() string {
+	if s.activeColumn == "" {
+		return ""
+	}
+	return fmt.Sprintf(`
+// Active limits results to active records
+func (%sScopes) Active() func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("%s = ?", %s)
+	}
+}
+`, s.lower, s.activeColumn, s.activeValue)
+}
+
+// tenantScope renders the ByTenant scope, or nothing when the model has no tenant key
+func (s modelScopes) tenantScope() string {
+	if s.tenantColumn == "" {
+		return ""
+	}
+	return fmt.Sprintf(`
+// ByTenant limits results to records owned by a single tenant
+func (%sScopes) ByTenant(id %s) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("%s = ?", id)
+	}
+}
+`, s.lower, s.tenantType, s.tenantColumn)
+}
+
+// queryFields renders the optional filters of the service query struct
+func (s modelScopes) queryFields() string {
+	var b strings.Builder
+	if s.activeColumn != "" {
+		b.WriteString("\tActiveOnly bool\n")
+	}
+	if s.tenantColumn != "" {
+		b.WriteString(fmt.Sprintf("\tTenantID   *%s\n", s.tenantType))
+	}
+	if s.searchable {
+		b.WriteString("\tTerm       string\n")
+	}
+	return b.String()
+}
+
+// serviceScopes renders the service code appending the optional scopes
+func (s modelScopes) serviceScopes() string {
+	var b strings.Builder
+	if s.activeColumn != "" {
+		b.WriteString(fmt.Sprintf("\tif q.ActiveOnly {\n\t\tscopes = append(scopes, repository.%sScopes.Active())\n\t}\n", s.model))
+	}
+	if s.tenantColumn != "" {
+		b.WriteString(fmt.Sprintf("\tif q.TenantID != nil {\n\t\tscopes = append(scopes, repository.%sScopes.ByTenant(*q.TenantID))\n\t}\n", s.model))
+	}
+	if s.searchable {
+		b.WriteString(fmt.Sprintf("\tif q.Term != \"\" {\n\t\tscopes = append(scopes, repository.%sScopes.Matching(q.Term))\n\t}\n", s.model))
+	}
+	return b.String()
+}
+
+// controllerFilters renders the controller code reading the optional filters from the query string
+func (s modelScopes) controllerFilters() string {
+	var b strings.Builder
+	if s.activeColumn != "" {
+		b.WriteString("\tq.ActiveOnly = c.QueryParam(\"active\") == \"true\"\n")
+	}
+	switch {
+	case s.tenantColumn == "":
+	case s.tenantType == "string":
+		b.WriteString("\tif tenant := c.QueryParam(\"tenant_id\"); tenant != \"\" {\n\t\tq.TenantID = &tenant\n\t}\n")
+	default:
+		b.WriteString(fmt.Sprintf("\tif tenant, err := strconv.ParseUint(c.QueryParam(\"tenant_id\"), 10, 64); err == nil {\n\t\ttenantID := %s(tenant)\n\t\tq.TenantID = &tenantID\n\t}\n", s.tenantType))
+	}
+	if s.searchable {
+		b.WriteString("\tq.Term = c.QueryParam(\"q\")\n")
+	}
+	return b.String()
+}
+
+// notes explains which field-dependent scopes were left out and why
+func (s modelScopes) notes() []string {
+	var notes []string
+	if s.activeColumn == "" {
+		notes = append(notes, "Active() was not generated: the model has no boolean active/is_active/enabled field or string status field.")
+	}
+	if s.tenantColumn == "" {
+		notes = append(notes, "ByTenant() was not generated: the model has no tenant_id field.")
+	}
+	return notes
+}
+
+// scopeFiles lists the scope files of a model in the order they appear in the instructions
+var scopeFiles = []fileFormat{
+	{Path: "internal/repository/{{.Lower}}/scopes.go", Language: "go", Template: "scopes/scopes.go"},
+	{Path: "internal/repository/{{.Lower}}/find.go", Language: "go", Template: "scopes/find.go"},
+	{Path: "internal/service/{{.Lower}}/search.go", Language: "go", Template: "scopes/search.go"},
+	{Path: "internal/controllers/{{.Lower}}/list.go", Language: "go", Template: "scopes/list.go"},
+}
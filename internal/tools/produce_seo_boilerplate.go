@@ -0,0 +1,242 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/codegen"
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceSeoBoilerplateTool returns the tool definition for produce_seo_boilerplate
+func GetProduceSeoBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_seo_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output search engine metadata for an HTML app: canonical URL, OpenGraph and Twitter card tags rendered by the base layout from each page, a /sitemap.xml listing the home page and the detail page of every record of the public models, and a /robots.txt pointing crawlers to it. Suited to content-oriented sites whose pages should be found and shared."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("base_url",
+			mcp.Description("Public origin of the site, used in canonical URLs, OpenGraph tags and the sitemap (e.g., https://shop.example.com). SITE_URL overrides it at runtime. Defaults to http://localhost:1323."),
+		),
+		mcp.WithString("models",
+			mcp.Description("Comma-separated models whose detail pages are public and listed in the sitemap (e.g., Product,Article). Defaults to the models with an HTML controller."),
+		),
+		mcp.WithString("disallow",
+			mcp.Description("Comma-separated path prefixes robots.txt asks crawlers to skip (e.g., /admin,/api). Defaults to none; the form pages are kept out of search results by a noindex tag instead."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceSeoBoilerplateHandler
+}
+
+// ProduceSeoBoilerplateHandler handles requests to generate the search engine metadata of an HTML application
+// The sitemap lists the detail pages of the models at the paths their HTML controllers were mounted on
+func ProduceSeoBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	baseURL := strings.TrimSuffix(request.GetString("base_url", "http://localhost:1323"), "/")
+	if !(strings.HasPrefix(baseURL, "https://") || strings.HasPrefix(baseURL, "http://")) || strings.Count(baseURL, "/") > 2 || strings.ContainsAny(baseURL, " \"") {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'base_url' '%s': expected a scheme and host without a path, such as https://%s.example.com.", baseURL, appName)), nil
+	}
+	var disallow []string
+	for _, path := range splitArguments(request.GetString("disallow", "")) {
+		if !strings.HasPrefix(path, "/") || strings.ContainsAny(path, " \"\n") {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid path '%s' in 'disallow': expected a path prefix starting with /, such as /admin.", path)), nil
+		}
+		disallow = append(disallow, strconv.Quote(path))
+	}
+
+	project, _ := state.From(ctx).Project(appName)
+	var known, modelNames []string
+	for _, m := range project.Models {
+		known = append(known, m.Name)
+		if slices.Contains(m.Components, "html_controller") {
+			modelNames = append(modelNames, m.Name)
+		}
+	}
+	if list := request.GetString("models", ""); list != "" {
+		modelNames = nil
+		for _, name := range splitArguments(list) {
+			if err := checkModelName(name); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			modelNames = append(modelNames, naming.Pascal(name))
+		}
+	}
+	if len(modelNames) == 0 {
+		return missingParameterResult(ctx, "models", "the models whose detail pages are public and belong in the sitemap (e.g., Product,Article).", known), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "seo", strings.Join(modelNames, ","))
+
+	// One source per model lists its detail pages, newest changes first when the model has timestamps
+	var sources []codegen.Decl
+	var wiring []string
+	var paths []string
+	for _, name := range modelNames {
+		path := detailPagePath(project, name)
+		paths = append(paths, fmt.Sprintf("`%s/:id`", path))
+		wiring = append(wiring, fmt.Sprintf("seo.%sPages(db)", name))
+		selection, order, lastMod := `"id"`, "id", ""
+		if modelTimestamps(ctx, appName, name) {
+			selection, order, lastMod = `"id", "updated_at"`, "updated_at DESC", ", LastMod: row.UpdatedAt"
+		}
+		sources = append(sources, codegen.Source(fmt.Sprintf(`// %[1]sPages lists the detail page of every %[2]s
+func %[1]sPages(db *gorm.DB) Source {
+	return func(ctx context.Context, limit int) ([]URL, error) {
+		var rows []models.%[1]s
+		if err := db.WithContext(ctx).Select(%[4]s).Order(%[5]q).Limit(limit).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		urls := make([]URL, len(rows))
+		for i, row := range rows {
+			urls[i] = URL{Path: "%[3]s/" + strconv.FormatUint(uint64(row.ID), 10)%[6]s}
+		}
+		return urls, nil
+	}
+}
+`, name, strings.ToLower(name), path, selection, order, lastMod)))
+	}
+	pages, err := codegen.File{
+		Package: "seo",
+		Module:  appName,
+		Imports: []string{"context", "strconv", "gorm.io/gorm", appName + "/internal/models"},
+		Decls:   sources,
+	}.Source()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("The sitemap sources cannot be generated: %v.", err)), nil
+	}
+
+	// The sources file follows the handlers using it, before the templ tags
+	files, err := renderFiles(ctx, seoFiles, map[string]any{"App": appName, "BaseURL": baseURL})
+	if err != nil {
+		return nil, err
+	}
+	files = slices.Insert(files, 2, scaffoldFile{Path: "internal/seo/pages.go", Language: "go", Content: pages})
+
+	args := []any{
+		appName,                                // %[1]s
+		strings.Join(wiring, ", "),             // %[2]s
+		strings.Join(disallow, ", "),           // %[3]s
+		strings.Join(paths, ", "),              // %[4]s
+		detailPagePath(project, modelNames[0]), // %[5]s
+	}
+
+	response := fmt.Sprintf(`
+# SEO Metadata Scaffold Instructions
+
+To scaffold the search engine metadata of the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/seo ui/layouts`"+`
+
+2. Create or update the file at `+"`internal/seo/sitemap.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+
+3. Create or update the file at `+"`internal/seo/robots.go`"+` with the following content:
+`+"```go"+`
+%[7]s`+"```"+`
+
+4. Create or update the file at `+"`internal/seo/pages.go`"+` with the following content:
+`+"```go"+`
+%[8]s`+"```"+`
+
+5. Create or update the file at `+"`ui/layouts/seo.templ`"+` with the following content:
+`+"```templ"+`
+%[9]s`+"```"+`
+
+6. Let pages describe themselves: add these fields to `+"`layouts.Page`"+` in `+"`ui/layouts/base.templ`"+`, and replace its `+"`og:description`"+` and `+"`og:title`"+` tags with `+"`@SEOTags(page)`"+`, keeping `+"`<meta name=\"description\">`"+`:
+   `+"```go"+`
+   Path    string // path of the canonical URL, e.g. %[5]s/42
+   Image   string // path or URL of the image shown in link previews
+   Type    string // OpenGraph type, website by default
+   NoIndex bool   // keeps the page out of search results, as forms should be
+   `+"```"+`
+   Set `+"`Path`"+` on the detail pages (e.g. `+"`Path: \"%[5]s/\" + item.ID.String()`"+`), and `+"`NoIndex: true`"+` in `+"`formPage`"+` of each form.
+
+7. Register the routes in `+"`cmd/web/main.go`"+`, once the database is open:
+   `+"```go"+`
+   e.GET("/sitemap.xml", seo.Sitemap(%[2]s))
+   e.GET("/robots.txt", seo.Robots(%[3]s))
+   `+"```"+`
+
+8. Generate the templ code:
+   `+"`templ generate`"+`
+
+   The sitemap lists the home page and %[4]s, with their last change when the model has timestamps; soft-deleted records drop out as GORM skips them. Set SITE_URL to the public origin in each environment, so canonical URLs and the sitemap point to the right host.
+`, append(args, fileContents(files)...)...) // %[6]s onwards: file contents
+
+	notes := []string{
+		"Submit " + baseURL + "/sitemap.xml in Google Search Console and Bing Webmaster Tools once deployed.",
+		"Records that are not public, such as drafts, belong out of the sitemap: add a Where clause to their source in internal/seo/pages.go.",
+	}
+	for _, name := range modelNames {
+		if !slices.Contains(known, name) {
+			notes = append(notes, fmt.Sprintf("The model %s has not been scaffolded in this session; its detail pages are assumed at %s/:id.", name, detailPagePath(project, name)))
+		}
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, register the sitemap and robots.txt routes in a function of internal/app taking *echo.Echo and *gorm.DB, added to fx.Invoke.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			"mkdir -p internal/seo ui/layouts",
+			"templ generate",
+		},
+		Notes:  notes,
+		routes: []string{"GET /sitemap.xml", "GET /robots.txt"},
+	}), nil
+}
+
+// detailPagePath returns the path the detail pages of a model are served under, e.g. /products, as recorded by
+// its HTML controller, or the default resource path when no HTML controller has been scaffolded
+func detailPagePath(project state.Project, modelName string) string {
+	for _, m := range project.Models {
+		if m.Name != modelName {
+			continue
+		}
+		for _, route := range m.Routes {
+			if base, ok := strings.CutSuffix(route, "/:id"); ok && strings.HasPrefix(base, "GET ") && slices.Contains(m.Routes, base+"/new") {
+				return strings.TrimPrefix(base, "GET ")
+			}
+		}
+	}
+	return "/" + naming.Plural(naming.Kebab(modelName))
+}
+
+// seoFiles lists the SEO files rendered from templates, in the order they appear in the instructions
+var seoFiles = []fileFormat{
+	{Path: "internal/seo/sitemap.go", Language: "go", Template: "seo/sitemap.go"},
+	{Path: "internal/seo/robots.go", Language: "go", Template: "seo/robots.go"},
+	{Path: "ui/layouts/seo.templ", Language: "templ", Template: "seo/seo.templ"},
+}
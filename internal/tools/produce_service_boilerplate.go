@@ -1,11 +1,16 @@
 package tools
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
 )
 
 // GetProduceServiceBoilerplateTool returns the tool definition for produce_service_boilerplate
@@ -13,12 +18,26 @@ func GetProduceServiceBoilerplateTool() (mcp.Tool, func(ctx context.Context, req
 	tool := mcp.NewTool("produce_service_boilerplate",
 		mcp.WithDescription("Instructs the LLM to output an example boilerplate for a new service layer with DTOs for a given model."),
 		mcp.WithString("app_name",
-			mcp.Description("The name of the application. This is used to output an example of correct import paths."),
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
 		),
 		mcp.WithString("model_name",
 			mcp.Required(),
 			mcp.Description("The name of the model for which to output an example a service (e.g., User, Product)."),
 		),
+		templateVersionOption,
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
 	)
 
 	return tool, ProduceServiceBoilerplateHandler
@@ -27,17 +46,52 @@ func GetProduceServiceBoilerplateTool() (mcp.Tool, func(ctx context.Context, req
 // ProduceServiceBoilerplateHandler handles requests to generate boilerplate for a service layer
 // It creates service files with DTOs (Data Transfer Objects) and business logic for a given model
 func ProduceServiceBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	appName := request.GetString("app_name", "") // Default app name if not provided
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
 	if appName == "" {
-		return mcp.NewToolResultError("App name is required"), nil
+		return missingAppNameResult(ctx), nil
 	}
-	modelName, err := request.RequireString("model_name")
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
+	}
+
+	titleModelName := naming.Pascal(modelName)
+	lowerModelName := strings.ToLower(titleModelName)
+
+	version, err := templateVersion(ctx, request, appName)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Error getting 'model_name': %v", err.Error())), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	titleModelName := strings.Title(modelName)
-	lowerModelName := strings.ToLower(modelName)
+	state.From(ctx).RecordComponent(appName, titleModelName, "service")
+
+	args := []any{
+		titleModelName, // %[1]s
+		lowerModelName, // %[2]s
+		appName,        // %[3]s
+	}
+	timestamps := serviceTimestampFragments(modelTimestamps(ctx, appName, titleModelName))
+	fields := recordedFields(ctx, appName, titleModelName)
+	requests := serviceRequestFragments(fields)
+	args = append(args, timestamps...) // %[4]s to %[6]s
+	args = append(args, requests...)   // %[7]s and %[8]s
+	files, err := renderFiles(ctx, versionFiles(version, serviceFiles), map[string]any{
+		"Model":           titleModelName,
+		"Lower":           lowerModelName,
+		"App":             appName,
+		"TimeImport":      dtoImports(modelTimestamps(ctx, appName, titleModelName), fields),
+		"ResponseFields":  timestamps[1],
+		"ResponseMapping": timestamps[2],
+		"CreateFields":    requests[0],
+		"UpdateFields":    requests[1],
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	response := fmt.Sprintf(`# Service Layer and DTOs Scaffold Instructions
 
@@ -84,49 +138,7 @@ To scaffold the service layer with DTOs for model '%[1]s', please perform the fo
 
 2. Create or update the file at internal/dto/%[2]s/dto.go with the following content:
 
-package dto
-
-import "time"
-
-// Create%[1]sRequest represents the request payload for creating a %[2]s
-type Create%[1]sRequest struct {
-	// Add your fields here based on your model
-	// Example fields - replace with actual model fields:
-	// Name        string `+"`json:\"name\" validate:\"required\"`"+`
-	// Email       string `+"`json:\"email\" validate:\"required,email\"`"+`
-	// Description string `+"`json:\"description\"`"+`
-}
-
-// Update%[1]sRequest represents the request payload for updating a %[2]s
-type Update%[1]sRequest struct {
-	ID uint `+"`json:\"id\" validate:\"required\"`"+`
-	// Add your fields here based on your model
-	// Example fields - replace with actual model fields:
-	// Name        *string `+"`json:\"name,omitempty\"`"+`
-	// Email       *string `+"`json:\"email,omitempty\"`"+`
-	// Description *string `+"`json:\"description,omitempty\"`"+`
-}
-
-// %[1]sResponse represents the response payload for %[2]s operations
-type %[1]sResponse struct {
-	ID        uint      `+"`json:\"id\"`"+`
-	CreatedAt time.Time `+"`json:\"created_at\"`"+`
-	UpdatedAt time.Time `+"`json:\"updated_at\"`"+`
-	// Add your fields here based on your model
-	// Example fields - replace with actual model fields:
-	// Name        string `+"`json:\"name\"`"+`
-	// Email       string `+"`json:\"email\"`"+`
-	// Description string `+"`json:\"description\"`"+`
-}
-
-// List%[1]sResponse represents the response payload for listing %[2]s
-type List%[1]sResponse struct {
-	Data  []%[1]sResponse `+"`json:\"data\"`"+`
-	Total int          `+"`json:\"total\"`"+`
-	Page  int          `+"`json:\"page\"`"+`
-	Limit int          `+"`json:\"limit\"`"+`
-}
-
+%[9]s
 3. Create the service directory (or ensure it exists):
    mkdir -p internal/service/%[2]s
 
@@ -134,195 +146,32 @@ type List%[1]sResponse struct {
 
    a. internal/service/%[2]s/service.go (interface and constructor):
 
-package service
-
-import (
-	"context"
-	"%[3]s/internal/dto"
-	"%[3]s/internal/models"
-	"%[3]s/internal/repository"
-)
-
-type %[1]sService interface {
-	Create(ctx context.Context, req *dto.Create%[1]sRequest) (*dto.%[1]sResponse, error)
-	Update(ctx context.Context, req *dto.Update%[1]sRequest) (*dto.%[1]sResponse, error)
-	Delete(ctx context.Context, id uint) error
-	GetByID(ctx context.Context, id uint) (*dto.%[1]sResponse, error)
-	List(ctx context.Context, page, limit int, filters map[string]interface{}) (*dto.List%[1]sResponse, error)
-}
-
-type %[1]sServiceImpl struct {
-	%[2]sRepo repository.%[1]sRepository
-}
-
-func New%[1]sService(%[2]sRepo repository.%[1]sRepository) %[1]sService {
-	return &%[1]sServiceImpl{%[2]sRepo: %[2]sRepo}
-}
-
-// Helper function to convert model to DTO
-func (s *%[1]sServiceImpl) modelToDTO(model *models.%[1]s) *dto.%[1]sResponse {
-	return &dto.%[1]sResponse{
-		ID:        model.ID,
-		CreatedAt: model.CreatedAt,
-		UpdatedAt: model.UpdatedAt,
-		// Map your model fields to DTO fields here
-		// Example:
-		// Name:        model.Name,
-		// Email:       model.Email,
-		// Description: model.Description,
-	}
-}
-
-// Helper function to convert create DTO to model
-func (s *%[1]sServiceImpl) createDTOToModel(req *dto.Create%[1]sRequest) *models.%[1]s {
-	return &models.%[1]s{
-		// Map your DTO fields to model fields here
-		// Example:
-		// Name:        req.Name,
-		// Email:       req.Email,
-		// Description: req.Description,
-	}
-}
-
+%[10]s
    b. internal/service/%[2]s/create.go (Create method):
 
-package service
-
-import (
-	"context"
-	"%[3]s/internal/dto"
-)
-
-func (s *%[1]sServiceImpl) Create(ctx context.Context, req *dto.Create%[1]sRequest) (*dto.%[1]sResponse, error) {
-	// Convert DTO to model
-	model := s.createDTOToModel(req)
-
-	// Create in repository
-	if err := s.%[2]sRepo.Create(ctx, model); err != nil {
-		return nil, err
-	}
-
-	// Convert model back to DTO and return
-	return s.modelToDTO(model), nil
-}
-
+%[11]s
    c. internal/service/%[2]s/update.go (Update method):
 
-package service
-
-import (
-	"context"
-	"errors"
-	"%[3]s/internal/dto"
-)
-
-func (s *%[1]sServiceImpl) Update(ctx context.Context, req *dto.Update%[1]sRequest) (*dto.%[1]sResponse, error) {
-	// First, get the existing record
-	filters := map[string]interface{}{"id": req.ID}
-	existing, err := s.%[2]sRepo.Get(ctx, filters)
-	if err != nil {
-		return nil, err
-	}
-	if len(existing) == 0 {
-		return nil, errors.New("%[2]s not found")
-	}
-
-	model := &existing[0]
-	// Update only the fields that are provided (not nil)
-	// Example:
-	// if req.Name != nil {
-	//     model.Name = *req.Name
-	// }
-	// if req.Email != nil {
-	//     model.Email = *req.Email
-	// }
-	// if req.Description != nil {
-	//     model.Description = *req.Description
-	// }
-
-	// Update in repository
-	if err := s.%[2]sRepo.Update(ctx, model); err != nil {
-		return nil, err
-	}
-
-	// Convert model back to DTO and return
-	return s.modelToDTO(model), nil
-}
-
+%[12]s
    d. internal/service/%[2]s/delete.go (Delete method):
 
-package service
-
-import "context"
-
-func (s *%[1]sServiceImpl) Delete(ctx context.Context, id uint) error {
-	return s.%[2]sRepo.Delete(ctx, id)
-}
-
+%[13]s
    e. internal/service/%[2]s/get_by_id.go (GetByID method):
 
-package service
-
-import (
-	"context"
-	"errors"
-	"%[3]s/internal/dto"
-)
-
-func (s *%[1]sServiceImpl) GetByID(ctx context.Context, id uint) (*dto.%[1]sResponse, error) {
-	filters := map[string]interface{}{"id": id}
-	results, err := s.%[2]sRepo.Get(ctx, filters)
-	if err != nil {
-		return nil, err
-	}
-	if len(results) == 0 {
-		return nil, errors.New("%[2]s not found")
-	}
-
-	return s.modelToDTO(&results[0]), nil
-}
-
+%[14]s
    f. internal/service/%[2]s/list.go (List method):
 
-package service
-
-import (
-	"context"
-	"%[3]s/internal/dto"
-)
-
-func (s *%[1]sServiceImpl) List(ctx context.Context, page, limit int, filters map[string]interface{}) (*dto.List%[1]sResponse, error) {
-	// Get data from repository
-	results, err := s.%[2]sRepo.Get(ctx, filters)
-	if err != nil {
-		return nil, err
-	}
-
-	// Convert models to DTOs
-	dtoResults := make([]dto.%[1]sResponse, len(results))
-	for i, model := range results {
-		dtoResults[i] = *s.modelToDTO(&model)
-	}
-
-	// TODO: Implement proper pagination in repository layer
-	// For now, return all results
-	return &dto.List%[1]sResponse{
-		Data:  dtoResults,
-		Total: len(dtoResults),
-		Page:  page,
-		Limit: limit,
-	}, nil
-}
-
+%[15]s
 5. Update your controller to use the service layer instead of repository directly.
    The controller should now inject the service and use DTOs for request/response.
 
 6. Bootstrap dependencies in cmd/web/main.go:
    After creating services, you will need to update cmd/web/main.go to bootstrap the service layer.
    This typically involves:
-   - Creating instances of your repositories (e.g., userRepo := repository.NewUserRepository(db)).
-   - Creating instances of your services, injecting repositories (e.g., userService := service.NewUserService(userRepo)).
-   - Creating instances of your controllers, injecting services (e.g., userController := controllers.NewUserController(userService)).
+   - Importing the repository, service and controllers of each model, which are packages of their own, under names of their own (e.g., userrepository "%[3]s/internal/repository/user").
+   - Creating instances of your repositories (e.g., userRepo := userrepository.NewUserRepository(db)).
+   - Creating instances of your services, injecting repositories (e.g., userService := userservice.NewUserService(userRepo)).
+   - Creating instances of your controllers, injecting services (e.g., userController := usercontrollers.NewUserController(userService)).
 
    Here's an example of how cmd/web/main.go might look with the service layer:
 
@@ -333,13 +182,12 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	"gorm.io/driver/sqlite"
-	"gorm.io/gorm"
 
+	"%[3]s/internal/database"
 	"%[3]s/internal/models"
-	"%[3]s/internal/repository"
-	"%[3]s/internal/service"
-	"%[3]s/internal/controllers"
+	%[2]srepository "%[3]s/internal/repository/%[2]s"
+	%[2]sservice "%[3]s/internal/service/%[2]s"
+	%[2]scontrollers "%[3]s/internal/controllers/%[2]s"
 )
 
 func main() {
@@ -347,8 +195,8 @@ func main() {
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 
-	// Database initialization
-	db, err := gorm.Open(sqlite.Open("gorm.db"), &gorm.Config{})
+	// Database initialization: pool tuning, startup ping with retry and slow-query logging
+	db, err := database.Open(database.ConfigFromEnv())
 	if err != nil {
 		e.Logger.Fatal("failed to connect database", err)
 	}
@@ -360,21 +208,21 @@ func main() {
 	}
 
 	// Initialize repositories
-	%[2]sRepo := repository.New%[1]sRepository(db)
+	%[2]sRepo := %[2]srepository.New%[1]sRepository(db)
 
 	// Initialize services
-	%[2]sService := service.New%[1]sService(%[2]sRepo)
+	%[2]sService := %[2]sservice.New%[1]sService(%[2]sRepo)
 
 	// Initialize controllers
-	%[2]sController := controllers.New%[1]sController(%[2]sService)
+	%[2]sController := %[2]scontrollers.New%[1]sController(%[2]sService)
 
 	// Routes
 	e.GET("/", hello)
-	e.POST("/%[2]ss", %[2]sController.Create%[1]s)
-	e.GET("/%[2]ss/:id", %[2]sController.Get%[1]sByID)
-	e.GET("/%[2]ss", %[2]sController.List%[1]s)
-	e.PUT("/%[2]ss/:id", %[2]sController.Update%[1]s)
-	e.DELETE("/%[2]ss/:id", %[2]sController.Delete%[1]s)
+	e.POST("/%[16]s", %[2]sController.Create%[1]s)
+	e.GET("/%[16]s/:id", %[2]sController.Get%[1]sByID)
+	e.GET("/%[16]s", %[2]sController.List%[1]s)
+	e.PUT("/%[16]s/:id", %[2]sController.Update%[1]s)
+	e.DELETE("/%[16]s/:id", %[2]sController.Delete%[1]s)
 
 	e.Logger.Fatal(e.Start(":1323"))
 }
@@ -382,11 +230,105 @@ func main() {
 func hello(c echo.Context) error {
 	return c.String(http.StatusOK, "Hello, World!")
 }
-`,
-		titleModelName, // %[1]s
-		lowerModelName, // %[2]s
-		appName,        // %[3]s
-	)
+`, append(append(args, fileContents(files)...), naming.Plural(naming.Kebab(titleModelName)))...) // %[9]s to %[15]s: file contents, %[16]s: the resource path
+
+	notes := []string{
+		"Replace the commented example fields in the DTOs and mapping helpers with the model's actual fields.",
+		"Controllers should depend on the service, and the service on the repository; bootstrap both in cmd/web/main.go.",
+	}
+	if usesFx(ctx, appName) {
+		notes[1] = fxProviderNote(fmt.Sprintf("`service.New%sService` to Services", titleModelName))
+	}
+	if usesWire(ctx, appName) {
+		notes[1] = wireProviderNote(fmt.Sprintf("`service.New%sService` to Providers", titleModelName))
+	}
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			fmt.Sprintf("mkdir -p internal/dto/%s", lowerModelName),
+			fmt.Sprintf("mkdir -p internal/service/%s", lowerModelName),
+		},
+		Notes: notes,
+	}), nil
+}
+
+// modelTimestamps reports whether the model recorded for appName has CreatedAt and UpdatedAt, assuming it does when unknown
+func modelTimestamps(ctx context.Context, appName, modelName string) bool {
+	if project, ok := state.From(ctx).Project(appName); ok {
+		for _, model := range project.Models {
+			if model.Name == modelName {
+				return model.Options["timestamps"] != "false"
+			}
+		}
+	}
+	return true
+}
+
+// serviceTimestampFragments returns the DTO import, response fields and mapping lines, with or without timestamps
+func serviceTimestampFragments(timestamps bool) []any {
+	if !timestamps {
+		return []any{
+			"",
+			"\tID uint `json:\"id\"`\n",
+			"\t\tID: model.ID,\n",
+		}
+	}
+	return []any{
+		"\nimport \"time\"\n",
+		"\tID        uint      `json:\"id\"`\n\tCreatedAt time.Time `json:\"created_at\"`\n\tUpdatedAt time.Time `json:\"updated_at\"`\n",
+		"\t\tID:        model.ID,\n\t\tCreatedAt: model.CreatedAt,\n\t\tUpdatedAt: model.UpdatedAt,\n",
+	}
+}
+
+// dtoImports renders the imports of the DTOs: time for the timestamps, and the packages of the request field types
+func dtoImports(timestamps bool, fields []state.Field) string {
+	var types []string
+	if timestamps {
+		types = append(types, "time.Time")
+	}
+	if validations, err := fieldValidations(fields); err == nil && len(validations) > 0 {
+		for _, field := range fields {
+			if scalarFieldType(field.Type) {
+				types = append(types, field.Type)
+			}
+		}
+	}
+	imports := fieldTypeImports(types)
+	slices.SortStableFunc(imports, func(a, b string) int {
+		return cmp.Compare(strings.Count(a, "."), strings.Count(b, "."))
+	})
+	return importBlock(imports)
+}
+
+// serviceRequestFragments returns the create and update request fields: the recorded fields with their validate tags
+// when the model declares validation rules, commented examples otherwise
+func serviceRequestFragments(fields []state.Field) []any {
+	if validations, err := fieldValidations(fields); err == nil && len(validations) > 0 {
+		create, update := requestDTOFields(fields, validations)
+		return []any{create, update}
+	}
+	return []any{
+		"\t// Add your fields here based on your model\n" +
+			"\t// Example fields - replace with actual model fields:\n" +
+			"\t// Name        string `json:\"name\" validate:\"required\"`\n" +
+			"\t// Email       string `json:\"email\" validate:\"required,email\"`\n" +
+			"\t// Description string `json:\"description\"`\n",
+		"\tID uint `json:\"id\" validate:\"required\"`\n" +
+			"\t// Add your fields here based on your model\n" +
+			"\t// Example fields - replace with actual model fields:\n" +
+			"\t// Name        *string `json:\"name,omitempty\"`\n" +
+			"\t// Email       *string `json:\"email,omitempty\"`\n" +
+			"\t// Description *string `json:\"description,omitempty\"`\n",
+	}
+}
 
-	return mcp.NewToolResultText(response), nil
+// serviceFiles lists the DTO and service files in the order they appear in the instructions
+var serviceFiles = []fileFormat{
+	{Path: "internal/dto/{{.Lower}}/dto.go", Language: "go", Template: "service/dto.go"},
+	{Path: "internal/service/{{.Lower}}/service.go", Language: "go", Template: "service/service.go"},
+	{Path: "internal/service/{{.Lower}}/create.go", Language: "go", Template: "service/create.go"},
+	{Path: "internal/service/{{.Lower}}/update.go", Language: "go", Template: "service/update.go"},
+	{Path: "internal/service/{{.Lower}}/delete.go", Language: "go", Template: "service/delete.go"},
+	{Path: "internal/service/{{.Lower}}/get_by_id.go", Language: "go", Template: "service/get_by_id.go"},
+	{Path: "internal/service/{{.Lower}}/list.go", Language: "go", Template: "service/list.go"},
 }
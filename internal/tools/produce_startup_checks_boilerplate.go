@@ -0,0 +1,217 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// startupChecks are the boot-time assertions the self-check can run, in report order
+var startupChecks = []string{"env", "migrations", "templ", "routes"}
+
+// GetProduceStartupChecksBoilerplateTool returns the tool definition for produce_startup_checks_boilerplate
+func GetProduceStartupChecksBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_startup_checks_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output boot-time self-checks run before the server starts: required environment variables are set, the database schema has every table and column of the models, templ pages are generated, and every route is bound to a handler of a non-nil controller. All checks run and the app exits with one report of every failure, instead of panicking on the first request that hits the problem."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("checks",
+			mcp.Description("Comma-separated checks to run: env (required environment variables), migrations (tables and columns of the scaffolded models), templ (generated templ pages under ui) and routes (handlers and controllers). Defaults to routes, plus env when variables are required, migrations when models have been scaffolded and templ when HTML controllers have."),
+		),
+		mcp.WithString("required_env",
+			mcp.Description("Comma-separated environment variables the app cannot start without (e.g., DB_DSN,SESSION_SECRET). Defaults to DB_DSN for postgres apps and DB_REPLICA_DSNS for apps with read replicas."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceStartupChecksBoilerplateHandler
+}
+
+// ProduceStartupChecksBoilerplateHandler handles requests to generate the startup self-checks of an application
+// The checks of the models, pages and controllers follow what the project manifest records
+func ProduceStartupChecksBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	project, _ := state.From(ctx).Project(appName)
+
+	var requiredEnv []string
+	if project.Options["dialect"] == "postgres" {
+		requiredEnv = append(requiredEnv, "DB_DSN")
+	}
+	if project.Options["read_replicas"] == "true" {
+		requiredEnv = append(requiredEnv, "DB_REPLICA_DSNS")
+	}
+	if list := request.GetString("required_env", ""); list != "" {
+		requiredEnv = splitArguments(list)
+	}
+
+	var models, controllers []string
+	html := false
+	for _, m := range project.Models {
+		lower := strings.ToLower(m.Name)
+		if slices.Contains(m.Components, "model") {
+			models = append(models, fmt.Sprintf("&models.%s{}", m.Name))
+		}
+		if slices.Contains(m.Components, "api_controller") {
+			controllers = append(controllers, lower+"Controller")
+		}
+		if slices.Contains(m.Components, "html_controller") {
+			controllers = append(controllers, lower+"HtmlController")
+			html = true
+		}
+	}
+
+	checks := []string{"routes"}
+	if len(requiredEnv) > 0 {
+		checks = append(checks, "env")
+	}
+	if len(models) > 0 {
+		checks = append(checks, "migrations")
+	}
+	if html {
+		checks = append(checks, "templ")
+	}
+	if list := request.GetString("checks", ""); list != "" {
+		checks = nil
+		for _, check := range strings.Split(list, ",") {
+			check = strings.ToLower(strings.TrimSpace(check))
+			if !slices.Contains(startupChecks, check) {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid check '%s' in 'checks': expected a comma-separated list of %s.", check, strings.Join(startupChecks, ", "))), nil
+			}
+			if !slices.Contains(checks, check) {
+				checks = append(checks, check)
+			}
+		}
+		if len(checks) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'checks': expected at least one of %s.", strings.Join(startupChecks, ", "))), nil
+		}
+	}
+	slices.SortFunc(checks, func(a, b string) int { return slices.Index(startupChecks, a) - slices.Index(startupChecks, b) })
+	if slices.Contains(checks, "env") && len(requiredEnv) == 0 {
+		return missingParameterResult(ctx, "required_env", "the environment variables the app cannot start without (e.g., DB_DSN,SESSION_SECRET).", nil), nil
+	}
+
+	state.From(ctx).SetOption(appName, "startup_checks", strings.Join(checks, ","))
+
+	// The wiring snippet lists one constructor call per check
+	var wiring strings.Builder
+	for _, check := range checks {
+		switch check {
+		case "env":
+			quoted := make([]string, len(requiredEnv))
+			for i, name := range requiredEnv {
+				quoted[i] = strconv.Quote(name)
+			}
+			fmt.Fprintf(&wiring, "   \tstartup.RequireEnv(%s),\n", strings.Join(quoted, ", "))
+		case "migrations":
+			if len(models) == 0 {
+				wiring.WriteString("   \tstartup.MigrationsUpToDate(db, &models.YourModel{}), // every model of the app\n")
+			} else {
+				fmt.Fprintf(&wiring, "   \tstartup.MigrationsUpToDate(db, %s),\n", strings.Join(models, ", "))
+			}
+		case "templ":
+			wiring.WriteString("   \tstartup.TemplGenerated(\"ui\"),\n")
+		case "routes":
+			wiring.WriteString("   \tstartup.RoutesBound(e, map[string]any{\n")
+			for _, controller := range controllers {
+				fmt.Fprintf(&wiring, "   \t\t%q: %s,\n", controller, controller)
+			}
+			if len(controllers) == 0 {
+				wiring.WriteString("   \t\t// \"userController\": userController,\n")
+			}
+			wiring.WriteString("   \t}),\n")
+		}
+	}
+
+	formats := []fileFormat{startupChecksFiles[0]}
+	for _, f := range startupChecksFiles[1:] {
+		if slices.Contains(checks, strings.TrimSuffix(f.Template[strings.LastIndex(f.Template, "/")+1:], ".go")) {
+			formats = append(formats, f)
+		}
+	}
+	files, err := renderFiles(ctx, formats, map[string]any{"App": appName})
+	if err != nil {
+		return nil, err
+	}
+
+	var steps strings.Builder
+	for i, f := range files {
+		fmt.Fprintf(&steps, "   %c. `%s`:\n```go\n%s```\n\n", 'a'+i, f.Path, f.Content)
+	}
+
+	response := fmt.Sprintf(`
+# Startup Checks Scaffold Instructions
+
+To scaffold boot-time self-checks for the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/startup`"+`
+
+2. For each of the following, create or update the file in `+"`internal/startup/`"+`:
+
+%[2]s3. Run the checks in `+"`cmd/web/main.go`"+` once the database is open and every route is registered, right before starting the server:
+   `+"```go"+`
+   startup.MustRun(context.Background(),
+%[3]s   )
+   e.Logger.Fatal(e.Start(":1323"))
+   `+"```"+`
+
+   Every check runs even after one fails, and the process exits with status 1 and a single report listing each problem and how to fix it, for example:
+   `+"```"+`
+   startup checks failed (2 of %[4]d):
+     - env: DB_DSN not set; export them or add them to the deployment environment
+     - migrations: table products has no column sku; run the migrations
+   `+"```"+`
+   A deployment then fails its rollout instead of serving errors, and the logs say why.
+`, appName, steps.String(), wiring.String(), len(checks))
+
+	notes := []string{
+		"Run the checks after registering the routes: RoutesBound only sees routes that exist when it runs.",
+		"Run this tool again after scaffolding more models or controllers, so the checks cover them too.",
+	}
+	if slices.Contains(checks, "templ") {
+		notes = append(notes, "TemplGenerated passes when the ui directory is absent, as in a container holding only the binary, so it only guards deployments built from the sources.")
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, call startup.Run in the OnStart hook of Start in internal/app/app.go before starting the server, returning its error so fx stops the app.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{"mkdir -p internal/startup"},
+		Notes:    notes,
+	}), nil
+}
+
+// startupChecksFiles lists the startup check files; each file after the first is named after the check it holds
+var startupChecksFiles = []fileFormat{
+	{Path: "internal/startup/checks.go", Language: "go", Template: "startup_checks/checks.go"},
+	{Path: "internal/startup/migrations.go", Language: "go", Template: "startup_checks/migrations.go"},
+	{Path: "internal/startup/templ.go", Language: "go", Template: "startup_checks/templ.go"},
+	{Path: "internal/startup/routes.go", Language: "go", Template: "startup_checks/routes.go"},
+}
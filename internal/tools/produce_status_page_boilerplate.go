@@ -0,0 +1,190 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// statusChecks are the health checks the status page can aggregate, in page order
+var statusChecks = []string{"db", "cache", "queue"}
+
+// GetProduceStatusPageBoilerplateTool returns the tool definition for produce_status_page_boilerplate
+func GetProduceStatusPageBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_status_page_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a public /status templ page and a /status.json endpoint aggregating health checks (database, cache, event queue latency) with the build version and commit, suitable for sharing with stakeholders and polling from uptime monitors."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("checks",
+			mcp.Description("Comma-separated health checks to aggregate: db (database ping, critical), cache (a ping function of your cache client) and queue (age of the oldest due event of the event log). Defaults to db, plus queue when the event log has been scaffolded."),
+		),
+		mcp.WithString("cache_ttl",
+			mcp.Description("How long a status report is reused before the checks run again, as a Go duration. Defaults to 15s."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceStatusPageBoilerplateHandler
+}
+
+// ProduceStatusPageBoilerplateHandler handles requests to generate the public status page
+// It creates the checker and its checks, the page and JSON controller, and the templ page
+func ProduceStatusPageBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	project, _ := state.From(ctx).Project(appName)
+	eventLog := project.Options["event_log_poll_interval"] != ""
+
+	checks := []string{"db"}
+	if eventLog {
+		checks = append(checks, "queue")
+	}
+	if list := request.GetString("checks", ""); list != "" {
+		checks = nil
+		for _, check := range strings.Split(list, ",") {
+			check = strings.ToLower(strings.TrimSpace(check))
+			if !slices.Contains(statusChecks, check) {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid check '%s' in 'checks': expected a comma-separated list of %s.", check, strings.Join(statusChecks, ", "))), nil
+			}
+			if !slices.Contains(checks, check) {
+				checks = append(checks, check)
+			}
+		}
+		if len(checks) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid 'checks': expected at least one of %s.", strings.Join(statusChecks, ", "))), nil
+		}
+	}
+	slices.SortFunc(checks, func(a, b string) int { return slices.Index(statusChecks, a) - slices.Index(statusChecks, b) })
+
+	cacheTTL, err := time.ParseDuration(request.GetString("cache_ttl", "15s"))
+	if err != nil || cacheTTL <= 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'cache_ttl': expected a positive Go duration such as 15s, got '%s'.", request.GetString("cache_ttl", ""))), nil
+	}
+
+	state.From(ctx).SetOption(appName, "status_page_checks", strings.Join(checks, ","))
+
+	// The wiring snippet lists one constructor call per check
+	var wiring strings.Builder
+	for _, check := range checks {
+		switch check {
+		case "db":
+			wiring.WriteString("   \tstatus.Database(db),\n")
+		case "cache":
+			wiring.WriteString("   \tstatus.Cache(func(ctx context.Context) error { return rdb.Ping(ctx).Err() }), // your cache client\n")
+		case "queue":
+			wiring.WriteString("   \tstatus.EventQueue(db),\n")
+		}
+	}
+
+	args := []any{
+		appName,              // %[1]s
+		wiring.String(),      // %[2]s
+		goDuration(cacheTTL), // %[3]s
+	}
+	files, err := renderFiles(ctx, statusPageFiles, map[string]any{
+		"App":      appName,
+		"CacheTTL": goDuration(cacheTTL),
+		"Database": slices.Contains(checks, "db"),
+		"Cache":    slices.Contains(checks, "cache"),
+		"Queue":    slices.Contains(checks, "queue"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Status Page Scaffold Instructions
+
+To scaffold the public status page for the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/status internal/controllers/status ui/pages/status`"+`
+
+2. Create or update the file at `+"`internal/status/status.go`"+` with the following content:
+`+"```go"+`
+%[4]s`+"```"+`
+
+3. Create or update the file at `+"`internal/status/checks.go`"+` with the following content:
+`+"```go"+`
+%[5]s`+"```"+`
+
+4. Create or update the file at `+"`internal/controllers/status/controller.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+
+5. Create or update the file at `+"`ui/pages/status/status.templ`"+` with the following content:
+`+"```templ"+`
+%[7]s`+"```"+`
+
+6. Wire it up in `+"`cmd/web/main.go`"+` after opening the database:
+   `+"```go"+`
+   checker := status.NewChecker(
+%[2]s   )
+   statusController := statuscontroller.NewStatusController(checker)
+   e.GET("/status", statusController.Page)
+   e.GET("/status.json", statusController.JSON)
+   `+"```"+`
+
+   The checks run concurrently with a two second deadline, and their report is reused for %[3]s, so the page can be shared widely without loading the dependencies. A failing critical check (the database) reports the service down and makes `+"`/status.json`"+` answer 503; any other failing or slow check reports it degraded. Check errors are logged with slog and shown on the page only as "unavailable".
+
+7. Stamp the build version when building, for example:
+   `+"`go build -ldflags \"-X %[1]s/internal/status.Version=$(git describe --tags --always)\" ./cmd/web`"+`
+
+   The commit is read from the VCS information Go embeds in the binary; set `+"`status.Commit`"+` the same way when building outside the repository.
+`, append(args, fileContents(files)...)...) // %[4]s onwards: file contents
+
+	notes := []string{
+		"The status page is public: keep it outside authenticated groups, and do not add checks whose details reveal internal hosts or credentials.",
+	}
+	if slices.Contains(checks, "queue") && !eventLog {
+		notes = append(notes, "The queue check reads the events table of produce_event_log_boilerplate; scaffold the event log first, or replace EventQueue with a check of your own queue.")
+	}
+	if project.Options["maintenance_backend"] != "" {
+		notes = append(notes, "Add \"/status\" to MaintenanceBypassPrefixes in internal/middleware/maintenance.go, so stakeholders can still read the status page during maintenance.")
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, create the checker and register the routes in a function taking *echo.Echo and *gorm.DB, and add it to the fx.Invoke calls in internal/app/app.go.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			"mkdir -p internal/status internal/controllers/status ui/pages/status",
+			"templ generate",
+		},
+		Notes: notes,
+	}), nil
+}
+
+// statusPageFiles lists the status page files in the order they appear in the instructions
+var statusPageFiles = []fileFormat{
+	{Path: "internal/status/status.go", Language: "go", Template: "status_page/status.go"},
+	{Path: "internal/status/checks.go", Language: "go", Template: "status_page/checks.go"},
+	{Path: "internal/controllers/status/controller.go", Language: "go", Template: "status_page/controller.go"},
+	{Path: "ui/pages/status/status.templ", Language: "templ", Template: "status_page/status.templ"},
+}
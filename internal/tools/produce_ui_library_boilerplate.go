@@ -0,0 +1,249 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceUiLibraryBoilerplateTool returns the tool definition for produce_ui_library_boilerplate
+func GetProduceUiLibraryBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_ui_library_boilerplate",
+		mcp.WithDescription("Instructs the LLM to move the generated layout and modules of an HTML app (base layout, navbar, theme switcher, breadcrumbs) into a UI library with its own go.mod, along with a data table and form modules and the templUI components, so several apps of an organization share one component set. The app keeps thin layouts and modules packages delegating to the library, so its pages build unchanged."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("library_module",
+			mcp.Description("Module path of the UI library (e.g., github.com/acme/uikit). Defaults to the library the app already uses, or the one another app exported, or uikit under the configured module prefix."),
+		),
+		mcp.WithString("library_dir",
+			mcp.Description("Directory of the library inside the project, required from the app with a replace directive until the library moves to its own repository. Defaults to uikit."),
+		),
+		mcp.WithBoolean("adopt",
+			mcp.Description("The library already exists, exported from another app: only point this app at it, without generating the library. Defaults to false."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceUiLibraryBoilerplateHandler
+}
+
+// libraryModule matches a module path, e.g. github.com/acme/uikit
+var libraryModule = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._~-]*(/[A-Za-z0-9._~-]+)*$`)
+
+// ProduceUiLibraryBoilerplateHandler handles requests to export the generated UI of an app into a shared library
+// The library module is recorded for the app, so other apps adopting it default to the same module
+func ProduceUiLibraryBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	module := request.GetString("library_module", uiLibraryModule(ctx, appName))
+	if !libraryModule.MatchString(module) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'library_module' '%s': expected a module path such as github.com/acme/uikit.", module)), nil
+	}
+	if module == appModule(ctx, appName) || strings.HasPrefix(module, appModule(ctx, appName)+"/") {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'library_module' '%s': the library needs a module path outside the app's module %s.", module, appModule(ctx, appName))), nil
+	}
+	dir := strings.Trim(request.GetString("library_dir", "uikit"), "/")
+	if !routePath.MatchString("/"+dir) || !filepath.IsLocal(dir) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'library_dir' '%s': expected a directory inside the project such as uikit.", dir)), nil
+	}
+	adopt := request.GetBool("adopt", false)
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "ui_module", module)
+
+	project, _ := state.From(ctx).Project(appName)
+	var links strings.Builder
+	for _, m := range project.Models {
+		if slices.Contains(m.Components, "html_controller") {
+			fmt.Fprintf(&links, "\t\t{Label: %q, URL: %q},\n", naming.Plural(m.Name), detailPagePath(project, m.Name))
+		}
+	}
+
+	data := map[string]any{
+		"App":    appName,
+		"Module": module,
+		"Dir":    dir,
+		"Links":  links.String(),
+	}
+	appFiles, err := renderFiles(ctx, uiAdapterFiles, data)
+	if err != nil {
+		return nil, err
+	}
+	args := []any{
+		appName, // %[1]s
+		module,  // %[2]s
+		dir,     // %[3]s
+	}
+	adapterSteps := fmt.Sprintf(`Replace `+"`ui/layouts/base.templ`"+` with the layout of the app, which names it and lists the links of its navbar:
+`+"```templ"+`
+%[1]s`+"```"+`
+
+Create `+"`ui/modules/modules.go`"+` in place of `+"`ui/modules/navbar.templ`"+`, `+"`ui/modules/theme_switcher.templ`"+` and `+"`ui/modules/breadcrumbs.templ`"+`, deleting them and their generated `+"`_templ.go`"+` files:
+`+"```go"+`
+%[2]s`+"```"+`
+
+Point the templUI imports of the pages at the library, replacing `+"`\"%[3]s/components/`"+` with `+"`\"%[4]s/components/`"+` in `+"`ui/pages`"+`, then delete the app's `+"`components`"+` directory.
+`, appFiles[0].Content, appFiles[1].Content, appName, module)
+
+	var response string
+	var files []scaffoldFile
+	var commands []string
+	if adopt {
+		files = appFiles
+		commands = []string{
+			"go get " + module + "@latest",
+			"templ generate",
+			"go mod tidy",
+		}
+		response = fmt.Sprintf(`
+# UI Library Adoption Instructions
+
+To build the pages of the application '%[1]s' from the UI library `+"`%[2]s`"+`, please perform the following steps:
+
+1. Require the library:
+   `+"`go get %[2]s@latest`"+`
+
+2. %[4]s
+3. Generate the templ code and tidy the module:
+   `+"`templ generate`"+`
+   `+"`go mod tidy`"+`
+`, append(args, adapterSteps)...)
+	} else {
+		libraryFiles, err := renderFiles(ctx, uiLibraryFiles, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(libraryFiles, appFiles...)
+		commands = []string{
+			fmt.Sprintf("mkdir -p %s/layouts %s/modules", dir, dir),
+			fmt.Sprintf("cd %s && templui init && templui add button card alert checkbox input && go get github.com/a-h/templ", dir),
+			fmt.Sprintf("go mod edit -require=%s@v0.0.0 -replace=%s=./%s", module, module, dir),
+			"templ generate",
+			fmt.Sprintf("cd %s && go mod tidy", dir),
+			"go mod tidy",
+		}
+		response = fmt.Sprintf(`
+# UI Library Export Instructions
+
+To move the layout and modules of the application '%[1]s' into the UI library `+"`%[2]s`"+`, please perform the following steps:
+
+1. Create the library module at `+"`%[3]s/go.mod`"+`:
+`+"```"+`
+%[5]s`+"```"+`
+   `+"`mkdir -p %[3]s/layouts %[3]s/modules`"+`
+
+2. Install the templUI components into the library, which owns them from now on:
+   `+"`cd %[3]s && templui init && templui add button card alert checkbox input && go get github.com/a-h/templ`"+`
+
+3. Create the file at `+"`%[3]s/layouts/base.templ`"+` with the base layout, which takes the site it renders:
+`+"```templ"+`
+%[6]s`+"```"+`
+
+4. Create the file at `+"`%[3]s/modules/navbar.templ`"+` with the navbar, which lists the links of the site:
+`+"```templ"+`
+%[7]s`+"```"+`
+
+5. Create the file at `+"`%[3]s/modules/theme_switcher.templ`"+` with the following content:
+`+"```templ"+`
+%[8]s`+"```"+`
+
+6. Create the file at `+"`%[3]s/modules/breadcrumbs.templ`"+` with the following content:
+`+"```templ"+`
+%[9]s`+"```"+`
+
+7. Create the file at `+"`%[3]s/modules/datatable.templ`"+` with the data table, its rows and cells, and the pagination:
+`+"```templ"+`
+%[10]s`+"```"+`
+
+8. Create the file at `+"`%[3]s/modules/form.templ`"+` with the labelled fields, the form error and the form actions:
+`+"```templ"+`
+%[11]s`+"```"+`
+
+9. Require the library from the app, from the directory it lives in until it is published:
+   `+"`go mod edit -require=%[2]s@v0.0.0 -replace=%[2]s=./%[3]s`"+`
+
+10. %[4]s
+11. Generate the templ code and tidy both modules:
+   `+"`templ generate`"+`
+   `+"`cd %[3]s && go mod tidy`"+`
+   `+"`go mod tidy`"+`
+
+   The pages of the app build unchanged: `+"`layouts.Page`"+` and `+"`modules.Crumb`"+` are now the library's types, and `+"`layouts.BaseLayout`"+` renders the library's layout with the site of the app.
+`, append(args, adapterSteps, files[0].Content, files[1].Content, files[2].Content, files[3].Content, files[4].Content, files[5].Content, files[6].Content)...)
+	}
+
+	notes := []string{
+		fmt.Sprintf("Once a second app adopts the library, move %s to its own repository, tag a version and drop the replace directive; other apps call produce_ui_library_boilerplate with adopt=true.", dir),
+		fmt.Sprintf("Tailwind CSS only generates the classes of the files it scans: add @source to assets/css/input.css for the library's .templ files, e.g. @source \"../../%s\";, or the module cache directory once it is published.", dir),
+	}
+	if links.Len() == 0 {
+		notes = append(notes, "No HTML controller has been scaffolded in this session, so the navbar has no links: add them to site in ui/layouts/base.templ.")
+	}
+	if project.Options["seo"] != "" {
+		notes = append(notes, fmt.Sprintf("produce_seo_boilerplate added fields to layouts.Page and SEOTags to the base layout: move them, with ui/layouts/seo.templ, into %s/layouts, passing the site to FullTitle.", dir))
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: commands,
+		Notes:    notes,
+	}), nil
+}
+
+// uiLibraryModule returns the UI library of appName, or the one another app exported, or uikit under the module prefix
+func uiLibraryModule(ctx context.Context, appName string) string {
+	if project, ok := state.From(ctx).Project(appName); ok && project.Options["ui_module"] != "" {
+		return project.Options["ui_module"]
+	}
+	for _, name := range state.From(ctx).Apps() {
+		if project, ok := state.From(ctx).Project(name); ok && project.Options["ui_module"] != "" {
+			return project.Options["ui_module"]
+		}
+	}
+	return settings.ModulePath("uikit")
+}
+
+// uiLibraryFiles lists the files of the UI library in the order they appear in the instructions
+var uiLibraryFiles = []fileFormat{
+	{Path: "{{.Dir}}/go.mod", Language: "text", Template: "ui_library/go.mod"},
+	{Path: "{{.Dir}}/layouts/base.templ", Language: "templ", Template: "ui_library/base.templ"},
+	{Path: "{{.Dir}}/modules/navbar.templ", Language: "templ", Template: "ui_library/navbar.templ"},
+	{Path: "{{.Dir}}/modules/theme_switcher.templ", Language: "templ", Template: "ui_library/theme_switcher.templ"},
+	{Path: "{{.Dir}}/modules/breadcrumbs.templ", Language: "templ", Template: "ui_library/breadcrumbs.templ"},
+	{Path: "{{.Dir}}/modules/datatable.templ", Language: "templ", Template: "ui_library/datatable.templ"},
+	{Path: "{{.Dir}}/modules/form.templ", Language: "templ", Template: "ui_library/form.templ"},
+}
+
+// uiAdapterFiles lists the files of the app delegating to the UI library
+var uiAdapterFiles = []fileFormat{
+	{Path: "ui/layouts/base.templ", Language: "templ", Template: "ui_library/app_base.templ"},
+	{Path: "ui/modules/modules.go", Language: "go", Template: "ui_library/app_modules.go"},
+}
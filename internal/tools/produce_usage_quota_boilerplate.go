@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceUsageQuotaBoilerplateTool returns the tool definition for produce_usage_quota_boilerplate
+func GetProduceUsageQuotaBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_usage_quota_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output usage metering with soft quotas: per-tenant or per-API-key counters of requests and created records, middleware incrementing them and answering 429 once a quota is used up, warning headers as it nears, per-subject quota overrides and a usage-report endpoint."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("subject",
+			mcp.Description("Who usage is metered against: tenant (c.Get(\"tenant_id\") set by the authentication middleware) or api_key (a digest of the X-API-Key header). Defaults to tenant."),
+			mcp.Enum("tenant", "api_key"),
+		),
+		mcp.WithString("period",
+			mcp.Description("How often the counters reset: day or month, in UTC. Defaults to month."),
+			mcp.Enum("day", "month"),
+		),
+		mcp.WithNumber("request_quota",
+			mcp.Description("Default number of requests per subject and period; 0 means unlimited. Defaults to 10000."),
+		),
+		mcp.WithNumber("record_quota",
+			mcp.Description("Default number of records a subject may create per period; 0 means unlimited. Defaults to 1000."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceUsageQuotaBoilerplateHandler
+}
+
+// ProduceUsageQuotaBoilerplateHandler handles requests to generate usage metering and quotas
+// It creates the usage models, the meter and record counter, the enforcing middleware and the report controller
+func ProduceUsageQuotaBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	subject := request.GetString("subject", "tenant")
+	if subject != "tenant" && subject != "api_key" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'subject': expected tenant or api_key, got '%s'.", subject)), nil
+	}
+	period := request.GetString("period", "month")
+	if period != "day" && period != "month" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'period': expected day or month, got '%s'.", period)), nil
+	}
+	requestQuota := request.GetFloat("request_quota", 10000)
+	if requestQuota < 0 || requestQuota != float64(int64(requestQuota)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'request_quota': expected a whole number, 0 for unlimited, got %v.", requestQuota)), nil
+	}
+	recordQuota := request.GetFloat("record_quota", 1000)
+	if recordQuota < 0 || recordQuota != float64(int64(recordQuota)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'record_quota': expected a whole number, 0 for unlimited, got %v.", recordQuota)), nil
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "usage_quota_subject", subject)
+
+	identify := "`c.Get(\"tenant_id\")`, which your authentication middleware must set before `Usage` runs"
+	if subject == "api_key" {
+		identify = "the `X-API-Key` header; only a SHA-256 digest of the key is stored"
+	}
+	args := []any{
+		appName,  // %[1]s
+		identify, // %[2]s
+		period,   // %[3]s
+	}
+	files, err := renderFiles(ctx, usageQuotaFiles, map[string]any{
+		"App":          appName,
+		"APIKey":       subject == "api_key",
+		"Daily":        period == "day",
+		"RequestQuota": strconv.FormatInt(int64(requestQuota), 10),
+		"RecordQuota":  strconv.FormatInt(int64(recordQuota), 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Usage Quota Scaffold Instructions
+
+To scaffold usage metering and quotas for the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/usage internal/controllers/usage`"+`
+
+2. Create or update the file at `+"`internal/models/usage.go`"+` with the following content:
+`+"```go"+`
+%[4]s`+"```"+`
+
+3. Create or update the file at `+"`internal/usage/meter.go`"+` with the following content:
+`+"```go"+`
+%[5]s`+"```"+`
+
+4. Create or update the file at `+"`internal/usage/records.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+
+5. Create or update the file at `+"`internal/middleware/usage.go`"+` with the following content:
+`+"```go"+`
+%[7]s`+"```"+`
+
+6. Create or update the file at `+"`internal/controllers/usage/controller.go`"+` with the following content:
+`+"```go"+`
+%[8]s`+"```"+`
+
+7. Wire it up in `+"`cmd/web/main.go`"+` after opening the database:
+   `+"```go"+`
+   if err := db.AutoMigrate(&models.UsageCounter{}, &models.UsageQuota{}); err != nil {
+   	e.Logger.Fatal("failed to migrate usage tables", err)
+   }
+   meter := usage.NewMeter(db)
+   if err := usage.CountRecords(db, meter); err != nil {
+   	e.Logger.Fatal("failed to register the usage callback", err)
+   }
+   usageController := usagecontroller.NewUsageController(meter)
+
+   // Meter the authenticated API group, e.g. api := e.Group("/api/v1", auth), and let clients read what they have left
+   api.Use(appmiddleware.Usage(meter))
+   api.GET("/usage", usageController.Current)
+
+   admin := e.Group("/admin") // add your admin authorization middleware here
+   admin.GET("/usage", usageController.Report)
+   admin.PUT("/usage/quotas/:subject", usageController.SetQuota)
+   `+"```"+`
+
+   Requests are metered against %[2]s. Counters reset every %[3]s. Once a subject has used SoftThreshold (80%%) of a quota, responses carry an `+"`X-Quota-Warning`"+` header; once it is used up, requests get 429 Too Many Requests with a `+"`Retry-After`"+` header pointing at the next period. The record quota only blocks POST requests, so a subject over it can still read and update what it has.
+`, append(args, fileContents(files)...)...) // %[4]s onwards: file contents
+
+	notes := []string{
+		"Add models.UsageCounter and models.UsageQuota to AutoMigrate, call usage.CountRecords and mount the usage routes in cmd/web/main.go.",
+		"Records are counted by a GORM callback, so repositories must use db.WithContext(ctx) with the request context for them to count.",
+		"The counters are updated outside the request's transaction, so a rolled-back create still counts against the quota.",
+		"Quotas are soft: concurrent requests near a limit may each pass the check, so a subject can exceed a quota by a few requests.",
+	}
+	if project, _ := state.From(ctx).Project(appName); project.Options["maintenance_backend"] != "" {
+		notes = append(notes, "Register Usage after the maintenance middleware, so requests rejected during maintenance are not metered.")
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, provide usage.NewMeter and usagecontroller.NewUsageController in internal/app/app.go, and register the middleware and routes in an fx.Invoke function.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{"mkdir -p internal/usage internal/controllers/usage"},
+		Notes:    notes,
+	}), nil
+}
+
+// usageQuotaFiles lists the usage quota files in the order they appear in the instructions
+var usageQuotaFiles = []fileFormat{
+	{Path: "internal/models/usage.go", Language: "go", Template: "usage_quota/usage.go"},
+	{Path: "internal/usage/meter.go", Language: "go", Template: "usage_quota/meter.go"},
+	{Path: "internal/usage/records.go", Language: "go", Template: "usage_quota/records.go"},
+	{Path: "internal/middleware/usage.go", Language: "go", Template: "usage_quota/middleware.go"},
+	{Path: "internal/controllers/usage/controller.go", Language: "go", Template: "usage_quota/controller.go"},
+}
@@ -0,0 +1,213 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceUserSettingsBoilerplateTool returns the tool definition for produce_user_settings_boilerplate
+func GetProduceUserSettingsBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_user_settings_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a user preferences subsystem: a UserSettings model (theme, locale, timezone and notification preferences), a store validating them, JSON endpoints and a templ settings page, and middleware loading the preferences of each request into its context, where translations and time formatting read the locale and timezone."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("locales",
+			mcp.Description("Comma-separated locales the app is translated into, the default first (e.g., en,fr,pt-BR). Anonymous users get the one best matching their Accept-Language header. Defaults to en."),
+		),
+		mcp.WithString("default_timezone",
+			mcp.Description("Timezone of anonymous users and of users who never chose one, as a tz database name (e.g., Europe/Paris). Defaults to UTC."),
+		),
+		mcp.WithString("notifications",
+			mcp.Description("Comma-separated notification preferences, each a boolean of the settings, on by default unless prefixed with ! (e.g., comments,mentions,!newsletter). Defaults to account,!product_updates."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceUserSettingsBoilerplateHandler
+}
+
+var (
+	// localeTag matches a locale such as en or pt-BR
+	localeTag = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})?$`)
+	// timezoneName matches a tz database name such as UTC or America/Argentina/Buenos_Aires
+	timezoneName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_+-]*(/[A-Za-z0-9_+-]+)*$`)
+	// notificationName matches a notification preference such as product_updates
+	notificationName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// ProduceUserSettingsBoilerplateHandler handles requests to generate user preferences
+// The locales are recorded for the app, so other scaffolds can offer the same ones
+func ProduceUserSettingsBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	var locales []string
+	for _, locale := range strings.Split(request.GetString("locales", "en"), ",") {
+		locale = strings.TrimSpace(locale)
+		if !localeTag.MatchString(locale) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid locale '%s' in 'locales': expected a comma-separated list of locales such as en,fr,pt-BR.", locale)), nil
+		}
+		if !slices.Contains(locales, locale) {
+			locales = append(locales, locale)
+		}
+	}
+	timezone := request.GetString("default_timezone", "UTC")
+	if !timezoneName.MatchString(timezone) || timezone == "Local" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'default_timezone' '%s': expected a tz database name such as UTC or Europe/Paris.", timezone)), nil
+	}
+
+	var notifications []map[string]string
+	for _, name := range strings.Split(request.GetString("notifications", "account,!product_updates"), ",") {
+		name = strings.TrimSpace(name)
+		on := !strings.HasPrefix(name, "!")
+		name = strings.TrimPrefix(name, "!")
+		if name == "" {
+			continue
+		}
+		if !notificationName.MatchString(name) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid notification '%s' in 'notifications': expected snake_case names such as product_updates.", name)), nil
+		}
+		label := strings.ReplaceAll(name, "_", " ")
+		notifications = append(notifications, map[string]string{
+			"Field":   "Notify" + naming.Pascal(name),
+			"JSON":    "notify_" + name,
+			"Label":   strings.ToUpper(label[:1]) + label[1:],
+			"Default": strconv.FormatBool(on),
+		})
+	}
+
+	state.From(ctx).RecordApp(appName)
+	state.From(ctx).SetOption(appName, "locales", strings.Join(locales, ","))
+
+	quoted := make([]string, len(locales))
+	for i, locale := range locales {
+		quoted[i] = strconv.Quote(locale)
+	}
+	args := []any{
+		appName,  // %[1]s
+		timezone, // %[2]s
+	}
+	files, err := renderFiles(ctx, userSettingsFiles, map[string]any{
+		"App":           appName,
+		"Locales":       strings.Join(quoted, ", "),
+		"Timezone":      timezone,
+		"Notifications": notifications,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# User Settings Scaffold Instructions
+
+To scaffold user preferences for the application '%[1]s', please perform the following steps:
+
+1. Create the directory structure (or ensure it exists):
+   `+"`mkdir -p internal/preferences internal/controllers/settings ui/pages/settings`"+`
+
+2. Create or update the file at `+"`internal/models/user_settings.go`"+` with the following content:
+`+"```go"+`
+%[3]s`+"```"+`
+
+3. Create or update the file at `+"`internal/preferences/preferences.go`"+` with the defaults, the validation and the store:
+`+"```go"+`
+%[4]s`+"```"+`
+
+4. Create or update the file at `+"`internal/preferences/context.go`"+` with the helpers reading the preferences of a request:
+`+"```go"+`
+%[5]s`+"```"+`
+
+5. Create or update the file at `+"`internal/middleware/preferences.go`"+` with the following content:
+`+"```go"+`
+%[6]s`+"```"+`
+
+6. Create or update the file at `+"`internal/controllers/settings/controller.go`"+` with the following content:
+`+"```go"+`
+%[7]s`+"```"+`
+
+7. Create or update the file at `+"`ui/pages/settings/settings.templ`"+` with the settings page:
+`+"```templ"+`
+%[8]s`+"```"+`
+
+8. Wire it up in `+"`cmd/web/main.go`"+` after opening the database:
+   `+"```go"+`
+   if err := db.AutoMigrate(&models.UserSettings{}); err != nil {
+   	e.Logger.Fatal("failed to migrate user settings", err)
+   }
+   settingsStore := preferences.NewStore(db)
+   settingsController := settingscontroller.NewSettingsController(settingsStore)
+
+   // After the authentication middleware, which sets c.Get("user_id")
+   e.Use(appmiddleware.Preferences(settingsStore))
+   e.GET("/settings", settingsController.Page)
+   e.POST("/settings", settingsController.Save)
+   e.GET("/api/settings", settingsController.Get)
+   e.PUT("/api/settings", settingsController.Update)
+   `+"```"+`
+
+   Handlers and templates read the preferences of the request with `+"`preferences.FromContext(ctx)`"+`: pass its `+"`Locale`"+` to your translations, and write times with `+"`preferences.Format(ctx, item.CreatedAt, time.DateTime)`"+` to show them in the user's timezone (%[2]s until they choose one).
+
+9. Generate the templ code:
+   `+"`templ generate`"+`
+`, append(args, fileContents(files)...)...) // %[3]s onwards: file contents
+
+	notes := []string{
+		"Add models.UserSettings to AutoMigrate, register middleware.Preferences after the authentication middleware and mount the settings routes in cmd/web/main.go.",
+		"The settings routes answer 401 without c.Get(\"user_id\"); keep them in the group of signed-in users.",
+		"The settings page applies the saved theme through localStorage, as the theme switcher does; to apply it on every page, move ThemeSync into ui/layouts/base.templ and render it there with preferences.FromContext(ctx).Theme.",
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, "With uber/fx, provide preferences.NewStore and settingscontroller.NewSettingsController in internal/app/app.go, and register the middleware and routes in an fx.Invoke function.")
+	}
+	if usesWire(ctx, appName) {
+		notes = append(notes, "With google/wire, add preferences.NewStore and settingscontroller.NewSettingsController to Providers, take them in NewEcho to register the middleware and routes, and run wire ./internal/app.")
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			"mkdir -p internal/preferences internal/controllers/settings ui/pages/settings",
+			"templ generate",
+		},
+		Notes:  notes,
+		routes: []string{"GET /settings", "POST /settings", "GET /api/settings", "PUT /api/settings"},
+	}), nil
+}
+
+// userSettingsFiles lists the user settings files in the order they appear in the instructions
+var userSettingsFiles = []fileFormat{
+	{Path: "internal/models/user_settings.go", Language: "go", Template: "user_settings/user_settings.go"},
+	{Path: "internal/preferences/preferences.go", Language: "go", Template: "user_settings/preferences.go"},
+	{Path: "internal/preferences/context.go", Language: "go", Template: "user_settings/context.go"},
+	{Path: "internal/middleware/preferences.go", Language: "go", Template: "user_settings/middleware.go"},
+	{Path: "internal/controllers/settings/controller.go", Language: "go", Template: "user_settings/controller.go"},
+	{Path: "ui/pages/settings/settings.templ", Language: "templ", Template: "user_settings/settings.templ"},
+}
@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProduceWiringChecksBoilerplateTool returns the tool definition for produce_wiring_checks_boilerplate
+func GetProduceWiringChecksBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_wiring_checks_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a compile-time checks file asserting that every scaffolded repository, service and controller implementation satisfies its interface and that every route handler exists, with a go generate hook, so drift between generated interfaces and implementations fails the build."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceWiringChecksBoilerplateHandler
+}
+
+// ProduceWiringChecksBoilerplateHandler handles requests to generate the wiring checks of an application
+// The assertions cover the layers and controllers recorded in the project manifest
+func ProduceWiringChecksBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+
+	checks := newWiringChecks(ctx, appName)
+	if len(checks.Models) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("No repositories, services or controllers of '%s' are in the project manifest yet. Scaffold them with produce_model_boilerplate, produce_service_boilerplate and the controller tools first.", appName)), nil
+	}
+	state.From(ctx).SetOption(appName, "wiring_checks", "true")
+
+	args := []any{
+		appName,                           // %[1]s
+		strings.Join(checks.Models, ", "), // %[2]s
+	}
+	files, err := renderFiles(ctx, wiringChecksFiles, checks.data(appName))
+	if err != nil {
+		return nil, err
+	}
+
+	response := fmt.Sprintf(`
+# Wiring Checks Scaffold Instructions
+
+To scaffold compile-time wiring checks for the application '%[1]s', please perform the following steps:
+
+1. Create or update the file at `+"`internal/wiring/checks.go`"+` with the following content:
+`+"```go"+`
+%[3]s`+"```"+`
+
+2. Run `+"`go generate ./internal/wiring`"+`, or simply `+"`go build ./...`"+`. The file covers %[2]s.
+
+   A build error in this file means a generated interface and its implementation have drifted apart: a method was renamed or its signature changed on one side only, or a route handler was removed from its controller. Fix the implementation, or regenerate the layer, rather than editing the check.
+
+3. Run this tool again after scaffolding more models or controllers, so the checks cover them too.
+`, append(args, fileContents(files)...)...) // %[3]s: file contents
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files:    files,
+		Commands: []string{"go generate ./internal/wiring"},
+		Notes: []string{
+			"Nothing imports internal/wiring; go build ./... and go vet ./... still compile it, so CI catches drift without an extra step.",
+			"The checks are generated from the project manifest; add assertions by hand for layers written outside these tools.",
+		},
+	}), nil
+}
+
+// wiringChecksFiles lists the files of the wiring checks
+var wiringChecksFiles = []fileFormat{
+	{Path: "internal/wiring/checks.go", Language: "go", Template: "wiring_checks/checks.go"},
+}
+
+// wiringChecks holds the assertions generated for the models of an application
+type wiringChecks struct {
+	Models      []string // models with at least one checked layer
+	imports     importSet
+	interfaces  strings.Builder
+	controllers strings.Builder
+	handlers    strings.Builder
+}
+
+// newWiringChecks builds an interface assertion for every recorded layer and a handler reference for every route
+func newWiringChecks(ctx context.Context, appName string) *wiringChecks {
+	w := &wiringChecks{imports: importSet{}}
+	project, _ := state.From(ctx).Project(appName)
+	for _, m := range project.Models {
+		has := func(component string) bool { return slices.Contains(m.Components, component) }
+		lower := strings.ToLower(m.Name)
+		checked := false
+		assert := func(layer, iface string) {
+			pkg := w.imports.model(appName, m.Name, layer)
+			fmt.Fprintf(&w.interfaces, "\t_ %[1]s.%[2]s = (*%[1]s.%[2]sImpl)(nil)\n", pkg, iface)
+			checked = true
+		}
+		reference := func(list []route) {
+			for _, r := range list {
+				fmt.Fprintf(&w.handlers, "\t\t%s,\n", r.Handler)
+			}
+		}
+
+		if has("repository") {
+			assert("repository", m.Name+"Repository")
+		}
+		if has("service") {
+			assert("service", m.Name+"Service")
+		}
+		if has("api_controller") {
+			assert("controllers", m.Name+"Controller")
+			fmt.Fprintf(&w.controllers, "\t%sController %s.%sController,\n", lower, modelPackage(m.Name, "controllers"), m.Name)
+			reference(apiControllerRoutes(m.Name, lower, has("merge_patch")))
+		}
+		if has("html_controller") {
+			assert("controllers", m.Name+"HtmlController")
+			fmt.Fprintf(&w.controllers, "\t%sHtmlController %s.%sHtmlController,\n", lower, modelPackage(m.Name, "controllers"), m.Name)
+			reference(htmlControllerRoutes(lower))
+		}
+		if checked {
+			w.Models = append(w.Models, m.Name)
+		}
+	}
+	return w
+}
+
+// data returns the template fields of checks.go
+func (w *wiringChecks) data(appName string) map[string]any {
+	var imports strings.Builder
+	if w.handlers.Len() > 0 {
+		imports.WriteString("\t\"github.com/labstack/echo/v4\"\n\n")
+	}
+	imports.WriteString(w.imports.lines())
+	return map[string]any{
+		"App":         appName,
+		"Imports":     imports.String(),
+		"Interfaces":  w.interfaces.String(),
+		"Controllers": w.controllers.String(),
+		"Handlers":    w.handlers.String(),
+	}
+}
@@ -0,0 +1,323 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+	"mcpgo/internal/state"
+)
+
+// GetProduceWizardBoilerplateTool returns the tool definition for produce_wizard_boilerplate
+func GetProduceWizardBoilerplateTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("produce_wizard_boilerplate",
+		mcp.WithDescription("Instructs the LLM to output a multi-step create flow for a model with many fields: a templ page per step, the draft kept in the session between steps, validation of each step's fields before moving on, and a review page submitting the draft through the service. Complements the single-page form of produce_html_controller_boilerplate."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("model_name",
+			mcp.Required(),
+			mcp.Description("The name of the model created through the wizard (e.g., Customer, Listing)."),
+		),
+		mcp.WithString("steps",
+			mcp.Description("The steps and their fields, separated by semicolons, each a title and a colon followed by comma-separated field names (e.g., Contact: Name,Email; Address: Street,City,Zip). Every field of the model must be in a step. Defaults to the fields of the model in groups of fields_per_step."),
+		),
+		mcp.WithNumber("fields_per_step",
+			mcp.Description("Without steps, the number of fields asked for on each step. Defaults to 4."),
+		),
+		routePrefixOption,
+		resourcePathOption,
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ProduceWizardBoilerplateHandler
+}
+
+// ProduceWizardBoilerplateHandler handles requests to generate a multi-step create flow for a model
+// The steps are built from the fields recorded when the model was scaffolded, unless the request lists them
+func ProduceWizardBoilerplateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
+	}
+	titleModelName := naming.Pascal(modelName)
+	lowerModelName := strings.ToLower(titleModelName)
+
+	mount, err := newRoutes(request, titleModelName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	perStep := request.GetFloat("fields_per_step", 4)
+	if perStep < 1 || perStep != float64(int(perStep)) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'fields_per_step': expected a positive whole number, got %v.", perStep)), nil
+	}
+
+	var fields []state.Field
+	for _, field := range recordedFields(ctx, appName, titleModelName) {
+		if scalarFieldType(field.Type) {
+			fields = append(fields, field)
+		}
+	}
+	validations, err := fieldValidations(fields)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	steps, err := wizardSteps(request.GetString("steps", ""), fields, int(perStep))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(steps) == 0 {
+		return missingParameterResult(ctx, "steps", fmt.Sprintf("the steps of the wizard and their fields (e.g., Contact: Name,Email; Address: Street,City), as the fields of model '%s' have not been recorded. Scaffold the model first to build the steps from its fields.", titleModelName), nil), nil
+	}
+
+	state.From(ctx).RecordComponent(appName, titleModelName, "wizard")
+	wizardRoutes := wizardControllerRoutes(lowerModelName)
+	state.From(ctx).RecordRoutes(appName, titleModelName, mount.list(wizardRoutes))
+
+	var stepsSource strings.Builder
+	var timeFields []string
+	for _, step := range steps {
+		fmt.Fprintf(&stepsSource, "\t{Title: %q, Fields: []%spages.WizardField{\n", step.title, lowerModelName)
+		for _, field := range step.fields {
+			fmt.Fprintf(&stepsSource, "\t\t{Name: %q, Label: %q, Input: %q},\n", field.Name, wizardLabel(field.Name), wizardInput(field, validations))
+			if strings.TrimPrefix(field.Type, "*") == "time.Time" {
+				timeFields = append(timeFields, field.Name)
+			}
+		}
+		stepsSource.WriteString("\t}},\n")
+	}
+
+	files, err := renderFiles(ctx, wizardFiles, map[string]any{
+		"Model":  titleModelName,
+		"Plural": naming.Plural(titleModelName),
+		"Lower":  lowerModelName,
+		"App":    appName,
+		"Path":   mount.Path,
+		"Steps":  stepsSource.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, validationFile())
+
+	routesStep := fmt.Sprintf("Register the routes in `cmd/web/main.go`:\n```go\n%sWizardController := %scontrollers.New%sWizardController(%sService)\n%s```\n", lowerModelName, lowerModelName, titleModelName, lowerModelName, mount.block(wizardRoutes))
+	if !usesFx(ctx, appName) {
+		recordRouterRoutes(ctx, appName, titleModelName, "wizard", mount, wizardRoutes)
+		router := routerFile(ctx, appName, appName, "internal/router/router.go")
+		files = append(files, router)
+		routesStep = routerStep(ctx, appName, router, titleModelName+"WizardController", fmt.Sprintf("%scontrollers.New%sWizardController(%sService)", lowerModelName, titleModelName, lowerModelName))
+	}
+	args := []any{
+		titleModelName, // %[1]s
+		lowerModelName, // %[2]s
+		appName,        // %[3]s
+		mount.Path,     // %[4]s
+		routesStep,     // %[5]s
+		len(steps),     // %[6]d
+	}
+
+	response := fmt.Sprintf(`
+# Wizard Scaffold Instructions
+
+To scaffold a %[6]d-step create wizard for model '%[1]s', please perform the following steps:
+
+1. Add the session middleware and create the directory structure (or ensure they exist):
+   `+"`go get github.com/labstack/echo-contrib/session github.com/gorilla/sessions github.com/go-playground/validator/v10`"+`
+   `+"`mkdir -p internal/controllers/%[2]s internal/validation ui/pages/%[2]s`"+`
+
+2. Create the file at `+"`ui/pages/%[2]s/wizard.templ`"+` with the step, review and progress components:
+`+"```templ"+`
+%[7]s`+"```"+`
+
+3. Create the file at `+"`internal/controllers/%[2]s/wizard_controller.go`"+` with the following content:
+`+"```go"+`
+%[8]s`+"```"+`
+
+   Each step binds the whole create request but keeps only its own fields, then validates the draft and reports the errors of those fields alone, as the later steps are still empty. Edit `+"`%[2]sWizardSteps`"+` to regroup or retitle the steps.
+
+4. Create or update the file at `+"`internal/validation/validation.go`"+`, so c.Validate checks the rules of the DTO:
+`+"```go"+`
+%[9]s`+"```"+`
+
+5. Register the session store and the validator in `+"`cmd/web/main.go`"+`:
+
+`+"```go"+`
+e.Use(session.Middleware(sessions.NewCookieStore([]byte(os.Getenv("SESSION_SECRET")))))
+e.Validator = validation.New()
+`+"```"+`
+
+6. %[5]s
+7. Generate the templ code:
+   `+"`templ generate`"+`
+
+   The wizard starts at `+"`%[4]s/wizard/1`"+`; link the New button of the list page there. The draft lives in the session cookie until it is created or discarded, so a user can leave and come back to it.
+`, append(args, fileContents(files)...)...) // %[7]s onwards: file contents
+
+	notes := []string{
+		"Set SESSION_SECRET to a random value of at least 32 bytes; the cookie store signs the draft with it.",
+		"Cookies hold about 4 KB: for models with long text fields, keep the draft in a server-side store such as github.com/wader/gormstore instead.",
+	}
+	if len(recordedFields(ctx, appName, titleModelName)) == 0 {
+		notes = append(notes, fmt.Sprintf("The model %s has not been scaffolded in this session; the fields of the steps are assumed to be text fields of dto.Create%sRequest.", titleModelName, titleModelName))
+	}
+	if len(timeFields) > 0 {
+		notes = append(notes, fmt.Sprintf("Echo binds time fields in RFC 3339 (e.g. 2026-01-02T15:04:05Z), so %s are text inputs; swap in a date picker formatting its value that way if needed.", strings.Join(timeFields, ", ")))
+	}
+	if len(validations) == 0 {
+		notes = append(notes, fmt.Sprintf("The fields of %s declare no validation rules, so every step is accepted as it is; add validate tags to dto.Create%sRequest to check them.", titleModelName, titleModelName))
+	}
+	if usesFx(ctx, appName) {
+		notes = append(notes, fxProviderNote(fmt.Sprintf("`%scontrollers.New%sWizardController` to Controllers", lowerModelName, titleModelName)))
+	}
+
+	return scaffoldResult(ctx, request, response, scaffold{
+		Files: files,
+		Commands: []string{
+			"go get github.com/labstack/echo-contrib/session github.com/gorilla/sessions github.com/go-playground/validator/v10",
+			fmt.Sprintf("mkdir -p internal/controllers/%[1]s internal/validation ui/pages/%[1]s", lowerModelName),
+			"templ generate",
+		},
+		Notes:  notes,
+		routes: mount.list(wizardRoutes),
+	}), nil
+}
+
+// wizardStep is a titled group of fields asked for on one page of the wizard
+type wizardStep struct {
+	title  string
+	fields []state.Field
+}
+
+// wizardSteps parses the steps of the request, e.g. Contact: Name,Email; Address: Street, or groups the fields
+// perStep at a time when the request lists none; every recorded field must belong to exactly one step
+func wizardSteps(list string, fields []state.Field, perStep int) ([]wizardStep, error) {
+	if strings.TrimSpace(list) == "" {
+		var steps []wizardStep
+		for chunk := range slices.Chunk(fields, perStep) {
+			steps = append(steps, wizardStep{title: fmt.Sprintf("Step %d", len(steps)+1), fields: chunk})
+		}
+		return steps, nil
+	}
+
+	var steps []wizardStep
+	assigned := map[string]bool{}
+	for _, part := range strings.Split(list, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		title, names, ok := strings.Cut(part, ":")
+		title = strings.TrimSpace(title)
+		if !ok || title == "" || strings.ContainsAny(title, "\"\\\n") {
+			return nil, fmt.Errorf("Invalid step '%s' in 'steps': expected a title, a colon and its fields, such as Contact: Name,Email.", strings.TrimSpace(part))
+		}
+		step := wizardStep{title: title}
+		for _, name := range splitArguments(names) {
+			if err := checkIdentifier("field", name); err != nil {
+				return nil, err
+			}
+			field := state.Field{Name: name, Type: "string"}
+			if len(fields) > 0 {
+				i := slices.IndexFunc(fields, func(f state.Field) bool { return f.Name == name })
+				if i < 0 {
+					return nil, fmt.Errorf("Unknown field '%s' in step '%s': expected one of %s.", name, title, strings.Join(recordedFieldNames(fields), ", "))
+				}
+				field = fields[i]
+			}
+			if assigned[name] {
+				return nil, fmt.Errorf("Field '%s' is in more than one step: each field belongs to a single step.", name)
+			}
+			assigned[name] = true
+			step.fields = append(step.fields, field)
+		}
+		if len(step.fields) == 0 {
+			return nil, fmt.Errorf("Step '%s' has no fields: list them after the colon, such as %s: Name,Email.", title, title)
+		}
+		steps = append(steps, step)
+	}
+	for _, field := range fields {
+		if !assigned[field.Name] {
+			return nil, fmt.Errorf("Field '%s' is in no step: add it to one, or the wizard would always leave it empty.", field.Name)
+		}
+	}
+	return steps, nil
+}
+
+// wizardLabel returns the label of a field input, e.g. Birth date for BirthDate
+func wizardLabel(name string) string {
+	words := naming.Words(name)
+	for i, word := range words {
+		if i > 0 && strings.ToUpper(word) != word {
+			words[i] = strings.ToLower(word)
+		}
+	}
+	label := strings.Join(words, " ")
+	if label == "" {
+		return name
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// wizardInput returns the HTML input type of a field, from its type and its email or url rule
+func wizardInput(field state.Field, validations []fieldValidation) string {
+	fieldType := strings.TrimPrefix(field.Type, "*")
+	switch {
+	case fieldType == "bool":
+		return "checkbox"
+	case strings.HasPrefix(fieldType, "int"), strings.HasPrefix(fieldType, "uint"), strings.HasPrefix(fieldType, "float"):
+		return "number"
+	}
+	if v, ok := findValidation(validations, field.Name); ok {
+		if _, ok := v.rule("email"); ok {
+			return "email"
+		}
+		if _, ok := v.rule("url"); ok {
+			return "url"
+		}
+	}
+	return "text"
+}
+
+// wizardControllerRoutes lists the routes of a model's wizard in registration order
+// The review and cancel routes are static, so echo matches them before the step parameter
+func wizardControllerRoutes(lower string) []route {
+	handler := lower + "WizardController."
+	return []route{
+		{"GET", "/wizard/review", handler + "Review"},
+		{"POST", "/wizard/review", handler + "Submit"},
+		{"POST", "/wizard/cancel", handler + "Cancel"},
+		{"GET", "/wizard/:step", handler + "Step"},
+		{"POST", "/wizard/:step", handler + "SaveStep"},
+	}
+}
+
+// wizardFiles lists the wizard files rendered from templates, in the order they appear in the instructions
+var wizardFiles = []fileFormat{
+	{Path: "ui/pages/{{.Lower}}/wizard.templ", Language: "templ", Template: "wizard/wizard.templ"},
+	{Path: "internal/controllers/{{.Lower}}/wizard_controller.go", Language: "go", Template: "wizard/wizard_controller.go"},
+}
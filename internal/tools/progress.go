@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressReporter emits MCP progress notifications for a single tool call
+// Notifications are only sent when the client asked for them by supplying a progress token
+type progressReporter struct {
+	ctx     context.Context
+	token   mcp.ProgressToken
+	total   int
+	current int
+}
+
+// newProgressReporter creates a reporter for a tool call made up of total components
+func newProgressReporter(ctx context.Context, request mcp.CallToolRequest, total int) *progressReporter {
+	var token mcp.ProgressToken
+	if request.Params.Meta != nil {
+		token = request.Params.Meta.ProgressToken
+	}
+	return &progressReporter{ctx: ctx, token: token, total: total}
+}
+
+// Add counts n more components to come, such as the files written once generated
+func (p *progressReporter) Add(n int) {
+	p.total += n
+}
+
+// Step marks one more component as generated and notifies the client
+func (p *progressReporter) Step(message string) {
+	p.current++
+	if p.token == nil {
+		return
+	}
+	srv := server.ServerFromContext(p.ctx)
+	if srv == nil {
+		return
+	}
+	// Progress is best effort, a slow or disconnected client must not fail the scaffold
+	_ = srv.SendNotificationToClient(p.ctx, "notifications/progress", map[string]any{
+		"progressToken": p.token,
+		"progress":      p.current,
+		"total":         p.total,
+		"message":       message,
+	})
+}
@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// GetProjectResourceTemplate returns the resource template definition for mcpgo://project/{app}
+func GetProjectResourceTemplate() (mcp.ResourceTemplate, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error)) {
+	template := mcp.NewResourceTemplate("mcpgo://project/{app}", "Project manifest",
+		mcp.WithTemplateDescription("The current scaffold inventory (models, fields, generated components, chosen options and the files of the last writes, relative to their target_dir) for an application scaffolded with this server."),
+		mcp.WithTemplateMIMEType("application/json"),
+	)
+
+	return template, ProjectResourceHandler
+}
+
+// ProjectResourceHandler returns the tracked project state for the requested application as JSON
+func ProjectResourceHandler(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := resourceArgument(request, "app")
+	if appName == "" {
+		return nil, fmt.Errorf("app name is required")
+	}
+
+	project, ok := state.From(ctx).Project(appName)
+	if !ok {
+		return nil, fmt.Errorf("no project named '%s' has been scaffolded with this server", appName)
+	}
+
+	data, err := json.MarshalIndent(newProjectManifest(project), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error encoding project '%s': %w", appName, err)
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
+
+// projectManifest is the project state clients read: what was scaffolded and how, without the absolute paths of the
+// directories written to, of their backups and of the files whose checksums are tracked, which stay on the server
+type projectManifest struct {
+	AppName string            `json:"app_name"`
+	Models  []state.Model     `json:"models"`
+	Options map[string]string `json:"options,omitempty"`
+	Writes  []projectWrite    `json:"writes,omitempty"`
+}
+
+// projectWrite is a write_files operation, with the paths relative to its target_dir
+type projectWrite struct {
+	ID       string   `json:"id"`
+	Created  []string `json:"created,omitempty"`
+	Replaced []string `json:"replaced,omitempty"`
+}
+
+// newProjectManifest returns the client view of a project
+func newProjectManifest(p state.Project) projectManifest {
+	m := projectManifest{AppName: p.AppName, Models: p.Models, Options: p.Options}
+	for _, w := range p.Writes {
+		m.Writes = append(m.Writes, projectWrite{ID: w.ID, Created: w.Created, Replaced: w.Replaced})
+	}
+	return m
+}
+
+// resourceArgument extracts a URI template variable from a resource read request
+func resourceArgument(request mcp.ReadResourceRequest, name string) string {
+	switch v := request.Params.Arguments[name].(type) {
+	case string:
+		return v
+	case []string:
+		if len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+func TestProjectResourceHidesServerPaths(t *testing.T) {
+	store := state.NewStore()
+	store.RecordModel("shop", "Product", []state.Field{{Name: "Name", Type: "string"}})
+	store.SetOption("shop", "module", "example.com/shop")
+	store.RecordFiles("shop", map[string]string{"/home/ana/src/shop/internal/models/product.go": "abc"})
+	store.RecordWrite("shop", state.Write{
+		ID: "20261016-142301.123", Dir: "/home/ana/src/shop",
+		Created: []string{"internal/models/product.go"}, Replaced: []string{"cmd/web/main.go"},
+		Backup:  "/home/ana/src/shop/.mcpgo/backups/20261016-142301.123",
+		Written: map[string]string{"/home/ana/src/shop/internal/models/product.go": "abc"},
+	})
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = "mcpgo://project/shop"
+	request.Params.Arguments = map[string]any{"app": "shop"}
+	contents, err := ProjectResourceHandler(state.WithStore(context.Background(), store), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := contents[0].(mcp.TextResourceContents).Text
+	if strings.Contains(text, "/home/ana") {
+		t.Errorf("the project resource exposes paths of the server:\n%s", text)
+	}
+	for _, want := range []string{`"name": "Product"`, `"module": "example.com/shop"`, `"internal/models/product.go"`, `"cmd/web/main.go"`} {
+		if !strings.Contains(text, want) {
+			t.Errorf("the project resource lacks %s:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registry holds the tools the server exposes, in the order clients list them
+var registry struct {
+	sync.Mutex
+	tools []server.ServerTool
+}
+
+// readOnlyAnnotations tell clients the tool only reads its arguments and the project, and returns instructions or a report
+var readOnlyAnnotations = mcp.WithToolAnnotation(mcp.ToolAnnotation{
+	ReadOnlyHint:  mcp.ToBoolPtr(true),
+	OpenWorldHint: mcp.ToBoolPtr(false),
+})
+
+// Register adds a tool after those registered before it
+// The next recommended step, if any, is appended to the description to guide the client through the tool sequence
+func Register(get func() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)), next string) {
+	tool, handler := get()
+	if next != "" {
+		tool.Description += "\n\nNext recommended step: " + next
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	registry.tools = append(registry.tools, server.ServerTool{Tool: tool, Handler: handler})
+}
+
+// All returns every registered tool in registration order
+func All() []server.ServerTool {
+	registry.Lock()
+	defer registry.Unlock()
+	return append([]server.ServerTool(nil), registry.tools...)
+}
+
+// init registers the built-in tools with guidance on the recommended tool sequence
+func init() {
+	// Step 1: Produce App Boilerplate
+	Register(GetProduceAppBoilerplateTool, "Use 'produce_model_boilerplate' to create your data models.")
+	// Step 2: Produce Model Boilerplate
+	Register(GetProduceModelBoilerplateTool, "Use 'produce_service_boilerplate' to create a service layer for your model.")
+	// Step 2b: Produce Query Scopes
+	Register(GetProduceScopesBoilerplateTool, "Use 'produce_service_boilerplate' to create a service layer that composes these scopes.")
+	// Step 3: Produce Service Boilerplate
+	Register(GetProduceServiceBoilerplateTool, "Use 'produce_api_controller_boilerplate' or 'produce_html_controller_boilerplate' to create controllers for your model.")
+	// Step 3b: Produce Ownership Authorization Policies
+	Register(GetProduceAuthorizationBoilerplateTool, "")
+	// Step 4a: Produce API Controller Boilerplate
+	Register(GetProduceApiControllerBoilerplateTool, "If needed, use 'produce_html_controller_boilerplate' to create HTML views for your model.")
+	// Step 4b: Produce HTML Controller Boilerplate
+	Register(GetProduceHtmlControllerBoilerplateTool, "If needed, use 'fix_app' to fix any issues with your application.")
+	// Steps 2 to 4a in one call
+	Register(GetScaffoldFullCrudTool, "If needed, use 'produce_html_controller_boilerplate' to create HTML views for your model.")
+
+	// Utilities
+	Register(GetProduceResilienceBoilerplateTool, "")
+	Register(GetProduceHttpClientBoilerplateTool, "Inject the client into a service created with 'produce_service_boilerplate'.")
+	Register(GetProduceIdempotencyBoilerplateTool, "")
+	Register(GetProduceEventLogBoilerplateTool, "")
+	Register(GetProduceActivityFeedBoilerplateTool, "")
+	Register(GetProduceStatusPageBoilerplateTool, "")
+	Register(GetProduceErrorPagesBoilerplateTool, "")
+	Register(GetProduceSeoBoilerplateTool, "")
+	Register(GetProduceWizardBoilerplateTool, "")
+	Register(GetProduceLiveSearchBoilerplateTool, "")
+	Register(GetProduceUiLibraryBoilerplateTool, "")
+	Register(GetProduceUserSettingsBoilerplateTool, "")
+	Register(GetProduceOrganizationsBoilerplateTool, "")
+	Register(GetProduceAuthThrottleBoilerplateTool, "")
+	Register(GetProduceCaptchaBoilerplateTool, "")
+	Register(GetProducePublicPagesBoilerplateTool, "")
+	Register(GetProduceConsentBoilerplateTool, "")
+	Register(GetProduceInviteOnlyBoilerplateTool, "")
+	Register(GetProduceUsageQuotaBoilerplateTool, "")
+	Register(GetProduceArchivalBoilerplateTool, "")
+	Register(GetProduceBackfillBoilerplateTool, "")
+	Register(GetProduceBackupBoilerplateTool, "")
+	Register(GetProduceCacheBoilerplateTool, "")
+	Register(GetProduceConfigProfilesBoilerplateTool, "")
+	Register(GetProduceDeploymentBoilerplateTool, "")
+	Register(GetProduceLoggingBoilerplateTool, "")
+	Register(GetProduceMaintenanceBoilerplateTool, "")
+	Register(GetProduceMapperBoilerplateTool, "")
+	Register(GetProduceQueryMetricsBoilerplateTool, "")
+	Register(GetProduceResponseCacheBoilerplateTool, "")
+	Register(GetProduceStartupChecksBoilerplateTool, "")
+	Register(GetProduceWiringChecksBoilerplateTool, "")
+	Register(GetDetectConventionsTool, "")
+	Register(GetListScaffoldedComponentsTool, "")
+	Register(GetDetectTemplateDriftTool, "")
+	Register(GetUndoLastScaffoldTool, "")
+	Register(GetFixAppTool, "")
+	Register(GetDescribeCapabilitiesTool, "")
+}
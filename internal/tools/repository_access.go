@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// readReplicasOption is shared by the tools generating database or repository code
+var readReplicasOption = mcp.WithBoolean("read_replicas",
+	mcp.Description("Route repository reads to read replicas and writes to the primary using GORM dbresolver. Defaults to the choice made when the app was scaffolded."),
+)
+
+// transactionsOption is shared by the tools generating database or repository code
+var transactionsOption = mcp.WithBoolean("transactions",
+	mcp.Description("Wrap every mutating HTTP request in a database transaction, with repositories taking the transaction from the request context. Defaults to the choice made when the app was scaffolded."),
+)
+
+// nPlusOneOption is shared by the tools generating database code
+var nPlusOneOption = mcp.WithBoolean("detect_n_plus_one",
+	mcp.Description("In development, count the queries of every request with a GORM plugin and log a warning with the call site for each identical query run several times, the N+1 pattern of an association loaded without Preload. Defaults to false."),
+)
+
+// dialectOption is shared by the tools generating dialect-specific column types
+var dialectOption = mcp.WithString("dialect",
+	mcp.Description("The database the generated columns target: sqlite or postgres. Postgres-only column types (jsonb, arrays, PostGIS) require postgres. Defaults to the choice recorded for the app, or the database configured for the server, or sqlite."),
+	mcp.Enum("sqlite", "postgres"),
+)
+
+// appDialect returns the requested database dialect, falling back to the one recorded for the app, then to the configured one
+func appDialect(ctx context.Context, request mcp.CallToolRequest, appName string) string {
+	dialect := valueOr(settings.Database, "sqlite")
+	if project, ok := state.From(ctx).Project(appName); ok && project.Options["dialect"] != "" {
+		dialect = project.Options["dialect"]
+	}
+	return request.GetString("dialect", dialect)
+}
+
+// repositoryAccess holds the code fragments controlling how generated repository methods reach the database
+type repositoryAccess struct {
+	Imports string // extra import lines, including indentation and newlines
+	DB      string // expression yielding the *gorm.DB of the current call
+	Read    string // clause appended to read queries
+	Write   string // clause appended to writes
+}
+
+// appOptionEnabled reports whether a boolean option was requested, falling back to the value recorded for the app
+func appOptionEnabled(ctx context.Context, request mcp.CallToolRequest, appName, key string) bool {
+	recorded := false
+	if project, ok := state.From(ctx).Project(appName); ok {
+		recorded = project.Options[key] == "true"
+	}
+	return request.GetBool(key, recorded)
+}
+
+// newRepositoryAccess returns the fragments for the read_replicas and transactions options of the request or app
+func newRepositoryAccess(ctx context.Context, request mcp.CallToolRequest, appName string) repositoryAccess {
+	access := repositoryAccess{DB: "r.db"}
+	if appOptionEnabled(ctx, request, appName, "read_replicas") {
+		access.Imports += "\t\"gorm.io/plugin/dbresolver\"\n"
+		access.Read = ".Clauses(dbresolver.Read)"
+		access.Write = ".Clauses(dbresolver.Write)"
+	}
+	if appOptionEnabled(ctx, request, appName, "transactions") {
+		access.Imports += fmt.Sprintf("\t\"%s/internal/database\"\n", appName)
+		access.DB = "database.FromContext(ctx, r.db)"
+	}
+	return access
+}
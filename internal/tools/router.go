@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mcpgo/internal/state"
+	"mcpgo/internal/templates"
+)
+
+// routerController is a kind of controller whose routes internal/router/router.go mounts
+type routerController struct {
+	Component string // component recorded for the model, e.g. api_controller
+	Suffix    string // of the controller type and variable, e.g. HtmlController
+	Pointer   bool   // the constructor returns a pointer rather than an interface
+	Register  string // suffix of the function registering the routes, e.g. Pages
+	Kind      string // what the routes serve, for the doc comment of the function
+}
+
+// routerControllers lists the controllers the router mounts, in the order of their functions in router.go
+var routerControllers = []routerController{
+	{Component: "api_controller", Suffix: "Controller", Register: "Routes", Kind: "API"},
+	{Component: "html_controller", Suffix: "HtmlController", Register: "Pages", Kind: "HTML"},
+	{Component: "live_search", Suffix: "SearchController", Pointer: true, Register: "Search", Kind: "live search"},
+	{Component: "wizard", Suffix: "WizardController", Pointer: true, Register: "Wizard", Kind: "wizard"},
+}
+
+// recordRouterRoutes remembers the registration of a controller's routes, so router.go is regenerated with them
+func recordRouterRoutes(ctx context.Context, appName, model, component string, mount routes, list []route) {
+	state.From(ctx).SetModelOption(appName, model, "router_"+component, routeBody(mount, list))
+}
+
+// routeBody renders the registration of the routes as the body of a function receiving e
+// A route_group is recreated from the prefix, as the function only receives the *echo.Echo
+func routeBody(mount routes, list []route) string {
+	var block strings.Builder
+	if mount.group != "" {
+		fmt.Fprintf(&block, "%s := e.Group(%q) // add the middleware of the %s group here\n", mount.group, strings.TrimSuffix(mount.Path, mount.resource), mount.group)
+	}
+	block.WriteString(mount.block(list))
+
+	var indented strings.Builder
+	for _, line := range strings.SplitAfter(block.String(), "\n") {
+		if line != "" {
+			indented.WriteString("\t" + line)
+		}
+	}
+	return indented.String()
+}
+
+// routerFile renders internal/router/router.go from the project manifest: a Deps field and a function per controller
+func routerFile(ctx context.Context, appName, module, path string) scaffoldFile {
+	imports := importSet{}
+	var fields, calls, funcs strings.Builder
+	project, _ := state.From(ctx).Project(appName)
+	for _, m := range project.Models {
+		lower := strings.ToLower(m.Name)
+		for _, c := range routerControllers {
+			body, ok := m.Options["router_"+c.Component]
+			if !ok {
+				continue
+			}
+			typ := imports.model(module, m.Name, "controllers") + "." + m.Name + c.Suffix
+			if c.Pointer {
+				typ = "*" + typ
+			}
+			register := "register" + m.Name + c.Register
+			fmt.Fprintf(&fields, "\t%s%s %s\n", m.Name, c.Suffix, typ)
+			fmt.Fprintf(&calls, "\t%s(e, deps.%s%s)\n", register, m.Name, c.Suffix)
+			fmt.Fprintf(&funcs, "\n// %s registers the %s routes of %s\nfunc %s(e *echo.Echo, %s%s %s) {\n%s}\n", register, c.Kind, m.Name, register, lower, c.Suffix, typ, body)
+		}
+	}
+
+	importLines := ""
+	if len(imports) > 0 {
+		importLines = "\n" + imports.lines()
+	}
+	return scaffoldFile{
+		Path:     path,
+		Language: "go",
+		Content: templates.MustRender("router/router.go", map[string]any{
+			"Imports": importLines,
+			"Fields":  fields.String(),
+			"Calls":   calls.String(),
+			"Funcs":   funcs.String(),
+		}),
+	}
+}
+
+// routerStep tells how to mount a controller's routes through router.go, and where the controller is built:
+// in cmd/web/main.go, or from the wire provider set
+func routerStep(ctx context.Context, appName string, file scaffoldFile, field, constructor string) string {
+	handover := fmt.Sprintf("Then add `%s: %s,` to the `router.Deps` that `cmd/web/main.go` passes to `router.RegisterRoutes`.", field, constructor)
+	if usesWire(ctx, appName) {
+		name, _, _ := strings.Cut(constructor, "(")
+		handover = fmt.Sprintf("Then add `%s,` to `Providers` in `internal/app/providers.go` and run `wire ./internal/app`, which fills `router.Deps` with the controller.", name)
+	}
+	return fmt.Sprintf("Mount the routes in `%s`, which registers those of every controller scaffolded so far:\n```go\n%s```\n\n   %s\n", file.Path, file.Content, handover)
+}
@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+)
+
+// The route options are shared by the controller tools to mount a resource somewhere else than the plural of the model
+var (
+	routePrefixOption = mcp.WithString("route_prefix",
+		mcp.Description("Prefix of the resource's routes, e.g. /api/v1. When route_group is set, the prefix the group was created with. Defaults to none."),
+	)
+	resourcePathOption = mcp.WithString("resource_path",
+		mcp.Description("Path of the resource under the prefix, overriding the plural of the model name in kebab-case, e.g. catalog/items for an Item model."),
+	)
+	routeGroupOption = mcp.WithString("route_group",
+		mcp.Description("Variable of an existing *echo.Group to register the routes on instead of e, e.g. api for api := e.Group(\"/api/v1\", auth)."),
+	)
+	middlewareOption = mcp.WithString("middleware",
+		mcp.Description("Comma-separated middleware applied to the resource's routes through a route group, as Go expressions, e.g. middleware.RequireAuth, echomw.BodyLimit(\"1M\")."),
+	)
+)
+
+var (
+	// routePath matches slash-separated path segments, without parameters or a trailing slash
+	routePath = regexp.MustCompile(`^(/[A-Za-z0-9._~-]+)*$`)
+	// routeGroup matches the Go identifier of a group variable
+	routeGroup = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// routeMiddleware matches a middleware value or call, e.g. auth.Required or middleware.BodyLimit("1M")
+	routeMiddleware = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*(\([^;\n]*\))?$`)
+)
+
+// routes describes where the routes of a resource are registered
+type routes struct {
+	Path       string // full path of the resource, e.g. /api/v1/products
+	group      string // existing group variable the routes are registered on, empty for e
+	resource   string // path below the group, e.g. /products
+	variable   string // variable of the group created for the middleware
+	middleware []string
+}
+
+// route is a single route of a resource, relative to its path
+type route struct {
+	Method  string // echo method, e.g. GET
+	Path    string // e.g. "" or "/:id/edit"
+	Handler string
+}
+
+// newRoutes reads the route options, defaulting to the plural of the model on e, e.g. /order-items for OrderItem
+func newRoutes(request mcp.CallToolRequest, modelName string) (routes, error) {
+	prefix := "/" + strings.Trim(request.GetString("route_prefix", ""), "/")
+	resource := "/" + strings.Trim(request.GetString("resource_path", naming.Plural(naming.Kebab(modelName))), "/")
+	if prefix == "/" {
+		prefix = ""
+	}
+	if !routePath.MatchString(prefix) {
+		return routes{}, fmt.Errorf("Invalid 'route_prefix' '%s': expected path segments such as /api/v1.", prefix)
+	}
+	if resource == "/" || !routePath.MatchString(resource) {
+		return routes{}, fmt.Errorf("Invalid 'resource_path' '%s': expected path segments such as people.", strings.TrimPrefix(resource, "/"))
+	}
+
+	r := routes{Path: prefix + resource, resource: resource, variable: strings.ToLower(modelName) + "Routes"}
+	if group := request.GetString("route_group", ""); group != "" {
+		if !routeGroup.MatchString(group) {
+			return routes{}, fmt.Errorf("Invalid 'route_group' '%s': expected the name of an *echo.Group variable such as api.", group)
+		}
+		r.group = group
+	}
+	for _, mw := range splitArguments(request.GetString("middleware", "")) {
+		if !routeMiddleware.MatchString(mw) {
+			return routes{}, fmt.Errorf("Invalid 'middleware' '%s': expected a Go expression such as middleware.RequireAuth.", mw)
+		}
+		r.middleware = append(r.middleware, mw)
+	}
+	return r, nil
+}
+
+// splitArguments splits a comma-separated list, keeping commas inside parentheses and quotes
+func splitArguments(list string) []string {
+	var parts []string
+	depth, start := 0, 0
+	quoted := false
+	for i, c := range list {
+		switch {
+		case c == '"' && (i == 0 || list[i-1] != '\\'):
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, list[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, list[start:])
+
+	var trimmed []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			trimmed = append(trimmed, part)
+		}
+	}
+	return trimmed
+}
+
+// block renders the registration of the routes, through a group when the resource has its own middleware
+func (r routes) block(list []route) string {
+	receiver, base := "e", r.Path
+	if r.group != "" {
+		receiver, base = r.group, r.resource
+	}
+
+	var b strings.Builder
+	if len(r.middleware) > 0 {
+		fmt.Fprintf(&b, "%s := %s.Group(%q, %s)\n", r.variable, receiver, base, strings.Join(r.middleware, ", "))
+		receiver, base = r.variable, ""
+	}
+	for _, rt := range list {
+		fmt.Fprintf(&b, "%s.%s(%q, %s)\n", receiver, rt.Method, base+rt.Path, rt.Handler)
+	}
+	return b.String()
+}
+
+// list returns the method and full path of each route, e.g. GET /products/:id, as recorded in the project state
+func (r routes) list(list []route) []string {
+	paths := make([]string, len(list))
+	for i, rt := range list {
+		paths[i] = rt.Method + " " + r.Path + rt.Path
+	}
+	return paths
+}
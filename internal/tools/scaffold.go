@@ -0,0 +1,272 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/i18n"
+	"mcpgo/internal/templates"
+)
+
+// scaffoldFile is a single file a tool instructs the client to create
+type scaffoldFile struct {
+	Path     string `json:"path"`
+	Language string `json:"language"`
+	Content  string `json:"content"`
+}
+
+// scaffold is the machine-readable form of a tool's instructions
+type scaffold struct {
+	Files    []scaffoldFile `json:"files"`
+	Commands []string       `json:"commands"`
+	Notes    []string       `json:"notes"`
+
+	routes    []string          // method and path of each route registered, e.g. GET /products/:id, listed by explain
+	appDir    bool              // paths and commands start with the app directory, which the module path rewrite must leave alone
+	rewritten bool              // the conventions of the app are already applied, as in a scaffold composed from other tools
+	progress  *progressReporter // the progress the call reported while generating, which writing the files continues
+}
+
+// structured returns the scaffold as the structuredContent of a result, with empty arrays rather than null
+func (s scaffold) structured() scaffold {
+	return scaffold{
+		Files:    append([]scaffoldFile{}, s.Files...),
+		Commands: append([]string{}, s.Commands...),
+		Notes:    append([]string{}, s.Notes...),
+	}
+}
+
+// fileFormat describes a scaffold file: its path is an inline template and its content an embedded one
+type fileFormat struct {
+	Path     string // e.g. internal/service/{{.Lower}}/create.go
+	Language string
+	Template string // name of the embedded template, e.g. service/create.go
+}
+
+// renderFiles renders every file format with the same data, stopping before the next file once ctx is done
+func renderFiles(ctx context.Context, formats []fileFormat, data map[string]any) ([]scaffoldFile, error) {
+	files := make([]scaffoldFile, 0, len(formats))
+	for _, f := range formats {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		path, err := templates.Expand(f.Path, data)
+		if err != nil {
+			return nil, fmt.Errorf("Could not render the path of %s: %v.", f.Template, err)
+		}
+		content, err := templates.Render(f.Template, data)
+		if err != nil {
+			return nil, fmt.Errorf("Could not render %s: %v.", f.Template, err)
+		}
+		files = append(files, scaffoldFile{Path: path, Language: f.Language, Content: content})
+	}
+	return files, nil
+}
+
+// fileContents returns the rendered content of each file, in order, for splicing into the markdown instructions
+func fileContents(files []scaffoldFile) []any {
+	contents := make([]any, 0, len(files))
+	for _, f := range files {
+		contents = append(contents, f.Content)
+	}
+	return contents
+}
+
+// embedFilesOption is shared by every produce_* tool to request one embedded resource per generated file
+var embedFilesOption = mcp.WithBoolean("embed_files",
+	mcp.Description("Return each generated file as an embedded resource (with URI and MIME type) plus a short summary, instead of one long markdown document."),
+)
+
+// explainOption is shared by every produce_* tool to preview what it would generate, without the code
+var explainOption = mcp.WithBoolean("explain",
+	mcp.Description("Return only the tree of files and directories and the routes the tool would create, without any code, for planning or to save context. Nothing is written, and the project state does not record the call. Defaults to false."),
+)
+
+// languageOption is shared by every produce_* tool to translate the prose of the instructions
+var languageOption = mcp.WithString("language",
+	mcp.Description("Language of the instructions (en, es, pt, ja). Code, paths and commands are never translated. Defaults to en."),
+	mcp.Enum(i18n.Supported()...),
+)
+
+// scaffoldResult returns the markdown instructions followed by the serialized scaffold
+// The scaffold is also the structuredContent of the result, following scaffoldOutputSchema; the JSON text block stays
+// for clients that do not understand structured results, as the MCP guidance recommends
+func scaffoldResult(ctx context.Context, request mcp.CallToolRequest, markdown string, s scaffold) *mcp.CallToolResult {
+	lang := request.GetString("language", i18n.DefaultLanguage)
+	if !i18n.IsSupported(lang) {
+		return mcp.NewToolResultError(fmt.Sprintf("Unsupported language '%s'. Supported languages: %s.", lang, strings.Join(i18n.Supported(), ", ")))
+	}
+	format := request.GetString("output_format", valueOr(settings.OutputFormat, "markdown"))
+	if format != "markdown" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid 'output_format': expected markdown or json, got '%s'.", format))
+	}
+	markdown, s = applyConventions(ctx, requestAppName(ctx, request), markdown, s)
+	markdown, s, err := formatGoFiles(markdown, s)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+	markdown, s, err = stampScaffold(ctx, request, markdown, s)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+	markdown, err = applyVerbosity(request.GetString("verbosity", "standard"), markdown, s)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+	if request.GetBool("explain", false) {
+		return explainResult(s, lang)
+	}
+	// Tools that report no progress while generating still report the files they write
+	if s.progress == nil {
+		s.progress = newProgressReporter(ctx, request, 0)
+	}
+	result := scaffoldOutput(ctx, request, markdown, s, format, lang)
+	if !result.IsError && result.StructuredContent == nil {
+		result.StructuredContent = s.structured()
+	}
+	if request.GetBool("verify", false) {
+		return withVerification(ctx, request, result, s, lang)
+	}
+	return result
+}
+
+// scaffoldOutput returns the scaffold in the requested format, writing its files first when write_files is set
+func scaffoldOutput(ctx context.Context, request mcp.CallToolRequest, markdown string, s scaffold, format, lang string) *mcp.CallToolResult {
+	if format == "json" {
+		return manifestResult(ctx, request, s)
+	}
+	markdown = i18n.Translate(markdown, lang)
+
+	if request.GetBool("write_files", false) {
+		return writtenFilesResult(ctx, request, s, lang)
+	}
+	if request.GetBool("embed_files", false) {
+		return embeddedFilesResult(s, lang)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return mcp.NewToolResultText(markdown)
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent(markdown),
+			mcp.NewTextContent(string(data)),
+		},
+	}
+}
+
+// embeddedFilesResult returns a summary of commands and notes followed by one embedded resource per file
+func embeddedFilesResult(s scaffold, lang string) *mcp.CallToolResult {
+	var summary strings.Builder
+	summary.WriteString("# Scaffold Summary\n\n")
+	if len(s.Commands) > 0 {
+		summary.WriteString("Run the following commands:\n")
+		for _, command := range s.Commands {
+			summary.WriteString(fmt.Sprintf("- `%s`\n", command))
+		}
+		summary.WriteString("\n")
+	}
+	summary.WriteString("Create the following files (attached as embedded resources):\n")
+	for _, f := range s.Files {
+		summary.WriteString(fmt.Sprintf("- `%s`\n", f.Path))
+	}
+	if len(s.Notes) > 0 {
+		summary.WriteString("\nNotes:\n")
+		for _, note := range s.Notes {
+			summary.WriteString(fmt.Sprintf("- %s\n", note))
+		}
+	}
+
+	content := []mcp.Content{mcp.NewTextContent(i18n.Translate(summary.String(), lang))}
+	for _, f := range s.Files {
+		content = append(content, mcp.NewEmbeddedResource(mcp.TextResourceContents{
+			URI:      fileURI(f.Path),
+			MIMEType: languageMIMEType(f.Language),
+			Text:     f.Content,
+		}))
+	}
+	return &mcp.CallToolResult{Content: content}
+}
+
+// fileURI identifies a generated file by its path relative to the project root
+func fileURI(path string) string {
+	return "mcpgo://file/" + strings.TrimPrefix(path, "/")
+}
+
+// languageMIMEType maps a scaffold file language to the MIME type advertised to clients
+func languageMIMEType(language string) string {
+	switch language {
+	case "go":
+		return "text/x-go"
+	case "templ":
+		return "text/x-templ"
+	case "css":
+		return "text/css"
+	case "makefile":
+		return "text/x-makefile"
+	case "sql":
+		return "application/sql"
+	case "yaml":
+		return "application/yaml"
+	case "json":
+		return "application/json"
+	case "dockerfile":
+		return "text/x-dockerfile"
+	default:
+		return "text/plain"
+	}
+}
+
+// scaffoldOutputSchema is the JSON Schema of the structured scaffold returned by the produce_* tools
+const scaffoldOutputSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Scaffold",
+  "type": "object",
+  "properties": {
+    "files": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "path": {"type": "string", "description": "Path of the file relative to the project root"},
+          "language": {"type": "string", "description": "Language of the file content (go, templ, css, makefile)"},
+          "content": {"type": "string", "description": "Full content of the file, left out when the tool is called with explain"},
+          "action": {"type": "string", "description": "With output_format=json, what writing the file does or did (create, update, merge, conflict, written, ...)"}
+        },
+        "required": ["path", "language"]
+      }
+    },
+    "commands": {"type": "array", "items": {"type": "string"}, "description": "Shell commands to run, in order"},
+    "notes": {"type": "array", "items": {"type": "string"}, "description": "Additional instructions that are not files or commands"}
+  },
+  "required": ["files", "commands", "notes"]
+}`
+
+// scaffoldOutputOption declares scaffoldOutputSchema as the output schema of every tool returning a scaffold
+var scaffoldOutputOption = mcp.WithRawOutputSchema(json.RawMessage(scaffoldOutputSchema))
+
+// GetScaffoldSchemaResource returns the resource definition exposing the scaffold output schema
+func GetScaffoldSchemaResource() (mcp.Resource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error)) {
+	resource := mcp.NewResource("mcpgo://schema/scaffold", "Scaffold output schema",
+		mcp.WithResourceDescription("JSON Schema of the structured scaffold (files, commands, notes) every produce_* tool returns as its structuredContent and as its second content block."),
+		mcp.WithMIMEType("application/schema+json"),
+	)
+
+	return resource, ScaffoldSchemaResourceHandler
+}
+
+// ScaffoldSchemaResourceHandler returns the scaffold output schema
+func ScaffoldSchemaResourceHandler(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/schema+json",
+			Text:     scaffoldOutputSchema,
+		},
+	}, nil
+}
@@ -0,0 +1,228 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/naming"
+)
+
+// GetScaffoldFullCrudTool returns the tool definition for scaffold_full_crud
+func GetScaffoldFullCrudTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("scaffold_full_crud",
+		mcp.WithDescription("Instructs the LLM to output everything a model needs from the database to its HTTP routes in one pass: the model and repository, the service and DTOs, the API controller and the route registration, as one consolidated plan. Runs produce_model_boilerplate, produce_service_boilerplate and produce_api_controller_boilerplate with the same arguments."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. This is used to output an example of correct import paths. Defaults to the application used last."),
+		),
+		mcp.WithString("model_name",
+			mcp.Required(),
+			mcp.Description("The name of the model (e.g., User, Product)."),
+		),
+		fieldsOption,
+		dialectOption,
+		mcp.WithBoolean("soft_delete",
+			mcp.Description("Give the model a DeletedAt column so Delete only hides records. Defaults to true."),
+		),
+		mcp.WithBoolean("timestamps",
+			mcp.Description("Give the model CreatedAt and UpdatedAt columns. Defaults to true."),
+		),
+		mcp.WithString("base_model",
+			mcp.Description("Name of the base struct embedded in the model instead of gorm.Model (e.g., BaseModel). Defaults to gorm.Model."),
+		),
+		readReplicasOption,
+		transactionsOption,
+		mcp.WithBoolean("merge_patch",
+			mcp.Description("Also generate a PATCH /<model>s/:id handler applying JSON merge patches (RFC 7386). Defaults to false."),
+		),
+		mcp.WithBoolean("content_negotiation",
+			mcp.Description("Let the list and get handlers answer in JSON, CSV or XML depending on the Accept header. Defaults to false."),
+		),
+		routePrefixOption,
+		resourcePathOption,
+		routeGroupOption,
+		middlewareOption,
+		errorFormatOption,
+		templateVersionOption,
+		embedFilesOption,
+		explainOption,
+		outputFormatOption,
+		writeFilesOption,
+		targetDirOption,
+		dryRunOption,
+		overwriteOption,
+		forceOption,
+		languageOption,
+		verbosityOption,
+		verifyOption,
+		writeAnnotations,
+		scaffoldOutputOption,
+	)
+
+	return tool, ScaffoldFullCrudHandler
+}
+
+// fullCrudStep is one of the tools scaffold_full_crud runs, in order
+type fullCrudStep struct {
+	Tool    string
+	Title   string
+	Handler func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+}
+
+// fullCrudSteps are the layers of a model from the database to its routes
+var fullCrudSteps = []fullCrudStep{
+	{"produce_model_boilerplate", "Model and repository", ProduceModelBoilerplateHandler},
+	{"produce_service_boilerplate", "Service and DTOs", ProduceServiceBoilerplateHandler},
+	{"produce_api_controller_boilerplate", "API controller", ProduceApiControllerBoilerplateHandler},
+}
+
+// fullCrudOutputArguments decide how a result is returned; the steps leave them to scaffold_full_crud
+var fullCrudOutputArguments = []string{"output_format", "write_files", "target_dir", "dry_run", "overwrite", "force", "embed_files", "explain", "language", "verify"}
+
+// ScaffoldFullCrudHandler handles requests to scaffold a model and every layer up to its routes
+// It runs the model, service and API controller tools and merges their files, commands and notes into one plan
+func ScaffoldFullCrudHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	if appName == "" {
+		return missingAppNameResult(ctx), nil
+	}
+	modelName, invalid := requestModelName(ctx, request, appName)
+	if invalid != nil {
+		return invalid, nil
+	}
+
+	// Every step gets the same arguments, with the app resolved once and the output left to this tool
+	arguments := map[string]any{}
+	for key, value := range request.GetArguments() {
+		if !slices.Contains(fullCrudOutputArguments, key) {
+			arguments[key] = value
+		}
+	}
+	arguments["app_name"] = appName
+	arguments["output_format"] = "markdown"
+
+	// One unit per layer, then one per file once written, so the total keeps running across both
+	progress := newProgressReporter(ctx, request, len(fullCrudSteps))
+	var plan scaffold
+	var sections strings.Builder
+	for i, step := range fullCrudSteps {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		stepRequest := mcp.CallToolRequest{}
+		stepRequest.Params.Name = step.Tool
+		stepRequest.Params.Arguments = arguments
+		result, err := step.Handler(ctx, stepRequest)
+		if err != nil {
+			return nil, err
+		}
+		if result.IsError || len(result.Content) < 2 {
+			return mcp.NewToolResultError(fmt.Sprintf("Step %d, %s, failed: %s", i+1, step.Tool, resultText(result))), nil
+		}
+		var s scaffold
+		if err := json.Unmarshal([]byte(resultText(&mcp.CallToolResult{Content: result.Content[1:]})), &s); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Step %d, %s, returned no scaffold: %v.", i+1, step.Tool, err)), nil
+		}
+
+		fmt.Fprintf(&sections, "### %s\n\nFrom `%s`.\n\n", step.Title, step.Tool)
+		for _, f := range s.Files {
+			if isGenerationManifest(f.Path) {
+				continue
+			}
+			fmt.Fprintf(&sections, "`%s`:\n```%s\n%s```\n\n", f.Path, f.Language, f.Content)
+		}
+		plan = mergeScaffold(plan, s)
+		progress.Step(fmt.Sprintf("Generated %s (step %d of %d)", strings.ToLower(step.Title), i+1, len(fullCrudSteps)))
+	}
+	plan.progress = progress
+
+	titleModelName := naming.Pascal(modelName)
+	lowerModelName := strings.ToLower(titleModelName)
+	mount, err := newRoutes(request, titleModelName)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	routes := apiControllerRoutes(titleModelName, lowerModelName, request.GetBool("merge_patch", false))
+	plan.routes = mount.list(routes)
+	wiring := "3. Wire the layers in `internal/app/providers.go`: add the constructors listed in the notes below. The routes file above registers the routes once fx provides the controller.\n"
+	if usesWire(ctx, appName) {
+		wiring = "3. Wire the layers in `internal/app/providers.go`: add the constructors listed in the notes below and run `wire ./internal/app`. The router file above registers the routes once wire fills `router.Deps` with the controller.\n"
+	} else if !usesFx(ctx, appName) {
+		// The layers of each model are packages of their own, imported under the names the router file uses
+		imports := importSet{appName + "/internal/models": ""}
+		repository := imports.model(appName, titleModelName, "repository")
+		service := imports.model(appName, titleModelName, "service")
+		controllers := imports.model(appName, titleModelName, "controllers")
+		wiring = fmt.Sprintf("3. Wire the layers in `cmd/web/main.go`. Add these imports:\n```go\nimport (\n%[3]s)\n```\n\n   Then, after opening the database with `database.Open`:\n```go\nif err := db.AutoMigrate(&models.%[1]s{}); err != nil {\n\te.Logger.Fatal(\"failed to auto migrate models\", err)\n}\n%[2]sRepo := %[4]s.New%[1]sRepository(db)\n%[2]sService := %[5]s.New%[1]sService(%[2]sRepo)\n%[2]sController := %[6]s.New%[1]sController(%[2]sService)\n```\n\n   Then add `%[1]sController: %[2]sController,` to the `router.Deps` passed to `router.RegisterRoutes`; the router file above registers the routes.\n",
+			titleModelName, lowerModelName, imports.lines(), repository, service, controllers)
+		wiring, _ = applyConventions(ctx, appName, wiring, scaffold{})
+	}
+
+	var commands strings.Builder
+	for _, command := range plan.Commands {
+		fmt.Fprintf(&commands, "   - `%s`\n", command)
+	}
+	var notes strings.Builder
+	for _, note := range plan.Notes {
+		fmt.Fprintf(&notes, "- %s\n", note)
+	}
+
+	response := fmt.Sprintf(`
+# Full CRUD Scaffold Plan
+
+To scaffold the model '%[1]s' of '%[2]s' from the database to its HTTP routes, please perform the following steps:
+
+1. Run the following commands:
+%[3]s
+2. Create or update the following files:
+
+%[4]s%[5]s
+Notes:
+%[6]s`, titleModelName, appName, commands.String(), sections.String(), wiring, notes.String())
+
+	// The steps already rewrote their files for the conventions of the app
+	plan.rewritten = true
+	return scaffoldResult(ctx, request, response, plan), nil
+}
+
+// mergeScaffold adds the files, commands and notes of s to plan, replacing files generated again and dropping repeats
+// The generation manifests of the steps are all kept, for scaffoldResult to merge
+func mergeScaffold(plan, s scaffold) scaffold {
+	for _, f := range s.Files {
+		if i := slices.IndexFunc(plan.Files, func(existing scaffoldFile) bool { return existing.Path == f.Path }); i >= 0 && !isGenerationManifest(f.Path) {
+			plan.Files[i] = f
+			continue
+		}
+		plan.Files = append(plan.Files, f)
+	}
+	for _, command := range s.Commands {
+		if !slices.Contains(plan.Commands, command) {
+			plan.Commands = append(plan.Commands, command)
+		}
+	}
+	for _, note := range s.Notes {
+		if !slices.Contains(plan.Notes, note) {
+			plan.Notes = append(plan.Notes, note)
+		}
+	}
+	return plan
+}
+
+// resultText joins the text blocks of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,26 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRenderFiles(t *testing.T) {
+	formats := []fileFormat{{Path: "internal/service/{{.Lower}}/create.go", Language: "go", Template: "service/create.go"}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if files, err := renderFiles(ctx, formats, map[string]any{"Lower": "product"}); !errors.Is(err, context.Canceled) || files != nil {
+		t.Errorf("a cancelled render returned %v, %v", files, err)
+	}
+
+	missing := []fileFormat{{Path: "internal/none.go", Language: "go", Template: "service/none.go"}}
+	if _, err := renderFiles(context.Background(), missing, nil); err == nil || !strings.Contains(err.Error(), "service/none.go") {
+		t.Errorf("rendering an unknown template returned %v", err)
+	}
+	path := []fileFormat{{Path: "internal/{{.Missing}}.go", Language: "go", Template: "service/create.go"}}
+	if _, err := renderFiles(context.Background(), path, map[string]any{}); err == nil {
+		t.Error("rendering a path with a missing key succeeded")
+	}
+}
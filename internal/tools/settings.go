@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"mcpgo/internal/config"
+	"mcpgo/internal/state"
+	"mcpgo/internal/templates"
+)
+
+// settings holds the server configuration; requests that leave a choice open fall back to it
+var settings config.Config
+
+// Configure applies cfg to every later tool call and loads its template overrides
+// It is meant to run at startup, before the server serves requests
+func Configure(cfg config.Config) error {
+	registered := All()
+	for _, name := range cfg.Tools {
+		if !slices.ContainsFunc(registered, func(t server.ServerTool) bool { return t.Tool.Name == name }) {
+			return fmt.Errorf("unknown tool %q in the enabled tools; registered tools: %s", name, strings.Join(toolNames(registered), ", "))
+		}
+	}
+	if cfg.Templates != "" {
+		if err := templates.Override(cfg.Templates); err != nil {
+			return err
+		}
+	}
+	settings = cfg
+	return nil
+}
+
+// Enabled returns the registered tools the configuration exposes, in registration order
+func Enabled() []server.ServerTool {
+	tools := All()
+	if len(settings.Tools) == 0 {
+		return tools
+	}
+	return slices.DeleteFunc(tools, func(t server.ServerTool) bool { return !slices.Contains(settings.Tools, t.Tool.Name) })
+}
+
+// toolNames lists the names of tools
+func toolNames(tools []server.ServerTool) []string {
+	names := make([]string, len(tools))
+	for i, t := range tools {
+		names[i] = t.Tool.Name
+	}
+	return names
+}
+
+// appModule returns the module path of appName: the one recorded for the app, or the app name under the configured prefix
+func appModule(ctx context.Context, appName string) string {
+	if project, ok := state.From(ctx).Project(appName); ok && project.Options["module"] != "" {
+		return project.Options["module"]
+	}
+	return settings.ModulePath(appName)
+}
@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/templates"
+)
+
+// templateURIPrefix identifies a template by its name under internal/templates, e.g. mcpgo://template/model/create.go
+const templateURIPrefix = "mcpgo://template/"
+
+// GetTemplateResources returns one resource per template the tools render, with the handler reading any of them
+func GetTemplateResources() ([]mcp.Resource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error)) {
+	var resources []mcp.Resource
+	for _, name := range templates.Names() {
+		resources = append(resources, mcp.NewResource(templateURIPrefix+name, name,
+			mcp.WithResourceDescription(fmt.Sprintf("The text/template the produce_* tools render %s from, before its {{.Field}} placeholders are filled in, or the file overriding it under the configured templates directory, if any.", name)),
+			mcp.WithMIMEType("text/plain"),
+		))
+	}
+
+	return resources, TemplateResourceHandler
+}
+
+// TemplateResourceHandler returns the raw text of the requested template
+func TemplateResourceHandler(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	name, ok := strings.CutPrefix(request.Params.URI, templateURIPrefix)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("template URIs start with %s, got '%s'", templateURIPrefix, request.Params.URI)
+	}
+	text, err := templates.Source(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "text/plain",
+			Text:     text,
+		},
+	}, nil
+}
@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+	"mcpgo/internal/templates"
+)
+
+// templateVersionOption selects the generation of the templates of the tools that have more than one
+var templateVersionOption = mcp.WithString("template_version",
+	mcp.Description("Generation of the templates to render: v1, the layout existing projects were scaffolded with, or v2, whose services return a not-found error the controllers answer with 404. The choice is pinned for the app, so later calls keep producing consistent code. Defaults to the version pinned for the app, or the version configured for the server, or v1."),
+	mcp.Enum(templates.Versions...),
+)
+
+// templateVersion returns the template generation of a request and pins it for the app, so a project keeps
+// its generation when the server default moves on
+func templateVersion(ctx context.Context, request mcp.CallToolRequest, appName string) (string, error) {
+	version := valueOr(settings.TemplateVersion, templates.Versions[0])
+	if project, ok := state.From(ctx).Project(appName); ok && project.Options["template_version"] != "" {
+		version = project.Options["template_version"]
+	}
+	version = request.GetString("template_version", version)
+	if !slices.Contains(templates.Versions, version) {
+		return "", fmt.Errorf("Invalid 'template_version' '%s': expected one of %s.", version, strings.Join(templates.Versions, ", "))
+	}
+	state.From(ctx).SetOption(appName, "template_version", version)
+	return version, nil
+}
+
+// versionFiles points the files at the templates of a generation, keeping those the generation does not change
+func versionFiles(version string, formats []fileFormat) []fileFormat {
+	versioned := make([]fileFormat, len(formats))
+	for i, f := range formats {
+		f.Template = templates.Versioned(version, f.Template)
+		versioned[i] = f
+	}
+	return versioned
+}
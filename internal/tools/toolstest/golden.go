@@ -0,0 +1,105 @@
+// Package toolstest runs tool handlers with canned inputs and compares their output with golden files
+package toolstest
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/diff"
+	"mcpgo/internal/state"
+)
+
+// update rewrites the golden files instead of comparing against them: go test ./internal/tools -update
+var update = flag.Bool("update", false, "rewrite the golden files with the current tool output")
+
+// Handler is the signature shared by every tool handler
+type Handler func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+// Case is one call of a tool and the golden file its output is compared with
+type Case struct {
+	Name      string // golden file name under testdata, without the .golden extension
+	Handler   Handler
+	Arguments map[string]any
+}
+
+// ToolName returns the name of the tool a handler serves, or an empty string; a package testing its tools sets it,
+// so each case is called with the name of its tool as the server calls it
+var ToolName = func(Handler) string { return "" }
+
+// Check inspects the result of each case besides comparing it with its golden file; a package testing its tools sets
+// it to check what every golden output must satisfy
+var Check = func(t *testing.T, c Case, result *mcp.CallToolResult) {}
+
+// Run calls the handler of each case in order, checks the result and compares it rendered with testdata/<name>.golden
+// The cases of a run share a fresh project state, so later calls see what earlier ones recorded
+func Run(t *testing.T, cases []Case) {
+	t.Helper()
+	previous := state.Default
+	state.Default = state.NewStore()
+	t.Cleanup(func() { state.Default = previous })
+
+	for _, c := range cases {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = ToolName(c.Handler)
+		request.Params.Arguments = c.Arguments
+		result, err := c.Handler(context.Background(), request)
+		if err != nil {
+			t.Fatalf("%s: %v", c.Name, err)
+		}
+		Check(t, c, result)
+		Compare(t, c.Name, Render(result))
+	}
+}
+
+// Compare checks got against testdata/<name>.golden, or writes it there when -update is set
+func Compare(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%s: %v (run go test with -update to create it)", name, err)
+	}
+	if got != string(want) {
+		t.Errorf("%s: output differs from %s (run go test with -update to accept it)\n%s", name, path, diff.Unified(path, "got", string(want), got))
+	}
+}
+
+// Render writes every content block of a result as text, headed by its kind, so it can be diffed line by line
+func Render(result *mcp.CallToolResult) string {
+	var b strings.Builder
+	if result.IsError {
+		b.WriteString("=== error ===\n")
+	}
+	for i, content := range result.Content {
+		switch c := content.(type) {
+		case mcp.TextContent:
+			fmt.Fprintf(&b, "=== content %d: text ===\n%s\n", i, c.Text)
+		case mcp.EmbeddedResource:
+			if r, ok := c.Resource.(mcp.TextResourceContents); ok {
+				fmt.Fprintf(&b, "=== content %d: resource %s (%s) ===\n%s\n", i, r.URI, r.MIMEType, r.Text)
+			} else {
+				fmt.Fprintf(&b, "=== content %d: resource %T ===\n", i, c.Resource)
+			}
+		default:
+			fmt.Fprintf(&b, "=== content %d: %T ===\n", i, content)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,217 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+// backupsDir is where the previous content of replaced files is kept, under target_dir
+const backupsDir = ".mcpgo/backups"
+
+// GetUndoLastScaffoldTool returns the tool definition for undo_last_scaffold
+func GetUndoLastScaffoldTool() (mcp.Tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)) {
+	tool := mcp.NewTool("undo_last_scaffold",
+		mcp.WithDescription("Reverses the most recent write_files operation of an application: deletes the files it created and restores the files it replaced from their backups. Call it again to undo earlier operations, up to the last 10. Files changed by hand since they were written are reported and nothing is undone, unless force is set."),
+		mcp.WithString("app_name",
+			mcp.Description("The name of the application. Defaults to the application used last."),
+		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("List the files that would be deleted and restored without changing anything. Defaults to false."),
+		),
+		mcp.WithBoolean("force",
+			mcp.Description("Also delete or restore files changed by hand since they were written, losing those changes. Defaults to false."),
+		),
+		// Each call undoes an earlier write than the one before
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			ReadOnlyHint:    mcp.ToBoolPtr(false),
+			DestructiveHint: mcp.ToBoolPtr(true),
+			IdempotentHint:  mcp.ToBoolPtr(false),
+			OpenWorldHint:   mcp.ToBoolPtr(false),
+		}),
+	)
+
+	return tool, UndoLastScaffoldHandler
+}
+
+// UndoLastScaffoldHandler handles requests to undo the last write_files operation of an application
+// Every file is checked first, so a file changed since it was written leaves the project untouched
+func UndoLastScaffoldHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appName := requestAppName(ctx, request)
+	w, ok := state.From(ctx).LastWrite(appName)
+	if !ok {
+		if appName == "" {
+			return missingAppNameResult(ctx), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Nothing to undo: no files of '%s' were written with write_files yet, or every write was undone already.", appName)), nil
+	}
+
+	var changed []string
+	if !request.GetBool("force", false) {
+		for _, path := range append(append([]string(nil), w.Created...), w.Replaced...) {
+			if fileChanged(w, path) {
+				changed = append(changed, path)
+			}
+		}
+	}
+	if len(changed) > 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Nothing was undone: the following files under `%s` changed since they were written on %s. Pass force=true to undo the write anyway, losing those changes:\n- `%s`\n", w.Dir, writeTime(w), strings.Join(changed, "`\n- `"))), nil
+	}
+
+	var summary strings.Builder
+	if request.GetBool("dry_run", false) {
+		summary.WriteString("# Undo Dry Run\n\n")
+		summary.WriteString(fmt.Sprintf("Nothing was changed. Undoing the write of %s under `%s` would:\n", writeTime(w), w.Dir))
+		for _, path := range w.Created {
+			summary.WriteString(fmt.Sprintf("- delete `%s`\n", path))
+		}
+		for _, path := range w.Replaced {
+			summary.WriteString(fmt.Sprintf("- restore `%s`\n", path))
+		}
+		return mcp.NewToolResultText(summary.String()), nil
+	}
+
+	var deleted, restored []string
+	for _, path := range w.Created {
+		target := filepath.Join(w.Dir, filepath.FromSlash(path))
+		if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return mcp.NewToolResultError(fmt.Sprintf("Could not delete '%s': %v. The files before it were undone: %s", path, err, undoneList(deleted, restored))), nil
+		}
+		removeEmptyDirs(w.Dir, filepath.Dir(target))
+		deleted = append(deleted, path)
+	}
+	for _, path := range w.Replaced {
+		data, err := os.ReadFile(filepath.Join(w.Backup, filepath.FromSlash(path)))
+		if err == nil {
+			err = os.WriteFile(filepath.Join(w.Dir, filepath.FromSlash(path)), data, 0o644)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Could not restore '%s': %v. The files before it were undone: %s", path, err, undoneList(deleted, restored))), nil
+		}
+		restored = append(restored, path)
+	}
+	state.From(ctx).PopWrite(appName)
+	removeBackup(w)
+
+	summary.WriteString("# Undo Summary\n\n")
+	summary.WriteString(fmt.Sprintf("Undid the write of %s under `%s`.\n\n", writeTime(w), w.Dir))
+	for _, group := range []struct {
+		label string
+		paths []string
+	}{
+		{"Deleted the following files:", deleted},
+		{"Restored the following files to their previous content:", restored},
+	} {
+		if len(group.paths) > 0 {
+			summary.WriteString(group.label + "\n")
+			for _, path := range group.paths {
+				summary.WriteString(fmt.Sprintf("- `%s`\n", path))
+			}
+			summary.WriteString("\n")
+		}
+	}
+	if _, more := state.From(ctx).LastWrite(appName); more {
+		summary.WriteString("Call undo_last_scaffold again to undo the write before it.\n")
+	}
+	return mcp.NewToolResultText(summary.String()), nil
+}
+
+// newWrite starts recording a write_files operation under dir, named after the time it runs
+func newWrite(dir string) state.Write {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	id := time.Now().Format("20060102-150405.000")
+	return state.Write{
+		ID:       id,
+		Dir:      abs,
+		Backup:   filepath.Join(abs, filepath.FromSlash(backupsDir), id),
+		Written:  map[string]string{},
+		Previous: map[string]string{},
+	}
+}
+
+// recordWrite adds a write that created or replaced files to the application's history, and removes the backups
+// of the writes too old to be undone
+func recordWrite(ctx context.Context, appName string, w state.Write) {
+	if len(w.Created) == 0 && len(w.Replaced) == 0 {
+		return
+	}
+	if len(w.Replaced) == 0 {
+		w.Backup = ""
+	}
+	for _, dropped := range state.From(ctx).RecordWrite(appName, w) {
+		removeBackup(dropped)
+	}
+}
+
+// backupFile saves the content of a file about to be replaced under the backup directory of its write
+func backupFile(backup, path, content string) error {
+	target := filepath.Join(backup, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(target, []byte(content), 0o644)
+}
+
+// removeBackup deletes the backup directory of a write, and the backups directory once it is empty
+func removeBackup(w state.Write) {
+	if w.Backup == "" {
+		return
+	}
+	os.RemoveAll(w.Backup)
+	removeEmptyDirs(w.Dir, filepath.Dir(w.Backup))
+}
+
+// removeEmptyDirs removes dir and its parents while they are empty, stopping at root
+func removeEmptyDirs(root, dir string) {
+	for dir != root && strings.HasPrefix(dir, root+string(filepath.Separator)) {
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// fileChanged reports whether a file of a write no longer has the content it was written with
+// A deleted file counts as changed unless the write created it, in which case there is nothing left to undo
+func fileChanged(w state.Write, path string) bool {
+	target := filepath.Join(w.Dir, filepath.FromSlash(path))
+	data, err := os.ReadFile(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return !slices.Contains(w.Created, path)
+	}
+	return err != nil || checksum(string(data)) != w.Written[target]
+}
+
+// writeTime formats the time a write ran, from its ID
+func writeTime(w state.Write) string {
+	t, err := time.ParseInLocation("20060102-150405.000", w.ID, time.Local)
+	if err != nil {
+		return w.ID
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// undoneList names the files already deleted or restored when undoing stops on an error
+func undoneList(deleted, restored []string) string {
+	if len(deleted)+len(restored) == 0 {
+		return "none."
+	}
+	return "`" + strings.Join(append(append([]string(nil), deleted...), restored...), "`, `") + "`."
+}
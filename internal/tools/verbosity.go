@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// verbosityOption is shared by every produce_* tool to trade the prose of the instructions for tokens
+var verbosityOption = mcp.WithString("verbosity",
+	mcp.Description("How much prose surrounds the code: minimal keeps only the code blocks, each under the path it belongs to, and the commands to run, for automated agents; standard returns the step-by-step instructions; verbose adds the notes and routes of the scaffold to them. Defaults to standard."),
+	mcp.Enum("minimal", "standard", "verbose"),
+)
+
+// applyVerbosity trims or extends the markdown instructions of a scaffold to the requested verbosity
+func applyVerbosity(verbosity, markdown string, s scaffold) (string, error) {
+	switch verbosity {
+	case "minimal":
+		return minimalMarkdown(markdown, s.Commands), nil
+	case "standard":
+		return markdown, nil
+	case "verbose":
+		return verboseMarkdown(markdown, s), nil
+	}
+	return "", fmt.Errorf("Invalid 'verbosity' '%s': expected minimal, standard or verbose.", verbosity)
+}
+
+// minimalMarkdown keeps the title, the fenced code blocks and the commands of markdown, dropping the prose
+// Each block is preceded by the first path quoted in the line introducing it, e.g. `internal/models/product.go`
+func minimalMarkdown(markdown string, commands []string) string {
+	var b strings.Builder
+	var intro, indent string
+	inCode := false
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```") && !inCode:
+			inCode = true
+			indent = line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			if path := quotedPath(intro); path != "" {
+				fmt.Fprintf(&b, "\n`%s`:\n", path)
+			} else {
+				b.WriteString("\n")
+			}
+			b.WriteString(trimmed + "\n")
+		case inCode:
+			if trimmed == "```" {
+				inCode = false
+			}
+			b.WriteString(strings.TrimPrefix(line, indent) + "\n")
+		case strings.HasPrefix(trimmed, "# ") && b.Len() == 0:
+			b.WriteString(trimmed + "\n")
+		case trimmed != "":
+			intro = trimmed
+		}
+	}
+	if len(commands) > 0 {
+		b.WriteString("\nCommands:\n")
+		for _, command := range commands {
+			fmt.Fprintf(&b, "- `%s`\n", command)
+		}
+	}
+	return b.String()
+}
+
+// quotedPath returns the first backquoted span of line that looks like a file path, or an empty string
+func quotedPath(line string) string {
+	parts := strings.Split(line, "`")
+	for i := 1; i < len(parts)-1; i += 2 {
+		if span := parts[i]; strings.ContainsAny(span, "/.") && !strings.ContainsAny(span, " ()") {
+			return span
+		}
+	}
+	return ""
+}
+
+// verboseMarkdown appends the routes and notes of the scaffold, which the standard instructions leave to the JSON
+func verboseMarkdown(markdown string, s scaffold) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(markdown, "\n") + "\n")
+	if len(s.routes) > 0 {
+		b.WriteString("\nRoutes:\n\n| Method | Path |\n|---|---|\n")
+		for _, r := range s.routes {
+			method, path, _ := strings.Cut(r, " ")
+			fmt.Fprintf(&b, "| %s | `%s` |\n", method, path)
+		}
+	}
+	if len(s.Notes) > 0 {
+		b.WriteString("\nNotes:\n")
+		for _, note := range s.Notes {
+			fmt.Fprintf(&b, "- %s\n", note)
+		}
+	}
+	return b.String()
+}
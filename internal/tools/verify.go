@@ -0,0 +1,213 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/i18n"
+)
+
+// verifyOption is shared by every produce_* tool to compile the generated code before handing it over
+var verifyOption = mcp.WithBoolean("verify",
+	mcp.Description("Compile the generated Go files with go build and go vet and append the compiler errors, if any, to the result. With target_dir, the files are checked in place of the project's own, without writing them; otherwise in a temporary module, where packages of the app that the tool does not generate are reported missing. Needs the go command on the server. Defaults to false."),
+)
+
+// goVerification is the outcome of compiling the Go files of a scaffold
+type goVerification struct {
+	Where   string // the module the files were compiled in, e.g. a temporary module
+	Failed  string // the command that failed, e.g. go build ./..., empty when every command passed
+	Output  string // the output of the failed command, with paths relative to the module
+	Skipped []string
+}
+
+// verifyScaffold compiles the Go files of a scaffold with go build and go vet
+// With a go.mod at the root of dir, the files are laid over the project with -overlay so nothing is written there;
+// otherwise they are materialized in a temporary module named module, requiring the pinned dependencies
+func verifyScaffold(ctx context.Context, dir, module string, files []scaffoldFile) (goVerification, error) {
+	var v goVerification
+	if _, err := exec.LookPath("go"); err != nil {
+		return v, errors.New("verify needs the go command on the PATH of the server.")
+	}
+	var goFiles []scaffoldFile
+	for _, f := range files {
+		switch {
+		case f.Language == "go" && strings.HasSuffix(f.Path, ".go"):
+			goFiles = append(goFiles, f)
+		case f.Language == "templ":
+			v.Skipped = append(v.Skipped, f.Path)
+		}
+	}
+
+	tmp, err := os.MkdirTemp("", "mcpgo-verify-")
+	if err != nil {
+		return v, fmt.Errorf("Could not create a directory to verify the generated files in: %v.", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	var commands [][]string
+	workDir := tmp
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); dir != "" && err == nil {
+		overlay, err := writeOverlay(tmp, dir, goFiles)
+		if err != nil {
+			return v, err
+		}
+		v.Where, workDir = "the project under `"+dir+"`", dir
+		commands = [][]string{
+			{"go", "build", "-overlay=" + overlay, "./..."},
+			{"go", "vet", "-overlay=" + overlay, "./..."},
+		}
+	} else {
+		if err := writeVerifyModule(tmp, module, goFiles); err != nil {
+			return v, err
+		}
+		v.Where = "a temporary module `" + module + "`"
+		commands = [][]string{
+			{"go", "mod", "tidy", "-e"},
+			{"go", "build", "./..."},
+			{"go", "vet", "./..."},
+		}
+	}
+
+	for _, args := range commands {
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Dir = workDir
+		cmd.Env = append(os.Environ(), "GOWORK=off")
+		output, err := cmd.CombinedOutput()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return v, fmt.Errorf("Verification stopped before %s finished: %v.", strings.Join(args, " "), ctxErr)
+		}
+		if err != nil {
+			v.Failed = strings.Join(stripOverlay(args), " ")
+			v.Output = strings.ReplaceAll(strings.TrimSpace(string(output)), tmp+string(filepath.Separator), "")
+			return v, nil
+		}
+	}
+	return v, nil
+}
+
+// writeOverlay writes the files to tmp, at the same paths so compiler errors name them, and returns the path of a go build -overlay file replacing them under dir
+func writeOverlay(tmp, dir string, files []scaffoldFile) (string, error) {
+	overlay := struct{ Replace map[string]string }{Replace: map[string]string{}}
+	if err := materialize(tmp, files); err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		overlay.Replace[trackedPath(dir, f.Path)] = filepath.Join(tmp, filepath.FromSlash(f.Path))
+	}
+	data, err := json.Marshal(overlay)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(tmp, "overlay.json")
+	return path, os.WriteFile(path, data, 0o644)
+}
+
+// writeVerifyModule materializes the files in tmp with a go.mod requiring the pinned versions of the modules they import
+func writeVerifyModule(tmp, module string, files []scaffoldFile) error {
+	if err := materialize(tmp, files); err != nil {
+		return err
+	}
+	var requires []string
+	for _, f := range files {
+		for _, importPath := range fileImports(f) {
+			dependency := dependencyModule(importPath)
+			if version, ok := dependencyVersions[dependency]; ok && !slices.Contains(requires, dependency+" "+version) {
+				requires = append(requires, dependency+" "+version)
+			}
+		}
+	}
+	slices.Sort(requires)
+
+	var goMod strings.Builder
+	fmt.Fprintf(&goMod, "module %s\n\ngo 1.23\n", module)
+	if len(requires) > 0 {
+		goMod.WriteString("\nrequire (\n")
+		for _, require := range requires {
+			fmt.Fprintf(&goMod, "\t%s\n", require)
+		}
+		goMod.WriteString(")\n")
+	}
+	return os.WriteFile(filepath.Join(tmp, "go.mod"), []byte(goMod.String()), 0o644)
+}
+
+// materialize writes the files under tmp at their paths
+func materialize(tmp string, files []scaffoldFile) error {
+	for _, f := range files {
+		if !filepath.IsLocal(filepath.FromSlash(f.Path)) {
+			return fmt.Errorf("Refusing to verify '%s' outside of the module.", f.Path)
+		}
+		path := filepath.Join(tmp, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("Could not create the directory of '%s' to verify it: %v.", f.Path, err)
+		}
+		if err := os.WriteFile(path, []byte(f.Content), 0o644); err != nil {
+			return fmt.Errorf("Could not write '%s' to verify it: %v.", f.Path, err)
+		}
+	}
+	return nil
+}
+
+// stripOverlay drops the -overlay flag from a command reported to the client, whose file is already deleted
+func stripOverlay(args []string) []string {
+	return slices.DeleteFunc(slices.Clone(args), func(arg string) bool { return strings.HasPrefix(arg, "-overlay=") })
+}
+
+// verificationReport renders the outcome of verifyScaffold as markdown
+func verificationReport(v goVerification) string {
+	var b strings.Builder
+	b.WriteString("# Verification\n\n")
+	if v.Failed == "" {
+		fmt.Fprintf(&b, "The generated Go files compile and pass go vet in %s.\n", v.Where)
+	} else {
+		fmt.Fprintf(&b, "`%s` failed on the generated Go files in %s:\n```text\n%s\n```\n", v.Failed, v.Where, v.Output)
+	}
+	if len(v.Skipped) > 0 {
+		fmt.Fprintf(&b, "\nNot compiled, run templ generate first: `%s`\n", strings.Join(v.Skipped, "`, `"))
+	}
+	return b.String()
+}
+
+// withVerification compiles the Go files of a scaffold and appends the outcome to result as a text block
+func withVerification(ctx context.Context, request mcp.CallToolRequest, result *mcp.CallToolResult, s scaffold, lang string) *mcp.CallToolResult {
+	if result.IsError {
+		return result
+	}
+	dir, files := request.GetString("target_dir", ""), s.Files
+	// The files of an app start with its directory, the root of its module
+	if s.appDir {
+		var root string
+		root, files = withoutAppDir(files)
+		if dir != "" {
+			dir = filepath.Join(dir, root)
+		}
+	}
+	v, err := verifyScaffold(ctx, dir, appModule(ctx, requestAppName(ctx, request)), files)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(i18n.Translate(verificationReport(v), lang)))
+	return result
+}
+
+// withoutAppDir returns the app directory the paths of an app scaffold start with, and the files relative to it
+func withoutAppDir(files []scaffoldFile) (string, []scaffoldFile) {
+	if len(files) == 0 {
+		return "", files
+	}
+	root, _, _ := strings.Cut(files[0].Path, "/")
+	relative := make([]scaffoldFile, len(files))
+	for i, f := range files {
+		f.Path = strings.TrimPrefix(f.Path, root+"/")
+		relative[i] = f
+	}
+	return root, relative
+}
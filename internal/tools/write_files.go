@@ -0,0 +1,387 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/diff"
+	"mcpgo/internal/i18n"
+	"mcpgo/internal/regions"
+	"mcpgo/internal/state"
+)
+
+// The write options are shared by every produce_* tool to let the server write the scaffold itself
+var (
+	writeFilesOption = mcp.WithBoolean("write_files",
+		mcp.Description("Write the generated files under target_dir and return a summary of the files written, instead of the file contents. Files the server wrote before are regenerated, keeping their protected regions between mcpgo:keep-begin and mcpgo:keep-end comments; if any of them changed by hand since, nothing is written and the conflicts are reported. Other existing files are regenerated when they have protected regions, and listed with their generated content to merge by hand otherwise, unless overwrite is set. Defaults to false."),
+	)
+	overwriteOption = mcp.WithBoolean("overwrite",
+		mcp.Description("With write_files, replace existing files the server did not write and that have no protected regions with the generated content, instead of leaving them unchanged. Defaults to false."),
+	)
+	forceOption = mcp.WithBoolean("force",
+		mcp.Description("With write_files, also replace files changed by hand since the server wrote them, instead of reporting them as conflicts. Implies overwrite. Defaults to false."),
+	)
+	targetDirOption = mcp.WithString("target_dir",
+		mcp.Description("Directory the generated paths are relative to, usually the project root (e.g., /home/me/src/shop). Required with write_files."),
+	)
+	dryRunOption = mcp.WithBoolean("dry_run",
+		mcp.Description("With write_files, write nothing and return a unified diff between the files under target_dir and the generated output instead, to review the changes first. The project state does not record the call. Defaults to false."),
+	)
+	// writeAnnotations tell clients the tool may replace files under target_dir, so they can ask before calling it,
+	// and that writing the same scaffold again changes nothing more
+	writeAnnotations = mcp.WithToolAnnotation(mcp.ToolAnnotation{
+		ReadOnlyHint:    mcp.ToBoolPtr(false),
+		DestructiveHint: mcp.ToBoolPtr(true),
+		IdempotentHint:  mcp.ToBoolPtr(true),
+		OpenWorldHint:   mcp.ToBoolPtr(false),
+	})
+)
+
+// writeResult is what writeScaffold did with each file of a scaffold
+type writeResult struct {
+	Written     []string       // paths of the files created
+	Merged      []string       // paths of the existing files regenerated around their protected regions
+	Overwritten []string       // paths of the existing files replaced
+	Unchanged   []string       // paths of the existing files that already have the generated content
+	Skipped     []scaffoldFile // files left alone because they already exist with other content
+	Conflicts   []conflict     // files changed since the server wrote them; nothing is written when there are any
+}
+
+// conflict is a file changed by hand since the server wrote it, with its current content
+type conflict struct {
+	File     scaffoldFile
+	Existing string
+}
+
+// writeOptions decide what happens to the files that already exist under target_dir
+type writeOptions struct {
+	app       string // application whose manifest records the checksums of the files written
+	overwrite bool   // replace files the server did not write
+	force     bool   // also replace files changed since the server wrote them
+}
+
+// requestWriteOptions reads the write options of a tool call
+func requestWriteOptions(ctx context.Context, request mcp.CallToolRequest) writeOptions {
+	return writeOptions{
+		app:       requestAppName(ctx, request),
+		overwrite: request.GetBool("overwrite", false),
+		force:     request.GetBool("force", false),
+	}
+}
+
+// checksum returns the SHA-256 of a file's generated lines: edits inside protected regions do not change it
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(regions.Strip(content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// trackedPath returns the absolute path a file's checksum is recorded under, whatever target_dir it was written with
+func trackedPath(dir, path string) string {
+	joined := filepath.Join(dir, filepath.FromSlash(path))
+	if abs, err := filepath.Abs(joined); err == nil {
+		return abs
+	}
+	return joined
+}
+
+// checkTarget reports a target_dir that is not a directory, and files whose path leaves it
+// Paths must stay inside dir, so a scaffold can never write elsewhere on the machine
+func checkTarget(dir string, files []scaffoldFile) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("Invalid 'target_dir' '%s': %v.", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("Invalid 'target_dir' '%s': not a directory.", dir)
+	}
+	for _, f := range files {
+		if !filepath.IsLocal(filepath.FromSlash(f.Path)) {
+			return fmt.Errorf("Refusing to write '%s' outside of '%s'.", f.Path, dir)
+		}
+	}
+	return nil
+}
+
+// writeScaffold creates the files of a scaffold under dir and records their checksums, and the write so it can be undone
+// Existing files are regenerated when the server wrote them or they have protected regions, which are kept; other
+// existing files are kept unless overwrite is set. Every file is checked first, so a conflict leaves the project untouched
+// Replaced files are backed up first, to be restored by undo_last_scaffold
+func writeScaffold(ctx context.Context, dir string, files []scaffoldFile, options writeOptions, progress *progressReporter) (writeResult, error) {
+	var result writeResult
+	if err := checkTarget(dir, files); err != nil {
+		return result, err
+	}
+
+	actions := make([]string, len(files))
+	existing := make([]string, len(files))
+	contents := make([]string, len(files))
+	for i, f := range files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		action, current, content, err := fileAction(ctx, dir, f, options)
+		if err != nil {
+			return result, err
+		}
+		if action == actionConflict {
+			result.Conflicts = append(result.Conflicts, conflict{File: f, Existing: current})
+		}
+		actions[i], existing[i], contents[i] = action, current, content
+	}
+	if len(result.Conflicts) > 0 {
+		return result, nil
+	}
+
+	// One notification per file, so a client can show how far a large scaffold got
+	progress.Add(len(files))
+	checksums := map[string]string{}
+	w := newWrite(dir)
+	defer func() {
+		state.From(ctx).RecordFiles(options.app, checksums)
+		recordWrite(ctx, options.app, w)
+	}()
+	for i, f := range files {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		path := filepath.Join(dir, filepath.FromSlash(f.Path))
+		tracked := trackedPath(dir, f.Path)
+		previous, _ := state.From(ctx).FileChecksum(options.app, tracked)
+		switch actions[i] {
+		case actionCreate:
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return result, fmt.Errorf("Could not create the directory of '%s': %v.", f.Path, err)
+			}
+			file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+			if errors.Is(err, fs.ErrExist) {
+				// Created since it was checked: keep what is there
+				result.Skipped = append(result.Skipped, f)
+				progress.Step("Skipped " + f.Path)
+				continue
+			}
+			if err != nil {
+				return result, fmt.Errorf("Could not create '%s': %v.", f.Path, err)
+			}
+			_, err = file.WriteString(contents[i])
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return result, fmt.Errorf("Could not write '%s': %v.", f.Path, err)
+			}
+			result.Written = append(result.Written, f.Path)
+			progress.Step("Wrote " + f.Path)
+			w.Created = append(w.Created, f.Path)
+			w.Written[tracked] = checksum(contents[i])
+		case actionMerge, actionOverwrite:
+			if err := backupFile(w.Backup, f.Path, existing[i]); err != nil {
+				return result, fmt.Errorf("Could not back up '%s' before replacing it: %v.", f.Path, err)
+			}
+			w.Replaced = append(w.Replaced, f.Path)
+			if err := os.WriteFile(path, []byte(contents[i]), 0o644); err != nil {
+				return result, fmt.Errorf("Could not write '%s': %v.", f.Path, err)
+			}
+			w.Written[tracked] = checksum(contents[i])
+			if actions[i] == actionMerge {
+				result.Merged = append(result.Merged, f.Path)
+				progress.Step("Merged " + f.Path)
+			} else {
+				result.Overwritten = append(result.Overwritten, f.Path)
+				progress.Step("Overwrote " + f.Path)
+			}
+		case actionUnchanged:
+			// Already has the generated content, so it is tracked like a file written now
+			result.Unchanged = append(result.Unchanged, f.Path)
+			progress.Step("Unchanged " + f.Path)
+		default:
+			result.Skipped = append(result.Skipped, f)
+			progress.Step("Skipped " + f.Path)
+			continue
+		}
+		checksums[tracked] = checksum(contents[i])
+		w.Previous[tracked] = previous
+	}
+	return result, nil
+}
+
+// writtenFilesResult writes the scaffold under target_dir and summarizes what is left to do
+func writtenFilesResult(ctx context.Context, request mcp.CallToolRequest, s scaffold, lang string) *mcp.CallToolResult {
+	dir := request.GetString("target_dir", "")
+	if dir == "" {
+		return missingParameterResult(ctx, "target_dir", "the directory to write the generated files under, usually the project root.", nil)
+	}
+	options := requestWriteOptions(ctx, request)
+	if request.GetBool("dry_run", false) {
+		return dryRunResult(ctx, dir, s.Files, options, lang)
+	}
+	result, err := writeScaffold(ctx, dir, s.Files, options, s.progress)
+	if err != nil && ctx.Err() != nil {
+		return cancelledWriteResult(dir, result, len(s.Files), lang)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+	if len(result.Conflicts) > 0 {
+		return conflictsResult(dir, result.Conflicts, lang)
+	}
+
+	var summary strings.Builder
+	summary.WriteString("# Scaffold Summary\n\n")
+	if len(result.Written) > 0 {
+		summary.WriteString(fmt.Sprintf("Wrote the following files under `%s`:\n", dir))
+		for _, path := range result.Written {
+			summary.WriteString(fmt.Sprintf("- `%s`\n", path))
+		}
+		summary.WriteString("\n")
+	}
+	for _, group := range []struct {
+		label string
+		paths []string
+	}{
+		{"Regenerated the following files, keeping their protected regions:", result.Merged},
+		{"Overwrote the following files with the generated content:", result.Overwritten},
+		{"The following files already have the generated content:", result.Unchanged},
+	} {
+		if len(group.paths) > 0 {
+			summary.WriteString(group.label + "\n")
+			for _, path := range group.paths {
+				summary.WriteString(fmt.Sprintf("- `%s`\n", path))
+			}
+			summary.WriteString("\n")
+		}
+	}
+	if len(result.Skipped) > 0 {
+		summary.WriteString("The following files already exist and were left unchanged. Merge the generated content into them:\n")
+		for _, f := range result.Skipped {
+			summary.WriteString(fmt.Sprintf("\n`%s`:\n```%s\n%s```\n", f.Path, f.Language, f.Content))
+		}
+		summary.WriteString("\n")
+	}
+	commands, satisfied := writtenCommands(dir, s)
+	if satisfied {
+		summary.WriteString("go.mod already requires every module the generated code imports.\n\n")
+	}
+	if len(commands) > 0 {
+		summary.WriteString("Run the following commands:\n")
+		for _, command := range commands {
+			summary.WriteString(fmt.Sprintf("- `%s`\n", command))
+		}
+		summary.WriteString("\n")
+	}
+	if len(s.Notes) > 0 {
+		summary.WriteString("Notes:\n")
+		for _, note := range s.Notes {
+			summary.WriteString(fmt.Sprintf("- %s\n", note))
+		}
+	}
+	return mcp.NewToolResultText(i18n.Translate(summary.String(), lang))
+}
+
+// cancelledWriteResult reports how far a write got before the call was cancelled between two files
+// The files changed so far are recorded like any other write, so undo_last_scaffold can restore them
+func cancelledWriteResult(dir string, result writeResult, total int, lang string) *mcp.CallToolResult {
+	changed := slices.Concat(result.Written, result.Merged, result.Overwritten)
+	done := len(changed) + len(result.Unchanged) + len(result.Skipped)
+
+	var report strings.Builder
+	report.WriteString("# Scaffold Cancelled\n\n")
+	if len(changed) == 0 {
+		report.WriteString(fmt.Sprintf("The call was cancelled before any file under `%s` was changed.\n", dir))
+		return mcp.NewToolResultError(i18n.Translate(report.String(), lang))
+	}
+	report.WriteString(fmt.Sprintf("The call was cancelled after %d of the %d files; the rest were not written. Changed under `%s`:\n", done, total, dir))
+	for _, path := range changed {
+		report.WriteString(fmt.Sprintf("- `%s`\n", path))
+	}
+	report.WriteString("\nCall undo_last_scaffold to restore these files, or call the tool again to write the rest.\n")
+	return mcp.NewToolResultError(i18n.Translate(report.String(), lang))
+}
+
+// remainingCommands drops the mkdir commands of a scaffold, whose directories were created with its files
+func remainingCommands(commands []string) []string {
+	var remaining []string
+	for _, command := range commands {
+		if !strings.HasPrefix(command, "mkdir -p ") {
+			remaining = append(remaining, command)
+		}
+	}
+	return remaining
+}
+
+// dryRunResult compares the scaffold with the files under dir without writing anything
+// New files are diffed against /dev/null; existing files show what write_files or merging by hand would change
+func dryRunResult(ctx context.Context, dir string, files []scaffoldFile, options writeOptions, lang string) *mcp.CallToolResult {
+	if err := checkTarget(dir, files); err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	var created, merged, overwritten, conflicts, changed, unchanged []string
+	var patch strings.Builder
+	for _, f := range files {
+		action, existing, content, err := fileAction(ctx, dir, f, options)
+		switch {
+		case err != nil:
+			return mcp.NewToolResultError(err.Error())
+		case action == actionCreate:
+			created = append(created, f.Path)
+			patch.WriteString(diff.Unified("/dev/null", "b/"+f.Path, "", content))
+			continue
+		case action == actionUnchanged:
+			unchanged = append(unchanged, f.Path)
+			continue
+		case action == actionMerge:
+			merged = append(merged, f.Path)
+		case action == actionOverwrite:
+			overwritten = append(overwritten, f.Path)
+		case action == actionConflict:
+			conflicts = append(conflicts, f.Path)
+		default:
+			changed = append(changed, f.Path)
+		}
+		patch.WriteString(diff.Unified("a/"+f.Path, "b/"+f.Path, existing, content))
+	}
+
+	var summary strings.Builder
+	summary.WriteString("# Scaffold Dry Run\n\n")
+	summary.WriteString(fmt.Sprintf("Nothing was written. Compared with the files under `%s`:\n", dir))
+	for _, group := range []struct {
+		label string
+		paths []string
+	}{
+		{"New files", created},
+		{"Existing files with protected regions (write_files regenerates them, keeping the regions)", merged},
+		{"Existing files that differ (write_files overwrites them)", overwritten},
+		{"Files changed since they were written (write_files refuses to overwrite them without force)", conflicts},
+		{"Existing files that differ (write_files keeps them; merge by hand)", changed},
+		{"Existing files already up to date", unchanged},
+	} {
+		if len(group.paths) > 0 {
+			summary.WriteString(fmt.Sprintf("- %s: `%s`\n", group.label, strings.Join(group.paths, "`, `")))
+		}
+	}
+	if patch.Len() > 0 {
+		summary.WriteString("\n```diff\n" + patch.String() + "```\n")
+	}
+	return mcp.NewToolResultText(i18n.Translate(summary.String(), lang))
+}
+
+// conflictsResult reports the files changed since the server wrote them, with what regenerating them would change
+func conflictsResult(dir string, conflicts []conflict, lang string) *mcp.CallToolResult {
+	var report strings.Builder
+	report.WriteString("# Scaffold Conflicts\n\n")
+	report.WriteString(fmt.Sprintf("Nothing was written: the following files under `%s` changed since they were generated. Merge the generated content into them by hand, or pass force=true to overwrite them:\n", dir))
+	for _, c := range conflicts {
+		report.WriteString(fmt.Sprintf("\n`%s`:\n```diff\n%s```\n", c.File.Path, diff.Unified("a/"+c.File.Path, "b/"+c.File.Path, c.Existing, c.File.Content)))
+	}
+	return mcp.NewToolResultError(i18n.Translate(report.String(), lang))
+}
@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"mcpgo/internal/state"
+)
+
+func TestWriteScaffoldCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(state.WithStore(context.Background(), state.NewStore()))
+	cancel()
+	dir := t.TempDir()
+	files := []scaffoldFile{{Path: "internal/a.go", Language: "go", Content: "package internal\n"}}
+	if _, err := writeScaffold(ctx, dir, files, writeOptions{app: "shop"}, &progressReporter{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("a cancelled write returned %v", err)
+	}
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 0 {
+		t.Errorf("a cancelled write wrote %v (%v)", entries, err)
+	}
+}
+
+// cancelAfter is a context cancelled once Err has been asked n times, to stop a write between two files
+type cancelAfter struct {
+	context.Context
+	n int
+}
+
+func (c *cancelAfter) Err() error {
+	if c.n--; c.n < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestWriteCancelledBetweenFiles(t *testing.T) {
+	// Each file is checked once before writing and once before it is written, so the second write is cancelled
+	ctx := &cancelAfter{Context: state.WithStore(context.Background(), state.NewStore()), n: 3}
+	dir := t.TempDir()
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"app_name": "shop", "target_dir": dir}
+	s := scaffold{Files: []scaffoldFile{
+		{Path: "internal/a.go", Language: "go", Content: "package internal\n"},
+		{Path: "internal/b.go", Language: "go", Content: "package internal\n"},
+	}, progress: &progressReporter{}}
+
+	result := writtenFilesResult(ctx, request, s, "")
+	text := resultText(result)
+	if !result.IsError || !strings.Contains(text, "cancelled after 1 of the 2 files") || !strings.Contains(text, "`internal/a.go`") || strings.Contains(text, "internal/b.go") {
+		t.Errorf("unexpected report:\n%s", text)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "internal", "b.go")); !os.IsNotExist(err) {
+		t.Errorf("the file after the cancellation was written (%v)", err)
+	}
+
+	// The partial write is recorded, so undo removes what it created
+	ctx.n = 100
+	undo := mcp.CallToolRequest{}
+	undo.Params.Arguments = map[string]any{"app_name": "shop"}
+	if result, err := UndoLastScaffoldHandler(ctx, undo); err != nil || result.IsError {
+		t.Fatalf("undo failed: %v %s", err, resultText(result))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "internal", "a.go")); !os.IsNotExist(err) {
+		t.Errorf("undo left the partially written file (%v)", err)
+	}
+}
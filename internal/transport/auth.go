@@ -0,0 +1,53 @@
+package transport
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// TokensEnv is the environment variable holding the accepted tokens when the -auth-tokens flag is not given
+const TokensEnv = "MCPGO_AUTH_TOKENS"
+
+// Tokens returns the comma-separated tokens of list, or of TokensEnv when list is empty
+func Tokens(list string) []string {
+	if list == "" {
+		list = os.Getenv(TokensEnv)
+	}
+	var tokens []string
+	for _, token := range strings.Split(list, ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// Authenticate lets through the requests carrying one of tokens, as a bearer token in the Authorization header or
+// in the X-API-Key header, and answers the others with 401 Unauthorized
+// Tokens are compared by their SHA-256 in constant time, so the response time does not reveal how much of one matched
+func Authenticate(tokens []string, next http.Handler) http.Handler {
+	sums := make([][sha256.Size]byte, len(tokens))
+	for i, token := range tokens {
+		sums[i] = sha256.Sum256([]byte(token))
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			token = r.Header.Get("X-API-Key")
+		}
+		sum := sha256.Sum256([]byte(token))
+		valid := 0
+		for _, accepted := range sums {
+			valid |= subtle.ConstantTimeCompare(sum[:], accepted[:])
+		}
+		if token == "" || valid == 0 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mcpgo"`)
+			http.Error(w, "missing or invalid token: send Authorization: Bearer <token> or X-API-Key: <token>", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
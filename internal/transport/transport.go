@@ -0,0 +1,100 @@
+// Package transport serves the MCP server over stdio, or over HTTP for remote clients, until its context is done
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// The transports the server can be reached over
+const (
+	Stdio = "stdio" // one client, the process that started the server
+	SSE   = "sse"   // HTTP with server-sent events: GET /sse streams, POST /message sends
+	HTTP  = "http"  // Streamable HTTP on a single /mcp endpoint
+)
+
+// Names lists the transports, in the order the flag help shows them
+var Names = []string{Stdio, SSE, HTTP}
+
+// HTTPPath is the endpoint of the Streamable HTTP transport
+const HTTPPath = "/mcp"
+
+// ShutdownTimeout bounds how long open HTTP requests may run once the server is asked to stop
+const ShutdownTimeout = 10 * time.Second
+
+// Validate reports a transport that is not one of Names
+func Validate(name string) error {
+	switch name {
+	case Stdio, SSE, HTTP:
+		return nil
+	}
+	return fmt.Errorf("unknown transport %q: expected %s", name, strings.Join(Names, ", "))
+}
+
+// Options configure the HTTP transports; stdio ignores them
+type Options struct {
+	Addr   string   // address to listen on, e.g. :8080
+	Tokens []string // accepted bearer tokens or API keys; empty lets every request through
+}
+
+// Serve runs s over the named transport until ctx is done
+// HTTP transports then stop accepting connections and get ShutdownTimeout to finish the requests in flight
+func Serve(ctx context.Context, s *server.MCPServer, name string, options Options) error {
+	switch name {
+	case Stdio:
+		err := server.NewStdioServer(s).Listen(ctx, os.Stdin, os.Stdout)
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return err
+	case SSE:
+		srv := &http.Server{Addr: options.Addr}
+		sse := server.NewSSEServer(s, server.WithHTTPServer(srv))
+		srv.Handler = options.handler(sse)
+		return listen(ctx, srv, sse.Shutdown)
+	case HTTP:
+		srv := &http.Server{Addr: options.Addr}
+		streamable := server.NewStreamableHTTPServer(s, server.WithStreamableHTTPServer(srv), server.WithEndpointPath(HTTPPath))
+		mux := http.NewServeMux()
+		mux.Handle(HTTPPath, streamable)
+		srv.Handler = options.handler(mux)
+		return listen(ctx, srv, streamable.Shutdown)
+	}
+	return Validate(name)
+}
+
+// handler wraps the handler of a transport in the authentication the options ask for
+func (o Options) handler(next http.Handler) http.Handler {
+	if len(o.Tokens) == 0 {
+		return next
+	}
+	return Authenticate(o.Tokens, next)
+}
+
+// listen serves srv until it fails or ctx is done, then stops it with shutdown, which also closes the open sessions
+func listen(ctx context.Context, srv *http.Server, shutdown func(context.Context) error) error {
+	served := make(chan error, 1)
+	go func() { served <- srv.ListenAndServe() }()
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	if err := shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestValidate(t *testing.T) {
+	for _, name := range Names {
+		if err := Validate(name); err != nil {
+			t.Errorf("Validate(%q) = %v", name, err)
+		}
+	}
+	if err := Validate("websocket"); err == nil {
+		t.Error("Validate accepted an unknown transport")
+	}
+}
+
+func TestServeStopsWithContext(t *testing.T) {
+	for _, name := range []string{SSE, HTTP} {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- Serve(ctx, server.NewMCPServer("test", "1.0.0"), name, Options{Addr: "127.0.0.1:0"}) }()
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("%s: Serve = %v, want nil after the context is done", name, err)
+			}
+		case <-time.After(ShutdownTimeout):
+			t.Errorf("%s: Serve did not return after the context was done", name)
+		}
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	handler := Authenticate([]string{"alpha", "beta"}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, tt := range []struct {
+		name, header, value string
+		want                int
+	}{
+		{"bearer", "Authorization", "Bearer beta", http.StatusOK},
+		{"api key", "X-API-Key", "alpha", http.StatusOK},
+		{"wrong token", "Authorization", "Bearer gamma", http.StatusUnauthorized},
+		{"prefix of a token", "X-API-Key", "alph", http.StatusUnauthorized},
+		{"basic scheme", "Authorization", "Basic YWxwaGE6", http.StatusUnauthorized},
+		{"no header", "", "", http.StatusUnauthorized},
+	} {
+		r := httptest.NewRequest(http.MethodPost, HTTPPath, nil)
+		if tt.header != "" {
+			r.Header.Set(tt.header, tt.value)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, w.Code, tt.want)
+		}
+	}
+}
+
+func TestTokens(t *testing.T) {
+	t.Setenv(TokensEnv, "from-env")
+	if got := Tokens(" a, ,b "); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("Tokens = %q, want [a b]", got)
+	}
+	if got := Tokens(""); len(got) != 1 || got[0] != "from-env" {
+		t.Errorf("Tokens without a list = %q, want the tokens of %s", got, TokensEnv)
+	}
+}
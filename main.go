@@ -1,56 +1,133 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/mark3labs/mcp-go/server"
 
+	"mcpgo/internal/config"
+	"mcpgo/internal/metrics"
+	"mcpgo/internal/state"
+	"mcpgo/internal/templates"
 	"mcpgo/internal/tools"
+	"mcpgo/internal/transport"
 )
 
 // main is the entry point for the MCP server
 // This server provides tools for scaffolding Echo web applications
 func main() {
+	toolTimeout := flag.Duration("tool-timeout", 30*time.Second, "Maximum duration of a single tool call (0 disables the timeout)")
+	verifyTimeout := flag.Duration("verify-timeout", 5*time.Minute, "Maximum duration of a tool call with verify, which compiles the generated code (0 disables the timeout)")
+	metricsAddr := flag.String("metrics-addr", "", "Address to serve Prometheus metrics on (e.g. :9090); empty disables the endpoint")
+	stateFile := flag.String("state-file", ".mcpgo/project.json", "Project manifest remembering apps, models and options across sessions; empty keeps them in memory")
+	exportTemplates := flag.String("export-templates", "", "Write the embedded templates to this directory and exit; arguments select template directories, e.g. model service")
+	transportName := flag.String("transport", transport.Stdio, "Transport to serve MCP over: "+strings.Join(transport.Names, ", ")+"; sse and http listen on -addr for remote clients")
+	addr := flag.String("addr", ":8080", "Address the sse and http transports listen on")
+	authTokens := flag.String("auth-tokens", "", "Comma-separated tokens the sse and http transports accept as a bearer token or X-API-Key; defaults to $"+transport.TokensEnv+", empty accepts every request")
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := transport.Validate(*transportName); err != nil {
+		fmt.Fprintf(os.Stderr, "Transport error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Give teams the embedded templates to start their overrides from
+	if *exportTemplates != "" {
+		written, err := templates.Export(*exportTemplates, flag.Args()...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Export error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d templates to %s; delete those you keep as they are, so they follow upgrades\n", len(written), *exportTemplates)
+		return
+	}
+
+	// Apply the team's config file and the flags overriding it before any tool runs
+	cfg, err := configFlags.Load()
+	if err == nil {
+		err = tools.Configure(cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Config error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Restore the projects scaffolded by earlier sessions, so app_name can be omitted
+	if *stateFile != "" {
+		if err := state.Default.Persist(*stateFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Project manifest error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	toolMetrics := metrics.NewRegistry()
+
 	// Create a new MCP server with name, version, and capabilities
 	s := server.NewMCPServer(
-		"Golang Echo Scaffolder Server",   // Server name
-		"1.0.0",                           // Server version
-		server.WithToolCapabilities(true), // Enable tool capabilities
+		"Golang Echo Scaffolder Server",                               // Server name
+		"1.0.0",                                                       // Server version
+		server.WithToolCapabilities(true),                             // Enable tool capabilities
+		server.WithResourceCapabilities(false, true),                  // Enable resource capabilities for project manifests and templates
+		server.WithToolHandlerMiddleware(toolMetrics.Middleware()),    // Record tool call metrics
+		server.WithToolHandlerMiddleware(tools.ElicitationMiddleware), // Ask the user for missing required arguments
+		server.WithToolHandlerMiddleware(tools.TimeoutMiddleware(*toolTimeout, *verifyTimeout)), // Bound every tool call
+		server.WithToolHandlerMiddleware(tools.PreviewMiddleware),                               // Record nothing for previews
+		server.WithElicitation(), // Enable elicitation of missing arguments
+		server.WithRecovery(),    // Report a panic, e.g. from a broken template override, as a tool error
 	)
 
-	// Add tools from the tools package with guidance on the recommended tool sequence
-
-	// Step 1: Produce App Boilerplate
-	appBoilerplateTool, appBoilerplateHandler := tools.GetProduceAppBoilerplateTool()
-	appBoilerplateTool.Description += "\n\nNext recommended step: Use 'produce_model_boilerplate' to create your data models."
-	s.AddTool(appBoilerplateTool, appBoilerplateHandler)
+	// fix_app asks the client's model for a patch when the client supports sampling
+	s.EnableSampling()
 
-	// Step 2: Produce Model Boilerplate
-	modelBoilerplateTool, modelBoilerplateHandler := tools.GetProduceModelBoilerplateTool()
-	modelBoilerplateTool.Description += "\n\nNext recommended step: Use 'produce_service_boilerplate' to create a service layer for your model."
-	s.AddTool(modelBoilerplateTool, modelBoilerplateHandler)
+	// Add the enabled tools; internal/tools/registry.go lists them in the recommended sequence
+	s.AddTools(tools.Enabled()...)
 
-	// Step 3: Produce Service Boilerplate
-	serviceBoilerplateTool, serviceBoilerplateHandler := tools.GetProduceServiceBoilerplateTool()
-	serviceBoilerplateTool.Description += "\n\nNext recommended step: Use 'produce_api_controller_boilerplate' or 'produce_html_controller_boilerplate' to create controllers for your model."
-	s.AddTool(serviceBoilerplateTool, serviceBoilerplateHandler)
+	// Resource: project manifest for each scaffolded application
+	projectResourceTemplate, projectResourceHandler := tools.GetProjectResourceTemplate()
+	s.AddResourceTemplate(projectResourceTemplate, projectResourceHandler)
 
-	// Step 4a: Produce API Controller Boilerplate
-	apiControllerBoilerplateTool, apiControllerBoilerplateHandler := tools.GetProduceApiControllerBoilerplateTool()
-	apiControllerBoilerplateTool.Description += "\n\nNext recommended step: If needed, use 'produce_html_controller_boilerplate' to create HTML views for your model."
-	s.AddTool(apiControllerBoilerplateTool, apiControllerBoilerplateHandler)
+	// Resource: JSON Schema of the structured scaffold returned by the produce_* tools
+	scaffoldSchemaResource, scaffoldSchemaHandler := tools.GetScaffoldSchemaResource()
+	s.AddResource(scaffoldSchemaResource, scaffoldSchemaHandler)
 
-	// Step 4b: Produce HTML Controller Boilerplate
-	htmlControllerBoilerplateTool, htmlControllerBoilerplateHandler := tools.GetProduceHtmlControllerBoilerplateTool()
-	htmlControllerBoilerplateTool.Description += "\n\nNext recommended step: If needed, use 'fix_app' to fix any issues with your application."
-	s.AddTool(htmlControllerBoilerplateTool, htmlControllerBoilerplateHandler)
+	// Resources: the raw text of every template, for clients retrieving one file's template instead of a whole scaffold
+	templateResources, templateResourceHandler := tools.GetTemplateResources()
+	for _, resource := range templateResources {
+		s.AddResource(resource, templateResourceHandler)
+	}
 
-	// Utility: Fix App
-	fixAppTool, fixAppHandler := tools.GetFixAppTool()
-	s.AddTool(fixAppTool, fixAppHandler)
+	// Serve operational metrics on a separate listener; stdout is reserved for the stdio transport, and the HTTP ones serve only MCP
+	if *metricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", toolMetrics.Handler())
+		go func() {
+			if err := http.ListenAndServe(*metricsAddr, mux); err != nil {
+				fmt.Fprintf(os.Stderr, "Metrics server error: %v\n", err)
+			}
+		}()
+	}
 
-	// Serve the MCP server using stdio for communication
-	if err := server.ServeStdio(s); err != nil {
-		fmt.Printf("Server error: %v\n", err)
+	// Serve until SIGINT or SIGTERM; the HTTP transports then finish the requests in flight
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	options := transport.Options{Addr: *addr, Tokens: transport.Tokens(*authTokens)}
+	if *transportName != transport.Stdio {
+		fmt.Fprintf(os.Stderr, "Serving MCP over %s on %s\n", *transportName, *addr)
+		if len(options.Tokens) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: no -auth-tokens or %s; anyone reaching %s can call the tools\n", transport.TokensEnv, *addr)
+		}
+	}
+	if err := transport.Serve(ctx, s, *transportName, options); err != nil {
+		fmt.Fprintf(os.Stderr, "Server error: %v\n", err)
+		os.Exit(1)
 	}
 }